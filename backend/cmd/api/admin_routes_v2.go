@@ -5,14 +5,19 @@ import (
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
+	"github.com/sorteos-platform/backend/internal/adapters/db"
 	adminHandler "github.com/sorteos-platform/backend/internal/adapters/http/handler/admin"
 	"github.com/sorteos-platform/backend/internal/adapters/http/middleware"
 	redisAdapter "github.com/sorteos-platform/backend/internal/adapters/redis"
 	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
 
 	// Use cases
 	categoryuc "github.com/sorteos-platform/backend/internal/usecase/admin/category"
 	configuc "github.com/sorteos-platform/backend/internal/usecase/admin/config"
+	adminraffleuc "github.com/sorteos-platform/backend/internal/usecase/admin/raffle"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	refunduc "github.com/sorteos-platform/backend/internal/usecase/refund"
 
 	"github.com/sorteos-platform/backend/pkg/config"
 	"github.com/sorteos-platform/backend/pkg/logger"
@@ -20,7 +25,7 @@ import (
 
 // setupAdminRoutesV2 configura las rutas de administración (versión simplificada)
 // Solo incluye los endpoints que tienen use cases 100% completos
-func setupAdminRoutesV2(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
+func setupAdminRoutesV2(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, wsHub *websocket.Hub, cfg *config.Config, log *logger.Logger) {
 	// Inicializar middleware
 	tokenMgr := redisAdapter.NewTokenManager(rdb, &cfg.JWT)
 	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
@@ -31,6 +36,12 @@ func setupAdminRoutesV2(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client,
 	adminGroup.Use(authMiddleware.Authenticate())
 	adminGroup.Use(authMiddleware.RequireRole("admin", "super_admin"))
 
+	// Procesador de reembolsos compartido por pagos y cancelación de rifas
+	refundProcessor := newRefundProcessor(gormDB, cfg, log)
+
+	// Sala del sorteo en vivo (sorteos manuales y programación de la cuenta regresiva)
+	drawRoom := raffleuc.NewDrawRoomService(db.NewDrawRoomRepository(gormDB, log), wsHub, raffleuc.DefaultDrawStepDelay, log)
+
 	// ==================== CATEGORY MANAGEMENT ====================
 	setupCategoryRoutesV2(adminGroup, gormDB, log)
 
@@ -47,10 +58,10 @@ func setupAdminRoutesV2(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client,
 	setupOrganizerRoutesV2(adminGroup, gormDB, log)
 
 	// ==================== PAYMENT MANAGEMENT ====================
-	setupPaymentRoutesV2(adminGroup, gormDB, log)
+	setupPaymentRoutesV2(adminGroup, gormDB, refundProcessor, log)
 
 	// ==================== RAFFLE MANAGEMENT ====================
-	setupRaffleRoutesV2(adminGroup, gormDB, log)
+	setupRaffleRoutesV2(adminGroup, gormDB, refundProcessor, drawRoom, newDrawEntropyResolver(cfg), newEmailNotifier(cfg, log), log)
 
 	// ==================== NOTIFICATIONS ====================
 	setupNotificationRoutesV2(adminGroup, gormDB, log)
@@ -66,6 +77,24 @@ func setupAdminRoutesV2(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client,
 
 	// ==================== WALLET MANAGEMENT ====================
 	setupWalletRoutesV2(adminGroup, gormDB, log)
+
+	// ==================== LOTTERY RESULTS ====================
+	setupLotteryRoutesV2(adminGroup, gormDB, log)
+
+	// Prize Claims
+	setupPrizeRoutesV2(adminGroup, gormDB, log)
+
+	// Withdrawals
+	setupWithdrawalRoutesV2(adminGroup, gormDB, log)
+
+	// Ledger
+	setupLedgerRoutesV2(adminGroup, gormDB, log)
+
+	// ==================== INVENTORY CONSISTENCY ====================
+	setupInventoryRoutesV2(adminGroup, gormDB, redisinfra.NewLockService(rdb), wsHub, log)
+
+	// ==================== CREDIT PURCHASE RECONCILIATION ====================
+	setupCreditReconciliationRoutesV2(adminGroup, gormDB, redisinfra.NewLockService(rdb), log)
 }
 
 // setupCategoryRoutesV2 configura rutas de gestión de categorías
@@ -192,9 +221,9 @@ func setupOrganizerRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logge
 }
 
 // setupPaymentRoutesV2 configura rutas de gestión de pagos
-func setupPaymentRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+func setupPaymentRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, refundProcessor *refunduc.RefundProcessor, log *logger.Logger) {
 	// Inicializar handler (el handler ya inicializa todos sus use cases internamente)
-	handler := adminHandler.NewPaymentHandler(db, log)
+	handler := adminHandler.NewPaymentHandler(db, refundProcessor, log)
 
 	// Configurar rutas
 	payments := adminGroup.Group("/payments")
@@ -211,9 +240,9 @@ func setupPaymentRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.
 }
 
 // setupRaffleRoutesV2 configura rutas de gestión de rifas
-func setupRaffleRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+func setupRaffleRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, refundProcessor *refunduc.RefundProcessor, drawRoom *raffleuc.DrawRoomService, drawEntropy *raffleuc.DrawEntropyResolver, mailer adminraffleuc.RaffleCancellationMailer, log *logger.Logger) {
 	// Inicializar handler (el handler ya inicializa todos sus use cases internamente)
-	handler := adminHandler.NewRaffleHandler(db, log)
+	handler := adminHandler.NewRaffleHandler(db, refundProcessor, drawRoom, drawEntropy, mailer, log)
 
 	// Configurar rutas
 	raffles := adminGroup.Group("/raffles")
@@ -222,12 +251,13 @@ func setupRaffleRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.L
 		raffles.GET("/:id/transactions", handler.ViewTransactions) // GET /api/v1/admin/raffles/:id/transactions
 		raffles.PUT("/:id/status", handler.ForceStatusChange)     // PUT /api/v1/admin/raffles/:id/status
 		raffles.POST("/:id/draw", handler.ManualDraw)             // POST /api/v1/admin/raffles/:id/draw
+		raffles.PUT("/:id/draw-room", handler.ScheduleDrawRoom)   // PUT /api/v1/admin/raffles/:id/draw-room
 		raffles.POST("/:id/notes", handler.AddNotes)              // POST /api/v1/admin/raffles/:id/notes
 		raffles.POST("/:id/cancel", handler.CancelWithRefund)     // POST /api/v1/admin/raffles/:id/cancel
 	}
 
 	log.Info("Admin raffle routes registered",
-		logger.Int("endpoints", 6),
+		logger.Int("endpoints", 7),
 		logger.String("base_path", "/api/v1/admin/raffles"))
 }
 
@@ -328,3 +358,120 @@ func setupWalletRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.L
 		logger.Int("endpoints", 5),
 		logger.String("base_path", "/api/v1/admin/wallets"))
 }
+
+// setupLotteryRoutesV2 configura rutas de resultados de la Lotería Nacional
+func setupLotteryRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+	// Inicializar handler
+	handler := adminHandler.NewLotteryHandler(db, log)
+
+	// Configurar rutas de resultados (ingreso manual con doble confirmación)
+	results := adminGroup.Group("/lottery-results")
+	{
+		results.GET("", handler.List)                  // GET /api/v1/admin/lottery-results
+		results.POST("", handler.Register)             // POST /api/v1/admin/lottery-results
+		results.POST("/:id/confirm", handler.Confirm)  // POST /api/v1/admin/lottery-results/:id/confirm
+		results.POST("/:id/reject", handler.Reject)    // POST /api/v1/admin/lottery-results/:id/reject
+	}
+
+	log.Info("Admin lottery routes registered",
+		logger.Int("endpoints", 4),
+		logger.String("base_path", "/api/v1/admin/lottery-results"))
+}
+
+// setupPrizeRoutesV2 configura rutas de seguimiento de premios
+func setupPrizeRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+	// Inicializar handler
+	handler := adminHandler.NewPrizeHandler(db, log)
+
+	// Configurar rutas de reclamos (verificación de identidad y entrega de premios físicos)
+	claims := adminGroup.Group("/prize-claims")
+	{
+		claims.GET("", handler.List)                                  // GET /api/v1/admin/prize-claims
+		claims.GET("/:id", handler.Get)                               // GET /api/v1/admin/prize-claims/:id
+		claims.POST("/:id/confirm-identity", handler.ConfirmIdentity) // POST /api/v1/admin/prize-claims/:id/confirm-identity
+		claims.POST("/:id/ship", handler.Ship)                        // POST /api/v1/admin/prize-claims/:id/ship
+		claims.POST("/:id/deliver", handler.Deliver)                  // POST /api/v1/admin/prize-claims/:id/deliver
+		claims.POST("/:id/cancel", handler.Cancel)                    // POST /api/v1/admin/prize-claims/:id/cancel
+	}
+
+	log.Info("Admin prize routes registered",
+		logger.Int("endpoints", 6),
+		logger.String("base_path", "/api/v1/admin/prize-claims"))
+}
+
+// setupWithdrawalRoutesV2 configura rutas de la cola de retiros
+func setupWithdrawalRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+	// Inicializar handler
+	handler := adminHandler.NewWithdrawalHandler(db, log)
+
+	// Configurar rutas (aprobación, lote bancario y confirmación de transferencias)
+	withdrawals := adminGroup.Group("/withdrawals")
+	{
+		withdrawals.GET("", handler.List)                    // GET /api/v1/admin/withdrawals
+		withdrawals.POST("/export", handler.ExportBatch)     // POST /api/v1/admin/withdrawals/export
+		withdrawals.POST("/:id/approve", handler.Approve)    // POST /api/v1/admin/withdrawals/:id/approve
+		withdrawals.POST("/:id/reject", handler.Reject)      // POST /api/v1/admin/withdrawals/:id/reject
+		withdrawals.POST("/:id/complete", handler.Complete)  // POST /api/v1/admin/withdrawals/:id/complete
+	}
+
+	log.Info("Admin withdrawal routes registered",
+		logger.Int("endpoints", 5),
+		logger.String("base_path", "/api/v1/admin/withdrawals"))
+}
+
+// setupLedgerRoutesV2 configura rutas del libro mayor
+func setupLedgerRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, log *logger.Logger) {
+	// Inicializar handler
+	handler := adminHandler.NewLedgerHandler(db, log)
+
+	// Configurar rutas (verificación de saldos contra el libro mayor)
+	ledger := adminGroup.Group("/ledger")
+	{
+		ledger.GET("/consistency", handler.CheckConsistency) // GET /api/v1/admin/ledger/consistency
+	}
+
+	log.Info("Admin ledger routes registered",
+		logger.Int("endpoints", 1),
+		logger.String("base_path", "/api/v1/admin/ledger"))
+}
+
+// setupInventoryRoutesV2 configura rutas de consistencia del inventario de números
+func setupInventoryRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, lockService *redisinfra.LockService, wsHub *websocket.Hub, log *logger.Logger) {
+	// Inicializar handler
+	handler := adminHandler.NewInventoryHandler(db, lockService, wsHub, log)
+
+	// Configurar rutas (reservas vs. raffle_numbers, locks de Redis y contadores)
+	inventory := adminGroup.Group("/inventory")
+	{
+		inventory.GET("/consistency", handler.CheckConsistency) // GET /api/v1/admin/inventory/consistency
+		inventory.POST("/repair", handler.Repair)              // POST /api/v1/admin/inventory/repair
+	}
+
+	log.Info("Admin inventory routes registered",
+		logger.Int("endpoints", 2),
+		logger.String("base_path", "/api/v1/admin/inventory"))
+}
+
+// setupCreditReconciliationRoutesV2 configura rutas de conciliación de compras de créditos con Pagadito
+func setupCreditReconciliationRoutesV2(adminGroup *gin.RouterGroup, db *gorm.DB, lockService *redisinfra.LockService, log *logger.Logger) {
+	// Sin Pagadito configurado solo se pueden consultar los reportes existentes
+	reconciler, err := newCreditReconciler(db, lockService, log)
+	if err != nil {
+		log.Warn("Pagadito not configured, manual credit reconciliation disabled", logger.Error(err))
+	}
+
+	// Inicializar handler
+	handler := adminHandler.NewCreditReconciliationHandler(db, reconciler, log)
+
+	// Configurar rutas (reportes de diferencias con Pagadito)
+	reconciliations := adminGroup.Group("/credit-purchases/reconciliations")
+	{
+		reconciliations.GET("", handler.List)     // GET /api/v1/admin/credit-purchases/reconciliations
+		reconciliations.POST("", handler.Run)     // POST /api/v1/admin/credit-purchases/reconciliations
+		reconciliations.GET("/:id", handler.View) // GET /api/v1/admin/credit-purchases/reconciliations/:id
+	}
+
+	log.Info("Admin credit reconciliation routes registered",
+		logger.Int("endpoints", 3),
+		logger.String("base_path", "/api/v1/admin/credit-purchases/reconciliations"))
+}
@@ -8,8 +8,16 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/infrastructure/lottery"
 	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
 	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/internal/jobs"
+	adminraffle "github.com/sorteos-platform/backend/internal/usecase/admin/raffle"
+	consistencyuc "github.com/sorteos-platform/backend/internal/usecase/consistency"
+	ledgeruc "github.com/sorteos-platform/backend/internal/usecase/ledger"
+	prizeuc "github.com/sorteos-platform/backend/internal/usecase/prize"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	settlementuc "github.com/sorteos-platform/backend/internal/usecase/settlement"
 	"github.com/sorteos-platform/backend/internal/usecases"
 	"github.com/sorteos-platform/backend/pkg/config"
 	"github.com/sorteos-platform/backend/pkg/logger"
@@ -22,6 +30,7 @@ func startBackgroundJobs(gormDB *gorm.DB, rdb *redis.Client, wsHub *websocket.Hu
 	raffleRepo := db.NewRaffleRepository(gormDB)
 	raffleNumberRepo := db.NewRaffleNumberRepository(gormDB)
 	userRepo := db.NewUserRepository(gormDB)
+	auditRepo := db.NewAuditLogRepository(gormDB)
 
 	// Inicializar lock service
 	lockService := redisinfra.NewLockService(rdb)
@@ -31,20 +40,150 @@ func startBackgroundJobs(gormDB *gorm.DB, rdb *redis.Client, wsHub *websocket.Hu
 		reservationRepo,
 		raffleRepo,
 		raffleNumberRepo,
+		db.NewPricingRuleRepository(gormDB, log),
 		userRepo,
 		lockService,
 		wsHub,
 	)
 
-	// Job de expiración de reservas (ejecutar cada 30 segundos)
-	go startReservationExpirationJob(reservationUseCases, log)
+	emailNotifier := newEmailNotifier(cfg, log)
+
+	// Lista de espera: recibe los números liberados por reservas expiradas
+	waitlistUseCases := usecases.NewWaitlistUseCases(
+		db.NewWaitlistRepository(gormDB),
+		reservationUseCases,
+		emailNotifier,
+	)
+
+	// Job de expiración de reservas y ofertas de lista de espera (ejecutar cada 30 segundos)
+	go startReservationExpirationJob(reservationUseCases, waitlistUseCases, log)
+
+	// Resolver de sorteos por Lotería Nacional (fuente opcional de resultados oficiales)
+	lotterySource, err := lottery.NewResultSource(lottery.Config{
+		Source:   cfg.Lottery.Source,
+		URL:      cfg.Lottery.URL,
+		FilePath: cfg.Lottery.FilePath,
+		Timeout:  cfg.Lottery.Timeout,
+	})
+	if err != nil {
+		log.Error("Invalid lottery result source, falling back to manual entry", logger.Error(err))
+	}
+	lotteryResolver := raffleuc.NewLotteryDrawResolver(
+		db.NewLotteryResultRepository(gormDB, log),
+		db.NewSystemParameterRepository(gormDB, log),
+		lotterySource,
+		log,
+	)
+
+	// Sala del sorteo en vivo (eventos por etapa y timeline)
+	drawRoom := raffleuc.NewDrawRoomService(db.NewDrawRoomRepository(gormDB, log), wsHub, raffleuc.DefaultDrawStepDelay, log)
+
+	// Job de sorteos programados (cierra ventas y sortea al llegar draw_date)
+	executeScheduledDraws := raffleuc.NewExecuteScheduledDrawsUseCase(
+		raffleRepo,
+		raffleNumberRepo,
+		db.NewRafflePrizeRepository(gormDB, log),
+		reservationRepo,
+		auditRepo,
+		lockService,
+		wsHub,
+		drawRoom,
+		lotteryResolver,
+		newDrawEntropyResolver(cfg),
+		log,
+	)
+	// Entrega de premios al completar cada sorteo (el job cubre sorteos manuales y reintentos)
+	prizeFulfillment := prizeuc.NewPrizeFulfillment(gormDB, log)
+	executeScheduledDraws.RegisterCompletedHandler(prizeFulfillment)
+
+	// Series recurrentes: el siguiente sorteo se genera al completarse el anterior o según su cron
+	// y recibe el premio mayor acumulado si el número oficial del anterior no se vendió
+	raffleImageRepo := db.NewRaffleImageRepository(gormDB)
+	raffleSeriesRepo := db.NewRaffleSeriesRepository(gormDB, log)
+	jackpotRollover := raffleuc.NewJackpotRollover(raffleSeriesRepo, raffleRepo, auditRepo, log)
+	executeScheduledDraws.SetJackpotRollover(jackpotRollover)
+	generateSeriesRaffles := raffleuc.NewGenerateSeriesRafflesUseCase(
+		raffleSeriesRepo,
+		db.NewRaffleTemplateRepository(gormDB, log),
+		raffleRepo,
+		raffleImageRepo,
+		auditRepo,
+		lockService,
+		raffleuc.NewCreateRaffleUseCase(raffleRepo, raffleNumberRepo, userRepo, auditRepo, gormDB, log),
+		raffleuc.NewPublishRaffleUseCase(raffleRepo, raffleImageRepo, raffleNumberRepo, auditRepo),
+		jackpotRollover,
+		log,
+	)
+	executeScheduledDraws.RegisterCompletedHandler(generateSeriesRaffles)
+
+	// Job de reembolsos (reintentos con backoff, confirmación en el proveedor y respaldo a billetera)
+	refundProcessor := newRefundProcessor(gormDB, cfg, log)
+	retryRefundsJob := jobs.NewRetryRefundsJob(refundProcessor, log, time.Minute)
+	go retryRefundsJob.Start()
+
+	// Cancelación automática con reembolsos (mínimo de ventas y sorteos sin ventas)
+	raffleCanceller := adminraffle.NewCancelRaffleWithRefundUseCase(gormDB, refundProcessor, log)
+	raffleCanceller.SetMailer(emailNotifier)
+
+	// Job de sorteos: los sorteos que llegan a su fecha sin números vendidos se cancelan
+	executeScheduledDraws.SetCanceller(raffleCanceller)
+	drawRafflesJob := jobs.NewDrawRafflesJob(executeScheduledDraws, log, time.Minute)
+	go drawRafflesJob.Start()
+
+	// Job de mínimo de ventas (pospone una vez o cancela con reembolsos al llegar el corte)
+	enforceMinimumSales := raffleuc.NewEnforceMinimumSalesUseCase(
+		raffleRepo,
+		raffleNumberRepo,
+		userRepo,
+		auditRepo,
+		lockService,
+		wsHub,
+		raffleCanceller,
+		emailNotifier,
+		log,
+	)
+	minimumSalesJob := jobs.NewMinimumSalesJob(enforceMinimumSales, log, 5*time.Minute)
+	go minimumSalesJob.Start()
+
+	// Job de series de sorteos (cron y series cuyo sorteo anterior finalizó fuera del job de sorteos)
+	raffleSeriesJob := jobs.NewRaffleSeriesJob(generateSeriesRaffles, log, time.Minute)
+	go raffleSeriesJob.Start()
+
+	// Job de premios (acredita premios en efectivo pendientes y expira reclamos físicos vencidos)
+	prizeFulfillmentJob := jobs.NewPrizeFulfillmentJob(prizeFulfillment, log, 5*time.Minute)
+	go prizeFulfillmentJob.Start()
+
+	// Job de liquidaciones programadas (lotes semanales/mensuales según payout_schedule del organizador)
+	scheduledSettlementsJob := jobs.NewScheduledSettlementsJob(settlementuc.NewScheduledSettlements(gormDB, log), log, time.Hour)
+	go scheduledSettlementsJob.Start()
+
+	// Job de verificación del libro mayor (saldos de billeteras vs. asientos contables)
+	ledgerConsistencyJob := jobs.NewLedgerConsistencyJob(ledgeruc.NewConsistencyChecker(gormDB, log), log, time.Hour)
+	go ledgerConsistencyJob.Start()
+
+	// Job de consistencia de inventario (reservas vs. raffle_numbers, locks de Redis y contadores)
+	inventoryRepairer := consistencyuc.NewInventoryRepairer(gormDB, lockService, wsHub, log)
+	inventoryConsistencyJob := jobs.NewInventoryConsistencyJob(inventoryRepairer, cfg.Business.InventoryAutoRepair, log, 15*time.Minute)
+	go inventoryConsistencyJob.Start()
+
+	// Job nocturno de conciliación de compras de créditos con Pagadito (pestañas cerradas antes del callback)
+	if creditReconciler, err := newCreditReconciler(gormDB, lockService, log); err != nil {
+		log.Warn("Pagadito not configured, credit reconciliation job disabled", logger.Error(err))
+	} else {
+		creditReconciliationJob := jobs.NewCreditReconciliationJob(creditReconciler, log, 3)
+		go creditReconciliationJob.Start()
+	}
 
 	log.Info("Background jobs started")
 }
 
+// reservationExpiryWarning anticipación del aviso privado de expiración de reserva
+const reservationExpiryWarning = 60 * time.Second
+
 // startReservationExpirationJob inicia el job de expiración de reservas
-func startReservationExpirationJob(reservationUC *usecases.ReservationUseCases, log *logger.Logger) {
-	ticker := time.NewTicker(30 * time.Second)
+func startReservationExpirationJob(reservationUC *usecases.ReservationUseCases, waitlistUC *usecases.WaitlistUseCases, log *logger.Logger) {
+	const interval = 30 * time.Second
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	log.Info("Starting expire reservations job", logger.String("interval", "30s"))
@@ -52,6 +191,11 @@ func startReservationExpirationJob(reservationUC *usecases.ReservationUseCases,
 	for range ticker.C {
 		ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
 
+		// Avisar por el canal privado las reservas que expiran en el próximo minuto
+		if _, err := reservationUC.WarnExpiringReservations(ctx, reservationExpiryWarning, interval); err != nil {
+			log.Error("Error warning expiring reservations", logger.Error(err))
+		}
+
 		count, err := reservationUC.ExpireOldReservations(ctx)
 		if err != nil {
 			log.Error("Error expiring reservations", logger.Error(err))
@@ -59,6 +203,14 @@ func startReservationExpirationJob(reservationUC *usecases.ReservationUseCases,
 			log.Info("Expired reservations", logger.Int("count", count))
 		}
 
+		// Ofertas de lista de espera no reclamadas: pasan al siguiente en la fila
+		offers, err := waitlistUC.ExpireOffers(ctx)
+		if err != nil {
+			log.Error("Error expiring waitlist offers", logger.Error(err))
+		} else if offers > 0 {
+			log.Info("Expired waitlist offers", logger.Int("count", offers))
+		}
+
 		cancel()
 	}
 }
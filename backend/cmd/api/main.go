@@ -59,8 +59,8 @@ func main() {
 		zap.Int("db", cfg.Redis.DB),
 	)
 
-	// Inicializar WebSocket Hub
-	wsHub := websocket.NewHub()
+	// Inicializar WebSocket Hub (fan-out entre réplicas vía Redis pub/sub)
+	wsHub := websocket.NewRedisHub(rdb)
 	go wsHub.Run() // Run hub in background goroutine
 	log.Info("WebSocket Hub initialized")
 
@@ -115,6 +115,10 @@ func main() {
 		log.Error("Error closing database", zap.Error(err))
 	}
 
+	if err := wsHub.Close(); err != nil {
+		log.Error("Error closing WebSocket Hub", zap.Error(err))
+	}
+
 	if err := rdb.Close(); err != nil {
 		log.Error("Error closing Redis", zap.Error(err))
 	}
@@ -333,17 +337,23 @@ func setupRoutes(router *gin.Engine, db *gorm.DB, rdb *redis.Client, wsHub *webs
 	setupRaffleRoutes(router, db, rdb, cfg, log)
 
 	// Setup WebSocket routes
-	setupWebSocketRoutes(router, wsHub, rdb, cfg, log)
+	setupWebSocketRoutes(router, db, wsHub, rdb, cfg, log)
 
 	// Setup reservation and payment routes
 	setupReservationAndPaymentRoutes(router, db, rdb, wsHub, cfg, log)
 
 	// Setup admin routes
-	setupAdminRoutesV2(router, db, rdb, cfg, log)
+	setupAdminRoutesV2(router, db, rdb, wsHub, cfg, log)
 
 	// Setup wallet routes
 	setupWalletRoutes(router, db, rdb, cfg, log)
 
+	// Setup withdrawal routes
+	setupWithdrawalRoutes(router, db, rdb, cfg, log)
+
+	// Setup raffle template and series routes
+	setupRaffleSeriesRoutes(router, db, rdb, cfg, log)
+
 	// Setup profile routes
 	setupProfileRoutes(router, db, rdb, cfg, log)
 
@@ -2,7 +2,10 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,9 +18,14 @@ import (
 	redisAdapter "github.com/sorteos-platform/backend/internal/adapters/redis"
 	"github.com/sorteos-platform/backend/internal/infrastructure/payment"
 	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	cartuc "github.com/sorteos-platform/backend/internal/usecase/cart"
+	refunduc "github.com/sorteos-platform/backend/internal/usecase/refund"
+	walletuc "github.com/sorteos-platform/backend/internal/usecase/wallet"
 	"github.com/sorteos-platform/backend/internal/usecases"
 	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
 	"github.com/sorteos-platform/backend/pkg/config"
+	apperrors "github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
 )
@@ -34,6 +42,99 @@ func getUserUUID(userRepo domain.UserRepository, userID int64) (uuid.UUID, error
 	return uuid.Parse(user.UUID)
 }
 
+// waitlistRequestIDs obtiene el UUID del sorteo del path y el UUID del usuario autenticado
+func waitlistRequestIDs(c *gin.Context, userRepo domain.UserRepository, log *logger.Logger) (uuid.UUID, uuid.UUID, bool) {
+	raffleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_RAFFLE_ID", "message": "invalid raffle id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userIDInt, _ := middleware.GetUserID(c)
+	userUUID, err := getUserUUID(userRepo, userIDInt)
+	if err != nil {
+		log.Error("Failed to get user UUID", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "USER_NOT_FOUND", "message": "user not found"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return raffleID, userUUID, true
+}
+
+// handleCartError mapea los errores del carrito a respuestas HTTP
+func handleCartError(c *gin.Context, log *logger.Logger, err error, message string) {
+	log.Error(message, logger.Error(err))
+
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		c.JSON(appErr.Status, gin.H{"code": appErr.Code, "message": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"code": "CART_FAILED", "message": "cart operation failed"})
+}
+
+// handleWaitlistError mapea los errores de la lista de espera a respuestas HTTP
+func handleWaitlistError(c *gin.Context, log *logger.Logger, err error) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidWaitlistQuantity):
+		c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+	case errors.Is(err, usecases.ErrWaitlistEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND", "message": err.Error()})
+	case errors.Is(err, entities.ErrWaitlistNoOffer), errors.Is(err, entities.ErrWaitlistOfferExpired):
+		c.JSON(http.StatusConflict, gin.H{"code": "NO_ACTIVE_OFFER", "message": err.Error()})
+	case errors.Is(err, usecases.ErrReservationLocksLost), errors.Is(err, usecases.ErrNumbersAlreadyReserved):
+		c.JSON(http.StatusConflict, gin.H{"code": "OFFER_LOST", "message": err.Error()})
+	case errors.Is(err, usecases.ErrRaffleNotActive), errors.Is(err, usecases.ErrRaffleSalesClosed):
+		c.JSON(http.StatusConflict, gin.H{"code": "SALES_CLOSED", "message": err.Error()})
+	default:
+		log.Error("Waitlist operation failed", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "WAITLIST_FAILED", "message": "waitlist operation failed"})
+	}
+}
+
+var (
+	paymentProviderOnce sync.Once
+	paymentProvider     payment.PaymentProvider
+)
+
+// getPaymentProvider inicializa una sola vez el payment provider configurado
+// (compartido por checkout, reembolsos de admin y el job de reintentos)
+func getPaymentProvider(cfg *config.Config, log *logger.Logger) payment.PaymentProvider {
+	paymentProviderOnce.Do(func() {
+		if paymentProviderName(cfg) == "paypal" {
+			provider, err := payment.NewPayPalProvider(
+				cfg.Payment.ClientID,
+				cfg.Payment.Secret,
+				cfg.Payment.Sandbox,
+			)
+			if err != nil {
+				log.Fatal("Failed to initialize PayPal provider", logger.Error(err))
+			}
+			paymentProvider = provider
+			log.Info("Using PayPal as payment provider")
+		} else {
+			// Fallback a Stripe si está configurado
+			paymentProvider = payment.NewStripeProvider(cfg.Stripe.SecretKey)
+			log.Info("Using Stripe as payment provider")
+		}
+	})
+	return paymentProvider
+}
+
+// paymentProviderName nombre del payment provider configurado
+func paymentProviderName(cfg *config.Config) string {
+	if cfg.Payment.Provider == "paypal" {
+		return "paypal"
+	}
+	return "stripe"
+}
+
+// newRefundProcessor crea el procesador de reembolsos sobre el payment provider configurado
+func newRefundProcessor(gormDB *gorm.DB, cfg *config.Config, log *logger.Logger) *refunduc.RefundProcessor {
+	return refunduc.NewRefundProcessor(gormDB, getPaymentProvider(cfg, log), paymentProviderName(cfg), log)
+}
+
 // setupReservationAndPaymentRoutes configura las rutas de reservas y pagos
 func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, wsHub *websocket.Hub, cfg *config.Config, log *logger.Logger) {
 	// Inicializar repositorios existentes
@@ -50,34 +151,29 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 	lockService := redisinfra.NewLockService(rdb)
 
 	// Inicializar payment provider basado en configuración
-	var paymentProvider payment.PaymentProvider
-	if cfg.Payment.Provider == "paypal" {
-		var err error
-		paymentProvider, err = payment.NewPayPalProvider(
-			cfg.Payment.ClientID,
-			cfg.Payment.Secret,
-			cfg.Payment.Sandbox,
-		)
-		if err != nil {
-			log.Fatal("Failed to initialize PayPal provider", logger.Error(err))
-		}
-		log.Info("Using PayPal as payment provider")
-	} else {
-		// Fallback a Stripe si está configurado
-		paymentProvider = payment.NewStripeProvider(cfg.Stripe.SecretKey)
-		log.Info("Using Stripe as payment provider")
-	}
+	paymentProvider := getPaymentProvider(cfg, log)
 
 	// Inicializar use cases
 	reservationUseCases := usecases.NewReservationUseCases(
 		reservationRepo,
 		raffleRepo,
 		raffleNumberRepo,
+		db.NewPricingRuleRepository(gormDB, log),
 		userRepo,
 		lockService,
 		wsHub,
 	)
 
+	// Lista de espera (recibe los números liberados por reservas canceladas)
+	waitlistUseCases := usecases.NewWaitlistUseCases(
+		db.NewWaitlistRepository(gormDB),
+		reservationUseCases,
+		newEmailNotifier(cfg, log),
+	)
+
+	// Checkout con saldo de billetera
+	payReservationUC := walletuc.NewPayReservationUseCase(gormDB, wsHub, log)
+
 	paymentUseCases := usecases.NewPaymentUseCases(
 		paymentRepo,
 		reservationRepo,
@@ -85,8 +181,17 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 		idempotencyKeyRepo,
 		paymentProvider,
 		reservationUseCases,
+		db.NewLedgerRepository(gormDB, log),
+		wsHub,
 	)
 
+	// Carrito multi-sorteo: un solo pago (tarjeta o saldo) para varias reservas
+	createCartUC := cartuc.NewCreateCartUseCase(gormDB, reservationUseCases, log)
+	getCartUC := cartuc.NewGetCartUseCase(gormDB)
+	createCartIntentUC := cartuc.NewCreateCartPaymentIntentUseCase(gormDB, paymentProvider, log)
+	payCartWithWalletUC := cartuc.NewPayCartWithWalletUseCase(gormDB, wsHub, log)
+	paymentUseCases.SetCartPaymentHandler(cartuc.NewHandleCartPaymentEventUseCase(gormDB, reservationUseCases, newRefundProcessor(gormDB, cfg, log), wsHub, log))
+
 	// Inicializar middlewares
 	tokenMgr := redisAdapter.NewTokenManager(rdb, &cfg.JWT)
 	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
@@ -146,6 +251,67 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 			},
 		)
 
+		// POST /api/v1/reservations/lucky-dip - Reservar N números al azar (con rate limiting)
+		reservationsGroup.POST("/lucky-dip",
+			rateLimiter.LimitByUser(cfg.Business.RateLimitReservePerMinute, time.Minute),
+			func(c *gin.Context) {
+				var req struct {
+					RaffleID    string `json:"raffle_id" binding:"required"`
+					Quantity    int    `json:"quantity" binding:"required,min=1"`
+					SessionID   string `json:"session_id" binding:"required"`
+					EndingDigit *int   `json:"ending_digit"`
+					MinNumber   *int   `json:"min_number"`
+					MaxNumber   *int   `json:"max_number"`
+				}
+
+				if err := c.ShouldBindJSON(&req); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+					return
+				}
+
+				userIDInt, _ := middleware.GetUserID(c)
+				userUUID, err := getUserUUID(userRepo, userIDInt)
+				if err != nil {
+					log.Error("Failed to get user UUID", logger.Error(err))
+					c.JSON(http.StatusInternalServerError, gin.H{"code": "USER_NOT_FOUND", "message": "user not found"})
+					return
+				}
+
+				raffleID, err := uuid.Parse(req.RaffleID)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_RAFFLE_ID", "message": "invalid raffle_id"})
+					return
+				}
+
+				reservation, err := reservationUseCases.CreateLuckyDipReservation(c.Request.Context(), usecases.LuckyDipInput{
+					RaffleID:    raffleID,
+					UserID:      userUUID,
+					Quantity:    req.Quantity,
+					SessionID:   req.SessionID,
+					EndingDigit: req.EndingDigit,
+					MinNumber:   req.MinNumber,
+					MaxNumber:   req.MaxNumber,
+				})
+
+				if err != nil {
+					switch {
+					case errors.Is(err, usecases.ErrInvalidLuckyDipQuantity), errors.Is(err, usecases.ErrInvalidLuckyDipFilter):
+						c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+					case errors.Is(err, usecases.ErrInsufficientNumbers):
+						c.JSON(http.StatusConflict, gin.H{"code": "INSUFFICIENT_NUMBERS", "message": "not enough available numbers match the request"})
+					default:
+						log.Error("Failed to create lucky dip reservation", logger.Error(err))
+						c.JSON(http.StatusConflict, gin.H{"code": "RESERVATION_FAILED", "message": err.Error()})
+					}
+					return
+				}
+
+				c.JSON(http.StatusCreated, gin.H{
+					"reservation": reservation,
+				})
+			},
+		)
+
 		// GET /api/v1/reservations/:id - Ver reserva
 		reservationsGroup.GET("/:id", func(c *gin.Context) {
 			reservationID, err := uuid.Parse(c.Param("id"))
@@ -223,6 +389,56 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 			c.JSON(http.StatusOK, gin.H{"success": true, "message": "reservation cancelled"})
 		})
 
+		// POST /api/v1/reservations/:id/checkout - Iniciar checkout (congela los números y extiende la reserva)
+		reservationsGroup.POST("/:id/checkout", func(c *gin.Context) {
+			reservationID, err := uuid.Parse(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid reservation id"})
+				return
+			}
+
+			userIDInt, _ := middleware.GetUserID(c)
+			userUUID, err := getUserUUID(userRepo, userIDInt)
+			if err != nil {
+				log.Error("Failed to get user UUID", logger.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"code": "USER_NOT_FOUND", "message": "user not found"})
+				return
+			}
+
+			// Verify ownership
+			reservation, err := reservationUseCases.GetReservation(c.Request.Context(), reservationID)
+			if err != nil || reservation.UserID != userUUID {
+				c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND", "message": "reservation not found"})
+				return
+			}
+
+			if err := reservationUseCases.MoveToCheckout(c.Request.Context(), reservationID); err != nil {
+				switch {
+				case errors.Is(err, entities.ErrNotInSelectionPhase):
+					c.JSON(http.StatusConflict, gin.H{"code": "NOT_IN_SELECTION_PHASE", "message": err.Error()})
+				case errors.Is(err, entities.ErrReservationExpired):
+					c.JSON(http.StatusConflict, gin.H{"code": "RESERVATION_EXPIRED", "message": err.Error()})
+				case errors.Is(err, usecases.ErrReservationLocksLost):
+					c.JSON(http.StatusConflict, gin.H{"code": "RESERVATION_LOCKS_LOST", "message": err.Error()})
+				case errors.Is(err, usecases.ErrRaffleSalesClosed):
+					c.JSON(http.StatusConflict, gin.H{"code": "SALES_CLOSED", "message": err.Error()})
+				default:
+					log.Error("Failed to start checkout", logger.Error(err))
+					c.JSON(http.StatusInternalServerError, gin.H{"code": "CHECKOUT_FAILED", "message": "failed to start checkout"})
+				}
+				return
+			}
+
+			updatedReservation, err := reservationUseCases.GetReservation(c.Request.Context(), reservationID)
+			if err != nil {
+				log.Error("Failed to get updated reservation", logger.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"code": "FETCH_FAILED", "message": "failed to fetch updated reservation"})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"reservation": updatedReservation})
+		})
+
 		// POST /api/v1/reservations/:id/add-number - Agregar número a reserva existente
 		reservationsGroup.POST("/:id/add-number", func(c *gin.Context) {
 			reservationID, err := uuid.Parse(c.Param("id"))
@@ -272,6 +488,14 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 					c.JSON(http.StatusConflict, gin.H{"code": "NUMBER_ALREADY_RESERVED", "message": "number is already reserved"})
 					return
 				}
+				if errors.Is(err, usecases.ErrRaffleSalesClosed) {
+					c.JSON(http.StatusConflict, gin.H{"code": "SALES_CLOSED", "message": err.Error()})
+					return
+				}
+				if errors.Is(err, entities.ErrCannotAddInCheckout) {
+					c.JSON(http.StatusConflict, gin.H{"code": "RESERVATION_IN_CHECKOUT", "message": err.Error()})
+					return
+				}
 
 				c.JSON(http.StatusInternalServerError, gin.H{"code": "ADD_NUMBER_FAILED", "message": err.Error()})
 				return
@@ -339,38 +563,43 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 			c.JSON(http.StatusOK, gin.H{"success": true, "reservation": updatedReservation})
 		})
 
-		// POST /api/v1/reservations/:id/confirm - Confirmar reserva (pago completado)
-		reservationsGroup.POST("/:id/confirm", func(c *gin.Context) {
-			reservationID, err := uuid.Parse(c.Param("id"))
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid reservation id"})
-				return
-			}
+		// POST /api/v1/reservations/:id/confirm - Confirmar reserva pagando con saldo de billetera
+		reservationsGroup.POST("/:id/confirm",
+			rateLimiter.LimitByUser(cfg.Business.RateLimitPaymentPerMinute, time.Minute),
+			func(c *gin.Context) {
+				reservationID, err := uuid.Parse(c.Param("id"))
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid reservation id"})
+					return
+				}
 
-			userIDInt, _ := middleware.GetUserID(c)
-			userUUID, err := getUserUUID(userRepo, userIDInt)
-			if err != nil {
-				log.Error("Failed to get user UUID", logger.Error(err))
-				c.JSON(http.StatusInternalServerError, gin.H{"code": "USER_NOT_FOUND", "message": "user not found"})
-				return
-			}
+				userIDInt, _ := middleware.GetUserID(c)
 
-			// Verify ownership
-			reservation, err := reservationUseCases.GetReservation(c.Request.Context(), reservationID)
-			if err != nil || reservation.UserID != userUUID {
-				c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND", "message": "reservation not found"})
-				return
-			}
+				// Debita el saldo y confirma la reserva en una sola transacción (idempotente por reserva)
+				result, err := payReservationUC.Execute(c.Request.Context(), &walletuc.PayReservationInput{
+					ReservationID: reservationID,
+					UserID:        userIDInt,
+				})
+				if err != nil {
+					log.Error("Failed to confirm reservation with wallet", logger.Error(err))
 
-			// Confirm reservation
-			if err := reservationUseCases.ConfirmReservation(c.Request.Context(), reservationID); err != nil {
-				log.Error("Failed to confirm reservation", logger.Error(err))
-				c.JSON(http.StatusInternalServerError, gin.H{"code": "CONFIRM_FAILED", "message": "failed to confirm reservation"})
-				return
-			}
+					var appErr *apperrors.AppError
+					if errors.As(err, &appErr) {
+						c.JSON(appErr.Status, gin.H{"code": appErr.Code, "message": appErr.Message})
+						return
+					}
 
-			c.JSON(http.StatusOK, gin.H{"success": true, "message": "reservation confirmed"})
-		})
+					c.JSON(http.StatusInternalServerError, gin.H{"code": "CONFIRM_FAILED", "message": "failed to confirm reservation"})
+					return
+				}
+
+				c.JSON(http.StatusOK, gin.H{
+					"success": true,
+					"message": "reservation confirmed",
+					"data":    result,
+				})
+			},
+		)
 	}
 
 	// GET /api/v1/raffles/:id/my-reservation - Obtener reserva activa del usuario para un sorteo
@@ -411,6 +640,208 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 		},
 	)
 
+	// Grupo de rutas de lista de espera de un sorteo
+	waitlistGroup := router.Group("/api/v1/raffles/:id/waitlist")
+	waitlistGroup.Use(authMiddleware.Authenticate())
+	waitlistGroup.Use(authMiddleware.RequireMinKYC("email_verified"))
+	{
+		// POST /api/v1/raffles/:id/waitlist - Unirse a la lista de espera
+		waitlistGroup.POST("", func(c *gin.Context) {
+			var req struct {
+				Quantity int `json:"quantity"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+				return
+			}
+			if req.Quantity == 0 {
+				req.Quantity = 1
+			}
+
+			raffleID, userUUID, ok := waitlistRequestIDs(c, userRepo, log)
+			if !ok {
+				return
+			}
+
+			status, err := waitlistUseCases.Join(c.Request.Context(), raffleID, userUUID, req.Quantity)
+			if err != nil {
+				handleWaitlistError(c, log, err)
+				return
+			}
+
+			c.JSON(http.StatusCreated, gin.H{"success": true, "data": status})
+		})
+
+		// GET /api/v1/raffles/:id/waitlist - Ver posición u oferta activa
+		waitlistGroup.GET("", func(c *gin.Context) {
+			raffleID, userUUID, ok := waitlistRequestIDs(c, userRepo, log)
+			if !ok {
+				return
+			}
+
+			status, err := waitlistUseCases.Get(c.Request.Context(), raffleID, userUUID)
+			if err != nil {
+				if errors.Is(err, usecases.ErrWaitlistEntryNotFound) {
+					// 200 con null para indicar que no está en la lista (no es un error)
+					c.JSON(http.StatusOK, gin.H{"success": true, "data": nil})
+					return
+				}
+				handleWaitlistError(c, log, err)
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"success": true, "data": status})
+		})
+
+		// DELETE /api/v1/raffles/:id/waitlist - Salir de la lista de espera
+		waitlistGroup.DELETE("", func(c *gin.Context) {
+			raffleID, userUUID, ok := waitlistRequestIDs(c, userRepo, log)
+			if !ok {
+				return
+			}
+
+			if err := waitlistUseCases.Leave(c.Request.Context(), raffleID, userUUID); err != nil {
+				handleWaitlistError(c, log, err)
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"success": true, "message": "left waitlist"})
+		})
+
+		// POST /api/v1/raffles/:id/waitlist/claim - Reservar los números ofrecidos
+		waitlistGroup.POST("/claim", func(c *gin.Context) {
+			var req struct {
+				SessionID string `json:"session_id" binding:"required"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+				return
+			}
+
+			raffleID, userUUID, ok := waitlistRequestIDs(c, userRepo, log)
+			if !ok {
+				return
+			}
+
+			reservation, err := waitlistUseCases.Claim(c.Request.Context(), raffleID, userUUID, req.SessionID)
+			if err != nil {
+				handleWaitlistError(c, log, err)
+				return
+			}
+
+			c.JSON(http.StatusCreated, gin.H{"reservation": reservation})
+		})
+	}
+
+	// Grupo de rutas del carrito multi-sorteo
+	cartsGroup := router.Group("/api/v1/carts")
+	cartsGroup.Use(authMiddleware.Authenticate())
+	cartsGroup.Use(authMiddleware.RequireMinKYC("email_verified"))
+	{
+		// POST /api/v1/carts - Crear carrito con reservas de varios sorteos (inicia su checkout)
+		cartsGroup.POST("", func(c *gin.Context) {
+			var req struct {
+				ReservationIDs []string `json:"reservation_ids" binding:"required,min=1"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_INPUT", "message": err.Error()})
+				return
+			}
+
+			reservationIDs := make([]uuid.UUID, 0, len(req.ReservationIDs))
+			for _, id := range req.ReservationIDs {
+				reservationID, err := uuid.Parse(id)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_RESERVATION_ID", "message": "invalid reservation id: " + id})
+					return
+				}
+				reservationIDs = append(reservationIDs, reservationID)
+			}
+
+			userIDInt, _ := middleware.GetUserID(c)
+			output, err := createCartUC.Execute(c.Request.Context(), &cartuc.CreateCartInput{
+				UserID:         userIDInt,
+				ReservationIDs: reservationIDs,
+			})
+			if err != nil {
+				handleCartError(c, log, err, "Failed to create cart")
+				return
+			}
+
+			c.JSON(http.StatusCreated, gin.H{"success": true, "data": output})
+		})
+
+		// GET /api/v1/carts/:id - Ver carrito
+		cartsGroup.GET("/:id", func(c *gin.Context) {
+			cartID, err := uuid.Parse(c.Param("id"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid cart id"})
+				return
+			}
+
+			userIDInt, _ := middleware.GetUserID(c)
+			output, err := getCartUC.Execute(c.Request.Context(), cartID, userIDInt)
+			if err != nil {
+				handleCartError(c, log, err, "Failed to get cart")
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"success": true, "data": output})
+		})
+
+		// POST /api/v1/carts/:id/payment-intent - Crear un solo payment intent por el total
+		cartsGroup.POST("/:id/payment-intent",
+			rateLimiter.LimitByUser(cfg.Business.RateLimitPaymentPerMinute, time.Minute),
+			func(c *gin.Context) {
+				cartID, err := uuid.Parse(c.Param("id"))
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid cart id"})
+					return
+				}
+
+				userIDInt, _ := middleware.GetUserID(c)
+				output, err := createCartIntentUC.Execute(c.Request.Context(), &cartuc.CreateCartPaymentIntentInput{
+					CartID: cartID,
+					UserID: userIDInt,
+				})
+				if err != nil {
+					handleCartError(c, log, err, "Failed to create cart payment intent")
+					return
+				}
+
+				c.JSON(http.StatusCreated, gin.H{"success": true, "data": output})
+			},
+		)
+
+		// POST /api/v1/carts/:id/pay-with-wallet - Pagar el carrito con un solo débito de saldo
+		cartsGroup.POST("/:id/pay-with-wallet",
+			rateLimiter.LimitByUser(cfg.Business.RateLimitPaymentPerMinute, time.Minute),
+			func(c *gin.Context) {
+				cartID, err := uuid.Parse(c.Param("id"))
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": "INVALID_ID", "message": "invalid cart id"})
+					return
+				}
+
+				userIDInt, _ := middleware.GetUserID(c)
+				result, err := payCartWithWalletUC.Execute(c.Request.Context(), &cartuc.PayCartWithWalletInput{
+					CartID: cartID,
+					UserID: userIDInt,
+				})
+				if err != nil {
+					handleCartError(c, log, err, "Failed to pay cart with wallet")
+					return
+				}
+
+				c.JSON(http.StatusOK, gin.H{
+					"success": true,
+					"message": "cart paid",
+					"data":    result,
+				})
+			},
+		)
+	}
+
 	// Grupo de rutas de pagos
 	paymentsGroup := router.Group("/api/v1/payments")
 	paymentsGroup.Use(authMiddleware.Authenticate())
@@ -465,10 +896,11 @@ func setupReservationAndPaymentRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *
 				c.JSON(http.StatusCreated, gin.H{
 					"success": true,
 					"data": gin.H{
-						"payment_id":    output.PaymentID.String(),
-						"client_secret": output.ClientSecret,
-						"amount":        output.Amount,
-						"currency":      output.Currency,
+						"payment_id":      output.PaymentID.String(),
+						"client_secret":   output.ClientSecret,
+						"amount":          output.Amount,
+						"currency":        output.Currency,
+						"price_breakdown": output.PriceBreakdown,
 					},
 				})
 			},
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	raffleHandler "github.com/sorteos-platform/backend/internal/adapters/http/handler/raffle"
+	"github.com/sorteos-platform/backend/internal/adapters/http/middleware"
+	redisAdapter "github.com/sorteos-platform/backend/internal/adapters/redis"
+	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/config"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// setupRaffleSeriesRoutes configura las rutas de plantillas de sorteo y series recurrentes
+func setupRaffleSeriesRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
+	// Inicializar repositorios
+	raffleRepo := db.NewRaffleRepository(gormDB)
+	raffleNumberRepo := db.NewRaffleNumberRepository(gormDB)
+	raffleImageRepo := db.NewRaffleImageRepository(gormDB)
+	userRepo := db.NewUserRepository(gormDB)
+	auditRepo := db.NewAuditLogRepository(gormDB)
+	templateRepo := db.NewRaffleTemplateRepository(gormDB, log)
+	seriesRepo := db.NewRaffleSeriesRepository(gormDB, log)
+	lockService := redisinfra.NewLockService(rdb)
+
+	// Inicializar use cases
+	createRaffleUC := raffleuc.NewCreateRaffleUseCase(raffleRepo, raffleNumberRepo, userRepo, auditRepo, gormDB, log)
+	publishRaffleUC := raffleuc.NewPublishRaffleUseCase(raffleRepo, raffleImageRepo, raffleNumberRepo, auditRepo)
+
+	createTemplateUC := raffleuc.NewCreateRaffleTemplateUseCase(
+		templateRepo,
+		raffleRepo,
+		raffleImageRepo,
+		db.NewRafflePrizeRepository(gormDB, log),
+		db.NewPricingRuleRepository(gormDB, log),
+		auditRepo,
+		log,
+	)
+	updateTemplateUC := raffleuc.NewUpdateRaffleTemplateUseCase(templateRepo, auditRepo, log)
+	deleteTemplateUC := raffleuc.NewDeleteRaffleTemplateUseCase(templateRepo, seriesRepo)
+	listTemplatesUC := raffleuc.NewListRaffleTemplatesUseCase(templateRepo)
+	getTemplateUC := raffleuc.NewGetRaffleTemplateUseCase(templateRepo)
+	createFromTemplateUC := raffleuc.NewCreateRaffleFromTemplateUseCase(templateRepo, raffleImageRepo, createRaffleUC, publishRaffleUC)
+
+	createSeriesUC := raffleuc.NewCreateRaffleSeriesUseCase(seriesRepo, templateRepo, auditRepo, log)
+	updateSeriesUC := raffleuc.NewUpdateRaffleSeriesUseCase(seriesRepo, auditRepo, lockService, log)
+	listSeriesUC := raffleuc.NewListRaffleSeriesUseCase(seriesRepo)
+	getSeriesStatsUC := raffleuc.NewGetRaffleSeriesStatsUseCase(seriesRepo, templateRepo)
+
+	// Inicializar handlers
+	createTemplateHandler := raffleHandler.NewCreateRaffleTemplateHandler(createTemplateUC)
+	updateTemplateHandler := raffleHandler.NewUpdateRaffleTemplateHandler(updateTemplateUC)
+	deleteTemplateHandler := raffleHandler.NewDeleteRaffleTemplateHandler(deleteTemplateUC)
+	listTemplatesHandler := raffleHandler.NewListRaffleTemplatesHandler(listTemplatesUC)
+	getTemplateHandler := raffleHandler.NewGetRaffleTemplateHandler(getTemplateUC)
+	createFromTemplateHandler := raffleHandler.NewCreateRaffleFromTemplateHandler(createFromTemplateUC)
+
+	createSeriesHandler := raffleHandler.NewCreateRaffleSeriesHandler(createSeriesUC)
+	updateSeriesHandler := raffleHandler.NewUpdateRaffleSeriesHandler(updateSeriesUC)
+	listSeriesHandler := raffleHandler.NewListRaffleSeriesHandler(listSeriesUC)
+	getSeriesStatsHandler := raffleHandler.NewGetRaffleSeriesStatsHandler(getSeriesStatsUC)
+
+	// Inicializar middlewares
+	tokenMgr := redisAdapter.NewTokenManager(rdb, &cfg.JWT)
+	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
+	authMiddleware := middleware.NewAuthMiddleware(tokenMgr, blacklistService, log)
+	rateLimiter := middleware.NewRateLimiter(rdb, log)
+
+	// Grupo de rutas de plantillas (requiere email verificado, igual que crear sorteos)
+	templatesGroup := router.Group("/api/v1/raffle-templates")
+	templatesGroup.Use(authMiddleware.Authenticate())
+	templatesGroup.Use(authMiddleware.RequireMinKYC("email_verified"))
+	{
+		// GET /api/v1/raffle-templates - Plantillas del organizador
+		templatesGroup.GET("", listTemplatesHandler.Handle)
+
+		// POST /api/v1/raffle-templates - Guardar plantilla (desde datos o desde un sorteo existente)
+		templatesGroup.POST("",
+			rateLimiter.LimitByUser(20, time.Hour), // Max 20 plantillas por hora
+			createTemplateHandler.Handle,
+		)
+
+		// GET /api/v1/raffle-templates/:id - Detalle de la plantilla
+		templatesGroup.GET("/:id", getTemplateHandler.Handle)
+
+		// PUT /api/v1/raffle-templates/:id - Actualizar plantilla
+		templatesGroup.PUT("/:id", updateTemplateHandler.Handle)
+
+		// DELETE /api/v1/raffle-templates/:id - Eliminar plantilla (sin series activas)
+		templatesGroup.DELETE("/:id", deleteTemplateHandler.Handle)
+
+		// POST /api/v1/raffle-templates/:id/raffles - Crear sorteo desde la plantilla
+		templatesGroup.POST("/:id/raffles",
+			rateLimiter.LimitByUser(10, time.Hour), // Mismo límite que crear sorteos
+			createFromTemplateHandler.Handle,
+		)
+	}
+
+	// Grupo de rutas de series recurrentes
+	seriesGroup := router.Group("/api/v1/raffle-series")
+	seriesGroup.Use(authMiddleware.Authenticate())
+	seriesGroup.Use(authMiddleware.RequireMinKYC("email_verified"))
+	{
+		// GET /api/v1/raffle-series - Series del organizador
+		seriesGroup.GET("", listSeriesHandler.Handle)
+
+		// POST /api/v1/raffle-series - Crear serie desde una plantilla
+		seriesGroup.POST("",
+			rateLimiter.LimitByUser(10, time.Hour), // Max 10 series por hora
+			createSeriesHandler.Handle,
+		)
+
+		// GET /api/v1/raffle-series/:id - Serie con sus sorteos y estadísticas
+		seriesGroup.GET("/:id", getSeriesStatsHandler.Handle)
+
+		// PATCH /api/v1/raffle-series/:id - Modificar, pausar, reanudar o finalizar la serie
+		seriesGroup.PATCH("/:id", updateSeriesHandler.Handle)
+	}
+
+	log.Info("Raffle series routes registered",
+		logger.Int("endpoints", 10),
+		logger.String("base_path", "/api/v1/raffle-templates, /api/v1/raffle-series"))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -17,6 +18,7 @@ import (
 	websocketHandler "github.com/sorteos-platform/backend/internal/adapters/http/handler/websocket"
 	"github.com/sorteos-platform/backend/internal/adapters/http/middleware"
 	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
 	redisAdapter "github.com/sorteos-platform/backend/internal/adapters/redis"
 	"github.com/sorteos-platform/backend/internal/adapters/notifier"
 	"github.com/sorteos-platform/backend/internal/usecase/auth"
@@ -28,6 +30,7 @@ import (
 	walletuc "github.com/sorteos-platform/backend/internal/usecase/wallet"
 	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
 	"github.com/sorteos-platform/backend/internal/infrastructure/pagadito"
+	"github.com/sorteos-platform/backend/internal/infrastructure/beacon"
 	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
 	"github.com/sorteos-platform/backend/cmd/api/handlers"
 	"github.com/sorteos-platform/backend/pkg/config"
@@ -35,6 +38,20 @@ import (
 )
 
 // setupAuthRoutes configura las rutas de autenticación y retorna el email notifier para testing
+// newEmailNotifier crea el notifier de email (SMTP o SendGrid según configuración)
+func newEmailNotifier(cfg *config.Config, log *logger.Logger) notifier.Notifier {
+	if cfg.EmailProvider == "smtp" {
+		return notifier.NewSMTPNotifier(&cfg.SMTP, log)
+	}
+	return notifier.NewSendGridNotifier(&cfg.SendGrid, log)
+}
+
+// newDrawEntropyResolver crea el resolver de la entropía pública de los sorteos verificables (beacon drand)
+func newDrawEntropyResolver(cfg *config.Config) *raffleuc.DrawEntropyResolver {
+	client := beacon.NewDrandClient(cfg.DrawBeacon.URL, domain.DrawBeaconChainHash, cfg.DrawBeacon.Timeout)
+	return raffleuc.NewDrawEntropyResolver(client)
+}
+
 func setupAuthRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg *config.Config, log *logger.Logger) notifier.Notifier {
 	// Inicializar repositorios
 	userRepo := db.NewUserRepository(gormDB)
@@ -49,16 +66,14 @@ func setupAuthRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg
 	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
 
 	// Inicializar notifier (SMTP o SendGrid según configuración)
-	var emailNotifier notifier.Notifier
+	emailNotifier := newEmailNotifier(cfg, log)
 	if cfg.EmailProvider == "smtp" {
-		emailNotifier = notifier.NewSMTPNotifier(&cfg.SMTP, log)
 		log.Info("Email provider configured",
 			logger.String("provider", "smtp"),
 			logger.String("host", cfg.SMTP.Host),
 			logger.Int("port", cfg.SMTP.Port),
 		)
 	} else {
-		emailNotifier = notifier.NewSendGridNotifier(&cfg.SendGrid, log)
 		log.Info("Email provider configured",
 			logger.String("provider", "sendgrid"),
 		)
@@ -139,6 +154,7 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 	raffleRepo := db.NewRaffleRepository(gormDB)
 	raffleNumberRepo := db.NewRaffleNumberRepository(gormDB)
 	raffleImageRepo := db.NewRaffleImageRepository(gormDB)
+	prizeRepo := db.NewRafflePrizeRepository(gormDB, log)
 	categoryRepo := db.NewCategoryRepository(gormDB, log)
 	userRepo := db.NewUserRepository(gormDB)
 	auditRepo := db.NewAuditLogRepository(gormDB)
@@ -158,11 +174,12 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 		gormDB,
 		log,
 	)
-	listRafflesUseCase := raffleuc.NewListRafflesUseCase(raffleRepo)
+	listRafflesUseCase := raffleuc.NewListRafflesUseCase(raffleRepo, prizeRepo)
 	getRaffleDetailUseCase := raffleuc.NewGetRaffleDetailUseCase(
 		raffleRepo,
 		raffleNumberRepo,
 		raffleImageRepo,
+		prizeRepo,
 	)
 	publishRaffleUseCase := raffleuc.NewPublishRaffleUseCase(
 		raffleRepo,
@@ -175,6 +192,11 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 	deleteRaffleUseCase := raffleuc.NewDeleteRaffleUseCase(raffleRepo, auditRepo)
 	getUserTicketsUseCase := raffleuc.NewGetUserTicketsUseCase(raffleNumberRepo, raffleRepo)
 	listRaffleBuyersUseCase := raffleuc.NewListRaffleBuyersUseCase(raffleRepo, raffleNumberRepo, userRepo)
+	verifyDrawUseCase := raffleuc.NewVerifyDrawUseCase(raffleRepo, raffleNumberRepo)
+	getDrawRoomUseCase := raffleuc.NewGetDrawRoomUseCase(raffleRepo, db.NewDrawRoomRepository(gormDB, log))
+	pricingRuleRepo := db.NewPricingRuleRepository(gormDB, log)
+	setPricingRulesUseCase := raffleuc.NewSetPricingRulesUseCase(raffleRepo, pricingRuleRepo, auditRepo)
+	getPricingRulesUseCase := raffleuc.NewGetPricingRulesUseCase(raffleRepo, pricingRuleRepo)
 
 	// Use case de categorías
 	listCategoriesUseCase := categoryuc.NewListCategoriesUseCase(categoryRepo, log)
@@ -196,6 +218,10 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 	deleteRaffleHandler := raffleHandler.NewDeleteRaffleHandler(deleteRaffleUseCase)
 	getUserTicketsHandler := raffleHandler.NewGetUserTicketsHandler(getUserTicketsUseCase)
 	listRaffleBuyersHandler := raffleHandler.NewListRaffleBuyersHandler(listRaffleBuyersUseCase)
+	verifyDrawHandler := raffleHandler.NewVerifyDrawHandler(verifyDrawUseCase)
+	getDrawRoomHandler := raffleHandler.NewGetDrawRoomHandler(getDrawRoomUseCase)
+	setPricingRulesHandler := raffleHandler.NewSetPricingRulesHandler(setPricingRulesUseCase)
+	getPricingRulesHandler := raffleHandler.NewGetPricingRulesHandler(getPricingRulesUseCase)
 
 	// Handler de categorías
 	listCategoriesHandler := categoryHandler.NewListCategoriesHandler(listCategoriesUseCase)
@@ -237,12 +263,24 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 
 			// Lista de compradores (solo para owner del sorteo)
 			protected.GET("/:id/buyers", listRaffleBuyersHandler.Handle)
+
+			// Reglas de precio: paquetes, descuentos por cantidad y anticipados (owner o admin)
+			protected.PUT("/:id/pricing", setPricingRulesHandler.Handle)
 		}
 
 		// Detalle de sorteo - DESPUÉS de rutas específicas para evitar conflictos
 		// Usa OptionalAuth para personalizar según si el usuario está logueado
 		rafflesGroup.GET("/:id", authMiddleware.OptionalAuth(), getRaffleDetailHandler.Handle)
 
+		// Verificación pública del sorteo (commit-reveal) - sin autenticación
+		rafflesGroup.GET("/:id/draw-proof", verifyDrawHandler.Handle)
+
+		// Sala del sorteo en vivo: cuenta regresiva, transmisión y timeline - sin autenticación
+		rafflesGroup.GET("/:id/draw-room", getDrawRoomHandler.Handle)
+
+		// Reglas de precio vigentes y cotización (?quantity=N) - sin autenticación
+		rafflesGroup.GET("/:id/pricing", getPricingRulesHandler.Handle)
+
 		// Rutas de admin
 		admin := rafflesGroup.Group("")
 		admin.Use(authMiddleware.Authenticate())
@@ -253,24 +291,76 @@ func setupRaffleRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, c
 	}
 }
 
+// newRaffleSnapshotProvider construye el snapshot de disponibilidad de números de un sorteo (por UUID)
+// agrupando los números por estado
+func newRaffleSnapshotProvider(gormDB *gorm.DB) websocket.SnapshotProvider {
+	raffleRepo := db.NewRaffleRepository(gormDB)
+	raffleNumberRepo := db.NewRaffleNumberRepository(gormDB)
+
+	return func(ctx context.Context, raffleID string) (map[string]interface{}, error) {
+		raffle, err := raffleRepo.FindByUUID(raffleID)
+		if err != nil {
+			return nil, err
+		}
+
+		numbers, err := raffleNumberRepo.FindByRaffleID(raffle.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		byStatus := map[domain.RaffleNumberStatus][]string{
+			domain.RaffleNumberStatusAvailable: {},
+			domain.RaffleNumberStatusReserved:  {},
+			domain.RaffleNumberStatusSold:      {},
+		}
+		for _, number := range numbers {
+			byStatus[number.Status] = append(byStatus[number.Status], number.Number)
+		}
+
+		return map[string]interface{}{
+			"raffle_status": raffle.Status,
+			"available":     byStatus[domain.RaffleNumberStatusAvailable],
+			"reserved":      byStatus[domain.RaffleNumberStatusReserved],
+			"sold":          byStatus[domain.RaffleNumberStatusSold],
+		}, nil
+	}
+}
+
 // setupWebSocketRoutes configura las rutas de WebSocket
-func setupWebSocketRoutes(router *gin.Engine, wsHub *websocket.Hub, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
+func setupWebSocketRoutes(router *gin.Engine, gormDB *gorm.DB, wsHub *websocket.Hub, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
 	// Inicializar token manager y auth middleware (opcional para WebSocket)
 	tokenMgr := redisAdapter.NewTokenManager(rdb, &cfg.JWT)
 	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
 	authMiddleware := middleware.NewAuthMiddleware(tokenMgr, blacklistService, log)
 
-	// Inicializar handler
-	wsHandler := websocketHandler.NewWebSocketHandler(wsHub)
+	// Inicializar handler (tickets de conexión y validación de origen)
+	wsTicketService := redisinfra.NewWSTicketService(rdb)
+	wsHandler := websocketHandler.NewWebSocketHandler(wsHub, wsTicketService, db.NewUserRepository(gormDB), cfg.Server.AllowedOrigins)
+
+	// Snapshot de disponibilidad para clientes que se reconectan con un hueco mayor al buffer de reenvío
+	wsHub.SetSnapshotProvider(newRaffleSnapshotProvider(gormDB))
+
+	// Canal privado del usuario (eventos de reserva y pago)
+	wsGroup := router.Group("/api/v1/ws")
+	{
+		// Ticket de un solo uso para abrir el WebSocket (requiere autenticación)
+		wsGroup.POST("/ticket",
+			authMiddleware.Authenticate(),
+			wsHandler.IssueTicket,
+		)
+
+		// WebSocket del canal privado (autenticado con ?ticket=)
+		wsGroup.GET("/me", wsHandler.HandleUserConnection)
+	}
 
 	// Grupo de rutas WebSocket
 	rafflesGroup := router.Group("/api/v1/raffles")
 	{
-		// WebSocket connection endpoint (público con autenticación opcional)
-		rafflesGroup.GET("/:id/ws",
-			// Opcional: agregar authMiddleware.Authenticate() para requerir login
-			wsHandler.HandleConnection,
-		)
+		// WebSocket connection endpoint (público, ?ticket= opcional identifica al usuario)
+		rafflesGroup.GET("/:id/ws", wsHandler.HandleConnection)
+
+		// Server-Sent Events (alternativa para redes que bloquean WebSocket, Last-Event-ID para reanudar)
+		rafflesGroup.GET("/:id/events", wsHandler.HandleEvents)
 
 		// Stats endpoint (solo para admin)
 		rafflesGroup.GET("/:id/ws/stats",
@@ -391,6 +481,50 @@ func loadPagaditoConfig(repo *db.PostgresPaymentProcessorRepository, log *logger
 	}, nil
 }
 
+// newCreditReconciler crea la conciliación de compras de créditos contra Pagadito
+// Retorna error si Pagadito no está configurado o está deshabilitado
+func newCreditReconciler(gormDB *gorm.DB, lockService *redisinfra.LockService, log *logger.Logger) (*creditsuc.ReconcilePurchasesUseCase, error) {
+	pagaditoConfig, err := loadPagaditoConfig(db.NewPaymentProcessorRepository(gormDB, log), log)
+	if err != nil {
+		return nil, err
+	}
+
+	creditPurchaseRepo := db.NewCreditPurchaseRepository(gormDB, log)
+	walletRepo := db.NewWalletRepository(gormDB, log)
+	walletTransactionRepo := db.NewWalletTransactionRepository(gormDB, log)
+	auditRepo := db.NewAuditLogRepository(gormDB)
+	pagaditoClient := pagadito.NewHTTPClient(pagaditoConfig)
+
+	addFundsUC := walletuc.NewAddFundsUseCase(
+		walletRepo,
+		walletTransactionRepo,
+		db.NewUserRepository(gormDB),
+		auditRepo,
+		log,
+	)
+
+	processCallbackUC := creditsuc.NewProcessPagaditoCallbackUseCase(
+		creditPurchaseRepo,
+		walletRepo,
+		walletTransactionRepo,
+		auditRepo,
+		pagaditoClient,
+		addFundsUC,
+		log,
+	)
+
+	return creditsuc.NewReconcilePurchasesUseCase(
+		creditPurchaseRepo,
+		db.NewCreditReconciliationRepository(gormDB, log),
+		db.NewSystemParameterRepository(gormDB, log),
+		auditRepo,
+		pagaditoClient,
+		processCallbackUC,
+		creditsuc.NewRedisReconciliationLocker(lockService),
+		log,
+	), nil
+}
+
 // setupCreditsRoutes configura las rutas de compra de créditos con Pagadito
 func setupCreditsRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
 	// Inicializar repositorios
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/http/handler/wallet"
+	"github.com/sorteos-platform/backend/internal/adapters/http/middleware"
+	redisAdapter "github.com/sorteos-platform/backend/internal/adapters/redis"
+	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/config"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// setupWithdrawalRoutes configura las rutas de retiros de ganancias a IBAN
+func setupWithdrawalRoutes(router *gin.Engine, gormDB *gorm.DB, rdb *redis.Client, cfg *config.Config, log *logger.Logger) {
+	// Inicializar use cases
+	requestWithdrawalUC := withdrawaluc.NewRequestWithdrawalUseCase(gormDB, log)
+	listWithdrawalsUC := withdrawaluc.NewListWithdrawalsUseCase(gormDB, log)
+	cancelWithdrawalUC := withdrawaluc.NewCancelWithdrawalUseCase(gormDB, log)
+
+	// Inicializar handlers
+	requestWithdrawalHandler := wallet.NewRequestWithdrawalHandler(requestWithdrawalUC, log)
+	listWithdrawalsHandler := wallet.NewListWithdrawalsHandler(listWithdrawalsUC, log)
+	cancelWithdrawalHandler := wallet.NewCancelWithdrawalHandler(cancelWithdrawalUC, log)
+
+	// Inicializar middlewares
+	tokenMgr := redisAdapter.NewTokenManager(rdb, &cfg.JWT)
+	blacklistService := redisinfra.NewTokenBlacklistService(rdb)
+	authMiddleware := middleware.NewAuthMiddleware(tokenMgr, blacklistService, log)
+	rateLimiter := middleware.NewRateLimiter(rdb, log)
+
+	// Grupo de rutas de retiros (requiere cédula verificada)
+	withdrawalsGroup := router.Group("/api/v1/wallet/withdrawals")
+	withdrawalsGroup.Use(authMiddleware.Authenticate())
+	withdrawalsGroup.Use(authMiddleware.RequireMinKYC("cedula_verified"))
+	{
+		// GET /api/v1/wallet/withdrawals - Historial de retiros
+		withdrawalsGroup.GET("", listWithdrawalsHandler.Handle)
+
+		// POST /api/v1/wallet/withdrawals - Solicitar retiro de ganancias
+		withdrawalsGroup.POST("",
+			rateLimiter.LimitByUser(5, time.Hour), // Max 5 solicitudes por hora
+			requestWithdrawalHandler.Handle,
+		)
+
+		// POST /api/v1/wallet/withdrawals/:uuid/cancel - Cancelar retiro pendiente
+		withdrawalsGroup.POST("/:uuid/cancel", cancelWithdrawalHandler.Handle)
+	}
+
+	log.Info("Withdrawal routes registered",
+		logger.Int("endpoints", 3),
+		logger.String("base_path", "/api/v1/wallet/withdrawals"))
+}
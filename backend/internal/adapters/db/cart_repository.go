@@ -0,0 +1,13 @@
+package db
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+	"github.com/sorteos-platform/backend/internal/infrastructure/database"
+)
+
+// NewCartRepository crea un nuevo repositorio de carritos
+func NewCartRepository(db *gorm.DB) repositories.CartRepository {
+	return database.NewPostgresCartRepository(db)
+}
@@ -249,3 +249,41 @@ func (r *PostgresCreditPurchaseRepository) MarkExpired() (int64, error) {
 
 	return result.RowsAffected, nil
 }
+
+// ExpireIfPending marca una compra como expirada solo si sigue pendiente; false si cambió de estado
+func (r *PostgresCreditPurchaseRepository) ExpireIfPending(id int64) (bool, error) {
+	result := r.db.
+		Model(&domain.CreditPurchase{}).
+		Where("id = ? AND status = ?", id, domain.CreditPurchaseStatusPending).
+		Updates(map[string]interface{}{
+			"status":     domain.CreditPurchaseStatusExpired,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		r.log.Error("Error expirando compra",
+			logger.Int64("purchase_id", id),
+			logger.Error(result.Error))
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindStale busca compras pendientes o en proceso sin actualizar desde updatedBefore (más antiguas primero)
+func (r *PostgresCreditPurchaseRepository) FindStale(updatedBefore time.Time, limit int) ([]*domain.CreditPurchase, error) {
+	var purchases []*domain.CreditPurchase
+
+	if err := r.db.
+		Where("status IN (?, ?)", domain.CreditPurchaseStatusPending, domain.CreditPurchaseStatusProcessing).
+		Where("updated_at < ?", updatedBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&purchases).Error; err != nil {
+		r.log.Error("Error buscando compras sin conciliar",
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return purchases, nil
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresCreditReconciliationRepository implementación de CreditReconciliationRepository con PostgreSQL
+type PostgresCreditReconciliationRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewCreditReconciliationRepository crea una nueva instancia
+func NewCreditReconciliationRepository(db *gorm.DB, log *logger.Logger) *PostgresCreditReconciliationRepository {
+	return &PostgresCreditReconciliationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create guarda la conciliación con su detalle (asociación has-many)
+func (r *PostgresCreditReconciliationRepository) Create(reconciliation *domain.CreditReconciliation) error {
+	if reconciliation.UUID == "" {
+		reconciliation.UUID = uuid.New().String()
+	}
+
+	if err := r.db.Create(reconciliation).Error; err != nil {
+		r.log.Error("Error guardando conciliación de compras de créditos",
+			logger.Int("items", len(reconciliation.Items)),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByID busca una conciliación con su detalle
+func (r *PostgresCreditReconciliationRepository) FindByID(id int64) (*domain.CreditReconciliation, error) {
+	var reconciliation domain.CreditReconciliation
+
+	if err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&reconciliation, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando conciliación por ID",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &reconciliation, nil
+}
+
+// List lista conciliaciones (más recientes primero, sin detalle)
+func (r *PostgresCreditReconciliationRepository) List(limit, offset int) ([]*domain.CreditReconciliation, int64, error) {
+	var reconciliations []*domain.CreditReconciliation
+	var total int64
+
+	if err := r.db.Model(&domain.CreditReconciliation{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	if err := r.db.Order("started_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reconciliations).Error; err != nil {
+		r.log.Error("Error listando conciliaciones", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return reconciliations, total, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresDrawRoomRepository implementación de DrawRoomRepository con PostgreSQL
+type PostgresDrawRoomRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewDrawRoomRepository crea una nueva instancia
+func NewDrawRoomRepository(db *gorm.DB, log *logger.Logger) *PostgresDrawRoomRepository {
+	return &PostgresDrawRoomRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// FindByRaffleID busca la sala de una rifa
+func (r *PostgresDrawRoomRepository) FindByRaffleID(raffleID int64) (*domain.DrawRoom, error) {
+	var room domain.DrawRoom
+
+	if err := r.db.Where("raffle_id = ?", raffleID).First(&room).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando sala de sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &room, nil
+}
+
+// Save crea o actualiza la sala
+func (r *PostgresDrawRoomRepository) Save(room *domain.DrawRoom) error {
+	if err := r.db.Save(room).Error; err != nil {
+		r.log.Error("Error guardando sala de sorteo",
+			logger.Int64("raffle_id", room.RaffleID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// AddEvent agrega un evento al timeline
+func (r *PostgresDrawRoomRepository) AddEvent(event *domain.DrawRoomEvent) error {
+	if err := r.db.Create(event).Error; err != nil {
+		r.log.Error("Error guardando evento de sala de sorteo",
+			logger.Int64("draw_room_id", event.DrawRoomID),
+			logger.String("type", string(event.Type)),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// ListEvents lista el timeline de la sala en orden cronológico
+func (r *PostgresDrawRoomRepository) ListEvents(roomID int64) ([]*domain.DrawRoomEvent, error) {
+	var events []*domain.DrawRoomEvent
+
+	if err := r.db.Where("draw_room_id = ?", roomID).
+		Order("created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		r.log.Error("Error listando eventos de sala de sorteo",
+			logger.Int64("draw_room_id", roomID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return events, nil
+}
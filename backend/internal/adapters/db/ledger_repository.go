@@ -0,0 +1,194 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresLedgerRepository implementación de LedgerRepository con PostgreSQL
+type PostgresLedgerRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewLedgerRepository crea una nueva instancia
+func NewLedgerRepository(db *gorm.DB, log *logger.Logger) *PostgresLedgerRepository {
+	return &PostgresLedgerRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// userBalancesQuery saldos del libro mayor por usuario y moneda (saldo acreedor: créditos - débitos)
+const userBalancesQuery = `
+	SELECT a.owner_user_id AS user_id, a.currency,
+		SUM(CASE WHEN a.account_type = 'user_available' THEN
+			CASE WHEN l.direction = 'credit' THEN l.amount ELSE -l.amount END ELSE 0 END) AS available,
+		SUM(CASE WHEN a.account_type = 'user_earnings' THEN
+			CASE WHEN l.direction = 'credit' THEN l.amount ELSE -l.amount END ELSE 0 END) AS earnings,
+		SUM(CASE WHEN a.account_type = 'user_pending' THEN
+			CASE WHEN l.direction = 'credit' THEN l.amount ELSE -l.amount END ELSE 0 END) AS pending
+	FROM journal_lines l
+	JOIN ledger_accounts a ON a.id = l.account_id
+	WHERE a.account_type IN ('user_available', 'user_earnings', 'user_pending')
+	GROUP BY a.owner_user_id, a.currency`
+
+// walletDiscrepanciesQuery billeteras cuyos saldos no coinciden con el libro mayor
+const walletDiscrepanciesQuery = `
+	SELECT w.id AS wallet_id, w.user_id, w.currency,
+		w.balance_available, COALESCE(b.available, 0) AS ledger_available,
+		w.earnings_balance, COALESCE(b.earnings, 0) AS ledger_earnings,
+		w.pending_balance, COALESCE(b.pending, 0) AS ledger_pending
+	FROM wallets w
+	LEFT JOIN (` + userBalancesQuery + `) b ON b.user_id = w.user_id AND b.currency = w.currency
+	WHERE w.balance_available <> COALESCE(b.available, 0)
+		OR w.earnings_balance <> COALESCE(b.earnings, 0)
+		OR w.pending_balance <> COALESCE(b.pending, 0)`
+
+// Post registra un asiento balanceado en la transacción actual
+func (r *PostgresLedgerRepository) Post(entry *domain.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	// Idempotencia: el movimiento ya fue registrado
+	existing, err := r.FindByIdempotencyKey(entry.IdempotencyKey)
+	if err != nil && err != errors.ErrNotFound {
+		return err
+	}
+	if existing != nil {
+		entry.ID = existing.ID
+		entry.UUID = existing.UUID
+		return nil
+	}
+
+	for _, line := range entry.Lines {
+		accountID, err := r.resolveAccount(line.Account, entry.Currency)
+		if err != nil {
+			return err
+		}
+		line.AccountID = accountID
+	}
+
+	if entry.UUID == "" {
+		entry.UUID = uuid.New().String()
+	}
+
+	// Crea el asiento y sus líneas (asociación has-many)
+	if err := r.db.Create(entry).Error; err != nil {
+		r.log.Error("Error registrando asiento contable",
+			logger.String("entry_type", string(entry.EntryType)),
+			logger.String("idempotency_key", entry.IdempotencyKey),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// resolveAccount obtiene el ID de una cuenta creándola si no existe
+func (r *PostgresLedgerRepository) resolveAccount(ref domain.LedgerAccountRef, currency string) (int64, error) {
+	if err := r.db.Exec(`
+		INSERT INTO ledger_accounts (account_type, owner_user_id, currency, created_at)
+		VALUES (?, ?, ?, NOW())
+		ON CONFLICT (account_type, (COALESCE(owner_user_id, 0)), currency) DO NOTHING
+	`, ref.Type, ref.OwnerUserID, currency).Error; err != nil {
+		return 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	var account domain.LedgerAccount
+	query := r.db.Where("account_type = ? AND currency = ?", ref.Type, currency)
+	if ref.OwnerUserID != nil {
+		query = query.Where("owner_user_id = ?", *ref.OwnerUserID)
+	} else {
+		query = query.Where("owner_user_id IS NULL")
+	}
+	if err := query.First(&account).Error; err != nil {
+		r.log.Error("Error resolviendo cuenta contable",
+			logger.String("account_type", string(ref.Type)),
+			logger.Error(err))
+		return 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return account.ID, nil
+}
+
+// FindByIdempotencyKey busca un asiento por su clave de idempotencia
+func (r *PostgresLedgerRepository) FindByIdempotencyKey(key string) (*domain.JournalEntry, error) {
+	var entry domain.JournalEntry
+
+	if err := r.db.Preload("Lines").Where("idempotency_key = ?", key).First(&entry).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando asiento contable",
+			logger.String("idempotency_key", key),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &entry, nil
+}
+
+// FindWalletDiscrepancies compara los saldos de las billeteras con los totales del libro mayor
+func (r *PostgresLedgerRepository) FindWalletDiscrepancies(limit int) ([]*domain.WalletLedgerDiscrepancy, int64, error) {
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM (" + walletDiscrepanciesQuery + ") d").Scan(&total).Error; err != nil {
+		r.log.Error("Error contando diferencias del libro mayor", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	var discrepancies []*domain.WalletLedgerDiscrepancy
+	if total == 0 {
+		return discrepancies, 0, nil
+	}
+
+	if err := r.db.Raw(walletDiscrepanciesQuery+" ORDER BY w.id LIMIT ?", limit).Scan(&discrepancies).Error; err != nil {
+		r.log.Error("Error buscando diferencias del libro mayor", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return discrepancies, total, nil
+}
+
+// FindUnbalancedEntries busca asientos cuyos débitos no igualan sus créditos
+func (r *PostgresLedgerRepository) FindUnbalancedEntries(limit int) ([]int64, error) {
+	var entryIDs []int64
+
+	if err := r.db.Raw(`
+		SELECT entry_id
+		FROM journal_lines
+		GROUP BY entry_id
+		HAVING SUM(CASE WHEN direction = 'debit' THEN amount ELSE -amount END) <> 0
+		ORDER BY entry_id
+		LIMIT ?
+	`, limit).Scan(&entryIDs).Error; err != nil {
+		r.log.Error("Error buscando asientos desbalanceados", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return entryIDs, nil
+}
+
+// Totals retorna la suma global de débitos y créditos
+func (r *PostgresLedgerRepository) Totals() (decimal.Decimal, decimal.Decimal, error) {
+	var totals struct {
+		Debits  decimal.Decimal
+		Credits decimal.Decimal
+	}
+
+	if err := r.db.Raw(`
+		SELECT
+			COALESCE(SUM(CASE WHEN direction = 'debit' THEN amount ELSE 0 END), 0) AS debits,
+			COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE 0 END), 0) AS credits
+		FROM journal_lines
+	`).Scan(&totals).Error; err != nil {
+		return decimal.Zero, decimal.Zero, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return totals.Debits, totals.Credits, nil
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresLotteryResultRepository implementación de LotteryResultRepository con PostgreSQL
+type PostgresLotteryResultRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewLotteryResultRepository crea una nueva instancia
+func NewLotteryResultRepository(db *gorm.DB, log *logger.Logger) *PostgresLotteryResultRepository {
+	return &PostgresLotteryResultRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea un nuevo resultado de lotería
+func (r *PostgresLotteryResultRepository) Create(result *domain.LotteryResult) error {
+	if result.UUID == "" {
+		result.UUID = uuid.New().String()
+	}
+
+	if err := result.Validate(); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	// Solo puede existir un resultado vigente por fecha
+	existing, err := r.FindActiveByDrawDate(result.DrawDate)
+	if err != nil && err != errors.ErrNotFound {
+		return err
+	}
+	if existing != nil {
+		return errors.New("LOTTERY_RESULT_EXISTS", "ya existe un resultado registrado para esa fecha", 409, nil)
+	}
+
+	if err := r.db.Create(result).Error; err != nil {
+		r.log.Error("Error creando resultado de lotería",
+			logger.String("draw_date", result.DrawDate.Format("2006-01-02")),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByID busca un resultado por ID
+func (r *PostgresLotteryResultRepository) FindByID(id int64) (*domain.LotteryResult, error) {
+	var result domain.LotteryResult
+
+	if err := r.db.First(&result, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando resultado de lotería por ID",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &result, nil
+}
+
+// FindActiveByDrawDate busca el resultado pendiente o confirmado de una fecha
+func (r *PostgresLotteryResultRepository) FindActiveByDrawDate(drawDate time.Time) (*domain.LotteryResult, error) {
+	var result domain.LotteryResult
+
+	if err := r.db.Where("draw_date = ? AND status <> ?",
+		drawDate.Format("2006-01-02"), domain.LotteryResultStatusRejected).
+		First(&result).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando resultado de lotería por fecha",
+			logger.String("draw_date", drawDate.Format("2006-01-02")),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &result, nil
+}
+
+// List lista resultados de lotería (más recientes primero)
+func (r *PostgresLotteryResultRepository) List(status *domain.LotteryResultStatus, limit, offset int) ([]*domain.LotteryResult, int64, error) {
+	var results []*domain.LotteryResult
+	var total int64
+
+	query := r.db.Model(&domain.LotteryResult{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	if err := query.Order("draw_date DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&results).Error; err != nil {
+		r.log.Error("Error listando resultados de lotería", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return results, total, nil
+}
+
+// Update actualiza un resultado existente
+func (r *PostgresLotteryResultRepository) Update(result *domain.LotteryResult) error {
+	if err := r.db.Save(result).Error; err != nil {
+		r.log.Error("Error actualizando resultado de lotería",
+			logger.Int64("id", result.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrRefundInProgress ya existe un reembolso en curso para el pago
+var ErrRefundInProgress = errors.New("REFUND_IN_PROGRESS", "ya existe un reembolso en curso para este pago", 409, nil)
+
+// PostgresPaymentRefundRepository implementación de PaymentRefundRepository con PostgreSQL
+type PostgresPaymentRefundRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewPaymentRefundRepository crea una nueva instancia
+func NewPaymentRefundRepository(db *gorm.DB, log *logger.Logger) *PostgresPaymentRefundRepository {
+	return &PostgresPaymentRefundRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea un nuevo reembolso
+func (r *PostgresPaymentRefundRepository) Create(refund *domain.PaymentRefund) error {
+	if refund.UUID == "" {
+		refund.UUID = uuid.New().String()
+	}
+
+	if err := refund.Validate(); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	if err := r.db.Create(refund).Error; err != nil {
+		// idx_payment_refunds_in_flight: un solo reembolso en curso por pago
+		if strings.Contains(err.Error(), "idx_payment_refunds_in_flight") {
+			return ErrRefundInProgress
+		}
+		r.log.Error("Error creando reembolso",
+			logger.String("payment_id", refund.PaymentID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByID busca un reembolso por ID
+func (r *PostgresPaymentRefundRepository) FindByID(id int64) (*domain.PaymentRefund, error) {
+	var refund domain.PaymentRefund
+
+	if err := r.db.First(&refund, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando reembolso por ID",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &refund, nil
+}
+
+// FindByPaymentID busca los reembolsos de un pago (más antiguos primero)
+func (r *PostgresPaymentRefundRepository) FindByPaymentID(paymentID string) ([]*domain.PaymentRefund, error) {
+	var refunds []*domain.PaymentRefund
+
+	if err := r.db.Where("payment_id = ?", paymentID).
+		Order("created_at ASC").
+		Find(&refunds).Error; err != nil {
+		r.log.Error("Error buscando reembolsos del pago",
+			logger.String("payment_id", paymentID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return refunds, nil
+}
+
+// FindDue busca reembolsos cuyo reintento o consulta de estado ya venció
+func (r *PostgresPaymentRefundRepository) FindDue(now time.Time, limit int) ([]*domain.PaymentRefund, error) {
+	var refunds []*domain.PaymentRefund
+
+	if err := r.db.Where("status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)",
+		[]domain.PaymentRefundStatus{domain.PaymentRefundStatusPending, domain.PaymentRefundStatusProcessing}, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&refunds).Error; err != nil {
+		r.log.Error("Error buscando reembolsos por reintentar", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return refunds, nil
+}
+
+// Claim reserva el reembolso hasta leaseUntil (update condicional para evitar intentos simultáneos)
+func (r *PostgresPaymentRefundRepository) Claim(id int64, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&domain.PaymentRefund{}).
+		Where("id = ? AND status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", id,
+			[]domain.PaymentRefundStatus{domain.PaymentRefundStatusPending, domain.PaymentRefundStatusProcessing}, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		r.log.Error("Error reservando reembolso",
+			logger.Int64("id", id),
+			logger.Error(result.Error))
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Update actualiza un reembolso existente
+func (r *PostgresPaymentRefundRepository) Update(refund *domain.PaymentRefund) error {
+	if err := r.db.Save(refund).Error; err != nil {
+		r.log.Error("Error actualizando reembolso",
+			logger.Int64("id", refund.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresPricingRuleRepository implementación de PricingRuleRepository con PostgreSQL
+type PostgresPricingRuleRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewPricingRuleRepository crea una nueva instancia
+func NewPricingRuleRepository(db *gorm.DB, log *logger.Logger) *PostgresPricingRuleRepository {
+	return &PostgresPricingRuleRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ReplaceForRaffle reemplaza las reglas de precio de un sorteo en una transacción
+func (r *PostgresPricingRuleRepository) ReplaceForRaffle(raffleID int64, rules []*domain.PricingRule) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("raffle_id = ?", raffleID).Delete(&domain.PricingRule{}).Error; err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			rule.ID = 0
+			rule.RaffleID = raffleID
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		r.log.Error("Error guardando reglas de precio del sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByRaffleID lista las reglas de precio de un sorteo
+func (r *PostgresPricingRuleRepository) FindByRaffleID(raffleID int64) ([]*domain.PricingRule, error) {
+	var rules []*domain.PricingRule
+
+	if err := r.db.Where("raffle_id = ?", raffleID).Order("id ASC").Find(&rules).Error; err != nil {
+		r.log.Error("Error buscando reglas de precio del sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return rules, nil
+}
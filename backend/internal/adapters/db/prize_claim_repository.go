@@ -0,0 +1,183 @@
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrPrizeClaimExists el premio del sorteo ya tiene un reclamo
+var ErrPrizeClaimExists = errors.New("PRIZE_CLAIM_EXISTS", "el premio de este sorteo ya fue procesado", 409, nil)
+
+// PostgresPrizeClaimRepository implementación de PrizeClaimRepository con PostgreSQL
+type PostgresPrizeClaimRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewPrizeClaimRepository crea una nueva instancia
+func NewPrizeClaimRepository(db *gorm.DB, log *logger.Logger) *PostgresPrizeClaimRepository {
+	return &PostgresPrizeClaimRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea un nuevo reclamo
+func (r *PostgresPrizeClaimRepository) Create(claim *domain.PrizeClaim) error {
+	if claim.UUID == "" {
+		claim.UUID = uuid.New().String()
+	}
+
+	if err := claim.Validate(); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	if err := r.db.Create(claim).Error; err != nil {
+		// idx_prize_claims_raffle_position: un solo reclamo por premio
+		if strings.Contains(err.Error(), "idx_prize_claims_raffle_position") {
+			return ErrPrizeClaimExists
+		}
+		r.log.Error("Error creando reclamo de premio",
+			logger.Int64("raffle_id", claim.RaffleID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByID busca un reclamo por ID
+func (r *PostgresPrizeClaimRepository) FindByID(id int64) (*domain.PrizeClaim, error) {
+	var claim domain.PrizeClaim
+
+	if err := r.db.First(&claim, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando reclamo de premio por ID",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &claim, nil
+}
+
+// FindByRaffleID lista los reclamos de un sorteo ordenados por posición del premio
+func (r *PostgresPrizeClaimRepository) FindByRaffleID(raffleID int64) ([]*domain.PrizeClaim, error) {
+	var claims []*domain.PrizeClaim
+
+	if err := r.db.Where("raffle_id = ?", raffleID).Order("prize_position ASC").Find(&claims).Error; err != nil {
+		r.log.Error("Error buscando reclamos de premio por sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return claims, nil
+}
+
+// List lista reclamos con filtros (plazo más próximo primero)
+func (r *PostgresPrizeClaimRepository) List(filters domain.PrizeClaimFilters, limit, offset int) ([]*domain.PrizeClaim, int64, error) {
+	var claims []*domain.PrizeClaim
+	var total int64
+
+	query := r.db.Model(&domain.PrizeClaim{})
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.OnlyOpen {
+		query = query.Where("status IN ?", []domain.PrizeClaimStatus{
+			domain.PrizeClaimStatusPendingIdentity,
+			domain.PrizeClaimStatusPendingDelivery,
+			domain.PrizeClaimStatusShipped,
+		})
+	}
+	if filters.PrizeType != nil {
+		query = query.Where("prize_type = ?", *filters.PrizeType)
+	}
+	if filters.RaffleID != nil {
+		query = query.Where("raffle_id = ?", *filters.RaffleID)
+	}
+	if filters.WinnerUserID != nil {
+		query = query.Where("winner_user_id = ?", *filters.WinnerUserID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	if err := query.Order("claim_deadline ASC NULLS LAST, created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&claims).Error; err != nil {
+		r.log.Error("Error listando reclamos de premios", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return claims, total, nil
+}
+
+// FindOverdue busca reclamos pendientes de identidad con plazo vencido
+func (r *PostgresPrizeClaimRepository) FindOverdue(now time.Time, limit int) ([]*domain.PrizeClaim, error) {
+	var claims []*domain.PrizeClaim
+
+	if err := r.db.Where("status = ? AND claim_deadline < ?", domain.PrizeClaimStatusPendingIdentity, now).
+		Order("claim_deadline ASC").
+		Limit(limit).
+		Find(&claims).Error; err != nil {
+		r.log.Error("Error buscando reclamos vencidos", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return claims, nil
+}
+
+// SumCashPrizes suma los premios en efectivo de un sorteo: pagados y con ganador aún sin acreditar
+// Un premio mayor acumulado al siguiente sorteo de la serie se retiene en el sorteo de origen, por lo que
+// se suma a su retención y se descuenta de la del sorteo que lo recibe
+func (r *PostgresPrizeClaimRepository) SumCashPrizes(raffleID int64) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	if err := r.db.Raw(`
+		SELECT GREATEST(COALESCE((
+			SELECT SUM(pc.amount) FROM prize_claims pc
+			WHERE pc.raffle_id = ? AND pc.prize_type = ? AND pc.status = ?
+		), 0) + COALESCE((
+			SELECT SUM(rp.value) FROM raffle_winners rw
+			JOIN raffle_prizes rp ON rp.id = rw.prize_id
+			WHERE rw.raffle_id = ? AND rw.user_id IS NOT NULL AND rp.prize_type = ?
+			AND NOT EXISTS (SELECT 1 FROM prize_claims pc WHERE pc.raffle_id = rw.raffle_id AND pc.prize_position = rw.position)
+		), 0) + COALESCE((
+			SELECT CASE WHEN r.unsold_policy_applied = ? THEN COALESCE(r.prize_amount, 0) ELSE 0 END
+				- COALESCE(r.rollover_amount, 0)
+			FROM raffles r WHERE r.id = ?
+		), 0), 0)
+	`, raffleID, domain.PrizeTypeCash, domain.PrizeClaimStatusPaid, raffleID, domain.PrizeTypeCash,
+		domain.UnsoldWinnerPolicyRollover, raffleID).
+		Row().Scan(&total); err != nil {
+		r.log.Error("Error sumando premios en efectivo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return decimal.Zero, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return total, nil
+}
+
+// Update actualiza un reclamo existente
+func (r *PostgresPrizeClaimRepository) Update(claim *domain.PrizeClaim) error {
+	if err := r.db.Save(claim).Error; err != nil {
+		r.log.Error("Error actualizando reclamo de premio",
+			logger.Int64("id", claim.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -18,9 +18,11 @@ type RaffleNumberRepository interface {
 	FindByRaffleAndNumber(raffleID int64, number string) (*domain.RaffleNumber, error)
 	FindByRaffleID(raffleID int64) ([]*domain.RaffleNumber, error)
 	FindAvailableByRaffleID(raffleID int64) ([]*domain.RaffleNumber, error)
+	FindSoldNumbers(raffleID int64) ([]string, error)
 	FindByUserID(userID int64, offset, limit int) ([]*domain.RaffleNumber, int64, error)
 	CountByStatus(raffleID int64, status domain.RaffleNumberStatus) (int64, error)
 	ReserveNumbers(raffleID int64, numbers []string, userID, reservationID int64, duration time.Duration) error
+	ExtendReservation(raffleID int64, numbers []string, userID int64, until time.Time) error
 	ReleaseExpiredReservations() (int, error)
 	MarkAsSold(id int64, userID, paymentID int64) error
 	CancelReservation(id int64) error
@@ -110,6 +112,18 @@ func (r *RaffleNumberRepositoryImpl) FindAvailableByRaffleID(raffleID int64) ([]
 	return numbers, nil
 }
 
+// FindSoldNumbers retorna los números vendidos de un sorteo en orden ascendente
+func (r *RaffleNumberRepositoryImpl) FindSoldNumbers(raffleID int64) ([]string, error) {
+	var numbers []string
+	if err := r.db.Model(&domain.RaffleNumber{}).
+		Where("raffle_id = ? AND status = ?", raffleID, domain.RaffleNumberStatusSold).
+		Order("number ASC").
+		Pluck("number", &numbers).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return numbers, nil
+}
+
 // FindByUserID busca números comprados por un usuario
 func (r *RaffleNumberRepositoryImpl) FindByUserID(userID int64, offset, limit int) ([]*domain.RaffleNumber, int64, error) {
 	var numbers []*domain.RaffleNumber
@@ -177,6 +191,19 @@ func (r *RaffleNumberRepositoryImpl) ReserveNumbers(raffleID int64, numbers []st
 	})
 }
 
+// ExtendReservation extiende el vencimiento de números reservados por el usuario
+func (r *RaffleNumberRepositoryImpl) ExtendReservation(raffleID int64, numbers []string, userID int64, until time.Time) error {
+	if err := r.db.Model(&domain.RaffleNumber{}).
+		Where("raffle_id = ? AND number IN ? AND status = ? AND reserved_by = ?", raffleID, numbers, domain.RaffleNumberStatusReserved, userID).
+		Updates(map[string]interface{}{
+			"reserved_until": until,
+			"updated_at":     time.Now(),
+		}).Error; err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
 // ReleaseExpiredReservations libera todas las reservas expiradas
 func (r *RaffleNumberRepositoryImpl) ReleaseExpiredReservations() (int, error) {
 	now := time.Now()
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrRaffleWinnerExists el premio o el número ya tiene un ganador registrado
+var ErrRaffleWinnerExists = errors.New("RAFFLE_WINNER_EXISTS", "el premio ya tiene ganador o el número ya ganó otro premio", 409, nil)
+
+// PostgresRafflePrizeRepository implementación de RafflePrizeRepository con PostgreSQL
+type PostgresRafflePrizeRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewRafflePrizeRepository crea una nueva instancia
+func NewRafflePrizeRepository(db *gorm.DB, log *logger.Logger) *PostgresRafflePrizeRepository {
+	return &PostgresRafflePrizeRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ReplaceForRaffle reemplaza los premios de un sorteo en una transacción
+func (r *PostgresRafflePrizeRepository) ReplaceForRaffle(raffleID int64, prizes []*domain.RafflePrize) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("raffle_id = ?", raffleID).Delete(&domain.RafflePrize{}).Error; err != nil {
+			return err
+		}
+		for _, prize := range prizes {
+			prize.ID = 0
+			prize.RaffleID = raffleID
+		}
+		if len(prizes) == 0 {
+			return nil
+		}
+		return tx.Create(&prizes).Error
+	})
+	if err != nil {
+		r.log.Error("Error guardando premios del sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByRaffleID lista los premios de un sorteo ordenados por posición
+func (r *PostgresRafflePrizeRepository) FindByRaffleID(raffleID int64) ([]*domain.RafflePrize, error) {
+	var prizes []*domain.RafflePrize
+
+	if err := r.db.Where("raffle_id = ?", raffleID).Order("position ASC").Find(&prizes).Error; err != nil {
+		r.log.Error("Error buscando premios del sorteo",
+			logger.Int64("raffle_id", raffleID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return prizes, nil
+}
+
+// FindByRaffleIDs lista los premios de varios sorteos agrupados por sorteo
+func (r *PostgresRafflePrizeRepository) FindByRaffleIDs(raffleIDs []int64) (map[int64][]*domain.RafflePrize, error) {
+	grouped := make(map[int64][]*domain.RafflePrize, len(raffleIDs))
+	if len(raffleIDs) == 0 {
+		return grouped, nil
+	}
+
+	var prizes []*domain.RafflePrize
+	if err := r.db.Where("raffle_id IN ?", raffleIDs).Order("raffle_id ASC, position ASC").Find(&prizes).Error; err != nil {
+		r.log.Error("Error buscando premios de sorteos", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	for _, prize := range prizes {
+		grouped[prize.RaffleID] = append(grouped[prize.RaffleID], prize)
+	}
+	return grouped, nil
+}
+
+// CreateWinners registra los ganadores de un sorteo
+func (r *PostgresRafflePrizeRepository) CreateWinners(winners []*domain.RaffleWinner) error {
+	if len(winners) == 0 {
+		return nil
+	}
+
+	if err := r.db.Create(&winners).Error; err != nil {
+		// idx_raffle_winners_prize / idx_raffle_winners_number: un ganador por premio y un premio por número
+		if strings.Contains(err.Error(), "idx_raffle_winners_") {
+			return ErrRaffleWinnerExists
+		}
+		r.log.Error("Error registrando ganadores del sorteo",
+			logger.Int64("raffle_id", winners[0].RaffleID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindWinnersByRaffleID lista los ganadores de un sorteo con su premio, ordenados por posición
+func (r *PostgresRafflePrizeRepository) FindWinnersByRaffleID(raffleID int64) ([]*domain.RaffleWinner, error) {
+	grouped, err := r.FindWinnersByRaffleIDs([]int64{raffleID})
+	if err != nil {
+		return nil, err
+	}
+	return grouped[raffleID], nil
+}
+
+// FindWinnersByRaffleIDs lista los ganadores de varios sorteos con su premio, agrupados por sorteo
+func (r *PostgresRafflePrizeRepository) FindWinnersByRaffleIDs(raffleIDs []int64) (map[int64][]*domain.RaffleWinner, error) {
+	grouped := make(map[int64][]*domain.RaffleWinner, len(raffleIDs))
+	if len(raffleIDs) == 0 {
+		return grouped, nil
+	}
+
+	var winners []*domain.RaffleWinner
+	if err := r.db.Where("raffle_id IN ?", raffleIDs).Order("raffle_id ASC, position ASC").Find(&winners).Error; err != nil {
+		r.log.Error("Error buscando ganadores de sorteos", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	prizes, err := r.FindByRaffleIDs(raffleIDs)
+	if err != nil {
+		return nil, err
+	}
+	prizesByID := make(map[int64]*domain.RafflePrize)
+	for _, rafflePrizes := range prizes {
+		for _, prize := range rafflePrizes {
+			prizesByID[prize.ID] = prize
+		}
+	}
+
+	for _, winner := range winners {
+		winner.Prize = prizesByID[winner.PrizeID]
+		grouped[winner.RaffleID] = append(grouped[winner.RaffleID], winner)
+	}
+	return grouped, nil
+}
@@ -1,12 +1,16 @@
 package db
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/sorteos-platform/backend/internal/domain"
 	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
 )
 
 // RaffleRepository define los métodos de acceso a datos para Raffle
@@ -26,7 +30,25 @@ type RaffleRepository interface {
 	IncrementSoldCount(id int64) error
 	DecrementSoldCount(id int64) error
 
+	// Scheduled draw methods
+	FindDueForDraw(now time.Time, limit int) ([]*domain.Raffle, error)
+	DeferDraw(raffle *domain.Raffle) error
+	MarkDrawPending(raffle *domain.Raffle) error
+	CloseSales(id int64, closedAt time.Time) (bool, error)
+	CommitDrawSeed(raffle *domain.Raffle) (bool, error)
+	CompleteDraw(raffle *domain.Raffle) (bool, error)
+
+	// Minimum sales methods
+	FindBelowMinimumSales(now time.Time, limit int) ([]*domain.Raffle, error)
+	ExtendDrawDate(raffle *domain.Raffle) (bool, error)
+
+	// Jackpot rollover methods
+	FindNextOpenInSeries(seriesID int64, afterSequence int) (*domain.Raffle, error)
+	ClaimPendingRollover(target *domain.Raffle, log *logger.Logger) (decimal.Decimal, error)
+
 	// Earnings methods
+	GetPaidRevenue(id int64) (decimal.Decimal, error)
+	GetPaidCurrencies(id int64) ([]string, error)
 	GetUserEarningsSummary(userID int64) (*domain.UserEarnings, error)
 	GetUserCompletedRaffles(userID int64, limit, offset int) ([]domain.RaffleEarning, error)
 }
@@ -264,6 +286,339 @@ func (r *RaffleRepositoryImpl) DecrementSoldCount(id int64) error {
 	return nil
 }
 
+// FindDueForDraw retorna sorteos activos sin ganador cuya fecha de sorteo ya pasó
+// Los sorteos que esperan su siguiente reintento o un sorteo manual del admin se omiten para no bloquear a los demás
+func (r *RaffleRepositoryImpl) FindDueForDraw(now time.Time, limit int) ([]*domain.Raffle, error) {
+	var raffles []*domain.Raffle
+	if err := r.db.Where("status = ? AND winner_number IS NULL AND deleted_at IS NULL AND draw_date <= ?",
+		domain.RaffleStatusActive, now).
+		Where("draw_next_attempt_at IS NULL OR draw_next_attempt_at <= ?", now).
+		Where("draw_pending_reason IS NULL OR draw_pending_reason = ?", domain.DrawPendingLotteryResult).
+		Order("COALESCE(draw_next_attempt_at, draw_date) ASC").
+		Limit(limit).
+		Find(&raffles).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return raffles, nil
+}
+
+// DeferDraw persiste el siguiente reintento del sorteo automático
+func (r *RaffleRepositoryImpl) DeferDraw(raffle *domain.Raffle) error {
+	if err := r.db.Model(&domain.Raffle{}).
+		Where("id = ? AND status = ? AND winner_number IS NULL", raffle.ID, domain.RaffleStatusActive).
+		Updates(map[string]interface{}{
+			"draw_attempts":        raffle.DrawAttempts,
+			"draw_next_attempt_at": raffle.DrawNextAttemptAt,
+		}).Error; err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// MarkDrawPending persiste el motivo por el que el sorteo vencido sigue pendiente
+func (r *RaffleRepositoryImpl) MarkDrawPending(raffle *domain.Raffle) error {
+	if err := r.db.Model(&domain.Raffle{}).
+		Where("id = ? AND status = ? AND winner_number IS NULL", raffle.ID, domain.RaffleStatusActive).
+		Updates(map[string]interface{}{
+			"draw_pending_reason": raffle.DrawPendingReason,
+			"draw_pending_since":  raffle.DrawPendingSince,
+		}).Error; err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// CloseSales registra el cierre de ventas de un sorteo activo
+// Retorna false si otra instancia ya cerró las ventas o el sorteo dejó de estar activo
+func (r *RaffleRepositoryImpl) CloseSales(id int64, closedAt time.Time) (bool, error) {
+	result := r.db.Model(&domain.Raffle{}).
+		Where("id = ? AND status = ? AND sales_closed_at IS NULL", id, domain.RaffleStatusActive).
+		Updates(map[string]interface{}{
+			"sales_closed_at": closedAt,
+			"updated_at":      closedAt,
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CommitDrawSeed persiste la semilla comprometida de una rifa que aún no tenía una
+// Retorna false si otra instancia ya comprometió una semilla
+func (r *RaffleRepositoryImpl) CommitDrawSeed(raffle *domain.Raffle) (bool, error) {
+	result := r.db.Model(&domain.Raffle{}).
+		Where("id = ? AND draw_seed_hash IS NULL", raffle.ID).
+		Updates(map[string]interface{}{
+			"draw_seed_hash":         raffle.DrawSeedHash,
+			"draw_server_seed":       raffle.DrawServerSeed,
+			"draw_seed_committed_at": raffle.DrawSeedCommittedAt,
+			"draw_beacon_round":      raffle.DrawBeaconRound,
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CompleteDraw persiste el resultado del sorteo y sus ganadores por premio solo si la rifa sigue activa y sin ganador
+// Retorna false si otra instancia ya completó el sorteo
+func (r *RaffleRepositoryImpl) CompleteDraw(raffle *domain.Raffle) (bool, error) {
+	completed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Raffle{}).
+			Where("id = ? AND status = ? AND winner_number IS NULL", raffle.ID, domain.RaffleStatusActive).
+			Updates(map[string]interface{}{
+				"status":                 raffle.Status,
+				"winner_number":          raffle.WinnerNumber,
+				"winner_user_id":         raffle.WinnerUserID,
+				"draw_proof":             raffle.DrawProof,
+				"draw_seed_hash":         raffle.DrawSeedHash,
+				"draw_server_seed":       raffle.DrawServerSeed,
+				"draw_seed_committed_at": raffle.DrawSeedCommittedAt,
+				"draw_beacon_round":      raffle.DrawBeaconRound,
+				"lottery_result_id":      raffle.LotteryResultID,
+				"lottery_mapped_number":  raffle.LotteryMappedNumber,
+				"unsold_policy_applied":  raffle.UnsoldPolicyApplied,
+				"draw_pending_reason":    nil,
+				"draw_pending_since":     nil,
+				"sales_closed_at":        raffle.SalesClosedAt,
+				"total_revenue":          raffle.TotalRevenue,
+				"platform_fee_amount":    raffle.PlatformFeeAmount,
+				"net_amount":             raffle.NetAmount,
+				"completed_at":           raffle.CompletedAt,
+				"updated_at":             raffle.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if len(raffle.Winners) > 0 {
+			if err := tx.Create(&raffle.Winners).Error; err != nil {
+				return err
+			}
+		}
+		completed = true
+		return nil
+	})
+	if err != nil {
+		return false, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return completed, nil
+}
+
+// FindBelowMinimumSales retorna sorteos activos que llegaron a su corte sin alcanzar el mínimo de vendidos
+func (r *RaffleRepositoryImpl) FindBelowMinimumSales(now time.Time, limit int) ([]*domain.Raffle, error) {
+	var raffles []*domain.Raffle
+	// Se cuentan los números vendidos en raffle_numbers: raffles.sold_count no se actualiza en cada venta
+	if err := r.db.Where("status = ? AND winner_number IS NULL AND deleted_at IS NULL AND min_sold_count IS NOT NULL", domain.RaffleStatusActive).
+		Where("(SELECT COUNT(*) FROM raffle_numbers WHERE raffle_numbers.raffle_id = raffles.id AND raffle_numbers.status = ?) < min_sold_count", domain.RaffleNumberStatusSold).
+		Where("draw_date - make_interval(hours => min_sales_cutoff_hours) <= ?", now).
+		Order("draw_date ASC").
+		Limit(limit).
+		Find(&raffles).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return raffles, nil
+}
+
+// ExtendDrawDate persiste la postergación del sorteo por ventas insuficientes
+// Retorna false si el sorteo ya fue pospuesto o dejó de estar activo
+func (r *RaffleRepositoryImpl) ExtendDrawDate(raffle *domain.Raffle) (bool, error) {
+	result := r.db.Model(&domain.Raffle{}).
+		Where("id = ? AND status = ? AND winner_number IS NULL AND min_sales_extended_at IS NULL", raffle.ID, domain.RaffleStatusActive).
+		Updates(map[string]interface{}{
+			"draw_date":             raffle.DrawDate,
+			"sales_closed_at":       nil,
+			"min_sales_extended_at": raffle.MinSalesExtendedAt,
+			"draw_beacon_round":     raffle.DrawBeaconRound,
+			"draw_attempts":         raffle.DrawAttempts,
+			"draw_next_attempt_at":  raffle.DrawNextAttemptAt,
+			"updated_at":            raffle.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindNextOpenInSeries busca el siguiente sorteo de la serie que aún vende números
+func (r *RaffleRepositoryImpl) FindNextOpenInSeries(seriesID int64, afterSequence int) (*domain.Raffle, error) {
+	var raffle domain.Raffle
+	if err := r.db.Where("series_id = ? AND series_sequence > ? AND status IN ? AND sales_closed_at IS NULL AND deleted_at IS NULL",
+		seriesID, afterSequence, []domain.RaffleStatus{domain.RaffleStatusDraft, domain.RaffleStatusActive}).
+		Order("series_sequence ASC").
+		First(&raffle).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return &raffle, nil
+}
+
+// ClaimPendingRollover suma al premio mayor del sorteo los premios acumulados de los sorteos anteriores
+// de su serie que aún no pasaron a otro sorteo, y los marca como transferidos
+// Cada acumulado se registra en el libro mayor como transferencia del sorteo de origen al que lo recibe
+// Retorna el monto recibido (cero si no hay acumulados o el sorteo ya cerró ventas)
+func (r *RaffleRepositoryImpl) ClaimPendingRollover(target *domain.Raffle, log *logger.Logger) (decimal.Decimal, error) {
+	claimed := decimal.Zero
+	var fromRaffleID int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var sources []*domain.Raffle
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("series_id = ? AND series_sequence < ? AND unsold_policy_applied = ? AND rolled_over_to_raffle_id IS NULL",
+				*target.SeriesID, *target.SeriesSequence, domain.UnsoldWinnerPolicyRollover).
+			Order("series_sequence ASC").
+			Find(&sources).Error; err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			return nil
+		}
+
+		amount := decimal.Zero
+		sourceIDs := make([]int64, 0, len(sources))
+		for _, source := range sources {
+			if source.PrizeAmount != nil {
+				amount = amount.Add(*source.PrizeAmount)
+			}
+			sourceIDs = append(sourceIDs, source.ID)
+		}
+		if amount.LessThanOrEqual(decimal.Zero) {
+			return nil
+		}
+		lastSourceID := sources[len(sources)-1].ID
+
+		// Solo un sorteo que sigue vendiendo y con premio mayor en efectivo recibe el acumulado
+		now := time.Now()
+		result := tx.Model(&domain.Raffle{}).
+			Where("id = ? AND status IN ? AND sales_closed_at IS NULL AND deleted_at IS NULL AND prize_type = ?",
+				target.ID, []domain.RaffleStatus{domain.RaffleStatusDraft, domain.RaffleStatusActive}, domain.PrizeTypeCash).
+			Updates(map[string]interface{}{
+				"prize_amount":               gorm.Expr("COALESCE(prize_amount, 0) + ?", amount),
+				"rollover_amount":            gorm.Expr("COALESCE(rollover_amount, 0) + ?", amount),
+				"rolled_over_from_raffle_id": lastSourceID,
+				"updated_at":                 now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&domain.RafflePrize{}).
+			Where("raffle_id = ? AND position = 1 AND prize_type = ?", target.ID, domain.PrizeTypeCash).
+			Updates(map[string]interface{}{
+				"value":      gorm.Expr("COALESCE(value, 0) + ?", amount),
+				"updated_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Raffle{}).
+			Where("id IN ?", sourceIDs).
+			Updates(map[string]interface{}{
+				"rolled_over_to_raffle_id": target.ID,
+				"updated_at":               now,
+			}).Error; err != nil {
+			return err
+		}
+
+		// El premio acumulado se retuvo en la liquidación del sorteo de origen y pasa al que lo recibe
+		txRepo := &RaffleRepositoryImpl{db: tx}
+		ledgerRepo := NewLedgerRepository(tx, log)
+		for _, source := range sources {
+			if source.PrizeAmount == nil || !source.PrizeAmount.IsPositive() {
+				continue
+			}
+			currencies, err := txRepo.GetPaidCurrencies(source.ID)
+			if err != nil {
+				return err
+			}
+			if len(currencies) != 1 {
+				return fmt.Errorf("raffle %d payments are not in a single currency", source.ID)
+			}
+
+			entry := domain.NewJournalEntry(domain.JournalEntryJackpotRollover, fmt.Sprintf("raffle:%d:rollover", source.ID), currencies[0]).
+				WithReference("raffle", target.ID).
+				WithDescription(fmt.Sprintf("Premio mayor acumulado del sorteo %d al sorteo %d", source.ID, target.ID)).
+				Debit(domain.OrganizerPayableAccount(source.UserID), *source.PrizeAmount).
+				Credit(domain.OrganizerPayableAccount(target.UserID), *source.PrizeAmount)
+			if err := ledgerRepo.Post(entry); err != nil {
+				return err
+			}
+		}
+
+		claimed = amount
+		fromRaffleID = lastSourceID
+		return nil
+	})
+	if err != nil {
+		return decimal.Zero, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if claimed.IsZero() {
+		return claimed, nil
+	}
+
+	// Reflejar el acumulado en la entidad en memoria
+	prizeAmount := claimed
+	if target.PrizeAmount != nil {
+		prizeAmount = target.PrizeAmount.Add(claimed)
+	}
+	rolloverAmount := claimed
+	if target.RolloverAmount != nil {
+		rolloverAmount = target.RolloverAmount.Add(claimed)
+	}
+	target.PrizeAmount = &prizeAmount
+	target.RolloverAmount = &rolloverAmount
+	target.RolledOverFromRaffleID = &fromRaffleID
+	for _, prize := range target.Prizes {
+		if prize.Position == 1 && prize.IsCash() {
+			value := claimed
+			if prize.Value != nil {
+				value = prize.Value.Add(claimed)
+			}
+			prize.Value = &value
+		}
+	}
+
+	return claimed, nil
+}
+
+// PaidRevenueSQL subconsulta con lo efectivamente pagado por un sorteo de la tabla raffles
+// (pagos exitosos con sus descuentos aplicados, netos de reembolsos parciales)
+const PaidRevenueSQL = "(SELECT COALESCE(SUM(p.amount - p.refunded_amount), 0) FROM payments p WHERE p.raffle_id = raffles.uuid AND p.status = 'succeeded')"
+
+// GetPaidRevenue obtiene lo efectivamente pagado por los números de un sorteo
+func (r *RaffleRepositoryImpl) GetPaidRevenue(id int64) (decimal.Decimal, error) {
+	var paid decimal.Decimal
+	if err := r.db.Table("raffles").
+		Select(PaidRevenueSQL+" AS paid_revenue").
+		Where("id = ?", id).
+		Row().Scan(&paid); err != nil {
+		return decimal.Zero, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return paid, nil
+}
+
+// GetPaidCurrencies obtiene las monedas de los pagos exitosos de un sorteo
+func (r *RaffleRepositoryImpl) GetPaidCurrencies(id int64) ([]string, error) {
+	var currencies []string
+	if err := r.db.Table("payments").
+		Distinct("UPPER(payments.currency)").
+		Joins("JOIN raffles ON raffles.uuid = payments.raffle_id").
+		Where("raffles.id = ? AND payments.status = ?", id, "succeeded").
+		Pluck("UPPER(payments.currency)", &currencies).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return currencies, nil
+}
+
 // GetUserEarningsSummary obtiene el resumen total de ganancias de un usuario
 func (r *RaffleRepositoryImpl) GetUserEarningsSummary(userID int64) (*domain.UserEarnings, error) {
 	type Summary struct {
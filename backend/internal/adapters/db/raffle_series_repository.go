@@ -0,0 +1,165 @@
+package db
+
+import (
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresRaffleSeriesRepository implementación de RaffleSeriesRepository con PostgreSQL
+type PostgresRaffleSeriesRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewRaffleSeriesRepository crea una nueva instancia
+func NewRaffleSeriesRepository(db *gorm.DB, log *logger.Logger) *PostgresRaffleSeriesRepository {
+	return &PostgresRaffleSeriesRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea una serie
+func (r *PostgresRaffleSeriesRepository) Create(series *domain.RaffleSeries) error {
+	if err := r.db.Create(series).Error; err != nil {
+		r.log.Error("Error creando serie de sorteos",
+			logger.Int64("user_id", series.UserID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// Update guarda los cambios de una serie
+func (r *PostgresRaffleSeriesRepository) Update(series *domain.RaffleSeries) error {
+	if err := r.db.Save(series).Error; err != nil {
+		r.log.Error("Error actualizando serie de sorteos",
+			logger.Int64("series_id", series.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// RecordGeneration guarda el sorteo generado solo si ninguna otra ejecución registró uno desde que se leyó la serie
+func (r *PostgresRaffleSeriesRepository) RecordGeneration(series *domain.RaffleSeries, previousOccurrences int) (bool, error) {
+	result := r.db.Model(&domain.RaffleSeries{}).
+		Where("id = ? AND occurrences = ?", series.ID, previousOccurrences).
+		Updates(map[string]interface{}{
+			"occurrences":    series.Occurrences,
+			"last_raffle_id": series.LastRaffleID,
+			"last_run_at":    series.LastRunAt,
+			"last_error":     series.LastError,
+			"next_run_at":    series.NextRunAt,
+			"status":         series.Status,
+			"ended_at":       series.EndedAt,
+		})
+	if result.Error != nil {
+		r.log.Error("Error registrando sorteo generado por la serie",
+			logger.Int64("series_id", series.ID),
+			logger.Error(result.Error))
+		return false, errors.Wrap(errors.ErrDatabaseError, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindByID obtiene una serie por ID
+func (r *PostgresRaffleSeriesRepository) FindByID(id int64) (*domain.RaffleSeries, error) {
+	var series domain.RaffleSeries
+
+	if err := r.db.First(&series, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando serie de sorteos por ID",
+			logger.Int64("series_id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &series, nil
+}
+
+// FindByUserID lista las series de un organizador (activas primero)
+func (r *PostgresRaffleSeriesRepository) FindByUserID(userID int64) ([]*domain.RaffleSeries, error) {
+	var series []*domain.RaffleSeries
+
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("CASE status WHEN 'active' THEN 0 WHEN 'paused' THEN 1 ELSE 2 END, created_at DESC").
+		Find(&series).Error; err != nil {
+		r.log.Error("Error listando series de sorteos",
+			logger.Int64("user_id", userID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return series, nil
+}
+
+// CountActiveByTemplateID cuenta las series activas o pausadas que usan una plantilla
+func (r *PostgresRaffleSeriesRepository) CountActiveByTemplateID(templateID int64) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.RaffleSeries{}).
+		Where("template_id = ? AND status IN ?", templateID, []domain.RaffleSeriesStatus{
+			domain.RaffleSeriesStatusActive,
+			domain.RaffleSeriesStatusPaused,
+		}).
+		Count(&count).Error; err != nil {
+		return 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
+
+// FindDue lista las series activas que deben generar su siguiente sorteo
+// Las series al completarse avanzan cuando su último sorteo finaliza (completado, cancelado o eliminado)
+func (r *PostgresRaffleSeriesRepository) FindDue(now time.Time, limit int) ([]*domain.RaffleSeries, error) {
+	var series []*domain.RaffleSeries
+
+	err := r.db.
+		Where("status = ?", domain.RaffleSeriesStatusActive).
+		Where(`(mode = ? AND next_run_at <= ?) OR (mode = ? AND (last_raffle_id IS NULL OR EXISTS (
+			SELECT 1 FROM raffles
+			WHERE raffles.id = raffle_series.last_raffle_id
+			  AND (raffles.status IN ? OR raffles.deleted_at IS NOT NULL)
+		)))`,
+			domain.RaffleSeriesModeCron, now,
+			domain.RaffleSeriesModeOnCompletion,
+			[]domain.RaffleStatus{domain.RaffleStatusCompleted, domain.RaffleStatusCancelled},
+		).
+		Order("id ASC").
+		Limit(limit).
+		Find(&series).Error
+	if err != nil {
+		r.log.Error("Error buscando series pendientes de generar", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return series, nil
+}
+
+// FindEntries lista los sorteos generados por la serie con sus ventas pagadas
+func (r *PostgresRaffleSeriesRepository) FindEntries(seriesID int64) ([]*domain.RaffleSeriesEntry, error) {
+	var entries []*domain.RaffleSeriesEntry
+
+	err := r.db.Table("raffles").
+		Select("id AS raffle_id, uuid AS raffle_uuid, title, series_sequence, status, draw_date, total_numbers, sold_count, "+PaidRevenueSQL+" AS paid_revenue").
+		Where("series_id = ? AND deleted_at IS NULL", seriesID).
+		Order("series_sequence ASC").
+		Scan(&entries).Error
+	if err != nil {
+		r.log.Error("Error listando sorteos de la serie",
+			logger.Int64("series_id", seriesID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return entries, nil
+}
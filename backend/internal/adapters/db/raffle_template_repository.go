@@ -0,0 +1,106 @@
+package db
+
+import (
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PostgresRaffleTemplateRepository implementación de RaffleTemplateRepository con PostgreSQL
+type PostgresRaffleTemplateRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewRaffleTemplateRepository crea una nueva instancia
+func NewRaffleTemplateRepository(db *gorm.DB, log *logger.Logger) *PostgresRaffleTemplateRepository {
+	return &PostgresRaffleTemplateRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea una plantilla
+func (r *PostgresRaffleTemplateRepository) Create(template *domain.RaffleTemplate) error {
+	if err := r.db.Create(template).Error; err != nil {
+		r.log.Error("Error creando plantilla de sorteo",
+			logger.Int64("user_id", template.UserID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// Update guarda los cambios de una plantilla
+func (r *PostgresRaffleTemplateRepository) Update(template *domain.RaffleTemplate) error {
+	if err := r.db.Save(template).Error; err != nil {
+		r.log.Error("Error actualizando plantilla de sorteo",
+			logger.Int64("template_id", template.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// SoftDelete elimina lógicamente una plantilla
+func (r *PostgresRaffleTemplateRepository) SoftDelete(id int64) error {
+	if err := r.db.Model(&domain.RaffleTemplate{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", time.Now()).Error; err != nil {
+		r.log.Error("Error eliminando plantilla de sorteo",
+			logger.Int64("template_id", id),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// FindByID obtiene una plantilla (no eliminada) por ID
+func (r *PostgresRaffleTemplateRepository) FindByID(id int64) (*domain.RaffleTemplate, error) {
+	var template domain.RaffleTemplate
+
+	if err := r.db.Where("id = ? AND deleted_at IS NULL", id).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando plantilla de sorteo por ID",
+			logger.Int64("template_id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &template, nil
+}
+
+// FindByUserID lista las plantillas de un organizador
+func (r *PostgresRaffleTemplateRepository) FindByUserID(userID int64) ([]*domain.RaffleTemplate, error) {
+	var templates []*domain.RaffleTemplate
+
+	if err := r.db.
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Order("name ASC, id ASC").
+		Find(&templates).Error; err != nil {
+		r.log.Error("Error listando plantillas de sorteo",
+			logger.Int64("user_id", userID),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return templates, nil
+}
+
+// CountByUserID cuenta las plantillas de un organizador
+func (r *PostgresRaffleTemplateRepository) CountByUserID(userID int64) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.RaffleTemplate{}).
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
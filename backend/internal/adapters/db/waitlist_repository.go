@@ -0,0 +1,13 @@
+package db
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+	"github.com/sorteos-platform/backend/internal/infrastructure/database"
+)
+
+// NewWaitlistRepository crea un nuevo repositorio de lista de espera
+func NewWaitlistRepository(db *gorm.DB) repositories.WaitlistRepository {
+	return database.NewPostgresWaitlistRepository(db)
+}
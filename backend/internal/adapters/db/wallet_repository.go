@@ -186,27 +186,32 @@ func (r *PostgresWalletRepository) Unlock(walletID int64) error {
 	return nil
 }
 
+// PostJournalEntry registra el asiento contable del movimiento con la misma conexión/transacción
+func (r *PostgresWalletRepository) PostJournalEntry(entry *domain.JournalEntry) error {
+	return NewLedgerRepository(r.db, r.log).Post(entry)
+}
+
 // WithTransaction ejecuta una función dentro de una transacción
+// Si el repositorio ya opera sobre una transacción se usa un savepoint (unidad de trabajo del llamador)
 func (r *PostgresWalletRepository) WithTransaction(fn func(repo domain.WalletRepository) error) error {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return errors.Wrap(errors.ErrDatabaseError, tx.Error)
-	}
-
-	// Crear repositorio con la transacción
-	txRepo := &PostgresWalletRepository{
-		db:  tx,
-		log: r.log,
-	}
+	var fnErr error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Crear repositorio con la transacción
+		txRepo := &PostgresWalletRepository{
+			db:  tx,
+			log: r.log,
+		}
 
-	// Ejecutar función
-	if err := fn(txRepo); err != nil {
-		tx.Rollback()
-		return err
+		// Ejecutar función
+		fnErr = fn(txRepo)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
 	}
 
 	// Commit
-	if err := tx.Commit().Error; err != nil {
+	if err != nil {
 		r.log.Error("Error en commit de transacción", logger.Error(err))
 		return errors.Wrap(errors.ErrDatabaseError, err)
 	}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrWithdrawalInProgress el usuario ya tiene un retiro abierto
+var ErrWithdrawalInProgress = errors.New("WITHDRAWAL_IN_PROGRESS", "ya tienes un retiro en proceso", 409, nil)
+
+// PostgresWithdrawalRepository implementación de WithdrawalRepository con PostgreSQL
+type PostgresWithdrawalRepository struct {
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// NewWithdrawalRepository crea una nueva instancia
+func NewWithdrawalRepository(db *gorm.DB, log *logger.Logger) *PostgresWithdrawalRepository {
+	return &PostgresWithdrawalRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create crea una nueva solicitud de retiro
+func (r *PostgresWithdrawalRepository) Create(withdrawal *domain.Withdrawal) error {
+	if withdrawal.UUID == "" {
+		withdrawal.UUID = uuid.New().String()
+	}
+
+	if err := withdrawal.Validate(); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	if err := r.db.Create(withdrawal).Error; err != nil {
+		// idx_withdrawals_user_open: un solo retiro abierto por usuario
+		if strings.Contains(err.Error(), "idx_withdrawals_user_open") {
+			return ErrWithdrawalInProgress
+		}
+		r.log.Error("Error creando retiro",
+			logger.Int64("user_id", withdrawal.UserID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// FindByID busca un retiro por ID
+func (r *PostgresWithdrawalRepository) FindByID(id int64) (*domain.Withdrawal, error) {
+	var withdrawal domain.Withdrawal
+
+	if err := r.db.First(&withdrawal, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando retiro por ID",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &withdrawal, nil
+}
+
+// FindByIDForUpdate busca un retiro por ID con lock pesimista (usar dentro de una transacción)
+func (r *PostgresWithdrawalRepository) FindByIDForUpdate(id int64) (*domain.Withdrawal, error) {
+	var withdrawal domain.Withdrawal
+
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&withdrawal, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error adquiriendo lock de retiro",
+			logger.Int64("id", id),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &withdrawal, nil
+}
+
+// FindByUUID busca un retiro por UUID
+func (r *PostgresWithdrawalRepository) FindByUUID(uuid string) (*domain.Withdrawal, error) {
+	var withdrawal domain.Withdrawal
+
+	if err := r.db.Where("uuid = ?", uuid).First(&withdrawal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		r.log.Error("Error buscando retiro por UUID",
+			logger.String("uuid", uuid),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return &withdrawal, nil
+}
+
+// List lista retiros con filtros (más antiguos primero, orden de la cola de aprobación)
+func (r *PostgresWithdrawalRepository) List(filters domain.WithdrawalFilters, limit, offset int) ([]*domain.Withdrawal, int64, error) {
+	var withdrawals []*domain.Withdrawal
+	var total int64
+
+	query := r.db.Model(&domain.Withdrawal{})
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+	if filters.BatchID != nil {
+		query = query.Where("batch_id = ?", *filters.BatchID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	order := "created_at ASC"
+	if filters.UserID != nil {
+		order = "created_at DESC"
+	}
+
+	if err := query.Order(order).
+		Limit(limit).
+		Offset(offset).
+		Find(&withdrawals).Error; err != nil {
+		r.log.Error("Error listando retiros", logger.Error(err))
+		return nil, 0, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return withdrawals, total, nil
+}
+
+// FindByStatus busca retiros por estado (los más antiguos primero)
+func (r *PostgresWithdrawalRepository) FindByStatus(status domain.WithdrawalStatus, limit int) ([]*domain.Withdrawal, error) {
+	var withdrawals []*domain.Withdrawal
+
+	if err := r.db.Where("status = ?", status).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&withdrawals).Error; err != nil {
+		r.log.Error("Error buscando retiros por estado",
+			logger.String("status", string(status)),
+			logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	return withdrawals, nil
+}
+
+// Update actualiza un retiro existente
+func (r *PostgresWithdrawalRepository) Update(withdrawal *domain.Withdrawal) error {
+	if err := r.db.Save(withdrawal).Error; err != nil {
+		r.log.Error("Error actualizando retiro",
+			logger.Int64("id", withdrawal.ID),
+			logger.Error(err))
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/credits"
+	creditsuc "github.com/sorteos-platform/backend/internal/usecase/credits"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// CreditReconciliationHandler maneja las peticiones HTTP de la conciliación de compras de créditos
+type CreditReconciliationHandler struct {
+	listReconciliationsUC *credits.ListReconciliationsUseCase
+	viewReconciliationUC  *credits.ViewReconciliationUseCase
+	runReconciliationUC   *credits.RunReconciliationUseCase
+	log                   *logger.Logger
+}
+
+// NewCreditReconciliationHandler crea una nueva instancia del handler
+// reconciler es nil si Pagadito no está configurado (solo se pueden consultar reportes)
+func NewCreditReconciliationHandler(db *gorm.DB, reconciler *creditsuc.ReconcilePurchasesUseCase, log *logger.Logger) *CreditReconciliationHandler {
+	return &CreditReconciliationHandler{
+		listReconciliationsUC: credits.NewListReconciliationsUseCase(db, log),
+		viewReconciliationUC:  credits.NewViewReconciliationUseCase(db, log),
+		runReconciliationUC:   credits.NewRunReconciliationUseCase(reconciler, log),
+		log:                   log,
+	}
+}
+
+// List lista las conciliaciones (más recientes primero)
+// GET /api/v1/admin/credit-purchases/reconciliations
+func (h *CreditReconciliationHandler) List(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	output, err := h.listReconciliationsUC.Execute(c.Request.Context(), &credits.ListReconciliationsInput{
+		Page:     page,
+		PageSize: pageSize,
+	}, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// View retorna el reporte de diferencias de una conciliación
+// GET /api/v1/admin/credit-purchases/reconciliations/:id
+func (h *CreditReconciliationHandler) View(c *gin.Context) {
+	reconciliationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de conciliación inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	output, err := h.viewReconciliationUC.Execute(c.Request.Context(), reconciliationID, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Run ejecuta la conciliación con Pagadito sin esperar al job nocturno
+// POST /api/v1/admin/credit-purchases/reconciliations
+func (h *CreditReconciliationHandler) Run(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	output, err := h.runReconciliationUC.Execute(c.Request.Context(), &credits.RunReconciliationInput{Limit: limit}, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/inventory"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// InventoryHandler maneja las peticiones HTTP de consistencia del inventario de números
+type InventoryHandler struct {
+	repairInventoryUC *inventory.RepairInventoryUseCase
+	log               *logger.Logger
+}
+
+// NewInventoryHandler crea una nueva instancia del handler
+func NewInventoryHandler(db *gorm.DB, lockService *redis.LockService, wsHub *websocket.Hub, log *logger.Logger) *InventoryHandler {
+	return &InventoryHandler{
+		repairInventoryUC: inventory.NewRepairInventoryUseCase(db, lockService, wsHub, log),
+		log:               log,
+	}
+}
+
+// CheckConsistency reporta las divergencias entre reservas, raffle_numbers, locks de Redis y contadores
+// GET /api/v1/admin/inventory/consistency?raffle_id=
+func (h *InventoryHandler) CheckConsistency(c *gin.Context) {
+	h.run(c, false)
+}
+
+// Repair reporta y corrige las divergencias usando la reserva como fuente de verdad
+// POST /api/v1/admin/inventory/repair?raffle_id=
+func (h *InventoryHandler) Repair(c *gin.Context) {
+	h.run(c, true)
+}
+
+// run ejecuta la verificación (y la reparación si repair)
+func (h *InventoryHandler) run(c *gin.Context, repair bool) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	input := &inventory.RepairInventoryInput{Repair: repair}
+	if raffleIDStr := c.Query("raffle_id"); raffleIDStr != "" {
+		raffleID, err := strconv.ParseInt(raffleIDStr, 10, 64)
+		if err != nil {
+			handleError(c, errors.ErrBadRequest)
+			return
+		}
+		input.RaffleID = &raffleID
+	}
+
+	output, err := h.repairInventoryUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
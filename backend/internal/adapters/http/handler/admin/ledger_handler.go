@@ -0,0 +1,48 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/ledger"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// LedgerHandler maneja las peticiones HTTP del libro mayor
+type LedgerHandler struct {
+	checkConsistencyUC *ledger.CheckConsistencyUseCase
+	log                *logger.Logger
+}
+
+// NewLedgerHandler crea una nueva instancia del handler
+func NewLedgerHandler(db *gorm.DB, log *logger.Logger) *LedgerHandler {
+	return &LedgerHandler{
+		checkConsistencyUC: ledger.NewCheckConsistencyUseCase(db, log),
+		log:                log,
+	}
+}
+
+// CheckConsistency verifica que los saldos de las billeteras coincidan con el libro mayor
+// GET /api/v1/admin/ledger/consistency
+func (h *LedgerHandler) CheckConsistency(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	output, err := h.checkConsistencyUC.Execute(c.Request.Context(), &ledger.CheckConsistencyInput{Limit: limit}, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
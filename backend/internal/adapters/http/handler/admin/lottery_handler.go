@@ -0,0 +1,189 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/lottery"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// LotteryHandler maneja las peticiones HTTP de resultados de la Lotería Nacional
+type LotteryHandler struct {
+	listResultsUC    *lottery.ListLotteryResultsUseCase
+	registerResultUC *lottery.RegisterLotteryResultUseCase
+	confirmResultUC  *lottery.ConfirmLotteryResultUseCase
+	rejectResultUC   *lottery.RejectLotteryResultUseCase
+	log              *logger.Logger
+}
+
+// NewLotteryHandler crea una nueva instancia del handler
+func NewLotteryHandler(db *gorm.DB, log *logger.Logger) *LotteryHandler {
+	return &LotteryHandler{
+		listResultsUC:    lottery.NewListLotteryResultsUseCase(db, log),
+		registerResultUC: lottery.NewRegisterLotteryResultUseCase(db, log),
+		confirmResultUC:  lottery.NewConfirmLotteryResultUseCase(db, log),
+		rejectResultUC:   lottery.NewRejectLotteryResultUseCase(db, log),
+		log:              log,
+	}
+}
+
+// List lista resultados de lotería
+// GET /api/v1/admin/lottery-results
+func (h *LotteryHandler) List(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	input := &lottery.ListLotteryResultsInput{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.LotteryResultStatus(status)
+		input.Status = &s
+	}
+
+	output, err := h.listResultsUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Register registra manualmente un resultado (queda pendiente de confirmación)
+// POST /api/v1/admin/lottery-results
+func (h *LotteryHandler) Register(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req struct {
+		DrawDate      string `json:"draw_date" binding:"required"`
+		WinningNumber string `json:"winning_number" binding:"required"`
+		DrawNumber    string `json:"draw_number"`
+		Series        string `json:"series"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "draw_date y winning_number son requeridos",
+		})
+		return
+	}
+
+	input := &lottery.RegisterLotteryResultInput{
+		DrawDate:      req.DrawDate,
+		WinningNumber: req.WinningNumber,
+		DrawNumber:    req.DrawNumber,
+		Series:        req.Series,
+	}
+
+	output, err := h.registerResultUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Confirm confirma un resultado registrado por otro admin
+// POST /api/v1/admin/lottery-results/:id/confirm
+func (h *LotteryHandler) Confirm(c *gin.Context) {
+	resultID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de resultado inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	input := &lottery.ConfirmLotteryResultInput{
+		ResultID: resultID,
+	}
+
+	output, err := h.confirmResultUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Reject rechaza un resultado pendiente
+// POST /api/v1/admin/lottery-results/:id/reject
+func (h *LotteryHandler) Reject(c *gin.Context) {
+	resultID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de resultado inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "La razón es requerida",
+		})
+		return
+	}
+
+	input := &lottery.RejectLotteryResultInput{
+		ResultID: resultID,
+		Reason:   req.Reason,
+	}
+
+	output, err := h.rejectResultUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
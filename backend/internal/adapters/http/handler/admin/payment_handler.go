@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sorteos-platform/backend/internal/usecase/admin/payment"
+	"github.com/sorteos-platform/backend/internal/usecase/refund"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
 )
@@ -20,11 +21,11 @@ type PaymentHandler struct {
 }
 
 // NewPaymentHandler crea una nueva instancia del handler
-func NewPaymentHandler(db *gorm.DB, log *logger.Logger) *PaymentHandler {
+func NewPaymentHandler(db *gorm.DB, refundProcessor *refund.RefundProcessor, log *logger.Logger) *PaymentHandler {
 	return &PaymentHandler{
 		listPaymentsUC:     payment.NewListPaymentsAdminUseCase(db, log),
 		viewPaymentDetailsUC: payment.NewViewPaymentDetailsUseCase(db, log),
-		processRefundUC:    payment.NewProcessRefundUseCase(db, log),
+		processRefundUC:    payment.NewProcessRefundUseCase(db, refundProcessor, log),
 		manageDisputeUC:    payment.NewManageDisputeUseCase(db, log),
 		log:                log,
 	}
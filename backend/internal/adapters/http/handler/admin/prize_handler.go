@@ -0,0 +1,215 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/prize"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PrizeHandler maneja las peticiones HTTP de reclamos de premios
+type PrizeHandler struct {
+	listClaimsUC   *prize.ListPrizeClaimsUseCase
+	getClaimUC     *prize.GetPrizeClaimUseCase
+	resolveClaimUC *prize.ResolvePrizeClaimUseCase
+	log            *logger.Logger
+}
+
+// NewPrizeHandler crea una nueva instancia del handler
+func NewPrizeHandler(db *gorm.DB, log *logger.Logger) *PrizeHandler {
+	return &PrizeHandler{
+		listClaimsUC:   prize.NewListPrizeClaimsUseCase(db, log),
+		getClaimUC:     prize.NewGetPrizeClaimUseCase(db, log),
+		resolveClaimUC: prize.NewResolvePrizeClaimUseCase(db, log),
+		log:            log,
+	}
+}
+
+// List lista reclamos de premios (por defecto solo los abiertos)
+// GET /api/v1/admin/prize-claims
+func (h *PrizeHandler) List(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	input := &prize.ListPrizeClaimsInput{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.PrizeClaimStatus(status)
+		input.Status = &s
+	} else {
+		input.OnlyOpen = c.DefaultQuery("only_open", "true") == "true"
+	}
+	if prizeType := c.Query("prize_type"); prizeType != "" {
+		t := domain.PrizeType(prizeType)
+		input.PrizeType = &t
+	}
+	if raffleIDStr := c.Query("raffle_id"); raffleIDStr != "" {
+		if raffleID, err := strconv.ParseInt(raffleIDStr, 10, 64); err == nil {
+			input.RaffleID = &raffleID
+		}
+	}
+
+	output, err := h.listClaimsUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Get obtiene el detalle de un reclamo
+// GET /api/v1/admin/prize-claims/:id
+func (h *PrizeHandler) Get(c *gin.Context) {
+	claimID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de reclamo inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	output, err := h.getClaimUC.Execute(c.Request.Context(), claimID, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// ConfirmIdentity confirma la identidad del ganador y registra la dirección de entrega
+// POST /api/v1/admin/prize-claims/:id/confirm-identity
+func (h *PrizeHandler) ConfirmIdentity(c *gin.Context) {
+	var req struct {
+		DeliveryAddress string `json:"delivery_address" binding:"required"`
+		Notes           string `json:"notes"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "delivery_address es requerido",
+		})
+		return
+	}
+
+	h.resolve(c, &prize.ResolvePrizeClaimInput{
+		Action:          prize.ResolveActionConfirmIdentity,
+		DeliveryAddress: req.DeliveryAddress,
+		Notes:           req.Notes,
+	})
+}
+
+// Ship registra el envío del premio
+// POST /api/v1/admin/prize-claims/:id/ship
+func (h *PrizeHandler) Ship(c *gin.Context) {
+	var req struct {
+		Carrier        string `json:"carrier" binding:"required"`
+		TrackingNumber string `json:"tracking_number"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "carrier es requerido",
+		})
+		return
+	}
+
+	h.resolve(c, &prize.ResolvePrizeClaimInput{
+		Action:         prize.ResolveActionShip,
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	})
+}
+
+// Deliver registra la entrega del premio
+// POST /api/v1/admin/prize-claims/:id/deliver
+func (h *PrizeHandler) Deliver(c *gin.Context) {
+	var req struct {
+		Notes string `json:"notes"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	h.resolve(c, &prize.ResolvePrizeClaimInput{
+		Action: prize.ResolveActionDeliver,
+		Notes:  req.Notes,
+	})
+}
+
+// Cancel cancela un reclamo abierto
+// POST /api/v1/admin/prize-claims/:id/cancel
+func (h *PrizeHandler) Cancel(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "La razón es requerida",
+		})
+		return
+	}
+
+	h.resolve(c, &prize.ResolvePrizeClaimInput{
+		Action: prize.ResolveActionCancel,
+		Notes:  req.Reason,
+	})
+}
+
+// resolve ejecuta una acción de seguimiento sobre el reclamo indicado en la ruta
+func (h *PrizeHandler) resolve(c *gin.Context, input *prize.ResolvePrizeClaimInput) {
+	claimID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de reclamo inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	input.ClaimID = claimID
+	output, err := h.resolveClaimUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
@@ -3,10 +3,13 @@ package admin
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sorteos-platform/backend/internal/domain"
 	"github.com/sorteos-platform/backend/internal/usecase/admin/raffle"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/internal/usecase/refund"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
 )
@@ -17,20 +20,26 @@ type RaffleHandler struct {
 	viewTransactionsUC     *raffle.ViewRaffleTransactionsUseCase
 	forceStatusChangeUC    *raffle.ForceStatusChangeUseCase
 	manualDrawWinnerUC     *raffle.ManualDrawWinnerUseCase
+	scheduleDrawRoomUC     *raffle.ScheduleDrawRoomUseCase
 	addAdminNotesUC        *raffle.AddAdminNotesUseCase
 	cancelWithRefundUC     *raffle.CancelRaffleWithRefundUseCase
 	log                    *logger.Logger
 }
 
 // NewRaffleHandler crea una nueva instancia del handler
-func NewRaffleHandler(db *gorm.DB, log *logger.Logger) *RaffleHandler {
+// mailer avisa a compradores y organizador cuando una rifa se cancela con reembolsos
+func NewRaffleHandler(db *gorm.DB, refundProcessor *refund.RefundProcessor, drawRoom *raffleuc.DrawRoomService, drawEntropy *raffleuc.DrawEntropyResolver, mailer raffle.RaffleCancellationMailer, log *logger.Logger) *RaffleHandler {
+	cancelWithRefundUC := raffle.NewCancelRaffleWithRefundUseCase(db, refundProcessor, log)
+	cancelWithRefundUC.SetMailer(mailer)
+
 	return &RaffleHandler{
 		listRafflesUC:          raffle.NewListRafflesAdminUseCase(db, log),
 		viewTransactionsUC:     raffle.NewViewRaffleTransactionsUseCase(db, log),
 		forceStatusChangeUC:    raffle.NewForceStatusChangeUseCase(db, log),
-		manualDrawWinnerUC:     raffle.NewManualDrawWinnerUseCase(db, log),
+		manualDrawWinnerUC:     raffle.NewManualDrawWinnerUseCase(db, drawRoom, drawEntropy, log),
+		scheduleDrawRoomUC:     raffle.NewScheduleDrawRoomUseCase(db, drawRoom, log),
 		addAdminNotesUC:        raffle.NewAddAdminNotesUseCase(db, log),
-		cancelWithRefundUC:     raffle.NewCancelRaffleWithRefundUseCase(db, log),
+		cancelWithRefundUC:     cancelWithRefundUC,
 		log:                    log,
 	}
 }
@@ -97,6 +106,15 @@ func (h *RaffleHandler) List(c *gin.Context) {
 		input.IncludeAll = true
 	}
 
+	if pendingDrawStr := c.Query("pending_draw"); pendingDrawStr == "true" {
+		input.PendingDraw = true
+	}
+
+	if pendingReason := c.Query("pending_reason"); pendingReason != "" {
+		reason := domain.DrawPendingReason(pendingReason)
+		input.PendingReason = &reason
+	}
+
 	// Ejecutar use case
 	output, err := h.listRafflesUC.Execute(c.Request.Context(), input, adminID)
 	if err != nil {
@@ -261,6 +279,61 @@ func (h *RaffleHandler) ManualDraw(c *gin.Context) {
 	})
 }
 
+// ScheduleDrawRoom programa la cuenta regresiva y la transmisión del sorteo en vivo
+// PUT /api/v1/admin/raffles/:id/draw-room
+func (h *RaffleHandler) ScheduleDrawRoom(c *gin.Context) {
+	// Obtener admin ID
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Parse raffle ID
+	raffleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_RAFFLE_ID",
+				"message": "invalid raffle ID",
+			},
+		})
+		return
+	}
+
+	// Parse body
+	var body struct {
+		ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // Default: fecha de sorteo de la rifa
+		StreamURL   *string    `json:"stream_url,omitempty"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_INPUT",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Ejecutar use case
+	room, err := h.scheduleDrawRoomUC.Execute(c.Request.Context(), &raffle.ScheduleDrawRoomInput{
+		RaffleID:    raffleID,
+		ScheduledAt: body.ScheduledAt,
+		StreamURL:   body.StreamURL,
+	}, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    room,
+	})
+}
+
 // AddNotes agrega notas administrativas a una rifa
 // POST /api/v1/admin/raffles/:id/notes
 func (h *RaffleHandler) AddNotes(c *gin.Context) {
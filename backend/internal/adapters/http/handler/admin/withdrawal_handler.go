@@ -0,0 +1,230 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/usecase/admin/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// WithdrawalHandler maneja las peticiones HTTP de la cola de retiros
+type WithdrawalHandler struct {
+	listWithdrawalsUC    *withdrawal.ListWithdrawalsUseCase
+	approveWithdrawalUC  *withdrawal.ApproveWithdrawalUseCase
+	rejectWithdrawalUC   *withdrawal.RejectWithdrawalUseCase
+	completeWithdrawalUC *withdrawal.CompleteWithdrawalUseCase
+	exportBatchUC        *withdrawal.ExportWithdrawalBatchUseCase
+	log                  *logger.Logger
+}
+
+// NewWithdrawalHandler crea una nueva instancia del handler
+func NewWithdrawalHandler(db *gorm.DB, log *logger.Logger) *WithdrawalHandler {
+	return &WithdrawalHandler{
+		listWithdrawalsUC:    withdrawal.NewListWithdrawalsUseCase(db, log),
+		approveWithdrawalUC:  withdrawal.NewApproveWithdrawalUseCase(db, log),
+		rejectWithdrawalUC:   withdrawal.NewRejectWithdrawalUseCase(db, log),
+		completeWithdrawalUC: withdrawal.NewCompleteWithdrawalUseCase(db, log),
+		exportBatchUC:        withdrawal.NewExportWithdrawalBatchUseCase(db, log),
+		log:                  log,
+	}
+}
+
+// List lista retiros (por defecto la cola de pendientes)
+// GET /api/v1/admin/withdrawals
+func (h *WithdrawalHandler) List(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	input := &withdrawal.ListWithdrawalsInput{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	status := domain.WithdrawalStatus(c.DefaultQuery("status", string(domain.WithdrawalStatusPending)))
+	if status != "all" {
+		input.Status = &status
+	}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		if userID, err := strconv.ParseInt(userIDStr, 10, 64); err == nil {
+			input.UserID = &userID
+		}
+	}
+	if batchID := c.Query("batch_id"); batchID != "" {
+		input.BatchID = &batchID
+	}
+
+	output, err := h.listWithdrawalsUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Approve aprueba un retiro pendiente
+// POST /api/v1/admin/withdrawals/:id/approve
+func (h *WithdrawalHandler) Approve(c *gin.Context) {
+	withdrawalID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de retiro inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	input := &withdrawal.ApproveWithdrawalInput{
+		WithdrawalID: withdrawalID,
+	}
+
+	output, err := h.approveWithdrawalUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Reject rechaza un retiro y devuelve el monto al saldo de ganancias
+// POST /api/v1/admin/withdrawals/:id/reject
+func (h *WithdrawalHandler) Reject(c *gin.Context) {
+	withdrawalID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de retiro inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "La razón es requerida",
+		})
+		return
+	}
+
+	input := &withdrawal.RejectWithdrawalInput{
+		WithdrawalID: withdrawalID,
+		Reason:       req.Reason,
+	}
+
+	output, err := h.rejectWithdrawalUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// Complete confirma la transferencia bancaria de un retiro exportado
+// POST /api/v1/admin/withdrawals/:id/complete
+func (h *WithdrawalHandler) Complete(c *gin.Context) {
+	withdrawalID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "ID de retiro inválido",
+		})
+		return
+	}
+
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req struct {
+		BankReference string `json:"bank_reference" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "bank_reference es requerido",
+		})
+		return
+	}
+
+	input := &withdrawal.CompleteWithdrawalInput{
+		WithdrawalID:  withdrawalID,
+		BankReference: req.BankReference,
+	}
+
+	output, err := h.completeWithdrawalUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    output,
+	})
+}
+
+// ExportBatch exporta los retiros aprobados en un archivo de transferencias bancarias
+// POST /api/v1/admin/withdrawals/export?batch_id=WD-... (batch_id regenera un lote existente)
+func (h *WithdrawalHandler) ExportBatch(c *gin.Context) {
+	adminID, err := getAdminIDFromContext(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	input := &withdrawal.ExportWithdrawalBatchInput{}
+	if batchID := c.Query("batch_id"); batchID != "" {
+		input.BatchID = &batchID
+	}
+
+	output, err := h.exportBatchUC.Execute(c.Request.Context(), input, adminID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+output.FileName)
+	c.Header("X-Batch-ID", output.BatchID)
+	c.Header("X-Batch-Count", strconv.Itoa(output.Count))
+	c.Header("X-Batch-Total", output.TotalAmount.StringFixed(2))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", output.Content)
+}
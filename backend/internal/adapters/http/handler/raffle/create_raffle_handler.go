@@ -21,6 +21,23 @@ type CreateRaffleRequest struct {
 	DrawDate              string  `json:"draw_date" binding:"required"` // ISO 8601
 	DrawMethod            string  `json:"draw_method" binding:"required,oneof=loteria_nacional_cr manual random"`
 	PlatformFeePercentage *float64 `json:"platform_fee_percentage,omitempty"`
+	PrizeType             string   `json:"prize_type" binding:"omitempty,oneof=cash physical"`
+	PrizeAmount           *float64 `json:"prize_amount,omitempty"`
+	PrizeDescription      *string  `json:"prize_description,omitempty"`
+	Prizes                []CreateRafflePrizeRequest `json:"prizes,omitempty" binding:"omitempty,max=10,dive"` // Premios en orden; el primero es el premio mayor
+	PricingRules          []PricingRuleRequest       `json:"pricing_rules,omitempty" binding:"omitempty,max=10,dive"` // Paquetes y descuentos
+	MinSoldCount          *int                       `json:"min_sold_count,omitempty" binding:"omitempty,min=1,max=10000"`       // Mínimo de vendidos para realizar el sorteo
+	MinSalesCutoffHours   *int                       `json:"min_sales_cutoff_hours,omitempty" binding:"omitempty,min=0,max=168"` // Horas antes del sorteo en que se evalúa (24 por defecto)
+	MinSalesExtensionDays *int                       `json:"min_sales_extension_days,omitempty" binding:"omitempty,min=1,max=30"` // Días a posponer una vez; sin valor se cancela
+	UnsoldWinnerPolicy    *string                    `json:"unsold_winner_policy,omitempty" binding:"omitempty,oneof=redraw nearest_sold rollover"` // Qué hacer si el número oficial no se vendió
+}
+
+// CreateRafflePrizeRequest premio del sorteo en el request
+type CreateRafflePrizeRequest struct {
+	Description string   `json:"description" binding:"required,max=500"`
+	PrizeType   string   `json:"prize_type" binding:"omitempty,oneof=cash physical"`
+	Value       *float64 `json:"value,omitempty" binding:"omitempty,gt=0"`
+	ImageURL    *string  `json:"image_url,omitempty" binding:"omitempty,url"`
 }
 
 // CreateRaffleResponse estructura de la respuesta
@@ -48,6 +65,22 @@ type RaffleDTO struct {
 	PlatformFeeAmount     string  `json:"platform_fee_amount"`
 	NetAmount             string  `json:"net_amount"`
 	SettlementStatus      string  `json:"settlement_status"`
+	PrizeType             string  `json:"prize_type"`
+	PrizeAmount           *string `json:"prize_amount,omitempty"`
+	PrizeDescription      *string `json:"prize_description,omitempty"`
+	Prizes                []RafflePrizeDTO `json:"prizes,omitempty"`
+	MinSoldCount          *int    `json:"min_sold_count,omitempty"`
+	MinSalesCutoffHours   int     `json:"min_sales_cutoff_hours"`
+	MinSalesExtensionDays *int    `json:"min_sales_extension_days,omitempty"`
+	MinSalesExtendedAt    *string `json:"min_sales_extended_at,omitempty"`
+	SeriesID              *int64  `json:"series_id,omitempty"`
+	SeriesSequence        *int    `json:"series_sequence,omitempty"`
+	UnsoldWinnerPolicy    *string `json:"unsold_winner_policy,omitempty"`
+	UnsoldPolicyApplied   *string `json:"unsold_policy_applied,omitempty"`
+	LotteryMappedNumber   *int    `json:"lottery_mapped_number,omitempty"`
+	RolloverAmount        *string `json:"rollover_amount,omitempty"`
+	RolledOverFromID      *int64  `json:"rolled_over_from_raffle_id,omitempty"`
+	RolledOverToID        *int64  `json:"rolled_over_to_raffle_id,omitempty"`
 	CreatedAt             string  `json:"created_at"`
 	PublishedAt           *string `json:"published_at,omitempty"`
 }
@@ -113,6 +146,15 @@ func (h *CreateRaffleHandler) Handle(c *gin.Context) {
 		TotalNumbers:   req.TotalNumbers,
 		DrawDate:       drawDate,
 		DrawMethod:     domain.DrawMethod(req.DrawMethod),
+
+		MinSoldCount:          req.MinSoldCount,
+		MinSalesCutoffHours:   req.MinSalesCutoffHours,
+		MinSalesExtensionDays: req.MinSalesExtensionDays,
+	}
+
+	if req.UnsoldWinnerPolicy != nil {
+		policy := domain.UnsoldWinnerPolicy(*req.UnsoldWinnerPolicy)
+		input.UnsoldWinnerPolicy = &policy
 	}
 
 	if req.PlatformFeePercentage != nil {
@@ -120,6 +162,35 @@ func (h *CreateRaffleHandler) Handle(c *gin.Context) {
 		input.PlatformFeePercentage = &fee
 	}
 
+	input.PrizeType = domain.PrizeType(req.PrizeType)
+	input.PrizeDescription = req.PrizeDescription
+	if req.PrizeAmount != nil {
+		amount := decimal.NewFromFloat(*req.PrizeAmount)
+		input.PrizeAmount = &amount
+	}
+
+	for _, prize := range req.Prizes {
+		prizeInput := raffleuc.RafflePrizeInput{
+			Description: prize.Description,
+			PrizeType:   domain.PrizeType(prize.PrizeType),
+			ImageURL:    prize.ImageURL,
+		}
+		if prize.Value != nil {
+			value := decimal.NewFromFloat(*prize.Value)
+			prizeInput.Value = &value
+		}
+		input.Prizes = append(input.Prizes, prizeInput)
+	}
+
+	input.PricingRules, err = toPricingRuleInputs(req.PricingRules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_DATE_FORMAT",
+			"message": "Las fechas de las reglas de precio deben estar en formato ISO 8601",
+		})
+		return
+	}
+
 	// 5. Ejecutar use case
 	output, err := h.useCase.Execute(c.Request.Context(), input)
 	if err != nil {
@@ -156,14 +227,50 @@ func toRaffleDTO(r *domain.Raffle) *RaffleDTO {
 		PlatformFeeAmount:     r.PlatformFeeAmount.String(),
 		NetAmount:             r.NetAmount.String(),
 		SettlementStatus:      string(r.SettlementStatus),
+		PrizeType:             string(r.PrizeType),
+		PrizeDescription:      r.PrizeDescription,
+		Prizes:                toRafflePrizeDTOs(r.Prizes),
+		MinSoldCount:          r.MinSoldCount,
+		MinSalesCutoffHours:   r.MinSalesCutoffHours,
+		MinSalesExtensionDays: r.MinSalesExtensionDays,
+		SeriesID:              r.SeriesID,
+		SeriesSequence:        r.SeriesSequence,
+		LotteryMappedNumber:   r.LotteryMappedNumber,
+		RolledOverFromID:      r.RolledOverFromRaffleID,
+		RolledOverToID:        r.RolledOverToRaffleID,
 		CreatedAt:             r.CreatedAt.Format(time.RFC3339),
 	}
 
+	if r.UnsoldWinnerPolicy != nil {
+		policy := string(*r.UnsoldWinnerPolicy)
+		dto.UnsoldWinnerPolicy = &policy
+	}
+
+	if r.UnsoldPolicyApplied != nil {
+		applied := string(*r.UnsoldPolicyApplied)
+		dto.UnsoldPolicyApplied = &applied
+	}
+
+	if r.RolloverAmount != nil {
+		rolloverAmount := r.RolloverAmount.String()
+		dto.RolloverAmount = &rolloverAmount
+	}
+
+	if r.MinSalesExtendedAt != nil {
+		extendedAt := r.MinSalesExtendedAt.Format(time.RFC3339)
+		dto.MinSalesExtendedAt = &extendedAt
+	}
+
 	if r.PublishedAt != nil {
 		publishedAt := r.PublishedAt.Format(time.RFC3339)
 		dto.PublishedAt = &publishedAt
 	}
 
+	if r.PrizeAmount != nil {
+		prizeAmount := r.PrizeAmount.String()
+		dto.PrizeAmount = &prizeAmount
+	}
+
 	return dto
 }
 
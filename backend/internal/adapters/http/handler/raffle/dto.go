@@ -29,6 +29,47 @@ type PublicRaffleDTO struct {
 	CategoryID    *int64         `json:"category_id,omitempty"`
 	CreatedAt     string         `json:"created_at"`
 	PublishedAt   *string        `json:"published_at,omitempty"`
+	DrawSeedHash  *string        `json:"draw_seed_hash,omitempty"` // Compromiso público del sorteo verificable
+	Prizes        []RafflePrizeDTO  `json:"prizes,omitempty"`  // Premios en orden (el primero es el premio mayor)
+	Winners       []RaffleWinnerDTO `json:"winners,omitempty"` // Ganador de cada premio sorteado
+	MinSales      *MinSalesDTO      `json:"min_sales,omitempty"` // Mínimo de vendidos para realizar el sorteo
+	UnsoldWinner  *UnsoldWinnerDTO  `json:"unsold_winner,omitempty"` // Regla si el número oficial no se vendió
+}
+
+// UnsoldWinnerDTO regla del sorteo si el número oficial de la lotería no se vendió y cómo se aplicó
+type UnsoldWinnerDTO struct {
+	Policy           string  `json:"policy,omitempty"`                     // "redraw", "nearest_sold" o "rollover"
+	Applied          *string `json:"applied,omitempty"`                    // Regla aplicada en el sorteo
+	MappedNumber     *int    `json:"lottery_mapped_number,omitempty"`      // Número oficial llevado al rango del sorteo
+	RolloverAmount   *string `json:"rollover_amount,omitempty"`            // Acumulado recibido de sorteos anteriores
+	RolledOverFromID *int64  `json:"rolled_over_from_raffle_id,omitempty"` // Último sorteo de la serie que acumuló
+	RolledOverToID   *int64  `json:"rolled_over_to_raffle_id,omitempty"`   // Sorteo que recibió el premio mayor
+}
+
+// MinSalesDTO mínimo de números vendidos del sorteo y qué ocurre si no se alcanza
+type MinSalesDTO struct {
+	MinSoldCount  int     `json:"min_sold_count"`
+	CutoffAt      string  `json:"cutoff_at"`                // Momento en que se evalúa
+	OnShortfall   string  `json:"on_shortfall"`             // "postpone" (una vez) o "cancel" (con reembolso)
+	ExtensionDays *int    `json:"extension_days,omitempty"` // Solo postpone
+	PostponedAt   *string `json:"postponed_at,omitempty"`   // Si el sorteo ya fue pospuesto
+}
+
+// RafflePrizeDTO premio del sorteo
+type RafflePrizeDTO struct {
+	Position    int     `json:"position"`
+	Description string  `json:"description"`
+	PrizeType   string  `json:"prize_type"`
+	Value       *string `json:"value,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+}
+
+// RaffleWinnerDTO número ganador de un premio (sin exponer user_id)
+type RaffleWinnerDTO struct {
+	Position         int    `json:"position"`
+	PrizeDescription string `json:"prize_description,omitempty"`
+	WinnerNumber     string `json:"winner_number"`
+	DrawnAt          string `json:"drawn_at"`
 }
 
 // BuyerRaffleDTO - Para usuarios autenticados que HAN comprado en este sorteo
@@ -77,6 +118,9 @@ func toPublicRaffleDTOWithOrganizer(raffle *domain.Raffle, organizer *OrganizerI
 		CategoryID:     raffle.CategoryID,
 		CreatedAt:      raffle.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		Organizer:      organizer,
+		DrawSeedHash:   raffle.DrawSeedHash,
+		Prizes:         toRafflePrizeDTOs(raffle.Prizes),
+		Winners:        toRaffleWinnerDTOs(raffle.Winners),
 	}
 
 	if raffle.PublishedAt != nil {
@@ -84,9 +128,100 @@ func toPublicRaffleDTOWithOrganizer(raffle *domain.Raffle, organizer *OrganizerI
 		dto.PublishedAt = &publishedStr
 	}
 
+	if raffle.HasMinimumSales() {
+		dto.MinSales = &MinSalesDTO{
+			MinSoldCount: *raffle.MinSoldCount,
+			CutoffAt:     raffle.MinimumSalesCutoff().Format("2006-01-02T15:04:05Z07:00"),
+			OnShortfall:  "cancel",
+		}
+		if raffle.CanExtendForMinimumSales() {
+			dto.MinSales.OnShortfall = "postpone"
+			dto.MinSales.ExtensionDays = raffle.MinSalesExtensionDays
+		}
+		if raffle.MinSalesExtendedAt != nil {
+			postponedAt := raffle.MinSalesExtendedAt.Format("2006-01-02T15:04:05Z07:00")
+			dto.MinSales.PostponedAt = &postponedAt
+		}
+	}
+
+	if raffle.UnsoldWinnerPolicy != nil || raffle.UnsoldPolicyApplied != nil || raffle.RolloverAmount != nil {
+		dto.UnsoldWinner = toUnsoldWinnerDTO(raffle)
+	}
+
 	return dto
 }
 
+// toUnsoldWinnerDTO convierte la regla de número no vendido del sorteo a DTO
+func toUnsoldWinnerDTO(raffle *domain.Raffle) *UnsoldWinnerDTO {
+	dto := &UnsoldWinnerDTO{
+		MappedNumber:     raffle.LotteryMappedNumber,
+		RolledOverFromID: raffle.RolledOverFromRaffleID,
+		RolledOverToID:   raffle.RolledOverToRaffleID,
+	}
+
+	if raffle.UnsoldWinnerPolicy != nil {
+		dto.Policy = string(*raffle.UnsoldWinnerPolicy)
+	}
+
+	if raffle.UnsoldPolicyApplied != nil {
+		applied := string(*raffle.UnsoldPolicyApplied)
+		dto.Applied = &applied
+		// Sin regla propia se aplicó el respaldo general de la plataforma
+		if dto.Policy == "" {
+			dto.Policy = applied
+		}
+	}
+
+	if raffle.RolloverAmount != nil {
+		rolloverAmount := raffle.RolloverAmount.String()
+		dto.RolloverAmount = &rolloverAmount
+	}
+
+	return dto
+}
+
+// toRafflePrizeDTOs convierte los premios del sorteo a DTOs
+func toRafflePrizeDTOs(prizes []*domain.RafflePrize) []RafflePrizeDTO {
+	if len(prizes) == 0 {
+		return nil
+	}
+
+	dtos := make([]RafflePrizeDTO, len(prizes))
+	for i, prize := range prizes {
+		dtos[i] = RafflePrizeDTO{
+			Position:    prize.Position,
+			Description: prize.Description,
+			PrizeType:   string(prize.PrizeType),
+			ImageURL:    prize.ImageURL,
+		}
+		if prize.Value != nil {
+			value := prize.Value.String()
+			dtos[i].Value = &value
+		}
+	}
+	return dtos
+}
+
+// toRaffleWinnerDTOs convierte los ganadores por premio a DTOs
+func toRaffleWinnerDTOs(winners []*domain.RaffleWinner) []RaffleWinnerDTO {
+	if len(winners) == 0 {
+		return nil
+	}
+
+	dtos := make([]RaffleWinnerDTO, len(winners))
+	for i, winner := range winners {
+		dtos[i] = RaffleWinnerDTO{
+			Position:     winner.Position,
+			WinnerNumber: winner.Number,
+			DrawnAt:      winner.DrawnAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if winner.Prize != nil {
+			dtos[i].PrizeDescription = winner.Prize.Description
+		}
+	}
+	return dtos
+}
+
 // toBuyerRaffleDTO convierte a DTO de comprador con su gasto personal (legacy)
 func toBuyerRaffleDTO(raffle *domain.Raffle, myTotalSpent string, myNumbersCount int) *BuyerRaffleDTO {
 	publicDTO := toPublicRaffleDTO(raffle)
@@ -0,0 +1,108 @@
+package raffle
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+)
+
+// DrawRoomEventDTO evento del timeline del sorteo en vivo
+type DrawRoomEventDTO struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Step       int             `json:"step"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	OccurredAt string          `json:"occurred_at"`
+}
+
+// DrawRoomResponse sala del sorteo en vivo con su timeline
+type DrawRoomResponse struct {
+	RaffleUUID       string             `json:"raffle_uuid"`
+	RaffleStatus     string             `json:"raffle_status"`
+	Status           string             `json:"status"`
+	ScheduledAt      string             `json:"scheduled_at"`
+	SecondsRemaining int64              `json:"seconds_remaining"`
+	StreamURL        *string            `json:"stream_url,omitempty"`
+	StartedAt        *string            `json:"started_at,omitempty"`
+	FinishedAt       *string            `json:"finished_at,omitempty"`
+	WinnerNumber     *string            `json:"winner_number,omitempty"`
+	Timeline         []DrawRoomEventDTO `json:"timeline"`
+}
+
+// GetDrawRoomHandler maneja la consulta pública de la sala del sorteo en vivo
+type GetDrawRoomHandler struct {
+	useCase *raffleuc.GetDrawRoomUseCase
+}
+
+// NewGetDrawRoomHandler crea una nueva instancia
+func NewGetDrawRoomHandler(useCase *raffleuc.GetDrawRoomUseCase) *GetDrawRoomHandler {
+	return &GetDrawRoomHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+// GET /api/v1/raffles/:id/draw-room
+func (h *GetDrawRoomHandler) Handle(c *gin.Context) {
+	// 1. Obtener ID o UUID del path
+	idOrUUID := c.Param("id")
+
+	input := &raffleuc.GetDrawRoomInput{}
+	if id, err := strconv.ParseInt(idOrUUID, 10, 64); err == nil {
+		input.RaffleID = &id
+	} else {
+		input.RaffleUUID = &idOrUUID
+	}
+
+	// 2. Ejecutar use case
+	output, err := h.useCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 3. Construir response
+	room := output.Room
+	response := &DrawRoomResponse{
+		RaffleUUID:   output.Raffle.UUID.String(),
+		RaffleStatus: string(output.Raffle.Status),
+		Status:       string(room.Status),
+		ScheduledAt:  room.ScheduledAt.Format(time.RFC3339),
+		StreamURL:    room.StreamURL,
+		Timeline:     make([]DrawRoomEventDTO, 0, len(output.Events)),
+	}
+
+	// Durante el sorteo en vivo el ganador solo se conoce con el evento winner_revealed
+	if room.Status != domain.DrawRoomStatusLive {
+		response.WinnerNumber = output.Raffle.WinnerNumber
+	}
+	if remaining := int64(time.Until(room.ScheduledAt).Seconds()); remaining > 0 {
+		response.SecondsRemaining = remaining
+	}
+	if room.StartedAt != nil {
+		startedStr := room.StartedAt.Format(time.RFC3339)
+		response.StartedAt = &startedStr
+	}
+	if room.FinishedAt != nil {
+		finishedStr := room.FinishedAt.Format(time.RFC3339)
+		response.FinishedAt = &finishedStr
+	}
+
+	for _, event := range output.Events {
+		response.Timeline = append(response.Timeline, DrawRoomEventDTO{
+			ID:         event.ID,
+			Type:       string(event.Type),
+			Step:       event.Step,
+			Data:       json.RawMessage(event.Data),
+			OccurredAt: event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,241 @@
+package raffle
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// PricingRuleRequest regla de precio en el request
+type PricingRuleRequest struct {
+	Type            string   `json:"type" binding:"required,oneof=bundle quantity_tier early_bird"`
+	Name            string   `json:"name" binding:"required,max=100"`
+	Quantity        int      `json:"quantity" binding:"omitempty,min=1"`
+	BundlePrice     *float64 `json:"bundle_price,omitempty" binding:"omitempty,gt=0"`
+	DiscountPercent *float64 `json:"discount_percent,omitempty" binding:"omitempty,gt=0,lt=100"`
+	StartsAt        *string  `json:"starts_at,omitempty"` // ISO 8601
+	EndsAt          *string  `json:"ends_at,omitempty"`   // ISO 8601
+	Active          *bool    `json:"active,omitempty"`
+}
+
+// SetPricingRulesRequest estructura del request
+type SetPricingRulesRequest struct {
+	PricingRules []PricingRuleRequest `json:"pricing_rules" binding:"max=10,dive"`
+}
+
+// PricingRuleDTO regla de precio en el response
+type PricingRuleDTO struct {
+	ID              int64   `json:"id"`
+	Type            string  `json:"type"`
+	Name            string  `json:"name"`
+	Quantity        int     `json:"quantity"`
+	BundlePrice     *string `json:"bundle_price,omitempty"`
+	DiscountPercent *string `json:"discount_percent,omitempty"`
+	StartsAt        *string `json:"starts_at,omitempty"`
+	EndsAt          *string `json:"ends_at,omitempty"`
+	Active          bool    `json:"active"`
+}
+
+// toPricingRuleInputs convierte las reglas del request al input del use case
+func toPricingRuleInputs(reqs []PricingRuleRequest) ([]raffleuc.PricingRuleInput, error) {
+	inputs := make([]raffleuc.PricingRuleInput, 0, len(reqs))
+	for _, req := range reqs {
+		input := raffleuc.PricingRuleInput{
+			Type:     domain.PricingRuleType(req.Type),
+			Name:     req.Name,
+			Quantity: req.Quantity,
+			Active:   req.Active,
+		}
+		if req.BundlePrice != nil {
+			price := decimal.NewFromFloat(*req.BundlePrice)
+			input.BundlePrice = &price
+		}
+		if req.DiscountPercent != nil {
+			percent := decimal.NewFromFloat(*req.DiscountPercent)
+			input.DiscountPercent = &percent
+		}
+		if req.StartsAt != nil {
+			startsAt, err := time.Parse(time.RFC3339, *req.StartsAt)
+			if err != nil {
+				return nil, err
+			}
+			input.StartsAt = &startsAt
+		}
+		if req.EndsAt != nil {
+			endsAt, err := time.Parse(time.RFC3339, *req.EndsAt)
+			if err != nil {
+				return nil, err
+			}
+			input.EndsAt = &endsAt
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// toPricingRuleDTOs convierte las reglas de precio a DTOs
+func toPricingRuleDTOs(rules []*domain.PricingRule) []PricingRuleDTO {
+	dtos := make([]PricingRuleDTO, len(rules))
+	for i, rule := range rules {
+		dtos[i] = PricingRuleDTO{
+			ID:       rule.ID,
+			Type:     string(rule.Type),
+			Name:     rule.Name,
+			Quantity: rule.Quantity,
+			Active:   rule.Active,
+		}
+		if rule.BundlePrice != nil {
+			price := rule.BundlePrice.String()
+			dtos[i].BundlePrice = &price
+		}
+		if rule.DiscountPercent != nil {
+			percent := rule.DiscountPercent.String()
+			dtos[i].DiscountPercent = &percent
+		}
+		if rule.StartsAt != nil {
+			startsAt := rule.StartsAt.Format(time.RFC3339)
+			dtos[i].StartsAt = &startsAt
+		}
+		if rule.EndsAt != nil {
+			endsAt := rule.EndsAt.Format(time.RFC3339)
+			dtos[i].EndsAt = &endsAt
+		}
+	}
+	return dtos
+}
+
+// SetPricingRulesHandler maneja la configuración de reglas de precio (owner o admin)
+type SetPricingRulesHandler struct {
+	useCase *raffleuc.SetPricingRulesUseCase
+}
+
+// NewSetPricingRulesHandler crea una nueva instancia
+func NewSetPricingRulesHandler(useCase *raffleuc.SetPricingRulesUseCase) *SetPricingRulesHandler {
+	return &SetPricingRulesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *SetPricingRulesHandler) Handle(c *gin.Context) {
+	// 1. Obtener usuario autenticado
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	userRole, exists := c.Get("user_role")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	// 2. Obtener ID del sorteo
+	raffleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ID",
+			"message": "ID de sorteo inválido",
+		})
+		return
+	}
+
+	// 3. Parsear request
+	var req SetPricingRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	rules, err := toPricingRuleInputs(req.PricingRules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_DATE_FORMAT",
+			"message": "Las fechas deben estar en formato ISO 8601",
+		})
+		return
+	}
+
+	// 4. Ejecutar use case
+	output, err := h.useCase.Execute(c.Request.Context(), &raffleuc.SetPricingRulesInput{
+		RaffleID: raffleID,
+		UserID:   userID.(int64),
+		UserRole: userRole.(domain.UserRole),
+		Rules:    rules,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 5. Response
+	c.JSON(http.StatusOK, gin.H{
+		"pricing_rules": toPricingRuleDTOs(output),
+	})
+}
+
+// GetPricingRulesHandler maneja la consulta pública de reglas de precio y la cotización de una compra
+type GetPricingRulesHandler struct {
+	useCase *raffleuc.GetPricingRulesUseCase
+}
+
+// NewGetPricingRulesHandler crea una nueva instancia
+func NewGetPricingRulesHandler(useCase *raffleuc.GetPricingRulesUseCase) *GetPricingRulesHandler {
+	return &GetPricingRulesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request (GET /raffles/:id/pricing?quantity=N)
+func (h *GetPricingRulesHandler) Handle(c *gin.Context) {
+	// 1. Obtener ID o UUID del path
+	idOrUUID := c.Param("id")
+	input := &raffleuc.GetPricingRulesInput{}
+
+	if id, err := strconv.ParseInt(idOrUUID, 10, 64); err == nil {
+		input.RaffleID = &id
+	} else {
+		input.RaffleUUID = &idOrUUID
+	}
+
+	// 2. Cantidad a cotizar (opcional)
+	if quantity := c.Query("quantity"); quantity != "" {
+		n, err := strconv.Atoi(quantity)
+		if err != nil || n < 1 || n > 10000 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    "INVALID_QUANTITY",
+				"message": "La cantidad debe ser un número entre 1 y 10000",
+			})
+			return
+		}
+		input.Quantity = n
+	}
+
+	// 3. Ejecutar use case
+	output, err := h.useCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 4. Response
+	response := gin.H{
+		"price_per_number": output.Raffle.PricePerNumber.String(),
+		"pricing_rules":    toPricingRuleDTOs(output.Rules),
+	}
+	if output.Quote != nil {
+		response["quote"] = output.Quote
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,361 @@
+package raffle
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// CreateRaffleSeriesRequest estructura del request
+type CreateRaffleSeriesRequest struct {
+	TemplateID      int64   `json:"template_id" binding:"required"`
+	Name            string  `json:"name" binding:"required,max=100"`
+	Mode            string  `json:"mode" binding:"required,oneof=on_completion cron"`
+	CronExpression  *string `json:"cron_expression,omitempty"`                            // Ej. "0 18 * * 1" (lunes 18:00)
+	Timezone        string  `json:"timezone,omitempty"`                                   // Por defecto America/Costa_Rica
+	DrawOffsetHours int     `json:"draw_offset_hours" binding:"required,min=25,max=2160"` // Horas entre la generación y el sorteo
+	AutoPublish     *bool   `json:"auto_publish,omitempty"`
+	MaxOccurrences  *int    `json:"max_occurrences,omitempty" binding:"omitempty,min=1"`
+}
+
+// UpdateRaffleSeriesRequest estructura del request (los campos omitidos no se modifican)
+type UpdateRaffleSeriesRequest struct {
+	Name            *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	CronExpression  *string `json:"cron_expression,omitempty"`
+	Timezone        *string `json:"timezone,omitempty"`
+	DrawOffsetHours *int    `json:"draw_offset_hours,omitempty" binding:"omitempty,min=25,max=2160"`
+	AutoPublish     *bool   `json:"auto_publish,omitempty"`
+	MaxOccurrences  *int    `json:"max_occurrences,omitempty" binding:"omitempty,min=0"` // 0 elimina el límite
+	Action          *string `json:"action,omitempty" binding:"omitempty,oneof=pause resume end"`
+}
+
+// RaffleSeriesDTO serie en el response
+type RaffleSeriesDTO struct {
+	ID              int64   `json:"id"`
+	UUID            string  `json:"uuid"`
+	TemplateID      int64   `json:"template_id"`
+	Name            string  `json:"name"`
+	Mode            string  `json:"mode"`
+	CronExpression  *string `json:"cron_expression,omitempty"`
+	Timezone        string  `json:"timezone"`
+	DrawOffsetHours int     `json:"draw_offset_hours"`
+	AutoPublish     bool    `json:"auto_publish"`
+	MaxOccurrences  *int    `json:"max_occurrences,omitempty"`
+	Status          string  `json:"status"`
+	Occurrences     int     `json:"occurrences"`
+	NextRunAt       *string `json:"next_run_at,omitempty"`
+	LastRaffleID    *int64  `json:"last_raffle_id,omitempty"`
+	LastRunAt       *string `json:"last_run_at,omitempty"`
+	LastError       *string `json:"last_error,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	EndedAt         *string `json:"ended_at,omitempty"`
+}
+
+// RaffleSeriesEntryDTO sorteo de la serie en el response
+type RaffleSeriesEntryDTO struct {
+	RaffleID       int64  `json:"raffle_id"`
+	RaffleUUID     string `json:"raffle_uuid"`
+	Title          string `json:"title"`
+	SeriesSequence int    `json:"series_sequence"`
+	Status         string `json:"status"`
+	DrawDate       string `json:"draw_date"`
+	TotalNumbers   int    `json:"total_numbers"`
+	SoldCount      int    `json:"sold_count"`
+	PaidRevenue    string `json:"paid_revenue"`
+}
+
+// RaffleSeriesStatsDTO estadísticas de la serie en el response
+type RaffleSeriesStatsDTO struct {
+	TotalRaffles     int            `json:"total_raffles"`
+	CompletedRaffles int            `json:"completed_raffles"`
+	RafflesByStatus  map[string]int `json:"raffles_by_status"`
+	TotalNumbers     int            `json:"total_numbers"`
+	SoldNumbers      int            `json:"sold_numbers"`
+	SellThroughRate  string         `json:"sell_through_rate"`
+	PaidRevenue      string         `json:"paid_revenue"`
+	AverageRevenue   string         `json:"average_revenue"`
+}
+
+// formatOptionalTime formatea una fecha opcional en ISO 8601
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+// toRaffleSeriesDTO convierte la serie a DTO
+func toRaffleSeriesDTO(s *domain.RaffleSeries) *RaffleSeriesDTO {
+	return &RaffleSeriesDTO{
+		ID:              s.ID,
+		UUID:            s.UUID.String(),
+		TemplateID:      s.TemplateID,
+		Name:            s.Name,
+		Mode:            string(s.Mode),
+		CronExpression:  s.CronExpression,
+		Timezone:        s.Timezone,
+		DrawOffsetHours: s.DrawOffsetHours,
+		AutoPublish:     s.AutoPublish,
+		MaxOccurrences:  s.MaxOccurrences,
+		Status:          string(s.Status),
+		Occurrences:     s.Occurrences,
+		NextRunAt:       formatOptionalTime(s.NextRunAt),
+		LastRaffleID:    s.LastRaffleID,
+		LastRunAt:       formatOptionalTime(s.LastRunAt),
+		LastError:       s.LastError,
+		CreatedAt:       s.CreatedAt.Format(time.RFC3339),
+		EndedAt:         formatOptionalTime(s.EndedAt),
+	}
+}
+
+// parseSeriesID obtiene el ID de la serie del path
+func parseSeriesID(c *gin.Context) (int64, bool) {
+	seriesID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ID",
+			"message": "ID de serie inválido",
+		})
+		return 0, false
+	}
+	return seriesID, true
+}
+
+// CreateRaffleSeriesHandler maneja la creación de series recurrentes
+type CreateRaffleSeriesHandler struct {
+	useCase *raffleuc.CreateRaffleSeriesUseCase
+}
+
+// NewCreateRaffleSeriesHandler crea una nueva instancia
+func NewCreateRaffleSeriesHandler(useCase *raffleuc.CreateRaffleSeriesUseCase) *CreateRaffleSeriesHandler {
+	return &CreateRaffleSeriesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *CreateRaffleSeriesHandler) Handle(c *gin.Context) {
+	// 1. Obtener usuario autenticado
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	// 2. Parsear request
+	var req CreateRaffleSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	// 3. Ejecutar use case
+	series, err := h.useCase.Execute(c.Request.Context(), &raffleuc.CreateRaffleSeriesInput{
+		UserID:          userID.(int64),
+		TemplateID:      req.TemplateID,
+		Name:            req.Name,
+		Mode:            domain.RaffleSeriesMode(req.Mode),
+		CronExpression:  req.CronExpression,
+		Timezone:        req.Timezone,
+		DrawOffsetHours: req.DrawOffsetHours,
+		AutoPublish:     req.AutoPublish,
+		MaxOccurrences:  req.MaxOccurrences,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 4. Response
+	c.JSON(http.StatusCreated, gin.H{
+		"series": toRaffleSeriesDTO(series),
+	})
+}
+
+// UpdateRaffleSeriesHandler maneja la modificación, pausa, reanudación y finalización de series
+type UpdateRaffleSeriesHandler struct {
+	useCase *raffleuc.UpdateRaffleSeriesUseCase
+}
+
+// NewUpdateRaffleSeriesHandler crea una nueva instancia
+func NewUpdateRaffleSeriesHandler(useCase *raffleuc.UpdateRaffleSeriesUseCase) *UpdateRaffleSeriesHandler {
+	return &UpdateRaffleSeriesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *UpdateRaffleSeriesHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	seriesID, ok := parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateRaffleSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	input := &raffleuc.UpdateRaffleSeriesInput{
+		SeriesID:        seriesID,
+		UserID:          userID.(int64),
+		Name:            req.Name,
+		CronExpression:  req.CronExpression,
+		Timezone:        req.Timezone,
+		DrawOffsetHours: req.DrawOffsetHours,
+		AutoPublish:     req.AutoPublish,
+		MaxOccurrences:  req.MaxOccurrences,
+	}
+	if req.Action != nil {
+		action := raffleuc.RaffleSeriesAction(*req.Action)
+		input.Action = &action
+	}
+
+	series, err := h.useCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": toRaffleSeriesDTO(series),
+	})
+}
+
+// ListRaffleSeriesHandler maneja el listado de series del organizador
+type ListRaffleSeriesHandler struct {
+	useCase *raffleuc.ListRaffleSeriesUseCase
+}
+
+// NewListRaffleSeriesHandler crea una nueva instancia
+func NewListRaffleSeriesHandler(useCase *raffleuc.ListRaffleSeriesUseCase) *ListRaffleSeriesHandler {
+	return &ListRaffleSeriesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *ListRaffleSeriesHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	series, err := h.useCase.Execute(c.Request.Context(), userID.(int64))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	dtos := make([]*RaffleSeriesDTO, len(series))
+	for i, s := range series {
+		dtos[i] = toRaffleSeriesDTO(s)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": dtos,
+	})
+}
+
+// GetRaffleSeriesStatsHandler maneja la consulta de una serie con sus sorteos y estadísticas
+type GetRaffleSeriesStatsHandler struct {
+	useCase *raffleuc.GetRaffleSeriesStatsUseCase
+}
+
+// NewGetRaffleSeriesStatsHandler crea una nueva instancia
+func NewGetRaffleSeriesStatsHandler(useCase *raffleuc.GetRaffleSeriesStatsUseCase) *GetRaffleSeriesStatsHandler {
+	return &GetRaffleSeriesStatsHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *GetRaffleSeriesStatsHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	userRole, exists := c.Get("user_role")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	seriesID, ok := parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.useCase.Execute(c.Request.Context(), &raffleuc.GetRaffleSeriesStatsInput{
+		SeriesID: seriesID,
+		UserID:   userID.(int64),
+		UserRole: userRole.(domain.UserRole),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	raffles := make([]RaffleSeriesEntryDTO, len(output.Raffles))
+	for i, entry := range output.Raffles {
+		raffles[i] = RaffleSeriesEntryDTO{
+			RaffleID:       entry.RaffleID,
+			RaffleUUID:     entry.RaffleUUID.String(),
+			Title:          entry.Title,
+			SeriesSequence: entry.SeriesSequence,
+			Status:         string(entry.Status),
+			DrawDate:       entry.DrawDate.Format(time.RFC3339),
+			TotalNumbers:   entry.TotalNumbers,
+			SoldCount:      entry.SoldCount,
+			PaidRevenue:    entry.PaidRevenue.String(),
+		}
+	}
+
+	byStatus := make(map[string]int, len(output.Stats.RafflesByStatus))
+	for status, count := range output.Stats.RafflesByStatus {
+		byStatus[string(status)] = count
+	}
+
+	response := gin.H{
+		"series":  toRaffleSeriesDTO(output.Series),
+		"raffles": raffles,
+		"stats": RaffleSeriesStatsDTO{
+			TotalRaffles:     output.Stats.TotalRaffles,
+			CompletedRaffles: output.Stats.CompletedRaffles,
+			RafflesByStatus:  byStatus,
+			TotalNumbers:     output.Stats.TotalNumbers,
+			SoldNumbers:      output.Stats.SoldNumbers,
+			SellThroughRate:  output.Stats.SellThroughRate.String(),
+			PaidRevenue:      output.Stats.PaidRevenue.String(),
+			AverageRevenue:   output.Stats.AverageRevenue.String(),
+		},
+	}
+	if output.Template != nil {
+		response["template"] = toRaffleTemplateDTO(output.Template)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,496 @@
+package raffle
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// RaffleTemplateDataRequest datos del sorteo guardados en la plantilla
+type RaffleTemplateDataRequest struct {
+	Title              string                       `json:"title" binding:"required,min=5,max=255"`
+	Description        string                       `json:"description"`
+	CategoryID         *int64                       `json:"category_id,omitempty"`
+	PricePerNumber     float64                      `json:"price_per_number" binding:"required,gt=0"`
+	TotalNumbers       int                          `json:"total_numbers" binding:"required,min=10,max=10000"`
+	MinNumber          int                          `json:"min_number" binding:"omitempty,min=0"`
+	MaxNumber          int                          `json:"max_number" binding:"omitempty,min=0"`
+	DrawMethod         string                       `json:"draw_method" binding:"required,oneof=loteria_nacional_cr manual random"`
+	PrizeType          string                       `json:"prize_type" binding:"omitempty,oneof=cash physical"`
+	PrizeAmount        *float64                     `json:"prize_amount,omitempty"`
+	PrizeDescription   *string                      `json:"prize_description,omitempty"`
+	Prizes             []CreateRafflePrizeRequest   `json:"prizes,omitempty" binding:"omitempty,max=10,dive"`
+	PricingRules       []TemplatePricingRuleRequest `json:"pricing_rules,omitempty" binding:"omitempty,max=10,dive"`
+	UnsoldWinnerPolicy *string                      `json:"unsold_winner_policy,omitempty" binding:"omitempty,oneof=redraw nearest_sold rollover"`
+}
+
+// TemplatePricingRuleRequest regla de precio de la plantilla; la ventana es relativa a la creación del sorteo
+type TemplatePricingRuleRequest struct {
+	Type             string   `json:"type" binding:"required,oneof=bundle quantity_tier early_bird"`
+	Name             string   `json:"name" binding:"required,max=100"`
+	Quantity         int      `json:"quantity" binding:"omitempty,min=1"`
+	BundlePrice      *float64 `json:"bundle_price,omitempty" binding:"omitempty,gt=0"`
+	DiscountPercent  *float64 `json:"discount_percent,omitempty" binding:"omitempty,gt=0,lt=100"`
+	StartsAfterHours *int     `json:"starts_after_hours,omitempty" binding:"omitempty,min=0"`
+	EndsAfterHours   *int     `json:"ends_after_hours,omitempty" binding:"omitempty,min=1"`
+	Active           *bool    `json:"active,omitempty"`
+}
+
+// CreateRaffleTemplateRequest estructura del request
+// Se indica from_raffle_id para copiar un sorteo existente, o raffle con los datos de la plantilla
+type CreateRaffleTemplateRequest struct {
+	Name         string                     `json:"name" binding:"max=100"`
+	FromRaffleID *int64                     `json:"from_raffle_id,omitempty"`
+	Raffle       *RaffleTemplateDataRequest `json:"raffle,omitempty"`
+}
+
+// UpdateRaffleTemplateRequest estructura del request
+type UpdateRaffleTemplateRequest struct {
+	Name   string                     `json:"name" binding:"required,max=100"`
+	Raffle *RaffleTemplateDataRequest `json:"raffle" binding:"required"`
+}
+
+// CreateRaffleFromTemplateRequest estructura del request
+type CreateRaffleFromTemplateRequest struct {
+	DrawDate string `json:"draw_date" binding:"required"` // ISO 8601
+	Publish  bool   `json:"publish"`
+}
+
+// RaffleTemplateImageDTO imagen de la plantilla en el response
+type RaffleTemplateImageDTO struct {
+	URLOriginal  *string `json:"url_original,omitempty"`
+	URLLarge     *string `json:"url_large,omitempty"`
+	URLMedium    *string `json:"url_medium,omitempty"`
+	URLThumbnail *string `json:"url_thumbnail,omitempty"`
+	AltText      string  `json:"alt_text,omitempty"`
+	DisplayOrder int     `json:"display_order"`
+	IsPrimary    bool    `json:"is_primary"`
+}
+
+// RaffleTemplateDTO plantilla en el response
+type RaffleTemplateDTO struct {
+	ID                 int64                        `json:"id"`
+	UUID               string                       `json:"uuid"`
+	Name               string                       `json:"name"`
+	Title              string                       `json:"title"`
+	Description        string                       `json:"description"`
+	CategoryID         *int64                       `json:"category_id,omitempty"`
+	PricePerNumber     string                       `json:"price_per_number"`
+	TotalNumbers       int                          `json:"total_numbers"`
+	MinNumber          int                          `json:"min_number"`
+	MaxNumber          int                          `json:"max_number"`
+	DrawMethod         string                       `json:"draw_method"`
+	PrizeType          string                       `json:"prize_type"`
+	PrizeAmount        *string                      `json:"prize_amount,omitempty"`
+	PrizeDescription   *string                      `json:"prize_description,omitempty"`
+	UnsoldWinnerPolicy *string                      `json:"unsold_winner_policy,omitempty"`
+	Prizes             []domain.TemplatePrize       `json:"prizes"`
+	PricingRules       []domain.TemplatePricingRule `json:"pricing_rules"`
+	Images             []RaffleTemplateImageDTO     `json:"images"`
+	CreatedAt          string                       `json:"created_at"`
+	UpdatedAt          string                       `json:"updated_at"`
+}
+
+// toRaffleTemplateInput convierte los datos del request al input del use case
+func toRaffleTemplateInput(req *RaffleTemplateDataRequest) *raffleuc.RaffleTemplateInput {
+	input := &raffleuc.RaffleTemplateInput{
+		Title:            req.Title,
+		Description:      req.Description,
+		CategoryID:       req.CategoryID,
+		PricePerNumber:   decimal.NewFromFloat(req.PricePerNumber),
+		TotalNumbers:     req.TotalNumbers,
+		MinNumber:        req.MinNumber,
+		MaxNumber:        req.MaxNumber,
+		DrawMethod:       domain.DrawMethod(req.DrawMethod),
+		PrizeType:        domain.PrizeType(req.PrizeType),
+		PrizeDescription: req.PrizeDescription,
+	}
+	if req.PrizeAmount != nil {
+		amount := decimal.NewFromFloat(*req.PrizeAmount)
+		input.PrizeAmount = &amount
+	}
+	if req.UnsoldWinnerPolicy != nil {
+		policy := domain.UnsoldWinnerPolicy(*req.UnsoldWinnerPolicy)
+		input.UnsoldWinnerPolicy = &policy
+	}
+
+	for _, prize := range req.Prizes {
+		prizeInput := raffleuc.RafflePrizeInput{
+			Description: prize.Description,
+			PrizeType:   domain.PrizeType(prize.PrizeType),
+			ImageURL:    prize.ImageURL,
+		}
+		if prize.Value != nil {
+			value := decimal.NewFromFloat(*prize.Value)
+			prizeInput.Value = &value
+		}
+		input.Prizes = append(input.Prizes, prizeInput)
+	}
+
+	for _, rule := range req.PricingRules {
+		templateRule := domain.TemplatePricingRule{
+			Type:             domain.PricingRuleType(rule.Type),
+			Name:             rule.Name,
+			Quantity:         rule.Quantity,
+			StartsAfterHours: rule.StartsAfterHours,
+			EndsAfterHours:   rule.EndsAfterHours,
+			Active:           rule.Active == nil || *rule.Active,
+		}
+		if rule.BundlePrice != nil {
+			price := decimal.NewFromFloat(*rule.BundlePrice)
+			templateRule.BundlePrice = &price
+		}
+		if rule.DiscountPercent != nil {
+			percent := decimal.NewFromFloat(*rule.DiscountPercent)
+			templateRule.DiscountPercent = &percent
+		}
+		input.PricingRules = append(input.PricingRules, templateRule)
+	}
+
+	return input
+}
+
+// toRaffleTemplateDTO convierte la plantilla a DTO
+func toRaffleTemplateDTO(t *domain.RaffleTemplate) *RaffleTemplateDTO {
+	dto := &RaffleTemplateDTO{
+		ID:               t.ID,
+		UUID:             t.UUID.String(),
+		Name:             t.Name,
+		Title:            t.Title,
+		Description:      t.Description,
+		CategoryID:       t.CategoryID,
+		PricePerNumber:   t.PricePerNumber.String(),
+		TotalNumbers:     t.TotalNumbers,
+		MinNumber:        t.MinNumber,
+		MaxNumber:        t.MaxNumber,
+		DrawMethod:       string(t.DrawMethod),
+		PrizeType:        string(t.PrizeType),
+		PrizeDescription: t.PrizeDescription,
+		Prizes:           []domain.TemplatePrize{},
+		PricingRules:     []domain.TemplatePricingRule{},
+		Images:           []RaffleTemplateImageDTO{},
+		CreatedAt:        t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        t.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if t.PrizeAmount != nil {
+		prizeAmount := t.PrizeAmount.String()
+		dto.PrizeAmount = &prizeAmount
+	}
+	if t.UnsoldWinnerPolicy != nil {
+		policy := string(*t.UnsoldWinnerPolicy)
+		dto.UnsoldWinnerPolicy = &policy
+	}
+
+	// Los campos JSON se validan al guardar; un error de decodificación deja la lista vacía
+	if prizes, err := t.PrizeList(); err == nil && prizes != nil {
+		dto.Prizes = prizes
+	}
+	if rules, err := t.PricingRuleList(); err == nil && rules != nil {
+		dto.PricingRules = rules
+	}
+	if images, err := t.ImageList(); err == nil {
+		for _, image := range images {
+			dto.Images = append(dto.Images, RaffleTemplateImageDTO{
+				URLOriginal:  image.URLOriginal,
+				URLLarge:     image.URLLarge,
+				URLMedium:    image.URLMedium,
+				URLThumbnail: image.URLThumbnail,
+				AltText:      image.AltText,
+				DisplayOrder: image.DisplayOrder,
+				IsPrimary:    image.IsPrimary,
+			})
+		}
+	}
+
+	return dto
+}
+
+// parseTemplateID obtiene el ID de la plantilla del path
+func parseTemplateID(c *gin.Context) (int64, bool) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ID",
+			"message": "ID de plantilla inválido",
+		})
+		return 0, false
+	}
+	return templateID, true
+}
+
+// CreateRaffleTemplateHandler maneja la creación de plantillas de sorteo
+type CreateRaffleTemplateHandler struct {
+	useCase *raffleuc.CreateRaffleTemplateUseCase
+}
+
+// NewCreateRaffleTemplateHandler crea una nueva instancia
+func NewCreateRaffleTemplateHandler(useCase *raffleuc.CreateRaffleTemplateUseCase) *CreateRaffleTemplateHandler {
+	return &CreateRaffleTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *CreateRaffleTemplateHandler) Handle(c *gin.Context) {
+	// 1. Obtener usuario autenticado
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	// 2. Parsear request
+	var req CreateRaffleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	input := &raffleuc.CreateRaffleTemplateInput{
+		UserID:       userID.(int64),
+		Name:         req.Name,
+		FromRaffleID: req.FromRaffleID,
+	}
+	if req.Raffle != nil {
+		input.Template = toRaffleTemplateInput(req.Raffle)
+	}
+
+	// 3. Ejecutar use case
+	template, err := h.useCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 4. Response
+	c.JSON(http.StatusCreated, gin.H{
+		"template": toRaffleTemplateDTO(template),
+	})
+}
+
+// UpdateRaffleTemplateHandler maneja la actualización de plantillas de sorteo
+type UpdateRaffleTemplateHandler struct {
+	useCase *raffleuc.UpdateRaffleTemplateUseCase
+}
+
+// NewUpdateRaffleTemplateHandler crea una nueva instancia
+func NewUpdateRaffleTemplateHandler(useCase *raffleuc.UpdateRaffleTemplateUseCase) *UpdateRaffleTemplateHandler {
+	return &UpdateRaffleTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *UpdateRaffleTemplateHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateRaffleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.useCase.Execute(c.Request.Context(), &raffleuc.UpdateRaffleTemplateInput{
+		TemplateID: templateID,
+		UserID:     userID.(int64),
+		Name:       req.Name,
+		Template:   toRaffleTemplateInput(req.Raffle),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": toRaffleTemplateDTO(template),
+	})
+}
+
+// DeleteRaffleTemplateHandler maneja la eliminación de plantillas de sorteo
+type DeleteRaffleTemplateHandler struct {
+	useCase *raffleuc.DeleteRaffleTemplateUseCase
+}
+
+// NewDeleteRaffleTemplateHandler crea una nueva instancia
+func NewDeleteRaffleTemplateHandler(useCase *raffleuc.DeleteRaffleTemplateUseCase) *DeleteRaffleTemplateHandler {
+	return &DeleteRaffleTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *DeleteRaffleTemplateHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Execute(c.Request.Context(), templateID, userID.(int64)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Plantilla eliminada exitosamente",
+	})
+}
+
+// ListRaffleTemplatesHandler maneja el listado de plantillas del organizador
+type ListRaffleTemplatesHandler struct {
+	useCase *raffleuc.ListRaffleTemplatesUseCase
+}
+
+// NewListRaffleTemplatesHandler crea una nueva instancia
+func NewListRaffleTemplatesHandler(useCase *raffleuc.ListRaffleTemplatesUseCase) *ListRaffleTemplatesHandler {
+	return &ListRaffleTemplatesHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *ListRaffleTemplatesHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	templates, err := h.useCase.Execute(c.Request.Context(), userID.(int64))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	dtos := make([]*RaffleTemplateDTO, len(templates))
+	for i, template := range templates {
+		dtos[i] = toRaffleTemplateDTO(template)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": dtos,
+	})
+}
+
+// GetRaffleTemplateHandler maneja la consulta de una plantilla del organizador
+type GetRaffleTemplateHandler struct {
+	useCase *raffleuc.GetRaffleTemplateUseCase
+}
+
+// NewGetRaffleTemplateHandler crea una nueva instancia
+func NewGetRaffleTemplateHandler(useCase *raffleuc.GetRaffleTemplateUseCase) *GetRaffleTemplateHandler {
+	return &GetRaffleTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *GetRaffleTemplateHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.useCase.Execute(c.Request.Context(), templateID, userID.(int64))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": toRaffleTemplateDTO(template),
+	})
+}
+
+// CreateRaffleFromTemplateHandler maneja la creación de un sorteo a partir de una plantilla
+type CreateRaffleFromTemplateHandler struct {
+	useCase *raffleuc.CreateRaffleFromTemplateUseCase
+}
+
+// NewCreateRaffleFromTemplateHandler crea una nueva instancia
+func NewCreateRaffleFromTemplateHandler(useCase *raffleuc.CreateRaffleFromTemplateUseCase) *CreateRaffleFromTemplateHandler {
+	return &CreateRaffleFromTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+func (h *CreateRaffleFromTemplateHandler) Handle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized})
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateRaffleFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "VALIDATION_FAILED",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	drawDate, err := time.Parse(time.RFC3339, req.DrawDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_DATE_FORMAT",
+			"message": "La fecha debe estar en formato ISO 8601. Recibido: " + req.DrawDate,
+		})
+		return
+	}
+
+	raffle, err := h.useCase.Execute(c.Request.Context(), &raffleuc.CreateRaffleFromTemplateInput{
+		TemplateID: templateID,
+		UserID:     userID.(int64),
+		DrawDate:   drawDate,
+		Publish:    req.Publish,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"raffle": toRaffleDTO(raffle),
+	})
+}
@@ -18,6 +18,14 @@ type UpdateRaffleRequest struct {
 	Description *string `json:"description,omitempty"`
 	DrawDate    *string `json:"draw_date,omitempty"` // ISO 8601
 	DrawMethod  *string `json:"draw_method,omitempty"`
+
+	// Mínimo de ventas: 0 en min_sold_count o min_sales_extension_days lo elimina
+	MinSoldCount          *int `json:"min_sold_count,omitempty" binding:"omitempty,min=0,max=10000"`
+	MinSalesCutoffHours   *int `json:"min_sales_cutoff_hours,omitempty" binding:"omitempty,min=0,max=168"`
+	MinSalesExtensionDays *int `json:"min_sales_extension_days,omitempty" binding:"omitempty,min=0,max=30"`
+
+	// Regla si el número oficial no se vendió: vacío la elimina
+	UnsoldWinnerPolicy *string `json:"unsold_winner_policy,omitempty" binding:"omitempty,oneof='' redraw nearest_sold rollover"`
 }
 
 // UpdateRaffleHandler maneja la actualización de sorteos
@@ -74,6 +82,15 @@ func (h *UpdateRaffleHandler) Handle(c *gin.Context) {
 		UserRole:    userRole.(domain.UserRole),
 		Title:       req.Title,
 		Description: req.Description,
+
+		MinSoldCount:          req.MinSoldCount,
+		MinSalesCutoffHours:   req.MinSalesCutoffHours,
+		MinSalesExtensionDays: req.MinSalesExtensionDays,
+	}
+
+	if req.UnsoldWinnerPolicy != nil {
+		policy := domain.UnsoldWinnerPolicy(*req.UnsoldWinnerPolicy)
+		input.UnsoldWinnerPolicy = &policy
 	}
 
 	// Parsear fecha si se provee
@@ -0,0 +1,164 @@
+package raffle
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+)
+
+// DrawProofWinnerDTO ganador de un premio dentro de la prueba
+type DrawProofWinnerDTO struct {
+	Position       int    `json:"position"`
+	CandidatesHash string `json:"candidates_hash"`
+	Counter        int    `json:"counter"`
+	WinnerIndex    int    `json:"winner_index"`
+	WinnerNumber   string `json:"winner_number"`
+}
+
+// DrawProofDTO prueba pública del sorteo verificable
+type DrawProofDTO struct {
+	Algorithm      string               `json:"algorithm"`
+	ServerSeed     string               `json:"server_seed"`
+	ServerSeedHash string               `json:"server_seed_hash"`
+	PublicEntropy  string               `json:"public_entropy"`
+	BeaconChain    string               `json:"beacon_chain_hash,omitempty"`
+	BeaconRound    int64                `json:"beacon_round,omitempty"`
+	Candidates     []string             `json:"candidates"`
+	CandidatesHash string               `json:"candidates_hash"`
+	Counter        int                  `json:"counter"`
+	WinnerIndex    int                  `json:"winner_index"`
+	WinnerNumber   string               `json:"winner_number"`
+	Winners        []DrawProofWinnerDTO `json:"winners,omitempty"`
+	Excluded       []string             `json:"excluded,omitempty"`
+	CommittedAt    *string              `json:"committed_at,omitempty"`
+	DrawnAt        string               `json:"drawn_at"`
+}
+
+// VerifyDrawResponse respuesta de la verificación pública
+type VerifyDrawResponse struct {
+	RaffleUUID        string        `json:"raffle_uuid"`
+	Status            string        `json:"status"`
+	DrawMethod        string        `json:"draw_method"`
+	ServerSeedHash    *string       `json:"server_seed_hash,omitempty"`
+	SeedCommittedAt   *string       `json:"seed_committed_at,omitempty"`
+	BeaconChain       string        `json:"beacon_chain_hash"`
+	BeaconRound       int64         `json:"beacon_round"`    // Ronda comprometida; su aleatoriedad será public_entropy
+	BeaconRoundAt     string        `json:"beacon_round_at"` // Momento en que drand publica la ronda
+	WinnerNumber      *string       `json:"winner_number,omitempty"`
+	Proof             *DrawProofDTO `json:"proof,omitempty"`
+	Verified          bool          `json:"verified"`
+	VerificationError string        `json:"verification_error,omitempty"`
+	CandidatesMatch   bool          `json:"candidates_match"`
+	Algorithm         string        `json:"algorithm_description"`
+}
+
+// drawAlgorithmDescription explica cómo recalcular el ganador fuera de la plataforma
+const drawAlgorithmDescription = "candidates_hash = SHA256(candidatos ordenados unidos por ','); " +
+	"para counter = 0,1,2...: v = primeros 8 bytes (big-endian) de HMAC-SHA256(key=server_seed, msg=public_entropy:candidates_hash:counter); " +
+	"si v < 2^64 - (2^64 mod n) entonces winner_index = v mod n. " +
+	"Con varios premios, cada premio se sortea en orden de posición retirando de los candidatos los números que ya ganaron; " +
+	"los números en excluded ganaron premios fuera de la prueba y no participan. " +
+	"SHA256(server_seed) debe ser igual a server_seed_hash publicado al activar la rifa. " +
+	"En sorteos aleatorios public_entropy es el campo randomness de la ronda beacon_round de la cadena drand beacon_chain_hash " +
+	"(GET https://api.drand.sh/{beacon_chain_hash}/public/{beacon_round}), comprometida al activar la rifa y publicada después del cierre de ventas."
+
+// VerifyDrawHandler maneja la verificación pública del sorteo
+type VerifyDrawHandler struct {
+	useCase *raffleuc.VerifyDrawUseCase
+}
+
+// NewVerifyDrawHandler crea una nueva instancia
+func NewVerifyDrawHandler(useCase *raffleuc.VerifyDrawUseCase) *VerifyDrawHandler {
+	return &VerifyDrawHandler{
+		useCase: useCase,
+	}
+}
+
+// Handle maneja el request
+// GET /api/v1/raffles/:id/draw-proof
+func (h *VerifyDrawHandler) Handle(c *gin.Context) {
+	// 1. Obtener ID o UUID del path
+	idOrUUID := c.Param("id")
+
+	input := &raffleuc.VerifyDrawInput{}
+	if id, err := strconv.ParseInt(idOrUUID, 10, 64); err == nil {
+		input.RaffleID = &id
+	} else {
+		input.RaffleUUID = &idOrUUID
+	}
+
+	// 2. Ejecutar use case
+	output, err := h.useCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// 3. Construir response
+	response := &VerifyDrawResponse{
+		RaffleUUID:        output.Raffle.UUID.String(),
+		Status:            string(output.Raffle.Status),
+		DrawMethod:        string(output.Raffle.DrawMethod),
+		ServerSeedHash:    output.ServerSeedHash,
+		WinnerNumber:      output.Raffle.WinnerNumber,
+		Proof:             toDrawProofDTO(output.Proof),
+		Verified:          output.Verified,
+		VerificationError: output.VerificationError,
+		CandidatesMatch:   output.CandidatesMatch,
+		BeaconChain:       domain.DrawBeaconChainHash,
+		BeaconRound:       output.Raffle.DrawEntropyRound(),
+		BeaconRoundAt:     domain.DrawBeaconRoundTime(output.Raffle.DrawEntropyRound()).Format("2006-01-02T15:04:05Z07:00"),
+		Algorithm:         drawAlgorithmDescription,
+	}
+
+	if output.SeedCommittedAt != nil {
+		committedStr := output.SeedCommittedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.SeedCommittedAt = &committedStr
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toDrawProofDTO convierte la prueba de dominio a DTO
+func toDrawProofDTO(proof *domain.DrawProof) *DrawProofDTO {
+	if proof == nil {
+		return nil
+	}
+
+	dto := &DrawProofDTO{
+		Algorithm:      proof.Algorithm,
+		ServerSeed:     proof.ServerSeed,
+		ServerSeedHash: proof.ServerSeedHash,
+		PublicEntropy:  proof.PublicEntropy,
+		BeaconChain:    proof.BeaconChain,
+		BeaconRound:    proof.BeaconRound,
+		Candidates:     proof.Candidates,
+		CandidatesHash: proof.CandidatesHash,
+		Counter:        proof.Counter,
+		WinnerIndex:    proof.WinnerIndex,
+		WinnerNumber:   proof.WinnerNumber,
+		Excluded:       proof.Excluded,
+		DrawnAt:        proof.DrawnAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for _, winner := range proof.Winners {
+		dto.Winners = append(dto.Winners, DrawProofWinnerDTO{
+			Position:       winner.Position,
+			CandidatesHash: winner.CandidatesHash,
+			Counter:        winner.Counter,
+			WinnerIndex:    winner.WinnerIndex,
+			WinnerNumber:   winner.WinnerNumber,
+		})
+	}
+
+	if proof.CommittedAt != nil {
+		committedStr := proof.CommittedAt.Format("2006-01-02T15:04:05Z07:00")
+		dto.CommittedAt = &committedStr
+	}
+
+	return dto
+}
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// CancelWithdrawalHandler maneja el endpoint de cancelación de retiros
+type CancelWithdrawalHandler struct {
+	useCase *withdrawaluc.CancelWithdrawalUseCase
+	logger  *logger.Logger
+}
+
+// NewCancelWithdrawalHandler crea una nueva instancia del handler
+func NewCancelWithdrawalHandler(useCase *withdrawaluc.CancelWithdrawalUseCase, logger *logger.Logger) *CancelWithdrawalHandler {
+	return &CancelWithdrawalHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Handle maneja la petición de cancelación de un retiro pendiente
+// POST /api/v1/wallet/withdrawals/:uuid/cancel
+func (h *CancelWithdrawalHandler) Handle(c *gin.Context) {
+	// Obtener user_id del contexto
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Usuario no autenticado",
+		})
+		return
+	}
+
+	withdrawal, err := h.useCase.Execute(c.Request.Context(), &withdrawaluc.CancelWithdrawalInput{
+		UserID:         userID.(int64),
+		WithdrawalUUID: c.Param("uuid"),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    toWithdrawalResponse(withdrawal),
+	})
+}
+
+// handleError maneja los errores y retorna la respuesta apropiada
+func (h *CancelWithdrawalHandler) handleError(c *gin.Context, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		h.logger.Error("Unexpected error in cancel withdrawal handler", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Error interno del servidor",
+		})
+		return
+	}
+
+	c.JSON(appErr.Status, ErrorResponse{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+	})
+}
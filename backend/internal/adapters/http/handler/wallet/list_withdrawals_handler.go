@@ -0,0 +1,101 @@
+package wallet
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ListWithdrawalsHandler maneja el endpoint de historial de retiros
+type ListWithdrawalsHandler struct {
+	useCase *withdrawaluc.ListWithdrawalsUseCase
+	logger  *logger.Logger
+}
+
+// NewListWithdrawalsHandler crea una nueva instancia del handler
+func NewListWithdrawalsHandler(useCase *withdrawaluc.ListWithdrawalsUseCase, logger *logger.Logger) *ListWithdrawalsHandler {
+	return &ListWithdrawalsHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Handle maneja la petición de listado de retiros
+// GET /api/v1/wallet/withdrawals?limit=20&offset=0
+func (h *ListWithdrawalsHandler) Handle(c *gin.Context) {
+	// Obtener user_id del contexto
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Usuario no autenticado",
+		})
+		return
+	}
+
+	// Parsear query params
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Validar límites
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	output, err := h.useCase.Execute(c.Request.Context(), &withdrawaluc.ListWithdrawalsInput{
+		UserID: userID.(int64),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	withdrawals := make([]gin.H, len(output.Withdrawals))
+	for i, w := range output.Withdrawals {
+		withdrawals[i] = toWithdrawalResponse(w)
+	}
+
+	// Respuesta exitosa
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"withdrawals": withdrawals,
+			"pagination": gin.H{
+				"total":  output.Total,
+				"limit":  output.Limit,
+				"offset": output.Offset,
+			},
+		},
+	})
+}
+
+// handleError maneja los errores y retorna la respuesta apropiada
+func (h *ListWithdrawalsHandler) handleError(c *gin.Context, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		h.logger.Error("Unexpected error in list withdrawals handler", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Error interno del servidor",
+		})
+		return
+	}
+
+	c.JSON(appErr.Status, ErrorResponse{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+	})
+}
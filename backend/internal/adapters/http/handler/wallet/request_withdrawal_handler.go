@@ -0,0 +1,117 @@
+package wallet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RequestWithdrawalRequest estructura del request
+type RequestWithdrawalRequest struct {
+	Amount string `json:"amount" binding:"required"`
+}
+
+// RequestWithdrawalHandler maneja el endpoint de solicitud de retiro
+type RequestWithdrawalHandler struct {
+	useCase *withdrawaluc.RequestWithdrawalUseCase
+	logger  *logger.Logger
+}
+
+// NewRequestWithdrawalHandler crea una nueva instancia del handler
+func NewRequestWithdrawalHandler(useCase *withdrawaluc.RequestWithdrawalUseCase, logger *logger.Logger) *RequestWithdrawalHandler {
+	return &RequestWithdrawalHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Handle maneja la petición de retiro de ganancias
+// POST /api/v1/wallet/withdrawals
+func (h *RequestWithdrawalHandler) Handle(c *gin.Context) {
+	// Obtener user_id del contexto
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Usuario no autenticado",
+		})
+		return
+	}
+
+	var req RequestWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Datos inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    "INVALID_AMOUNT",
+			Message: "Monto inválido",
+		})
+		return
+	}
+
+	withdrawal, err := h.useCase.Execute(c.Request.Context(), &withdrawaluc.RequestWithdrawalInput{
+		UserID: userID.(int64),
+		Amount: amount,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    toWithdrawalResponse(withdrawal),
+	})
+}
+
+// handleError maneja los errores y retorna la respuesta apropiada
+func (h *RequestWithdrawalHandler) handleError(c *gin.Context, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		h.logger.Error("Unexpected error in request withdrawal handler", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Error interno del servidor",
+		})
+		return
+	}
+
+	c.JSON(appErr.Status, ErrorResponse{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+	})
+}
+
+// toWithdrawalResponse convierte un retiro al formato de respuesta (IBAN enmascarado)
+func toWithdrawalResponse(w *domain.Withdrawal) gin.H {
+	iban := w.IBAN
+	if len(iban) > 4 {
+		iban = "CR**" + iban[len(iban)-4:]
+	}
+
+	return gin.H{
+		"uuid":             w.UUID,
+		"amount":           w.Amount.String(),
+		"currency":         w.Currency,
+		"iban":             iban,
+		"status":           w.Status,
+		"rejection_reason": w.RejectionReason,
+		"bank_reference":   w.BankReference,
+		"created_at":       w.CreatedAt,
+		"reviewed_at":      w.ReviewedAt,
+		"completed_at":     w.CompletedAt,
+	}
+}
@@ -0,0 +1,128 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	ws "github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+)
+
+const (
+	// Interval between heartbeat comments so proxies keep the stream open
+	sseHeartbeatInterval = 15 * time.Second
+
+	// Time allowed to write an event to the peer (overrides the server WriteTimeout per write)
+	sseWriteWait = 10 * time.Second
+
+	// Reconnection delay suggested to EventSource clients, in milliseconds
+	sseRetryMillis = 3000
+)
+
+// sseEvent holds the fields of a hub message used to frame the event
+type sseEvent struct {
+	Seq  int64  `json:"seq"`
+	Type string `json:"type"`
+}
+
+// HandleEvents streams the raffle hub messages as Server-Sent Events
+// for clients that cannot open a WebSocket (same payloads, event = message type, id = seq)
+// A Last-Event-ID header (or ?last_event_id=) resumes from that sequence
+// Route: GET /api/v1/raffles/:id/events
+func (h *WebSocketHandler) HandleEvents(c *gin.Context) {
+	raffleID := c.Param("id")
+	if raffleID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "raffle_id is required"})
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var lastSeq int64
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Last-Event-ID"})
+			return
+		}
+		lastSeq = seq
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable nginx buffering
+	c.Status(http.StatusOK)
+
+	rc := http.NewResponseController(c.Writer)
+	write := func(frame string) bool {
+		rc.SetWriteDeadline(time.Now().Add(sseWriteWait))
+		if _, err := c.Writer.WriteString(frame); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !write(fmt.Sprintf("retry: %d\n\n", sseRetryMillis)) {
+		return
+	}
+
+	client := ws.NewStreamClient(h.hub, raffleID)
+	h.hub.Register <- client
+	defer func() {
+		h.hub.Unregister <- client
+	}()
+
+	log.Printf("[SSE Handler] Client %s connected to raffle %s", client.ID, raffleID)
+
+	if lastEventID != "" {
+		h.hub.Resume(client, lastSeq)
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case payload, ok := <-client.Send:
+			if !ok {
+				// The hub dropped a slow client
+				return
+			}
+			if !write(formatSSEEvent(payload)) {
+				return
+			}
+
+		case <-heartbeat.C:
+			if !write(": heartbeat\n\n") {
+				return
+			}
+
+		case <-c.Request.Context().Done():
+			log.Printf("[SSE Handler] Client %s disconnected from raffle %s", client.ID, raffleID)
+			return
+		}
+	}
+}
+
+// formatSSEEvent frames a hub message (JSON) as a Server-Sent Event
+func formatSSEEvent(payload []byte) string {
+	var event sseEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Sprintf("data: %s\n\n", payload)
+	}
+
+	frame := ""
+	if event.Seq > 0 {
+		frame += fmt.Sprintf("id: %d\n", event.Seq)
+	}
+	return frame + fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, payload)
+}
@@ -7,33 +7,85 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 
+	"github.com/sorteos-platform/backend/internal/adapters/http/middleware"
+	"github.com/sorteos-platform/backend/internal/domain"
+	redisinfra "github.com/sorteos-platform/backend/internal/infrastructure/redis"
 	ws "github.com/sorteos-platform/backend/internal/infrastructure/websocket"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// TODO: In production, validate origin against allowed domains
-		// origin := r.Header.Get("Origin")
-		// return origin == "https://sorteos.com" || origin == "https://www.sorteos.com"
-		return true // Allow all origins in development
-	},
-}
-
 // WebSocketHandler handles WebSocket connections
 type WebSocketHandler struct {
-	hub *ws.Hub
+	hub      *ws.Hub
+	tickets  *redisinfra.WSTicketService
+	userRepo domain.UserRepository
+	upgrader websocket.Upgrader
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(hub *ws.Hub) *WebSocketHandler {
+// Connections are only accepted from allowedOrigins ("*" allows any origin)
+func NewWebSocketHandler(hub *ws.Hub, tickets *redisinfra.WSTicketService, userRepo domain.UserRepository, allowedOrigins []string) *WebSocketHandler {
 	return &WebSocketHandler{
-		hub: hub,
+		hub:      hub,
+		tickets:  tickets,
+		userRepo: userRepo,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     originChecker(allowedOrigins),
+		},
+	}
+}
+
+// originChecker validates the Origin header against the configured allowed origins
+// Requests without Origin (non-browser clients) are accepted: they must still present a ticket for private events
+func originChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+		}
+
+		log.Printf("[WebSocket Handler] Rejected connection from origin %s", origin)
+		return false
+	}
+}
+
+// IssueTicket issues a short-lived, single-use ticket to open an authenticated WebSocket
+// Route: POST /api/v1/ws/ticket
+func (h *WebSocketHandler) IssueTicket(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+
+	ticket, err := h.tickets.Issue(c.Request.Context(), user.UUID)
+	if err != nil {
+		log.Printf("[WebSocket Handler] Failed to issue ticket for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not issue websocket ticket"})
+		return
 	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticket":     ticket,
+		"expires_in": int(redisinfra.WSTicketTTL.Seconds()),
+	})
 }
 
 // HandleConnection upgrades HTTP connection to WebSocket for a specific raffle
+// An optional ?ticket= identifies the user on the connection
 // Route: GET /api/v1/raffles/:id/ws
 func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 	raffleID := c.Param("id")
@@ -42,16 +94,18 @@ func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 		return
 	}
 
-	// Optional: Get user ID from JWT token if authenticated
 	var userID *string
-	if userIDVal, exists := c.Get("user_id"); exists {
-		if uid, ok := userIDVal.(string); ok {
-			userID = &uid
+	if ticket := c.Query("ticket"); ticket != "" {
+		uid, err := h.tickets.Redeem(c.Request.Context(), ticket)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired ticket"})
+			return
 		}
+		userID = &uid
 	}
 
 	// Upgrade HTTP connection to WebSocket
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("[WebSocket Handler] Failed to upgrade connection: %v", err)
 		return
@@ -70,6 +124,31 @@ func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 	log.Printf("[WebSocket Handler] Client %s connected to raffle %s", client.ID, raffleID)
 }
 
+// HandleUserConnection upgrades HTTP connection to WebSocket on the user's private channel
+// (checkout, payment and reservation expiry events). Requires ?ticket=
+// Route: GET /api/v1/ws/me
+func (h *WebSocketHandler) HandleUserConnection(c *gin.Context) {
+	userID, err := h.tickets.Redeem(c.Request.Context(), c.Query("ticket"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired ticket"})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("[WebSocket Handler] Failed to upgrade connection: %v", err)
+		return
+	}
+
+	client := ws.NewUserClient(h.hub, conn, userID)
+	h.hub.Register <- client
+
+	go client.WritePump()
+	go client.ReadPump()
+
+	log.Printf("[WebSocket Handler] Client %s connected to private channel of user %s", client.ID, userID)
+}
+
 // GetConnectionStats returns statistics about WebSocket connections
 // Route: GET /api/v1/raffles/:id/ws/stats
 func (h *WebSocketHandler) GetConnectionStats(c *gin.Context) {
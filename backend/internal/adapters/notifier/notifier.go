@@ -1,5 +1,7 @@
 package notifier
 
+import "time"
+
 // Notifier es la interface para envío de notificaciones por email
 // Permite usar diferentes implementaciones (SendGrid, SMTP, etc.)
 // manteniendo el mismo contrato
@@ -12,4 +14,13 @@ type Notifier interface {
 
 	// SendWelcomeEmail envía un email de bienvenida post-verificación
 	SendWelcomeEmail(email, firstName string) error
+
+	// SendWaitlistOfferEmail avisa que se retuvieron números liberados para un usuario en lista de espera
+	SendWaitlistOfferEmail(email, raffleTitle, raffleID string, numbers []string, expiresAt time.Time) error
+
+	// SendRaffleCancelledEmail avisa que un sorteo fue cancelado y sus pagos se reembolsan
+	SendRaffleCancelledEmail(email, raffleTitle, reason string) error
+
+	// SendDrawPostponedEmail avisa que la fecha de un sorteo fue pospuesta
+	SendDrawPostponedEmail(email, raffleTitle, raffleID string, drawDate time.Time, reason string) error
 }
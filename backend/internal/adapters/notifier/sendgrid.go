@@ -2,6 +2,8 @@ package notifier
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -247,3 +249,209 @@ Equipo de Sorteos Platform
 
 	return nil
 }
+
+// SendWaitlistOfferEmail avisa a un usuario en lista de espera que se le retuvieron números liberados
+func (n *SendGridNotifier) SendWaitlistOfferEmail(email, raffleTitle, raffleID string, numbers []string, expiresAt time.Time) error {
+	to := mail.NewEmail("", email)
+	subject := "Números disponibles - Sorteos Platform"
+	numbersList := strings.Join(numbers, ", ")
+	raffleURL := fmt.Sprintf("%s/sorteo/%s", n.config.FrontendURL, raffleID)
+
+	plainTextContent := fmt.Sprintf(`
+Hola,
+
+Se liberaron números del sorteo "%s" y los apartamos para ti: %s
+
+Tienes hasta las %s (UTC) para reservarlos. Después se ofrecerán a la siguiente persona en la lista de espera.
+
+Reserva aquí: %s
+
+Saludos,
+Equipo de Sorteos Platform
+	`, raffleTitle, numbersList, expiresAt.UTC().Format("15:04"), raffleURL)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Números disponibles</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #3B82F6;">¡Hay números disponibles para ti!</h2>
+        <p>Se liberaron números del sorteo <strong>%s</strong> y los apartamos para ti:</p>
+        <div style="background-color: #F0FDF4; border-left: 4px solid #10B981; padding: 15px; margin: 20px 0;">
+            <p style="margin: 0; color: #065F46; font-size: 18px; font-weight: bold;">%s</p>
+        </div>
+        <p>Tienes hasta las <strong>%s (UTC)</strong> para reservarlos. Después se ofrecerán a la siguiente persona en la lista de espera.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" style="background-color: #3B82F6; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">Reservar ahora</a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #E2E8F0; margin: 30px 0;">
+        <p style="color: #94A3B8; font-size: 12px;">
+            Saludos,<br>
+            <strong>Equipo de Sorteos Platform</strong>
+        </p>
+    </div>
+</body>
+</html>
+	`, raffleTitle, numbersList, expiresAt.UTC().Format("15:04"), raffleURL)
+
+	message := mail.NewSingleEmail(n.fromMail, subject, to, plainTextContent, htmlContent)
+
+	response, err := n.client.Send(message)
+	if err != nil {
+		n.logger.Error("Error sending waitlist offer email",
+			logger.String("email", email),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid error: %d - %s", response.StatusCode, response.Body)
+	}
+
+	n.logger.Info("Waitlist offer email sent",
+		logger.String("email", email),
+	)
+
+	return nil
+}
+
+// SendRaffleCancelledEmail avisa a compradores y organizador que un sorteo fue cancelado con reembolsos
+func (n *SendGridNotifier) SendRaffleCancelledEmail(email, raffleTitle, reason string) error {
+	to := mail.NewEmail("", email)
+	subject := "Sorteo cancelado - Sorteos Platform"
+
+	plainTextContent := fmt.Sprintf(`
+Hola,
+
+El sorteo "%s" fue cancelado.
+
+Motivo: %s
+
+Si compraste números, el monto pagado se reembolsa a tu billetera o al medio de pago original. Los reembolsos con tarjeta pueden tardar algunos días hábiles en reflejarse.
+
+Saludos,
+Equipo de Sorteos Platform
+	`, raffleTitle, reason)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Sorteo cancelado</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #DC2626;">Sorteo cancelado</h2>
+        <p>El sorteo <strong>%s</strong> fue cancelado.</p>
+        <div style="background-color: #FEF2F2; border-left: 4px solid #DC2626; padding: 15px; margin: 20px 0;">
+            <p style="margin: 0; color: #991B1B;"><strong>Motivo:</strong> %s</p>
+        </div>
+        <p>Si compraste números, el monto pagado se reembolsa a tu billetera o al medio de pago original. Los reembolsos con tarjeta pueden tardar algunos días hábiles en reflejarse.</p>
+        <hr style="border: none; border-top: 1px solid #E2E8F0; margin: 30px 0;">
+        <p style="color: #94A3B8; font-size: 12px;">
+            Saludos,<br>
+            <strong>Equipo de Sorteos Platform</strong>
+        </p>
+    </div>
+</body>
+</html>
+	`, raffleTitle, reason)
+
+	message := mail.NewSingleEmail(n.fromMail, subject, to, plainTextContent, htmlContent)
+
+	response, err := n.client.Send(message)
+	if err != nil {
+		n.logger.Error("Error sending raffle cancelled email",
+			logger.String("email", email),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid error: %d - %s", response.StatusCode, response.Body)
+	}
+
+	n.logger.Info("Raffle cancelled email sent",
+		logger.String("email", email),
+	)
+
+	return nil
+}
+
+// SendDrawPostponedEmail avisa a compradores y organizador que la fecha de un sorteo cambió
+func (n *SendGridNotifier) SendDrawPostponedEmail(email, raffleTitle, raffleID string, drawDate time.Time, reason string) error {
+	to := mail.NewEmail("", email)
+	subject := "Sorteo pospuesto - Sorteos Platform"
+	raffleURL := fmt.Sprintf("%s/sorteo/%s", n.config.FrontendURL, raffleID)
+
+	plainTextContent := fmt.Sprintf(`
+Hola,
+
+El sorteo "%s" fue pospuesto.
+
+Motivo: %s
+
+Nueva fecha del sorteo: %s (UTC). Tus números siguen participando.
+
+Ver sorteo: %s
+
+Saludos,
+Equipo de Sorteos Platform
+	`, raffleTitle, reason, drawDate.UTC().Format("02/01/2006 15:04"), raffleURL)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Sorteo pospuesto</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #F59E0B;">Sorteo pospuesto</h2>
+        <p>El sorteo <strong>%s</strong> fue pospuesto.</p>
+        <div style="background-color: #FFFBEB; border-left: 4px solid #F59E0B; padding: 15px; margin: 20px 0;">
+            <p style="margin: 0; color: #92400E;"><strong>Motivo:</strong> %s</p>
+        </div>
+        <p>Nueva fecha del sorteo: <strong>%s (UTC)</strong>. Tus números siguen participando.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" style="background-color: #3B82F6; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">Ver sorteo</a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #E2E8F0; margin: 30px 0;">
+        <p style="color: #94A3B8; font-size: 12px;">
+            Saludos,<br>
+            <strong>Equipo de Sorteos Platform</strong>
+        </p>
+    </div>
+</body>
+</html>
+	`, raffleTitle, reason, drawDate.UTC().Format("02/01/2006 15:04"), raffleURL)
+
+	message := mail.NewSingleEmail(n.fromMail, subject, to, plainTextContent, htmlContent)
+
+	response, err := n.client.Send(message)
+	if err != nil {
+		n.logger.Error("Error sending draw postponed email",
+			logger.String("email", email),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid error: %d - %s", response.StatusCode, response.Body)
+	}
+
+	n.logger.Info("Draw postponed email sent",
+		logger.String("email", email),
+	)
+
+	return nil
+}
@@ -24,16 +24,27 @@ const (
 	AuditActionKYCLevelChanged  AuditAction = "kyc_level_changed"
 
 	// Raffles
-	AuditActionRaffleCreated    AuditAction = "raffle_created"
-	AuditActionRafflePublished  AuditAction = "raffle_published"
-	AuditActionRaffleSuspended  AuditAction = "raffle_suspended"
-	AuditActionRaffleCompleted  AuditAction = "raffle_completed"
-	AuditActionRaffleDeleted    AuditAction = "raffle_deleted"
+	AuditActionRaffleCreated     AuditAction = "raffle_created"
+	AuditActionRafflePublished   AuditAction = "raffle_published"
+	AuditActionRaffleSuspended   AuditAction = "raffle_suspended"
+	AuditActionRaffleCompleted   AuditAction = "raffle_completed"
+	AuditActionRaffleDeleted     AuditAction = "raffle_deleted"
+	AuditActionRafflePricingSet  AuditAction = "raffle_pricing_set"
+	AuditActionRafflePostponed   AuditAction = "raffle_draw_postponed"
+	AuditActionRaffleCancelled   AuditAction = "raffle_cancelled"
+	AuditActionRaffleRolledOver  AuditAction = "raffle_jackpot_rolled_over"
+	AuditActionRaffleDrawPending AuditAction = "raffle_draw_pending"
+
+	// Raffle templates & series
+	AuditActionRaffleTemplateSaved   AuditAction = "raffle_template_saved"
+	AuditActionRaffleSeriesUpdated   AuditAction = "raffle_series_updated"
+	AuditActionRaffleSeriesGenerated AuditAction = "raffle_series_generated"
 
 	// Reservations
 	AuditActionNumbersReserved      AuditAction = "numbers_reserved"
 	AuditActionReservationExpired   AuditAction = "reservation_expired"
 	AuditActionReservationCancelled AuditAction = "reservation_cancelled"
+	AuditActionInventoryRepaired    AuditAction = "inventory_repaired"
 
 	// Payments
 	AuditActionPaymentCreated   AuditAction = "payment_created"
@@ -47,6 +58,24 @@ const (
 	AuditActionSettlementPaid     AuditAction = "settlement_paid"
 	AuditActionSettlementRejected AuditAction = "settlement_rejected"
 
+	// Prizes
+	AuditActionPrizePaid          AuditAction = "prize_paid"
+	AuditActionPrizeClaimOpened   AuditAction = "prize_claim_opened"
+	AuditActionPrizeClaimResolved AuditAction = "prize_claim_resolved"
+
+	// Withdrawals
+	AuditActionWithdrawalRequested AuditAction = "withdrawal_requested"
+	AuditActionWithdrawalApproved  AuditAction = "withdrawal_approved"
+	AuditActionWithdrawalExported  AuditAction = "withdrawal_exported"
+	AuditActionWithdrawalCompleted AuditAction = "withdrawal_completed"
+	AuditActionWithdrawalRejected  AuditAction = "withdrawal_rejected"
+	AuditActionWithdrawalCancelled AuditAction = "withdrawal_cancelled"
+
+	// Credit Purchases
+	AuditActionCreditPurchaseCompleted AuditAction = "credit_purchase_completed"
+	AuditActionCreditPurchaseFailed    AuditAction = "credit_purchase_failed"
+	AuditActionCreditPurchaseExpired   AuditAction = "credit_purchase_expired"
+
 	// Admin Actions
 	AuditActionAdminActionPerformed   AuditAction = "admin_action_performed"
 	AuditActionSystemParameterChanged AuditAction = "system_parameter_changed"
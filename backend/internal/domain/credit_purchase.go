@@ -248,4 +248,10 @@ type CreditPurchaseRepository interface {
 
 	// MarkExpired marca como expiradas las compras que superaron el TTL
 	MarkExpired() (int64, error)
+
+	// ExpireIfPending marca una compra como expirada solo si sigue pendiente; false si cambió de estado
+	ExpireIfPending(id int64) (bool, error)
+
+	// FindStale busca compras pendientes o en proceso sin actualizar desde updatedBefore
+	FindStale(updatedBefore time.Time, limit int) ([]*CreditPurchase, error)
 }
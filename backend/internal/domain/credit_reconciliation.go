@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreditReconciliationAction resultado de conciliar una compra de créditos con Pagadito
+type CreditReconciliationAction string
+
+const (
+	CreditReconciliationCredited     CreditReconciliationAction = "credited"      // Pagada en Pagadito, créditos acreditados
+	CreditReconciliationFailed       CreditReconciliationAction = "failed"        // Rechazada o fallida en Pagadito
+	CreditReconciliationExpired      CreditReconciliationAction = "expired"       // Abandonada por el usuario
+	CreditReconciliationStillPending CreditReconciliationAction = "still_pending" // Sin cambios, se revisa en la próxima ejecución
+	CreditReconciliationNeedsReview  CreditReconciliationAction = "needs_review"  // Requiere revisión manual (montos distintos, verificación vencida)
+	CreditReconciliationError        CreditReconciliationAction = "error"         // Error consultando Pagadito o acreditando fondos
+)
+
+// IsDiscrepancy indica si el resultado requiere atención de un admin
+func (a CreditReconciliationAction) IsDiscrepancy() bool {
+	return a == CreditReconciliationNeedsReview || a == CreditReconciliationError
+}
+
+// CreditReconciliation ejecución de la conciliación de compras de créditos contra Pagadito
+type CreditReconciliation struct {
+	ID   int64  `json:"id" gorm:"primaryKey"`
+	UUID string `json:"uuid" gorm:"type:uuid;unique;not null"`
+
+	// Admin que la ejecutó manualmente (nil = job nocturno)
+	TriggeredBy *int64 `json:"triggered_by,omitempty"`
+
+	// Compras pendientes/en proceso sin actualizar desde esta fecha
+	StaleBefore time.Time `json:"stale_before"`
+
+	// Totales
+	Checked       int `json:"checked"`
+	Credited      int `json:"credited"`
+	Failed        int `json:"failed"`
+	Expired       int `json:"expired"`
+	StillPending  int `json:"still_pending"`
+	Discrepancies int `json:"discrepancies"`
+
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	Items []*CreditReconciliationItem `json:"items,omitempty" gorm:"foreignKey:ReconciliationID"`
+}
+
+// TableName especifica el nombre de la tabla
+func (CreditReconciliation) TableName() string {
+	return "credit_purchase_reconciliations"
+}
+
+// CreditReconciliationItem detalle de una compra conciliada
+type CreditReconciliationItem struct {
+	ID               int64 `json:"id" gorm:"primaryKey"`
+	ReconciliationID int64 `json:"reconciliation_id" gorm:"not null;index"`
+
+	PurchaseID     int64                      `json:"purchase_id" gorm:"not null;index"`
+	UserID         int64                      `json:"user_id" gorm:"not null"`
+	ERN            string                     `json:"ern" gorm:"not null"`
+	LocalStatus    CreditPurchaseStatus       `json:"local_status" gorm:"type:varchar(20);not null"`
+	PagaditoStatus *string                    `json:"pagadito_status,omitempty"`
+	Action         CreditReconciliationAction `json:"action" gorm:"type:varchar(20);not null"`
+
+	// Montos (el de Pagadito solo cuando el procesador lo informa)
+	ChargeAmount   decimal.Decimal  `json:"charge_amount" gorm:"type:decimal(12,2);not null"`
+	PagaditoAmount *decimal.Decimal `json:"pagadito_amount,omitempty" gorm:"type:decimal(12,2)"`
+
+	Detail    *string   `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (CreditReconciliationItem) TableName() string {
+	return "credit_purchase_reconciliation_items"
+}
+
+// NewCreditReconciliationItem crea el detalle de una compra con su estado local previo
+func NewCreditReconciliationItem(purchase *CreditPurchase, action CreditReconciliationAction) *CreditReconciliationItem {
+	return &CreditReconciliationItem{
+		PurchaseID:   purchase.ID,
+		UserID:       purchase.UserID,
+		ERN:          purchase.ERN,
+		LocalStatus:  purchase.Status,
+		Action:       action,
+		ChargeAmount: purchase.ChargeAmount,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Add suma el resultado de una compra a los totales y guarda el detalle
+// Las compras sin cambios solo se cuentan
+func (r *CreditReconciliation) Add(item *CreditReconciliationItem) {
+	r.Checked++
+	switch item.Action {
+	case CreditReconciliationCredited:
+		r.Credited++
+	case CreditReconciliationFailed:
+		r.Failed++
+	case CreditReconciliationExpired:
+		r.Expired++
+	case CreditReconciliationStillPending:
+		r.StillPending++
+		return
+	default:
+		r.Discrepancies++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// CreditReconciliationRepository define el contrato para el repositorio de conciliaciones
+type CreditReconciliationRepository interface {
+	// Create guarda la conciliación con su detalle
+	Create(reconciliation *CreditReconciliation) error
+
+	// FindByID busca una conciliación con su detalle
+	FindByID(id int64) (*CreditReconciliation, error)
+
+	// List lista conciliaciones (más recientes primero, sin detalle)
+	List(limit, offset int) ([]*CreditReconciliation, int64, error)
+}
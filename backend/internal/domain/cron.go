@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule expresión cron estándar de 5 campos (minuto hora día-del-mes mes día-de-la-semana)
+// Soporta *, listas (1,15), rangos (1-5), pasos (*/15, 0-30/10) y los alias @daily, @weekly y @monthly.
+// Si día-del-mes y día-de-la-semana están restringidos, basta con que coincida uno de los dos (semántica de cron)
+type CronSchedule struct {
+	expression string
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	domAny     bool
+	dowAny     bool
+}
+
+// cronField rango permitido de un campo de la expresión
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minuto", min: 0, max: 59},
+	{name: "hora", min: 0, max: 23},
+	{name: "día del mes", min: 1, max: 31},
+	{name: "mes", min: 1, max: 12},
+	{name: "día de la semana", min: 0, max: 7}, // 0 y 7 son domingo
+}
+
+var cronAliases = map[string]string{
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronSearchLimit horizonte máximo de búsqueda de la siguiente ejecución
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCronSchedule interpreta una expresión cron de 5 campos
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	spec := expression
+	if alias, ok := cronAliases[strings.ToLower(spec)]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("la expresión cron debe tener %d campos (minuto hora día mes día-de-la-semana)", len(cronFields))
+	}
+
+	bits := make([]uint64, len(cronFields))
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	// El domingo puede escribirse como 0 o 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = (bits[4] | 1) &^ (1 << 7)
+	}
+
+	return &CronSchedule{
+		expression: expression,
+		minute:     bits[0],
+		hour:       bits[1],
+		dom:        bits[2],
+		month:      bits[3],
+		dow:        bits[4],
+		domAny:     parts[2] == "*",
+		dowAny:     parts[4] == "*",
+	}, nil
+}
+
+// parseCronField convierte un campo de la expresión en un conjunto de bits
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			rangePart = item[:idx]
+			s, err := strconv.Atoi(item[idx+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("paso inválido en el campo %s: %q", spec.name, item)
+			}
+			step = s
+		}
+
+		start, end := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("rango inválido en el campo %s: %q", spec.name, item)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("valor inválido en el campo %s: %q", spec.name, item)
+			}
+			start = value
+			end = value
+			if step > 1 {
+				end = spec.max
+			}
+		}
+
+		if start < spec.min || end > spec.max || start > end {
+			return 0, fmt.Errorf("el campo %s debe estar entre %d y %d: %q", spec.name, spec.min, spec.max, item)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// String retorna la expresión original
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+// Next retorna la primera ejecución estrictamente posterior a after, evaluada en loc
+// Retorna el instante cero si la expresión no tiene ejecuciones en los próximos 5 años (ej. 31 de febrero)
+func (s *CronSchedule) Next(after time.Time, loc *time.Location) time.Time {
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay verifica el día del mes y el día de la semana
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// MinInterval retorna el menor intervalo entre las próximas n ejecuciones a partir de from
+func (s *CronSchedule) MinInterval(from time.Time, loc *time.Location, n int) time.Duration {
+	var min time.Duration
+	prev := s.Next(from, loc)
+	for i := 0; i < n && !prev.IsZero(); i++ {
+		next := s.Next(prev, loc)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); min == 0 || gap < min {
+			min = gap
+		}
+		prev = next
+	}
+	return min
+}
@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DrawAlgorithmHMACSHA256 identifica el algoritmo del sorteo verificable
+// winner_index = HMAC-SHA256(server_seed, public_entropy:candidates_hash:counter) con
+// muestreo por rechazo para evitar sesgo de módulo
+const DrawAlgorithmHMACSHA256 = "hmac-sha256-v1"
+
+// drawSeedBytes tamaño en bytes de la semilla del servidor
+const drawSeedBytes = 32
+
+// Beacon de aleatoriedad pública (drand quicknet, League of Entropy) usado como entropía del sorteo
+// Al publicar la rifa se compromete la primera ronda emitida en la fecha del sorteo o después,
+// por lo que nadie (ni la plataforma ni un admin) conoce la entropía mientras la semilla está comprometida
+const (
+	DrawBeaconChainHash = "52db9ba70e0cc0f6eaf7803dd07447a1f5477735fd3f661792ba94600c84e971"
+	drawBeaconGenesis   = int64(1692803367) // Unix, ronda 1
+	drawBeaconPeriod    = int64(3)          // Segundos entre rondas
+)
+
+// DrawBeaconRoundAt retorna la primera ronda del beacon emitida en el momento t o después
+func DrawBeaconRoundAt(t time.Time) int64 {
+	elapsed := t.Unix() - drawBeaconGenesis
+	if elapsed <= 0 {
+		return 1
+	}
+	return (elapsed+drawBeaconPeriod-1)/drawBeaconPeriod + 1
+}
+
+// DrawBeaconRoundTime retorna el momento en que el beacon emite la ronda
+func DrawBeaconRoundTime(round int64) time.Time {
+	return time.Unix(drawBeaconGenesis+(round-1)*drawBeaconPeriod, 0).UTC()
+}
+
+// DrawProofWinner ganador de un premio dentro de la prueba
+// CandidatesHash corresponde a los participantes que quedaban al sortear ese premio
+type DrawProofWinner struct {
+	Position       int    `json:"position"`
+	CandidatesHash string `json:"candidates_hash"`
+	Counter        int    `json:"counter"`
+	WinnerIndex    int    `json:"winner_index"`
+	WinnerNumber   string `json:"winner_number"`
+}
+
+// DrawProof prueba pública de un sorteo commit-reveal
+// Con estos datos cualquier persona puede recalcular el número ganador
+// Con varios premios, cada premio se sortea en orden entre los números que aún no ganaron;
+// los campos de ganador de primer nivel corresponden al primer premio sorteado en la prueba
+type DrawProof struct {
+	Algorithm      string            `json:"algorithm"`
+	ServerSeed     string            `json:"server_seed"`
+	ServerSeedHash string            `json:"server_seed_hash"`
+	PublicEntropy  string            `json:"public_entropy"`
+	BeaconChain    string            `json:"beacon_chain_hash,omitempty"` // Cadena drand de la que proviene public_entropy
+	BeaconRound    int64             `json:"beacon_round,omitempty"`      // Ronda comprometida al publicar la rifa
+	Candidates     []string          `json:"candidates"`
+	CandidatesHash string            `json:"candidates_hash"`
+	Counter        int               `json:"counter"`
+	WinnerIndex    int               `json:"winner_index"`
+	WinnerNumber   string            `json:"winner_number"`
+	Winners        []DrawProofWinner `json:"winners,omitempty"`
+	Excluded       []string          `json:"excluded,omitempty"`     // Números vendidos que ganaron premios fuera de la prueba (lotería)
+	CommittedAt    *time.Time        `json:"committed_at,omitempty"` // nil si la semilla no se publicó antes del sorteo
+	DrawnAt        time.Time         `json:"drawn_at"`
+}
+
+// GenerateDrawSeed genera una semilla aleatoria del servidor y su hash SHA-256
+func GenerateDrawSeed() (seed string, seedHash string, err error) {
+	buf := make([]byte, drawSeedBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("error generando semilla del sorteo: %w", err)
+	}
+
+	seed = hex.EncodeToString(buf)
+	return seed, HashDrawSeed(seed), nil
+}
+
+// HashDrawSeed calcula el compromiso público (SHA-256 hex) de una semilla
+func HashDrawSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashDrawCandidates calcula el hash de la lista ordenada de números participantes
+func HashDrawCandidates(candidates []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(candidates, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SortDrawCandidates ordena los números participantes de forma canónica
+// Los números se almacenan con ceros a la izquierda, por lo que el orden lexicográfico coincide con el numérico
+func SortDrawCandidates(candidates []string) []string {
+	sorted := make([]string, len(candidates))
+	copy(sorted, candidates)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// ComputeDrawWinnerIndex deriva el índice ganador a partir de la semilla revelada y la entropía pública
+// Retorna el índice y el contador utilizado en el muestreo por rechazo
+func ComputeDrawWinnerIndex(serverSeed, publicEntropy, candidatesHash string, candidateCount int) (int, int, error) {
+	if candidateCount <= 0 {
+		return 0, 0, fmt.Errorf("no hay números participantes para el sorteo")
+	}
+
+	n := uint64(candidateCount)
+	limit := math.MaxUint64 - (math.MaxUint64 % n)
+
+	for counter := 0; counter < 1000; counter++ {
+		mac := hmac.New(sha256.New, []byte(serverSeed))
+		mac.Write([]byte(fmt.Sprintf("%s:%s:%d", publicEntropy, candidatesHash, counter)))
+		digest := mac.Sum(nil)
+
+		value := binary.BigEndian.Uint64(digest[:8])
+		if value < limit {
+			return int(value % n), counter, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("no se pudo derivar un índice ganador sin sesgo")
+}
+
+// NewDrawProof ejecuta el sorteo verificable de un único premio y construye su prueba
+func NewDrawProof(serverSeed, publicEntropy string, candidates []string, committedAt *time.Time) (*DrawProof, error) {
+	return NewPrizesDrawProof(serverSeed, publicEntropy, candidates, 1, 1, committedAt)
+}
+
+// NewPrizesDrawProof ejecuta el sorteo verificable de count premios a partir de la posición firstPosition
+// Cada premio se sortea sobre los participantes restantes, por lo que los números ganadores son distintos
+// El primer premio sorteado produce el mismo resultado que NewDrawProof con los mismos datos
+func NewPrizesDrawProof(serverSeed, publicEntropy string, candidates []string, firstPosition, count int, committedAt *time.Time) (*DrawProof, error) {
+	if serverSeed == "" {
+		return nil, fmt.Errorf("la semilla del servidor es requerida")
+	}
+	if publicEntropy == "" {
+		return nil, fmt.Errorf("la entropía pública es requerida")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("se debe sortear al menos un premio")
+	}
+	if count > len(candidates) {
+		return nil, fmt.Errorf("no hay suficientes números participantes para %d premios", count)
+	}
+
+	sorted := SortDrawCandidates(candidates)
+	winners, err := computeDrawWinners(serverSeed, publicEntropy, sorted, firstPosition, count)
+	if err != nil {
+		return nil, err
+	}
+
+	first := winners[0]
+	return &DrawProof{
+		Algorithm:      DrawAlgorithmHMACSHA256,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: HashDrawSeed(serverSeed),
+		PublicEntropy:  publicEntropy,
+		Candidates:     sorted,
+		CandidatesHash: first.CandidatesHash,
+		Counter:        first.Counter,
+		WinnerIndex:    first.WinnerIndex,
+		WinnerNumber:   first.WinnerNumber,
+		Winners:        winners,
+		CommittedAt:    committedAt,
+		DrawnAt:        time.Now(),
+	}, nil
+}
+
+// computeDrawWinners sortea count premios en orden, retirando cada número ganador de los participantes
+func computeDrawWinners(serverSeed, publicEntropy string, sorted []string, firstPosition, count int) ([]DrawProofWinner, error) {
+	remaining := make([]string, len(sorted))
+	copy(remaining, sorted)
+
+	winners := make([]DrawProofWinner, 0, count)
+	for i := 0; i < count; i++ {
+		candidatesHash := HashDrawCandidates(remaining)
+		index, counter, err := ComputeDrawWinnerIndex(serverSeed, publicEntropy, candidatesHash, len(remaining))
+		if err != nil {
+			return nil, err
+		}
+
+		winners = append(winners, DrawProofWinner{
+			Position:       firstPosition + i,
+			CandidatesHash: candidatesHash,
+			Counter:        counter,
+			WinnerIndex:    index,
+			WinnerNumber:   remaining[index],
+		})
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+
+	return winners, nil
+}
+
+// WinnerNumbers retorna los números ganadores de la prueba indexados por posición del premio
+func (p *DrawProof) WinnerNumbers() map[int]string {
+	numbers := make(map[int]string, len(p.Winners))
+	for _, winner := range p.Winners {
+		numbers[winner.Position] = winner.WinnerNumber
+	}
+	if len(numbers) == 0 {
+		numbers[1] = p.WinnerNumber
+	}
+	return numbers
+}
+
+// Verify recalcula el sorteo y confirma que la prueba es consistente
+func (p *DrawProof) Verify() error {
+	if p.Algorithm != DrawAlgorithmHMACSHA256 {
+		return fmt.Errorf("algoritmo de sorteo no soportado: %s", p.Algorithm)
+	}
+
+	if HashDrawSeed(p.ServerSeed) != p.ServerSeedHash {
+		return fmt.Errorf("la semilla revelada no coincide con el hash publicado")
+	}
+
+	sorted := SortDrawCandidates(p.Candidates)
+	if HashDrawCandidates(sorted) != p.CandidatesHash {
+		return fmt.Errorf("la lista de participantes no coincide con su hash")
+	}
+
+	index, counter, err := ComputeDrawWinnerIndex(p.ServerSeed, p.PublicEntropy, p.CandidatesHash, len(sorted))
+	if err != nil {
+		return err
+	}
+
+	if index != p.WinnerIndex || counter != p.Counter || sorted[index] != p.WinnerNumber {
+		return fmt.Errorf("el número ganador recalculado (%s) no coincide con el publicado (%s)", sorted[index], p.WinnerNumber)
+	}
+
+	// Pruebas de un solo premio anteriores a los sorteos con varios premios
+	if len(p.Winners) == 0 {
+		return nil
+	}
+
+	winners, err := computeDrawWinners(p.ServerSeed, p.PublicEntropy, sorted, p.Winners[0].Position, len(p.Winners))
+	if err != nil {
+		return err
+	}
+	for i, winner := range winners {
+		if winner != p.Winners[i] {
+			return fmt.Errorf("el número ganador recalculado del premio %d (%s) no coincide con el publicado (%s)",
+				p.Winners[i].Position, winner.WinnerNumber, p.Winners[i].WinnerNumber)
+		}
+	}
+
+	return nil
+}
+
+// ToJSON serializa la prueba para almacenarla en la rifa
+func (p *DrawProof) ToJSON() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ParseDrawProof deserializa una prueba almacenada
+func ParseDrawProof(data []byte) (*DrawProof, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("el sorteo no tiene prueba registrada")
+	}
+
+	var proof DrawProof
+	if err := json.Unmarshal(data, &proof); err != nil {
+		return nil, fmt.Errorf("error deserializando prueba del sorteo: %w", err)
+	}
+	return &proof, nil
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// DrawRoomStatus estado de la sala del sorteo en vivo
+type DrawRoomStatus string
+
+const (
+	DrawRoomStatusScheduled DrawRoomStatus = "scheduled" // Cuenta regresiva hacia ScheduledAt
+	DrawRoomStatusLive      DrawRoomStatus = "live"      // Sorteo en curso
+	DrawRoomStatusFinished  DrawRoomStatus = "finished"  // Ganador revelado
+)
+
+// DrawRoomEventType tipo de evento del timeline del sorteo
+type DrawRoomEventType string
+
+const (
+	DrawRoomEventScheduled      DrawRoomEventType = "draw_scheduled"  // Fecha o transmisión programada/modificada
+	DrawRoomEventStarted        DrawRoomEventType = "draw_started"    // Inicio del sorteo (participantes)
+	DrawRoomEventStep           DrawRoomEventType = "draw_step"       // Paso intermedio (prueba, resultado de lotería)
+	DrawRoomEventWinnerRevealed DrawRoomEventType = "winner_revealed" // Número ganador
+	DrawRoomEventAborted        DrawRoomEventType = "draw_aborted"    // El sorteo no se pudo completar, la sala vuelve a programada
+)
+
+// DrawRoom sala del sorteo en vivo de una rifa
+type DrawRoom struct {
+	ID       int64 `json:"id" gorm:"primaryKey"`
+	RaffleID int64 `json:"raffle_id" gorm:"not null;uniqueIndex"`
+
+	// Cuenta regresiva y transmisión opcional
+	ScheduledAt time.Time `json:"scheduled_at" gorm:"not null"`
+	StreamURL   *string   `json:"stream_url,omitempty"`
+
+	Status     DrawRoomStatus `json:"status" gorm:"type:varchar(20);not null"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (DrawRoom) TableName() string {
+	return "draw_rooms"
+}
+
+// NewDrawRoom crea la sala de una rifa con la cuenta regresiva hacia su fecha de sorteo
+func NewDrawRoom(raffle *Raffle) *DrawRoom {
+	now := time.Now()
+	return &DrawRoom{
+		RaffleID:    raffle.ID,
+		ScheduledAt: raffle.DrawDate,
+		Status:      DrawRoomStatusScheduled,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Start marca la sala en vivo
+func (r *DrawRoom) Start() {
+	now := time.Now()
+	r.Status = DrawRoomStatusLive
+	r.StartedAt = &now
+	r.FinishedAt = nil
+	r.UpdatedAt = now
+}
+
+// Finish marca la sala como finalizada (ganador revelado)
+func (r *DrawRoom) Finish() {
+	now := time.Now()
+	r.Status = DrawRoomStatusFinished
+	r.FinishedAt = &now
+	r.UpdatedAt = now
+}
+
+// Reset devuelve la sala a la cuenta regresiva (sorteo abortado)
+func (r *DrawRoom) Reset() {
+	r.Status = DrawRoomStatusScheduled
+	r.StartedAt = nil
+	r.UpdatedAt = time.Now()
+}
+
+// DrawRoomEvent evento persistido del timeline del sorteo (para quienes entran tarde)
+type DrawRoomEvent struct {
+	ID         int64             `json:"id" gorm:"primaryKey"`
+	DrawRoomID int64             `json:"draw_room_id" gorm:"not null;index"`
+	Type       DrawRoomEventType `json:"type" gorm:"type:varchar(30);not null"`
+	Step       int               `json:"step"` // Orden dentro de la ejecución del sorteo (0 = fuera de una ejecución)
+	Data       datatypes.JSON    `json:"data,omitempty" gorm:"type:jsonb"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (DrawRoomEvent) TableName() string {
+	return "draw_room_events"
+}
+
+// NewDrawRoomEvent crea un evento del timeline
+func NewDrawRoomEvent(room *DrawRoom, eventType DrawRoomEventType, step int, data map[string]interface{}) (*DrawRoomEvent, error) {
+	event := &DrawRoomEvent{
+		DrawRoomID: room.ID,
+		Type:       eventType,
+		Step:       step,
+		CreatedAt:  time.Now(),
+	}
+
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		event.Data = datatypes.JSON(raw)
+	}
+
+	return event, nil
+}
+
+// DrawRoomRepository define el contrato para el repositorio de salas de sorteo
+type DrawRoomRepository interface {
+	// FindByRaffleID busca la sala de una rifa (ErrNotFound si no existe)
+	FindByRaffleID(raffleID int64) (*DrawRoom, error)
+
+	// Save crea o actualiza la sala
+	Save(room *DrawRoom) error
+
+	// AddEvent agrega un evento al timeline
+	AddEvent(event *DrawRoomEvent) error
+
+	// ListEvents lista el timeline de la sala en orden cronológico
+	ListEvents(roomID int64) ([]*DrawRoomEvent, error)
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CartStatus represents the state of a multi-raffle cart
+type CartStatus string
+
+const (
+	CartStatusOpen      CartStatus = "open"      // Waiting for payment
+	CartStatusPaid      CartStatus = "paid"      // Every reservation confirmed with one payment
+	CartStatusFailed    CartStatus = "failed"    // Payment failed: every reservation released
+	CartStatusCancelled CartStatus = "cancelled" // Payment cancelled: every reservation released
+)
+
+// MaxReservationsPerCart limits how many reservations can be paid in one checkout
+const MaxReservationsPerCart = 10
+
+var (
+	ErrCartEmpty               = errors.New("cart must contain at least one reservation")
+	ErrCartTooManyReservations = errors.New("maximum 10 reservations per cart")
+	ErrCartDuplicateRaffle     = errors.New("cart contains more than one reservation for the same raffle")
+	ErrCartNotOpen             = errors.New("cart is not open")
+)
+
+// Cart groups reservations of several raffles under a single checkout
+// The cart is paid with one payment intent or one wallet debit and its reservations
+// are confirmed or released together
+type Cart struct {
+	ID             uuid.UUID      `json:"id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	ReservationIDs pq.StringArray `json:"reservation_ids" gorm:"type:text[]"`
+	TotalAmount    float64        `json:"total_amount"`
+	Currency       string         `json:"currency"`
+	Status         CartStatus     `json:"status"`
+
+	PaymentMethod   string `json:"payment_method,omitempty"`
+	PaymentIntentID string `json:"payment_intent_id,omitempty"` // Provider intent or wallet transaction reference
+	ErrorMessage    string `json:"error_message,omitempty"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	PaidAt    *time.Time `json:"paid_at,omitempty"`
+}
+
+// NewCart creates an open cart for the user's reservations (one per raffle)
+func NewCart(userID uuid.UUID, reservations []*Reservation, currency string) (*Cart, error) {
+	if len(reservations) == 0 {
+		return nil, ErrCartEmpty
+	}
+	if len(reservations) > MaxReservationsPerCart {
+		return nil, ErrCartTooManyReservations
+	}
+
+	if currency == "" {
+		currency = "USD"
+	}
+
+	raffles := make(map[uuid.UUID]bool, len(reservations))
+	reservationIDs := make([]string, len(reservations))
+	total := 0.0
+	for i, reservation := range reservations {
+		if raffles[reservation.RaffleID] {
+			return nil, ErrCartDuplicateRaffle
+		}
+		raffles[reservation.RaffleID] = true
+		reservationIDs[i] = reservation.ID.String()
+		total += reservation.TotalAmount
+	}
+
+	if total <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	now := time.Now()
+	return &Cart{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ReservationIDs: pq.StringArray(reservationIDs),
+		TotalAmount:    total,
+		Currency:       currency,
+		Status:         CartStatusOpen,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// IsOpen checks if the cart is still waiting for payment
+func (c *Cart) IsOpen() bool {
+	return c.Status == CartStatusOpen
+}
+
+// SetPaymentIntent records the provider intent that pays the whole cart
+func (c *Cart) SetPaymentIntent(method, intentID string) error {
+	if !c.IsOpen() {
+		return ErrCartNotOpen
+	}
+
+	c.PaymentMethod = method
+	c.PaymentIntentID = intentID
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkAsPaid marks the cart as paid once every reservation was confirmed
+func (c *Cart) MarkAsPaid(method, intentID string) error {
+	if !c.IsOpen() {
+		return ErrCartNotOpen
+	}
+
+	now := time.Now()
+	c.Status = CartStatusPaid
+	c.PaymentMethod = method
+	c.PaymentIntentID = intentID
+	c.PaidAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// MarkAsFailed marks the cart as failed (or cancelled) after its reservations were released
+func (c *Cart) MarkAsFailed(status CartStatus, errorMessage string) error {
+	if !c.IsOpen() {
+		return ErrCartNotOpen
+	}
+
+	c.Status = status
+	c.ErrorMessage = errorMessage
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// ReservationUUIDs returns the IDs of the reservations in the cart
+func (c *Cart) ReservationUUIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(c.ReservationIDs))
+	for _, id := range c.ReservationIDs {
+		if parsed, err := uuid.Parse(id); err == nil {
+			ids = append(ids, parsed)
+		}
+	}
+	return ids
+}
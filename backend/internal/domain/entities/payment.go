@@ -20,6 +20,9 @@ const (
 	PaymentStatusRefunded   PaymentStatus = "refunded"
 )
 
+// PaymentMethodWallet payment method for purchases paid with wallet balance
+const PaymentMethodWallet = "wallet"
+
 var (
 	ErrPaymentAlreadyProcessed = errors.New("payment already processed")
 	ErrPaymentFailed           = errors.New("payment failed")
@@ -34,6 +37,7 @@ type Payment struct {
 	ReservationID          uuid.UUID     `json:"reservation_id"`
 	UserID                 uuid.UUID     `json:"user_id"`
 	RaffleID               uuid.UUID     `json:"raffle_id"`
+	CartID                 *uuid.UUID    `json:"cart_id,omitempty"` // Set when paid together with other reservations
 	StripePaymentIntentID  string        `json:"stripe_payment_intent_id"`
 	StripeClientSecret     string        `json:"stripe_client_secret"`
 	Amount                 float64       `json:"amount"`
@@ -45,14 +49,17 @@ type Payment struct {
 	CreatedAt              time.Time     `json:"created_at"`
 	UpdatedAt              time.Time     `json:"updated_at"`
 	PaidAt                 *time.Time    `json:"paid_at,omitempty"`
+	RefundedAmount         float64       `json:"refunded_amount"`
+	RefundedAt             *time.Time    `json:"refunded_at,omitempty"`
 }
 
 // PaymentMetadata represents additional payment information
 type PaymentMetadata struct {
-	NumberCount   int      `json:"number_count"`
-	NumberIDs     []string `json:"number_ids"`
-	RaffleTitle   string   `json:"raffle_title,omitempty"`
-	CustomerEmail string   `json:"customer_email,omitempty"`
+	NumberCount    int             `json:"number_count"`
+	NumberIDs      []string        `json:"number_ids"`
+	RaffleTitle    string          `json:"raffle_title,omitempty"`
+	CustomerEmail  string          `json:"customer_email,omitempty"`
+	PriceBreakdown json.RawMessage `json:"price_breakdown,omitempty"` // Pricing rules applied to the reservation total
 }
 
 // NewPayment creates a new payment in pending state
@@ -144,6 +151,35 @@ func (p *Payment) Refund() error {
 	return nil
 }
 
+// RefundableAmount returns the amount that has not been refunded yet
+func (p *Payment) RefundableAmount() float64 {
+	if p.Status != PaymentStatusSucceeded {
+		return 0
+	}
+	return p.Amount - p.RefundedAmount
+}
+
+// ApplyRefund records a successful refund; the payment becomes refunded once fully returned
+func (p *Payment) ApplyRefund(amount float64) error {
+	if amount <= 0 || amount > p.RefundableAmount()+0.005 {
+		return ErrInvalidPaymentAmount
+	}
+
+	now := time.Now()
+	p.RefundedAmount += amount
+	p.UpdatedAt = now
+	if p.Amount-p.RefundedAmount < 0.005 {
+		p.Status = PaymentStatusRefunded
+		p.RefundedAt = &now
+	}
+	return nil
+}
+
+// PaidWithWallet checks if the payment was made with wallet balance
+func (p *Payment) PaidWithWallet() bool {
+	return p.PaymentMethod == PaymentMethodWallet
+}
+
 // IsCompleted checks if payment is in final state
 func (p *Payment) IsCompleted() bool {
 	return p.Status == PaymentStatusSucceeded ||
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -48,6 +49,7 @@ var (
 	ErrNotInSelectionPhase     = errors.New("reservation not in selection phase")
 	ErrNumberNotInReservation  = errors.New("number not found in reservation")
 	ErrCannotRemoveLastNumber  = errors.New("cannot remove last number, cancel reservation instead")
+	ErrInvalidReservationPhase = errors.New("reservation is not in a payable phase")
 )
 
 // Reservation represents a temporary hold on raffle numbers
@@ -60,6 +62,9 @@ type Reservation struct {
 	SessionID   string            `json:"session_id"`   // For idempotency tracking
 	TotalAmount float64           `json:"total_amount"` // Total cost for reserved numbers
 
+	// Pricing rules applied to TotalAmount (subtotal, discounts and total)
+	PriceBreakdown json.RawMessage `json:"price_breakdown,omitempty" gorm:"type:jsonb"`
+
 	// Double timeout system
 	Phase               ReservationPhase `json:"phase" gorm:"type:reservation_phase"`
 	SelectionStartedAt  time.Time        `json:"selection_started_at"`
@@ -102,8 +107,20 @@ func NewReservation(raffleID, userID uuid.UUID, numberIDs []string, sessionID st
 }
 
 // IsExpired checks if the reservation has expired
+// A reservation in checkout also expires once the checkout timeout has elapsed
 func (r *Reservation) IsExpired() bool {
-	return time.Now().After(r.ExpiresAt) && r.Status == ReservationStatusPending
+	if r.Status != ReservationStatusPending {
+		return false
+	}
+	return time.Now().After(r.ExpiresAt) || r.IsCheckoutTimedOut()
+}
+
+// IsCheckoutTimedOut checks if the checkout phase lasted longer than ReservationCheckoutTimeout
+func (r *Reservation) IsCheckoutTimedOut() bool {
+	if r.Phase != ReservationPhaseCheckout || r.CheckoutStartedAt == nil {
+		return false
+	}
+	return time.Since(*r.CheckoutStartedAt) > ReservationCheckoutTimeout
 }
 
 // CanBePaid checks if the reservation can be paid
@@ -123,6 +140,20 @@ func (r *Reservation) CanBePaid() error {
 	return nil
 }
 
+// CanCheckout checks if the reservation can be paid and confirmed in its current phase
+// Only reservations in selection or checkout phase can be paid
+func (r *Reservation) CanCheckout() error {
+	if err := r.CanBePaid(); err != nil {
+		return err
+	}
+
+	if r.Phase == ReservationPhaseCompleted || r.Phase == ReservationPhaseExpired {
+		return ErrInvalidReservationPhase
+	}
+
+	return nil
+}
+
 // AddNumber adds a number to an existing reservation (only in selection phase)
 func (r *Reservation) AddNumber(numberID string) error {
 	if r.Phase != ReservationPhaseSelection {
@@ -179,8 +210,20 @@ func (r *Reservation) RemoveNumber(numberID string) error {
 	return nil
 }
 
+// SetPrice sets the reservation total and the breakdown of the pricing rules applied to it
+func (r *Reservation) SetPrice(totalAmount float64, breakdown json.RawMessage) error {
+	if totalAmount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	r.TotalAmount = totalAmount
+	r.PriceBreakdown = breakdown
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
 // MoveToCheckout transitions the reservation from selection to checkout phase
-// This extends the timeout by an additional 5 minutes
+// The number set is frozen and the reservation expires ReservationCheckoutTimeout from now
 func (r *Reservation) MoveToCheckout() error {
 	if r.Phase != ReservationPhaseSelection {
 		return ErrNotInSelectionPhase
@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WaitlistStatus represents the state of a waitlist entry
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"   // Queued for released numbers
+	WaitlistStatusOffered   WaitlistStatus = "offered"   // Released numbers held exclusively for the user
+	WaitlistStatusClaimed   WaitlistStatus = "claimed"   // Offer turned into a reservation
+	WaitlistStatusExpired   WaitlistStatus = "expired"   // Offer not claimed within the hold window
+	WaitlistStatusCancelled WaitlistStatus = "cancelled" // User left the waitlist
+)
+
+// WaitlistOfferHoldDuration is how long offered numbers are held exclusively for a waitlisted user
+const WaitlistOfferHoldDuration = 2 * time.Minute
+
+var (
+	ErrInvalidWaitlistQuantity = errors.New("waitlist quantity must be between 1 and 10")
+	ErrWaitlistNotWaiting      = errors.New("waitlist entry is not waiting for numbers")
+	ErrWaitlistNoOffer         = errors.New("waitlist entry has no active offer")
+	ErrWaitlistOfferExpired    = errors.New("waitlist offer has expired")
+)
+
+// WaitlistEntry represents a user queued for numbers of a sold-out raffle
+// Entries are served in FIFO order (CreatedAt) when reservations release numbers
+type WaitlistEntry struct {
+	ID       uuid.UUID      `json:"id"`
+	RaffleID uuid.UUID      `json:"raffle_id"`
+	UserID   uuid.UUID      `json:"user_id"`
+	Quantity int            `json:"quantity"` // Numbers wanted (1-MaxNumbersPerReservation)
+	Status   WaitlistStatus `json:"status"`
+
+	// Active offer: numbers locked for the user until OfferExpiresAt
+	// OfferReservationID owns the number locks and becomes the reservation ID on claim
+	OfferedNumbers     pq.StringArray `json:"offered_numbers,omitempty" gorm:"type:text[]"`
+	OfferReservationID *uuid.UUID     `json:"offer_reservation_id,omitempty"`
+	OfferedAt          *time.Time     `json:"offered_at,omitempty"`
+	OfferExpiresAt     *time.Time     `json:"offer_expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewWaitlistEntry creates a new entry at the end of the raffle waitlist
+func NewWaitlistEntry(raffleID, userID uuid.UUID, quantity int) (*WaitlistEntry, error) {
+	if quantity < 1 || quantity > MaxNumbersPerReservation {
+		return nil, ErrInvalidWaitlistQuantity
+	}
+
+	now := time.Now()
+	return &WaitlistEntry{
+		ID:        uuid.New(),
+		RaffleID:  raffleID,
+		UserID:    userID,
+		Quantity:  quantity,
+		Status:    WaitlistStatusWaiting,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Offer holds released numbers for the user during the hold window
+func (e *WaitlistEntry) Offer(numbers []string, reservationID uuid.UUID, hold time.Duration) error {
+	if e.Status != WaitlistStatusWaiting {
+		return ErrWaitlistNotWaiting
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(hold)
+	e.Status = WaitlistStatusOffered
+	e.OfferedNumbers = pq.StringArray(numbers)
+	e.OfferReservationID = &reservationID
+	e.OfferedAt = &now
+	e.OfferExpiresAt = &expiresAt
+	e.UpdatedAt = now
+	return nil
+}
+
+// IsOfferExpired checks if the hold window of the offer has passed
+func (e *WaitlistEntry) IsOfferExpired() bool {
+	return e.Status == WaitlistStatusOffered && e.OfferExpiresAt != nil && time.Now().After(*e.OfferExpiresAt)
+}
+
+// Claim marks the offer as turned into a reservation
+func (e *WaitlistEntry) Claim() error {
+	if e.Status != WaitlistStatusOffered {
+		return ErrWaitlistNoOffer
+	}
+	if e.IsOfferExpired() {
+		return ErrWaitlistOfferExpired
+	}
+
+	e.Status = WaitlistStatusClaimed
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// ExpireOffer removes the entry from the waitlist after an unclaimed offer
+func (e *WaitlistEntry) ExpireOffer() error {
+	if e.Status != WaitlistStatusOffered {
+		return ErrWaitlistNoOffer
+	}
+
+	e.Status = WaitlistStatusExpired
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel removes the entry from the waitlist at the user's request
+func (e *WaitlistEntry) Cancel() error {
+	if !e.IsActive() {
+		return ErrWaitlistNotWaiting
+	}
+
+	e.Status = WaitlistStatusCancelled
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsActive checks if the entry is still in the waitlist (waiting or holding an offer)
+func (e *WaitlistEntry) IsActive() bool {
+	return e.Status == WaitlistStatusWaiting || e.Status == WaitlistStatusOffered
+}
@@ -0,0 +1,288 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LedgerAccountType tipo de cuenta del libro mayor
+type LedgerAccountType string
+
+const (
+	// Cuentas por usuario (pasivo: lo que la plataforma debe al usuario)
+	LedgerAccountUserAvailable LedgerAccountType = "user_available" // Espejo de wallets.balance_available
+	LedgerAccountUserEarnings  LedgerAccountType = "user_earnings"  // Espejo de wallets.earnings_balance
+	LedgerAccountUserPending   LedgerAccountType = "user_pending"   // Espejo de wallets.pending_balance (retiros retenidos)
+
+	// Cuentas por organizador
+	LedgerAccountOrganizerPayable LedgerAccountType = "organizer_payable" // Ventas por liquidar al organizador
+
+	// Cuentas de la plataforma
+	LedgerAccountPlatformRevenue   LedgerAccountType = "platform_revenue"   // Comisiones de recarga y de liquidación
+	LedgerAccountProcessorClearing LedgerAccountType = "processor_clearing" // Fondos en procesadores y bancos
+	LedgerAccountProcessorFees     LedgerAccountType = "processor_fees"     // Comisiones cobradas por los procesadores
+	LedgerAccountRefunds           LedgerAccountType = "refunds"            // Devoluciones emitidas
+	LedgerAccountOpeningBalance    LedgerAccountType = "opening_balance"    // Saldos previos a la adopción del libro mayor
+)
+
+// IsDebitNormal indica si el saldo natural de la cuenta es deudor (activos y gastos)
+func (t LedgerAccountType) IsDebitNormal() bool {
+	return t == LedgerAccountProcessorClearing ||
+		t == LedgerAccountProcessorFees ||
+		t == LedgerAccountRefunds
+}
+
+// IsUserAccount indica si la cuenta pertenece a un usuario/organizador
+func (t LedgerAccountType) IsUserAccount() bool {
+	return t == LedgerAccountUserAvailable ||
+		t == LedgerAccountUserEarnings ||
+		t == LedgerAccountUserPending ||
+		t == LedgerAccountOrganizerPayable
+}
+
+// JournalEntryType tipo de asiento contable
+type JournalEntryType string
+
+const (
+	JournalEntryDeposit           JournalEntryType = "deposit"            // Recarga confirmada por el procesador
+	JournalEntryPurchase          JournalEntryType = "purchase"           // Compra de números (saldo o tarjeta)
+	JournalEntryRefund            JournalEntryType = "refund"             // Devolución al proveedor o a la billetera
+	JournalEntrySettlementFee     JournalEntryType = "settlement_fee"     // Comisión de plataforma al liquidar un sorteo
+	JournalEntrySettlementPayout  JournalEntryType = "settlement_payout"  // Pago de la liquidación al organizador
+	JournalEntryPrize             JournalEntryType = "prize"              // Premio en efectivo acreditado al ganador
+	JournalEntryJackpotRollover   JournalEntryType = "jackpot_rollover"   // Premio mayor acumulado al siguiente sorteo de la serie
+	JournalEntryWithdrawalHold    JournalEntryType = "withdrawal_hold"    // Retención de ganancias al solicitar un retiro
+	JournalEntryWithdrawalRelease JournalEntryType = "withdrawal_release" // Retiro rechazado o cancelado
+	JournalEntryWithdrawalPayout  JournalEntryType = "withdrawal_payout"  // Retiro transferido al banco
+	JournalEntryOpeningBalance    JournalEntryType = "opening_balance"
+)
+
+// JournalDirection lado del asiento
+type JournalDirection string
+
+const (
+	JournalDebit  JournalDirection = "debit"
+	JournalCredit JournalDirection = "credit"
+)
+
+// LedgerAccountRef identifica una cuenta sin conocer su ID (se resuelve al registrar el asiento)
+type LedgerAccountRef struct {
+	Type        LedgerAccountType
+	OwnerUserID *int64
+}
+
+// UserAvailableAccount cuenta de saldo de recargas de un usuario
+func UserAvailableAccount(userID int64) LedgerAccountRef {
+	return LedgerAccountRef{Type: LedgerAccountUserAvailable, OwnerUserID: &userID}
+}
+
+// UserEarningsAccount cuenta de saldo de ganancias de un usuario
+func UserEarningsAccount(userID int64) LedgerAccountRef {
+	return LedgerAccountRef{Type: LedgerAccountUserEarnings, OwnerUserID: &userID}
+}
+
+// UserPendingAccount cuenta de saldo retenido de un usuario
+func UserPendingAccount(userID int64) LedgerAccountRef {
+	return LedgerAccountRef{Type: LedgerAccountUserPending, OwnerUserID: &userID}
+}
+
+// OrganizerPayableAccount cuenta por pagar a un organizador
+func OrganizerPayableAccount(organizerID int64) LedgerAccountRef {
+	return LedgerAccountRef{Type: LedgerAccountOrganizerPayable, OwnerUserID: &organizerID}
+}
+
+// PlatformAccount cuenta global de la plataforma
+func PlatformAccount(accountType LedgerAccountType) LedgerAccountRef {
+	return LedgerAccountRef{Type: accountType}
+}
+
+// LedgerAccount cuenta del libro mayor
+type LedgerAccount struct {
+	ID          int64             `json:"id" gorm:"primaryKey"`
+	AccountType LedgerAccountType `json:"account_type" gorm:"type:varchar(30);not null"`
+	OwnerUserID *int64            `json:"owner_user_id,omitempty"`
+	Currency    string            `json:"currency" gorm:"type:varchar(3);not null"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (LedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+// JournalEntry asiento contable: la suma de débitos siempre es igual a la suma de créditos
+type JournalEntry struct {
+	ID             int64            `json:"id" gorm:"primaryKey"`
+	UUID           string           `json:"uuid" gorm:"type:uuid;unique;not null"`
+	EntryType      JournalEntryType `json:"entry_type" gorm:"type:varchar(30);not null"`
+	Currency       string           `json:"currency" gorm:"type:varchar(3);not null"`
+	IdempotencyKey string           `json:"idempotency_key" gorm:"uniqueIndex;not null"`
+
+	// Origen del movimiento
+	ReferenceType       *string `json:"reference_type,omitempty"`
+	ReferenceID         *string `json:"reference_id,omitempty"`
+	WalletTransactionID *int64  `json:"wallet_transaction_id,omitempty"`
+	Description         *string `json:"description,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	Lines []*JournalLine `json:"lines" gorm:"foreignKey:EntryID"`
+}
+
+// TableName especifica el nombre de la tabla
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// JournalLine línea de un asiento contable
+type JournalLine struct {
+	ID        int64            `json:"id" gorm:"primaryKey"`
+	EntryID   int64            `json:"entry_id" gorm:"not null;index"`
+	AccountID int64            `json:"account_id" gorm:"not null;index"`
+	Direction JournalDirection `json:"direction" gorm:"type:varchar(6);not null"`
+	Amount    decimal.Decimal  `json:"amount" gorm:"type:decimal(12,2);not null"`
+
+	// Cuenta a resolver antes de insertar (no se mapea a columna)
+	Account LedgerAccountRef `json:"-" gorm:"-"`
+}
+
+// TableName especifica el nombre de la tabla
+func (JournalLine) TableName() string {
+	return "journal_lines"
+}
+
+// NewJournalEntry crea un asiento vacío en la moneda del movimiento (requerida, ver Validate)
+// La clave de idempotencia evita registrar dos veces el mismo movimiento
+func NewJournalEntry(entryType JournalEntryType, idempotencyKey, currency string) *JournalEntry {
+	return &JournalEntry{
+		EntryType:      entryType,
+		Currency:       currency,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// Debit agrega una línea deudora (los montos en cero se omiten)
+func (e *JournalEntry) Debit(account LedgerAccountRef, amount decimal.Decimal) *JournalEntry {
+	return e.addLine(account, JournalDebit, amount)
+}
+
+// Credit agrega una línea acreedora (los montos en cero se omiten)
+func (e *JournalEntry) Credit(account LedgerAccountRef, amount decimal.Decimal) *JournalEntry {
+	return e.addLine(account, JournalCredit, amount)
+}
+
+func (e *JournalEntry) addLine(account LedgerAccountRef, direction JournalDirection, amount decimal.Decimal) *JournalEntry {
+	if amount.IsZero() {
+		return e
+	}
+	e.Lines = append(e.Lines, &JournalLine{
+		Account:   account,
+		Direction: direction,
+		Amount:    amount.Round(2),
+	})
+	return e
+}
+
+// WithReference asocia el asiento a la entidad que lo originó
+func (e *JournalEntry) WithReference(referenceType string, referenceID interface{}) *JournalEntry {
+	id := fmt.Sprint(referenceID)
+	e.ReferenceType = &referenceType
+	e.ReferenceID = &id
+	return e
+}
+
+// WithWalletTransaction asocia el asiento a la transacción de billetera correspondiente
+func (e *JournalEntry) WithWalletTransaction(transactionID int64) *JournalEntry {
+	e.WalletTransactionID = &transactionID
+	return e
+}
+
+// WithDescription agrega una descripción
+func (e *JournalEntry) WithDescription(description string) *JournalEntry {
+	e.Description = &description
+	return e
+}
+
+// IsEmpty indica si el asiento no tiene líneas (movimiento de monto cero)
+func (e *JournalEntry) IsEmpty() bool {
+	return len(e.Lines) == 0
+}
+
+// Totals retorna la suma de débitos y de créditos
+func (e *JournalEntry) Totals() (decimal.Decimal, decimal.Decimal) {
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, line := range e.Lines {
+		if line.Direction == JournalDebit {
+			debits = debits.Add(line.Amount)
+		} else {
+			credits = credits.Add(line.Amount)
+		}
+	}
+	return debits, credits
+}
+
+// Validate valida que el asiento esté balanceado
+func (e *JournalEntry) Validate() error {
+	if e.IdempotencyKey == "" {
+		return fmt.Errorf("idempotency_key es requerido")
+	}
+
+	if len(e.Currency) != 3 {
+		return fmt.Errorf("la moneda del asiento es requerida")
+	}
+
+	if len(e.Lines) < 2 {
+		return fmt.Errorf("el asiento debe tener al menos dos líneas")
+	}
+
+	for _, line := range e.Lines {
+		if line.Amount.LessThanOrEqual(decimal.Zero) {
+			return fmt.Errorf("los montos de las líneas deben ser mayores a cero")
+		}
+		if line.Account.Type.IsUserAccount() && line.Account.OwnerUserID == nil {
+			return fmt.Errorf("la cuenta %s requiere un usuario", line.Account.Type)
+		}
+	}
+
+	debits, credits := e.Totals()
+	if !debits.Equal(credits) {
+		return fmt.Errorf("asiento desbalanceado (débitos: %s, créditos: %s)", debits.String(), credits.String())
+	}
+
+	return nil
+}
+
+// WalletLedgerDiscrepancy diferencia entre los saldos de una billetera y los del libro mayor
+type WalletLedgerDiscrepancy struct {
+	WalletID         int64           `json:"wallet_id"`
+	UserID           int64           `json:"user_id"`
+	Currency         string          `json:"currency"`
+	BalanceAvailable decimal.Decimal `json:"balance_available"`
+	LedgerAvailable  decimal.Decimal `json:"ledger_available"`
+	EarningsBalance  decimal.Decimal `json:"earnings_balance"`
+	LedgerEarnings   decimal.Decimal `json:"ledger_earnings"`
+	PendingBalance   decimal.Decimal `json:"pending_balance"`
+	LedgerPending    decimal.Decimal `json:"ledger_pending"`
+}
+
+// LedgerRepository define el contrato para el repositorio del libro mayor
+type LedgerRepository interface {
+	// Post registra un asiento balanceado resolviendo (o creando) sus cuentas
+	// Es idempotente: si la clave ya existe no se registra de nuevo
+	Post(entry *JournalEntry) error
+
+	// FindByIdempotencyKey busca un asiento por su clave de idempotencia
+	FindByIdempotencyKey(key string) (*JournalEntry, error)
+
+	// FindWalletDiscrepancies compara los saldos de las billeteras con los totales del libro mayor
+	FindWalletDiscrepancies(limit int) ([]*WalletLedgerDiscrepancy, int64, error)
+
+	// FindUnbalancedEntries busca asientos cuyos débitos no igualan sus créditos
+	FindUnbalancedEntries(limit int) ([]int64, error)
+
+	// Totals retorna la suma global de débitos y créditos
+	Totals() (decimal.Decimal, decimal.Decimal, error)
+}
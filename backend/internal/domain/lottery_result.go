@@ -0,0 +1,294 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// LotteryTimezone zona horaria de los sorteos de la Junta de Protección Social
+const LotteryTimezone = "America/Costa_Rica"
+
+// LotteryResultSource representa el origen de un resultado de lotería
+type LotteryResultSource string
+
+const (
+	LotteryResultSourceHTTP   LotteryResultSource = "http"
+	LotteryResultSourceFile   LotteryResultSource = "file"
+	LotteryResultSourceManual LotteryResultSource = "manual"
+)
+
+// LotteryResultStatus representa el estado de un resultado de lotería
+type LotteryResultStatus string
+
+const (
+	LotteryResultStatusPendingConfirmation LotteryResultStatus = "pending_confirmation"
+	LotteryResultStatusConfirmed           LotteryResultStatus = "confirmed"
+	LotteryResultStatusRejected            LotteryResultStatus = "rejected"
+)
+
+// LotteryResult resultado oficial del premio mayor de la Lotería Nacional de Costa Rica
+type LotteryResult struct {
+	ID   int64  `json:"id" gorm:"primaryKey"`
+	UUID string `json:"uuid" gorm:"type:uuid;unique;not null"`
+
+	// Datos oficiales
+	DrawDate      time.Time `json:"draw_date" gorm:"type:date;not null"` // Fecha del sorteo (hora de Costa Rica)
+	DrawNumber    *string   `json:"draw_number,omitempty"`               // Número de sorteo de la JPS
+	WinningNumber string    `json:"winning_number" gorm:"not null"`      // Número del premio mayor
+	Series        *string   `json:"series,omitempty"`                    // Serie del premio mayor
+
+	// Origen y confirmación
+	Source          LotteryResultSource `json:"source" gorm:"type:varchar(20);not null"`
+	Status          LotteryResultStatus `json:"status" gorm:"type:varchar(30);not null"`
+	EnteredBy       *int64              `json:"entered_by,omitempty"`   // Admin que registró el resultado (solo manual)
+	ConfirmedBy     *int64              `json:"confirmed_by,omitempty"` // Segundo admin que confirmó
+	ConfirmedAt     *time.Time          `json:"confirmed_at,omitempty"`
+	RejectionReason *string             `json:"rejection_reason,omitempty"`
+	RawPayload      datatypes.JSON      `json:"raw_payload,omitempty" gorm:"type:jsonb"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (LotteryResult) TableName() string {
+	return "lottery_results"
+}
+
+// NewManualLotteryResult crea un resultado ingresado manualmente pendiente de confirmación
+func NewManualLotteryResult(drawDate time.Time, winningNumber string, enteredBy int64) *LotteryResult {
+	now := time.Now()
+	return &LotteryResult{
+		DrawDate:      LotteryDate(drawDate),
+		WinningNumber: winningNumber,
+		Source:        LotteryResultSourceManual,
+		Status:        LotteryResultStatusPendingConfirmation,
+		EnteredBy:     &enteredBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Validate valida el resultado
+func (lr *LotteryResult) Validate() error {
+	if lr.DrawDate.IsZero() {
+		return fmt.Errorf("la fecha del sorteo es requerida")
+	}
+
+	if lr.WinningNumber == "" {
+		return fmt.Errorf("el número ganador es requerido")
+	}
+
+	if _, err := strconv.ParseUint(lr.WinningNumber, 10, 64); err != nil {
+		return fmt.Errorf("el número ganador debe contener solo dígitos")
+	}
+
+	if lr.Source == LotteryResultSourceManual && lr.EnteredBy == nil {
+		return fmt.Errorf("un resultado manual requiere el admin que lo registró")
+	}
+
+	return nil
+}
+
+// IsConfirmed verifica si el resultado puede usarse para completar sorteos
+func (lr *LotteryResult) IsConfirmed() bool {
+	return lr.Status == LotteryResultStatusConfirmed
+}
+
+// Confirm confirma un resultado manual; debe hacerlo un admin distinto al que lo registró
+func (lr *LotteryResult) Confirm(adminID int64) error {
+	if lr.Status != LotteryResultStatusPendingConfirmation {
+		return fmt.Errorf("solo se pueden confirmar resultados pendientes (estado actual: %s)", lr.Status)
+	}
+
+	if lr.EnteredBy != nil && *lr.EnteredBy == adminID {
+		return fmt.Errorf("el resultado debe ser confirmado por un admin distinto al que lo registró")
+	}
+
+	now := time.Now()
+	lr.Status = LotteryResultStatusConfirmed
+	lr.ConfirmedBy = &adminID
+	lr.ConfirmedAt = &now
+	lr.UpdatedAt = now
+
+	return nil
+}
+
+// Reject rechaza un resultado manual pendiente
+func (lr *LotteryResult) Reject(adminID int64, reason string) error {
+	if lr.Status != LotteryResultStatusPendingConfirmation {
+		return fmt.Errorf("solo se pueden rechazar resultados pendientes (estado actual: %s)", lr.Status)
+	}
+
+	lr.Status = LotteryResultStatusRejected
+	lr.ConfirmedBy = &adminID
+	lr.RejectionReason = &reason
+	lr.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// LotteryDate normaliza una fecha al día calendario del sorteo en hora de Costa Rica
+func LotteryDate(t time.Time) time.Time {
+	loc, err := time.LoadLocation(LotteryTimezone)
+	if err != nil {
+		// Costa Rica no usa horario de verano: UTC-6 fijo
+		loc = time.FixedZone("CST", -6*60*60)
+	}
+
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// LotteryMappingMethod forma de llevar el número oficial al rango de la rifa
+type LotteryMappingMethod string
+
+const (
+	// LotteryMappingLastDigits usa los últimos dígitos del número oficial (tantos como el número máximo de la rifa)
+	LotteryMappingLastDigits LotteryMappingMethod = "last_digits"
+	// LotteryMappingModulo usa el número oficial completo módulo el total de números
+	LotteryMappingModulo LotteryMappingMethod = "modulo"
+)
+
+// LotteryFallback regla a aplicar cuando el número resultante no fue vendido
+type LotteryFallback string
+
+const (
+	LotteryFallbackNextSold     LotteryFallback = "next_sold"     // Siguiente número vendido hacia arriba (circular)
+	LotteryFallbackPreviousSold LotteryFallback = "previous_sold" // Número vendido anterior (circular)
+	LotteryFallbackNearestSold  LotteryFallback = "nearest_sold"  // Número vendido más cercano (empate hacia arriba)
+	LotteryFallbackManual       LotteryFallback = "manual"        // El sorteo queda pendiente para un admin
+)
+
+// LotteryMappingRule regla configurable (system_parameters.lottery_mapping_rule)
+type LotteryMappingRule struct {
+	Method   LotteryMappingMethod `json:"method"`
+	Fallback LotteryFallback      `json:"fallback"`
+}
+
+// DefaultLotteryMappingRule regla por defecto: últimos dígitos y siguiente número vendido
+func DefaultLotteryMappingRule() LotteryMappingRule {
+	return LotteryMappingRule{
+		Method:   LotteryMappingLastDigits,
+		Fallback: LotteryFallbackNextSold,
+	}
+}
+
+// Validate valida la regla
+func (r LotteryMappingRule) Validate() error {
+	switch r.Method {
+	case LotteryMappingLastDigits, LotteryMappingModulo:
+	default:
+		return fmt.Errorf("método de mapeo inválido: %s", r.Method)
+	}
+
+	switch r.Fallback {
+	case LotteryFallbackNextSold, LotteryFallbackPreviousSold, LotteryFallbackNearestSold, LotteryFallbackManual:
+	default:
+		return fmt.Errorf("regla de respaldo inválida: %s", r.Fallback)
+	}
+
+	return nil
+}
+
+// MapNumber lleva el número oficial al rango MinNumber..MaxNumber de la rifa
+func (r LotteryMappingRule) MapNumber(winningNumber string, raffle *Raffle) (int, error) {
+	digits := winningNumber
+	if r.Method == LotteryMappingLastDigits {
+		width := len(strconv.Itoa(raffle.MaxNumber))
+		if len(digits) > width {
+			digits = digits[len(digits)-width:]
+		}
+	}
+
+	value, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("número ganador inválido: %s", winningNumber)
+	}
+
+	total := uint64(raffle.MaxNumber - raffle.MinNumber + 1)
+	if total == 0 {
+		return 0, fmt.Errorf("rango de números inválido")
+	}
+
+	// Dentro del rango el número se mantiene; fuera de él se envuelve de forma circular
+	if value >= uint64(raffle.MinNumber) && value <= uint64(raffle.MaxNumber) {
+		return int(value), nil
+	}
+	offset := (value + total - uint64(raffle.MinNumber)%total) % total
+	return raffle.MinNumber + int(offset), nil
+}
+
+// ResolveWinner aplica la regla de respaldo sobre los números vendidos
+// Retorna el número ganador formateado y si se usó el respaldo; "" si el sorteo requiere intervención manual
+func (r LotteryMappingRule) ResolveWinner(mapped int, raffle *Raffle, soldNumbers []string) (string, bool, error) {
+	sold := make([]int, 0, len(soldNumbers))
+	formatted := make(map[int]string, len(soldNumbers))
+	for _, n := range soldNumbers {
+		value, err := strconv.Atoi(n)
+		if err != nil {
+			return "", false, fmt.Errorf("número vendido inválido: %s", n)
+		}
+		sold = append(sold, value)
+		formatted[value] = n
+	}
+
+	if n, ok := formatted[mapped]; ok {
+		return n, false, nil
+	}
+
+	if len(sold) == 0 || r.Fallback == LotteryFallbackManual {
+		return "", false, nil
+	}
+
+	sort.Ints(sold)
+	total := raffle.MaxNumber - raffle.MinNumber + 1
+
+	// Distancia circular dentro del rango de la rifa
+	up := func(v int) int { return ((v-mapped)%total + total) % total }
+	down := func(v int) int { return ((mapped-v)%total + total) % total }
+
+	best := sold[0]
+	for _, v := range sold[1:] {
+		switch r.Fallback {
+		case LotteryFallbackNextSold:
+			if up(v) < up(best) {
+				best = v
+			}
+		case LotteryFallbackPreviousSold:
+			if down(v) < down(best) {
+				best = v
+			}
+		case LotteryFallbackNearestSold:
+			dv, db := min(up(v), down(v)), min(up(best), down(best))
+			if dv < db || (dv == db && up(v) < up(best)) {
+				best = v
+			}
+		}
+	}
+
+	return formatted[best], true, nil
+}
+
+// LotteryResultRepository define el contrato para el repositorio de resultados de lotería
+type LotteryResultRepository interface {
+	// Create crea un nuevo resultado
+	Create(result *LotteryResult) error
+
+	// FindByID busca un resultado por ID
+	FindByID(id int64) (*LotteryResult, error)
+
+	// FindActiveByDrawDate busca el resultado vigente (pendiente o confirmado) de una fecha
+	FindActiveByDrawDate(drawDate time.Time) (*LotteryResult, error)
+
+	// List lista resultados (paginado), opcionalmente filtrados por estado
+	List(status *LotteryResultStatus, limit, offset int) ([]*LotteryResult, int64, error)
+
+	// Update actualiza un resultado existente
+	Update(result *LotteryResult) error
+}
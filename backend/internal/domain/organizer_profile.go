@@ -119,6 +119,23 @@ func (op *OrganizerProfile) GetEffectiveCommission(globalDefault float64) float6
 	return globalDefault
 }
 
+// PayoutCutoff retorna el inicio del período de pago vigente según PayoutSchedule
+// Los sorteos completados antes del corte se liquidan en el lote del período anterior
+// weekly: lunes 00:00 de la semana actual; monthly: día 1 00:00 del mes actual; manual: sin corte
+func (op *OrganizerProfile) PayoutCutoff(now time.Time) (time.Time, bool) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch op.PayoutSchedule {
+	case PayoutScheduleWeekly:
+		daysSinceMonday := (int(startOfDay.Weekday()) + 6) % 7
+		return startOfDay.AddDate(0, 0, -daysSinceMonday), true
+	case PayoutScheduleMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), true
+	default:
+		return time.Time{}, false
+	}
+}
+
 // HasBankInfo verifica si el organizador tiene información bancaria completa
 func (op *OrganizerProfile) HasBankInfo() bool {
 	return op.BankName != nil && *op.BankName != "" &&
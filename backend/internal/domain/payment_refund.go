@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RefundMaxAttempts intentos contra el proveedor antes de dejar el reembolso para revisión manual
+const RefundMaxAttempts = 5
+
+// PaymentRefundStatus representa el estado de un reembolso
+type PaymentRefundStatus string
+
+const (
+	PaymentRefundStatusPending    PaymentRefundStatus = "pending"    // Por ejecutar o esperando reintento
+	PaymentRefundStatusProcessing PaymentRefundStatus = "processing" // Aceptado por el proveedor, pendiente de confirmación
+	PaymentRefundStatusSucceeded  PaymentRefundStatus = "succeeded"
+	PaymentRefundStatusFailed     PaymentRefundStatus = "failed"
+)
+
+// PaymentRefundMethod forma en que se devuelve el dinero
+type PaymentRefundMethod string
+
+const (
+	PaymentRefundMethodProvider     PaymentRefundMethod = "provider"      // Al medio de pago original (Stripe/PayPal)
+	PaymentRefundMethodWalletCredit PaymentRefundMethod = "wallet_credit" // Crédito al saldo de la billetera
+)
+
+// PaymentRefundProviderWallet proveedor de los reembolsos acreditados a la billetera
+const PaymentRefundProviderWallet = "wallet"
+
+// PaymentRefund reembolso de un pago
+type PaymentRefund struct {
+	ID        int64  `json:"id" gorm:"primaryKey"`
+	UUID      string `json:"uuid" gorm:"type:uuid;unique;not null"`
+	PaymentID string `json:"payment_id" gorm:"type:uuid;not null"`
+	UserID    string `json:"user_id" gorm:"type:uuid;not null"`
+	RaffleID  string `json:"raffle_id" gorm:"type:uuid;not null"`
+
+	// Monto
+	Amount   decimal.Decimal `json:"amount" gorm:"type:decimal(10,2);not null"`
+	Currency string          `json:"currency" gorm:"type:varchar(3);not null"`
+
+	// Forma de devolución
+	Method              PaymentRefundMethod `json:"method" gorm:"type:varchar(20);not null"`
+	Provider            string              `json:"provider" gorm:"type:varchar(20);not null"`
+	ProviderRefundID    *string             `json:"provider_refund_id,omitempty"`
+	ProviderStatus      *string             `json:"provider_status,omitempty"`
+	WalletTransactionID *int64              `json:"wallet_transaction_id,omitempty"`
+
+	// Máquina de estados
+	Status        PaymentRefundStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Attempts      int                 `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt *time.Time          `json:"next_attempt_at,omitempty"`
+	LastError     *string             `json:"last_error,omitempty"`
+
+	// Contexto
+	Reason      string `json:"reason" gorm:"not null"`
+	RequestedBy *int64 `json:"requested_by,omitempty"`
+
+	// Auditoría
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName especifica el nombre de la tabla
+func (PaymentRefund) TableName() string {
+	return "payment_refunds"
+}
+
+// NewPaymentRefund crea un reembolso pendiente
+func NewPaymentRefund(paymentID, userID, raffleID string, amount decimal.Decimal, currency string, method PaymentRefundMethod, provider, reason string, requestedBy *int64) *PaymentRefund {
+	now := time.Now()
+	return &PaymentRefund{
+		PaymentID:   paymentID,
+		UserID:      userID,
+		RaffleID:    raffleID,
+		Amount:      amount,
+		Currency:    currency,
+		Method:      method,
+		Provider:    provider,
+		Status:      PaymentRefundStatusPending,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate valida el reembolso
+func (r *PaymentRefund) Validate() error {
+	if r.PaymentID == "" || r.UserID == "" || r.RaffleID == "" {
+		return fmt.Errorf("payment_id, user_id y raffle_id son requeridos")
+	}
+
+	if r.Amount.LessThanOrEqual(decimal.Zero) {
+		return fmt.Errorf("el monto debe ser mayor a cero")
+	}
+
+	if r.Method != PaymentRefundMethodProvider && r.Method != PaymentRefundMethodWalletCredit {
+		return fmt.Errorf("método de reembolso inválido: %s", r.Method)
+	}
+
+	if r.Reason == "" {
+		return fmt.Errorf("la razón es requerida")
+	}
+
+	return nil
+}
+
+// IsFinal verifica si el reembolso terminó (exitoso o fallido)
+func (r *PaymentRefund) IsFinal() bool {
+	return r.Status == PaymentRefundStatusSucceeded || r.Status == PaymentRefundStatusFailed
+}
+
+// IsDue verifica si corresponde un intento (pending) o una consulta de estado (processing)
+func (r *PaymentRefund) IsDue(now time.Time) bool {
+	if r.IsFinal() {
+		return false
+	}
+	return r.NextAttemptAt == nil || !r.NextAttemptAt.After(now)
+}
+
+// StartAttempt registra un nuevo intento de ejecución
+func (r *PaymentRefund) StartAttempt() error {
+	if r.Status != PaymentRefundStatusPending {
+		return fmt.Errorf("solo se pueden ejecutar reembolsos pendientes (estado actual: %s)", r.Status)
+	}
+
+	r.Attempts++
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkProcessing el proveedor aceptó el reembolso pero aún no lo confirma
+func (r *PaymentRefund) MarkProcessing(providerRefundID, providerStatus string, checkAt time.Time) {
+	r.Status = PaymentRefundStatusProcessing
+	r.ProviderRefundID = &providerRefundID
+	r.ProviderStatus = &providerStatus
+	r.NextAttemptAt = &checkAt
+	r.UpdatedAt = time.Now()
+}
+
+// MarkSucceeded marca el reembolso como completado
+func (r *PaymentRefund) MarkSucceeded(providerRefundID, providerStatus string) {
+	now := time.Now()
+	r.Status = PaymentRefundStatusSucceeded
+	if providerRefundID != "" {
+		r.ProviderRefundID = &providerRefundID
+	}
+	if providerStatus != "" {
+		r.ProviderStatus = &providerStatus
+	}
+	r.NextAttemptAt = nil
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
+
+// RecordFailure registra un intento fallido y programa el reintento con backoff
+// Tras RefundMaxAttempts el reembolso queda fallido para revisión manual (el resultado en el proveedor es incierto)
+func (r *PaymentRefund) RecordFailure(reason string, now time.Time) {
+	r.LastError = &reason
+	r.UpdatedAt = now
+
+	if r.Attempts >= RefundMaxAttempts {
+		r.Status = PaymentRefundStatusFailed
+		r.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(refundBackoff(r.Attempts))
+	r.Status = PaymentRefundStatusPending
+	r.NextAttemptAt = &next
+}
+
+// MarkFailed marca el reembolso como fallido sin más reintentos (rechazo definitivo del proveedor)
+func (r *PaymentRefund) MarkFailed(reason string) {
+	r.Status = PaymentRefundStatusFailed
+	r.LastError = &reason
+	r.NextAttemptAt = nil
+	r.UpdatedAt = time.Now()
+}
+
+// CanFallbackToWallet verifica si un reembolso al proveedor fallido puede acreditarse a la billetera
+// Solo corresponde si el proveedor confirmó que no devolvió el dinero
+func (r *PaymentRefund) CanFallbackToWallet() bool {
+	return r.Status == PaymentRefundStatusFailed && r.Method == PaymentRefundMethodProvider
+}
+
+// FallbackToWalletCredit cambia un reembolso fallido en el proveedor a crédito en billetera
+func (r *PaymentRefund) FallbackToWalletCredit() error {
+	if !r.CanFallbackToWallet() {
+		return fmt.Errorf("el reembolso no puede pasar a crédito en billetera (estado: %s, método: %s)", r.Status, r.Method)
+	}
+
+	r.Method = PaymentRefundMethodWalletCredit
+	r.Provider = PaymentRefundProviderWallet
+	r.Status = PaymentRefundStatusPending
+	r.Attempts = 0
+	r.NextAttemptAt = nil
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// refundBackoff espera antes del siguiente intento: 1m, 5m, 15m, 1h
+func refundBackoff(attempts int) time.Duration {
+	switch attempts {
+	case 0, 1:
+		return time.Minute
+	case 2:
+		return 5 * time.Minute
+	case 3:
+		return 15 * time.Minute
+	default:
+		return time.Hour
+	}
+}
+
+// PaymentRefundRepository define el contrato para el repositorio de reembolsos
+type PaymentRefundRepository interface {
+	// Create crea un nuevo reembolso
+	Create(refund *PaymentRefund) error
+
+	// FindByID busca un reembolso por ID
+	FindByID(id int64) (*PaymentRefund, error)
+
+	// FindByPaymentID busca los reembolsos de un pago
+	FindByPaymentID(paymentID string) ([]*PaymentRefund, error)
+
+	// FindDue busca reembolsos con reintento o consulta de estado vencidos
+	FindDue(now time.Time, limit int) ([]*PaymentRefund, error)
+
+	// Claim reserva el reembolso para un intento hasta leaseUntil; false si otro proceso ya lo tomó
+	Claim(id int64, now, leaseUntil time.Time) (bool, error)
+
+	// Update actualiza un reembolso existente
+	Update(refund *PaymentRefund) error
+}
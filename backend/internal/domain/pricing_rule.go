@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxPricingRules máximo de reglas de precio por sorteo
+const MaxPricingRules = 10
+
+// PricingRuleType tipo de regla de precio
+type PricingRuleType string
+
+const (
+	PricingRuleTypeBundle       PricingRuleType = "bundle"        // Paquete: Quantity números por BundlePrice (ej. 3 números por ₡5.000)
+	PricingRuleTypeQuantityTier PricingRuleType = "quantity_tier" // Descuento porcentual desde Quantity números (ej. 10% desde 5)
+	PricingRuleTypeEarlyBird    PricingRuleType = "early_bird"    // Descuento porcentual dentro de la ventana StartsAt/EndsAt
+)
+
+// PricingRule regla de precio de un sorteo
+// Las reglas se evalúan al calcular el total de una reserva; sin reglas se cobra PricePerNumber por número
+type PricingRule struct {
+	ID              int64            `json:"id" gorm:"primaryKey"`
+	RaffleID        int64            `json:"raffle_id" gorm:"not null"`
+	Type            PricingRuleType  `json:"type" gorm:"type:varchar(20);not null"`
+	Name            string           `json:"name" gorm:"not null"`
+	Quantity        int              `json:"quantity" gorm:"not null"`                            // Números del paquete o mínimo de números para el descuento
+	BundlePrice     *decimal.Decimal `json:"bundle_price,omitempty" gorm:"type:decimal(12,2)"`    // Solo bundle
+	DiscountPercent *decimal.Decimal `json:"discount_percent,omitempty" gorm:"type:decimal(5,2)"` // Solo quantity_tier y early_bird
+	StartsAt        *time.Time       `json:"starts_at,omitempty"`
+	EndsAt          *time.Time       `json:"ends_at,omitempty"`
+	Active          bool             `json:"active" gorm:"not null"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
+
+// Validate valida la regla contra el precio por número del sorteo
+func (p *PricingRule) Validate(pricePerNumber decimal.Decimal) error {
+	if p.Name == "" {
+		return fmt.Errorf("el nombre de la regla de precio es requerido")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("la regla %q debe terminar después de comenzar", p.Name)
+	}
+
+	switch p.Type {
+	case PricingRuleTypeBundle:
+		if p.Quantity < 2 {
+			return fmt.Errorf("el paquete %q debe incluir al menos 2 números", p.Name)
+		}
+		if p.BundlePrice == nil || p.BundlePrice.LessThanOrEqual(decimal.Zero) {
+			return fmt.Errorf("el precio del paquete %q debe ser mayor a 0", p.Name)
+		}
+		if p.BundlePrice.GreaterThanOrEqual(pricePerNumber.Mul(decimal.NewFromInt(int64(p.Quantity)))) {
+			return fmt.Errorf("el precio del paquete %q debe ser menor al precio de sus números por separado", p.Name)
+		}
+		if p.DiscountPercent != nil {
+			return fmt.Errorf("el paquete %q no admite porcentaje de descuento", p.Name)
+		}
+	case PricingRuleTypeQuantityTier, PricingRuleTypeEarlyBird:
+		if p.Type == PricingRuleTypeQuantityTier && p.Quantity < 2 {
+			return fmt.Errorf("el descuento por cantidad %q debe aplicar desde al menos 2 números", p.Name)
+		}
+		if p.Type == PricingRuleTypeEarlyBird && p.EndsAt == nil {
+			return fmt.Errorf("el descuento anticipado %q requiere fecha de fin", p.Name)
+		}
+		if p.Quantity < 1 {
+			return fmt.Errorf("la cantidad mínima de %q debe ser al menos 1", p.Name)
+		}
+		if p.DiscountPercent == nil || p.DiscountPercent.LessThanOrEqual(decimal.Zero) || p.DiscountPercent.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+			return fmt.Errorf("el descuento de %q debe estar entre 0 y 100", p.Name)
+		}
+		if p.BundlePrice != nil {
+			return fmt.Errorf("la regla %q no admite precio de paquete", p.Name)
+		}
+	default:
+		return fmt.Errorf("tipo de regla de precio inválido: %s", p.Type)
+	}
+
+	return nil
+}
+
+// IsActiveAt verifica si la regla está vigente en el momento indicado
+func (p *PricingRule) IsActiveAt(at time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && at.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !at.Before(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// ValidatePricingRules valida el conjunto de reglas de precio de un sorteo
+func ValidatePricingRules(rules []*PricingRule, pricePerNumber decimal.Decimal) error {
+	if len(rules) > MaxPricingRules {
+		return fmt.Errorf("el sorteo no puede tener más de %d reglas de precio", MaxPricingRules)
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(pricePerNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PriceAdjustment descuento aplicado por una regla de precio
+type PriceAdjustment struct {
+	RuleID   int64           `json:"rule_id"`
+	Type     PricingRuleType `json:"type"`
+	Name     string          `json:"name"`
+	Quantity int             `json:"quantity"` // Números cubiertos por la regla
+	Discount decimal.Decimal `json:"discount"`
+}
+
+// PriceBreakdown desglose del total de una compra
+type PriceBreakdown struct {
+	Quantity    int               `json:"quantity"`
+	UnitPrice   decimal.Decimal   `json:"unit_price"`
+	Subtotal    decimal.Decimal   `json:"subtotal"`
+	Discount    decimal.Decimal   `json:"discount"`
+	Total       decimal.Decimal   `json:"total"`
+	Adjustments []PriceAdjustment `json:"adjustments,omitempty"`
+}
+
+// CalculatePrice calcula el total de quantity números aplicando las reglas vigentes en at
+// Se eligen los paquetes que dan el menor total; los números fuera de paquetes reciben el mayor
+// porcentaje vigente (por cantidad o anticipado), sin acumular porcentajes entre sí
+func CalculatePrice(unitPrice decimal.Decimal, quantity int, rules []*PricingRule, at time.Time) *PriceBreakdown {
+	subtotal := unitPrice.Mul(decimal.NewFromInt(int64(quantity))).Round(2)
+	breakdown := &PriceBreakdown{
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		Subtotal:  subtotal,
+		Discount:  decimal.Zero,
+		Total:     subtotal,
+	}
+	if quantity <= 0 {
+		return breakdown
+	}
+
+	var bundles []*PricingRule
+	var percentRule *PricingRule
+	for _, rule := range rules {
+		if !rule.IsActiveAt(at) || rule.Quantity > quantity {
+			continue
+		}
+		switch rule.Type {
+		case PricingRuleTypeBundle:
+			bundles = append(bundles, rule)
+		case PricingRuleTypeQuantityTier, PricingRuleTypeEarlyBird:
+			if percentRule == nil || rule.DiscountPercent.GreaterThan(*percentRule.DiscountPercent) {
+				percentRule = rule
+			}
+		}
+	}
+
+	hundred := decimal.NewFromInt(100)
+	effectiveUnit := unitPrice
+	if percentRule != nil {
+		effectiveUnit = unitPrice.Mul(hundred.Sub(*percentRule.DiscountPercent)).Div(hundred)
+	}
+
+	// best[n]: menor costo de n números; choice[n]: paquete usado en el último paso (-1 = número suelto)
+	best := make([]decimal.Decimal, quantity+1)
+	choice := make([]int, quantity+1)
+	for n := 1; n <= quantity; n++ {
+		best[n] = best[n-1].Add(effectiveUnit)
+		choice[n] = -1
+		for i, bundle := range bundles {
+			if bundle.Quantity > n {
+				continue
+			}
+			if cost := best[n-bundle.Quantity].Add(*bundle.BundlePrice); cost.LessThan(best[n]) {
+				best[n] = cost
+				choice[n] = i
+			}
+		}
+	}
+
+	bundleCounts := make([]int, len(bundles))
+	looseNumbers := 0
+	for n := quantity; n > 0; {
+		if choice[n] < 0 {
+			looseNumbers++
+			n--
+			continue
+		}
+		bundleCounts[choice[n]]++
+		n -= bundles[choice[n]].Quantity
+	}
+
+	for i, bundle := range bundles {
+		if bundleCounts[i] == 0 {
+			continue
+		}
+		count := decimal.NewFromInt(int64(bundleCounts[i]))
+		listPrice := unitPrice.Mul(decimal.NewFromInt(int64(bundle.Quantity)))
+		breakdown.addAdjustment(bundle, bundleCounts[i]*bundle.Quantity, listPrice.Sub(*bundle.BundlePrice).Mul(count))
+	}
+	if percentRule != nil && looseNumbers > 0 {
+		loose := unitPrice.Mul(decimal.NewFromInt(int64(looseNumbers)))
+		breakdown.addAdjustment(percentRule, looseNumbers, loose.Mul(*percentRule.DiscountPercent).Div(hundred))
+	}
+
+	return breakdown
+}
+
+// addAdjustment registra el descuento de una regla y lo descuenta del total
+func (b *PriceBreakdown) addAdjustment(rule *PricingRule, quantity int, discount decimal.Decimal) {
+	discount = discount.Round(2)
+	b.Adjustments = append(b.Adjustments, PriceAdjustment{
+		RuleID:   rule.ID,
+		Type:     rule.Type,
+		Name:     rule.Name,
+		Quantity: quantity,
+		Discount: discount,
+	})
+	b.Discount = b.Discount.Add(discount)
+	b.Total = b.Subtotal.Sub(b.Discount)
+}
+
+// PricingRuleRepository define el contrato para el repositorio de reglas de precio
+type PricingRuleRepository interface {
+	// ReplaceForRaffle reemplaza las reglas de precio de un sorteo
+	ReplaceForRaffle(raffleID int64, rules []*PricingRule) error
+
+	// FindByRaffleID lista las reglas de precio de un sorteo
+	FindByRaffleID(raffleID int64) ([]*PricingRule, error)
+}
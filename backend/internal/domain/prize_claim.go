@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PrizeClaimStatus representa el estado de la entrega de un premio
+type PrizeClaimStatus string
+
+const (
+	PrizeClaimStatusPaid            PrizeClaimStatus = "paid"             // Efectivo acreditado a la billetera
+	PrizeClaimStatusPendingIdentity PrizeClaimStatus = "pending_identity" // Esperando confirmación de identidad del ganador
+	PrizeClaimStatusPendingDelivery PrizeClaimStatus = "pending_delivery" // Identidad confirmada, pendiente de envío
+	PrizeClaimStatusShipped         PrizeClaimStatus = "shipped"
+	PrizeClaimStatusDelivered       PrizeClaimStatus = "delivered"
+	PrizeClaimStatusExpired         PrizeClaimStatus = "expired" // El ganador no reclamó antes del plazo
+	PrizeClaimStatusCancelled       PrizeClaimStatus = "cancelled"
+)
+
+// DefaultPrizeClaimDeadlineDays plazo por defecto para reclamar un premio físico
+const DefaultPrizeClaimDeadlineDays = 30
+
+// PrizeClaim entrega de un premio de un sorteo completado (uno por premio)
+type PrizeClaim struct {
+	ID            int64  `json:"id" gorm:"primaryKey"`
+	UUID          string `json:"uuid" gorm:"type:uuid;unique;not null"`
+	RaffleID      int64  `json:"raffle_id" gorm:"not null"`
+	PrizeID       *int64 `json:"prize_id,omitempty"`
+	PrizePosition int    `json:"prize_position" gorm:"not null"`
+	WinnerUserID  int64  `json:"winner_user_id" gorm:"not null"`
+	WinnerNumber  string `json:"winner_number" gorm:"not null"`
+
+	// Premio
+	PrizeType           PrizeType        `json:"prize_type" gorm:"type:varchar(20);not null"`
+	Amount              *decimal.Decimal `json:"amount,omitempty" gorm:"type:decimal(12,2)"`
+	Description         *string          `json:"description,omitempty"`
+	WalletTransactionID *int64           `json:"wallet_transaction_id,omitempty"`
+
+	// Estado del reclamo
+	Status        PrizeClaimStatus `json:"status" gorm:"type:varchar(30);not null"`
+	ClaimDeadline *time.Time       `json:"claim_deadline,omitempty"`
+
+	// Verificación de identidad
+	IdentityConfirmedAt *time.Time `json:"identity_confirmed_at,omitempty"`
+	IdentityConfirmedBy *int64     `json:"identity_confirmed_by,omitempty"`
+	IdentityNotes       *string    `json:"identity_notes,omitempty"`
+
+	// Entrega
+	DeliveryAddress *string    `json:"delivery_address,omitempty"`
+	Carrier         *string    `json:"carrier,omitempty"`
+	TrackingNumber  *string    `json:"tracking_number,omitempty"`
+	ShippedAt       *time.Time `json:"shipped_at,omitempty"`
+	DeliveredAt     *time.Time `json:"delivered_at,omitempty"`
+
+	// Resolución
+	ResolvedBy      *int64  `json:"resolved_by,omitempty"`
+	ResolutionNotes *string `json:"resolution_notes,omitempty"`
+
+	// Auditoría
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName especifica el nombre de la tabla
+func (PrizeClaim) TableName() string {
+	return "prize_claims"
+}
+
+// NewCashPrizeClaim crea el registro de un premio en efectivo (se acredita en la misma operación)
+func NewCashPrizeClaim(prize *RafflePrize, winner *RaffleWinner, amount decimal.Decimal) *PrizeClaim {
+	claim := newPrizeClaim(prize, winner)
+	claim.PrizeType = PrizeTypeCash
+	claim.Amount = &amount
+	claim.Status = PrizeClaimStatusPaid
+	return claim
+}
+
+// NewPhysicalPrizeClaim crea el reclamo de un premio físico con su fecha límite
+func NewPhysicalPrizeClaim(prize *RafflePrize, winner *RaffleWinner, deadline time.Time) *PrizeClaim {
+	claim := newPrizeClaim(prize, winner)
+	claim.PrizeType = PrizeTypePhysical
+	claim.Status = PrizeClaimStatusPendingIdentity
+	claim.ClaimDeadline = &deadline
+	return claim
+}
+
+func newPrizeClaim(prize *RafflePrize, winner *RaffleWinner) *PrizeClaim {
+	now := time.Now()
+	description := prize.Description
+	claim := &PrizeClaim{
+		RaffleID:      winner.RaffleID,
+		PrizePosition: winner.Position,
+		WinnerNumber:  winner.Number,
+		Description:   &description,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if prize.ID > 0 {
+		claim.PrizeID = &prize.ID
+	}
+	if winner.UserID != nil {
+		claim.WinnerUserID = *winner.UserID
+	}
+	return claim
+}
+
+// Validate valida el reclamo
+func (c *PrizeClaim) Validate() error {
+	if c.RaffleID <= 0 || c.WinnerUserID <= 0 {
+		return fmt.Errorf("raffle_id y winner_user_id son requeridos")
+	}
+
+	if c.PrizePosition < 1 {
+		return fmt.Errorf("la posición del premio es requerida")
+	}
+
+	if c.WinnerNumber == "" {
+		return fmt.Errorf("el número ganador es requerido")
+	}
+
+	switch c.PrizeType {
+	case PrizeTypeCash:
+		if c.Amount == nil || c.Amount.LessThanOrEqual(decimal.Zero) {
+			return fmt.Errorf("el monto del premio debe ser mayor a cero")
+		}
+	case PrizeTypePhysical:
+		if c.ClaimDeadline == nil {
+			return fmt.Errorf("la fecha límite de reclamo es requerida")
+		}
+	default:
+		return fmt.Errorf("tipo de premio inválido: %s", c.PrizeType)
+	}
+
+	return nil
+}
+
+// IsOpen verifica si el reclamo sigue pendiente de resolución
+func (c *PrizeClaim) IsOpen() bool {
+	return c.Status == PrizeClaimStatusPendingIdentity ||
+		c.Status == PrizeClaimStatusPendingDelivery ||
+		c.Status == PrizeClaimStatusShipped
+}
+
+// IsOverdue verifica si venció el plazo sin que el ganador confirmara su identidad
+func (c *PrizeClaim) IsOverdue(now time.Time) bool {
+	return c.Status == PrizeClaimStatusPendingIdentity &&
+		c.ClaimDeadline != nil &&
+		now.After(*c.ClaimDeadline)
+}
+
+// ConfirmIdentity registra la verificación de identidad del ganador
+func (c *PrizeClaim) ConfirmIdentity(adminID int64, deliveryAddress, notes string) error {
+	if c.Status != PrizeClaimStatusPendingIdentity {
+		return fmt.Errorf("solo se puede confirmar la identidad de reclamos pendientes (estado actual: %s)", c.Status)
+	}
+	if c.IsOverdue(time.Now()) {
+		return fmt.Errorf("el plazo para reclamar el premio venció")
+	}
+	if deliveryAddress == "" {
+		return fmt.Errorf("la dirección de entrega es requerida")
+	}
+
+	now := time.Now()
+	c.Status = PrizeClaimStatusPendingDelivery
+	c.IdentityConfirmedAt = &now
+	c.IdentityConfirmedBy = &adminID
+	c.DeliveryAddress = &deliveryAddress
+	if notes != "" {
+		c.IdentityNotes = &notes
+	}
+	c.UpdatedAt = now
+	return nil
+}
+
+// MarkShipped registra el envío del premio
+func (c *PrizeClaim) MarkShipped(carrier, trackingNumber string) error {
+	if c.Status != PrizeClaimStatusPendingDelivery {
+		return fmt.Errorf("solo se pueden enviar premios con identidad confirmada (estado actual: %s)", c.Status)
+	}
+	if carrier == "" {
+		return fmt.Errorf("el transportista es requerido")
+	}
+
+	now := time.Now()
+	c.Status = PrizeClaimStatusShipped
+	c.Carrier = &carrier
+	if trackingNumber != "" {
+		c.TrackingNumber = &trackingNumber
+	}
+	c.ShippedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// MarkDelivered registra la entrega del premio y cierra el reclamo
+// Se permite desde pending_delivery para entregas en persona sin envío
+func (c *PrizeClaim) MarkDelivered(adminID int64, notes string) error {
+	if c.Status != PrizeClaimStatusShipped && c.Status != PrizeClaimStatusPendingDelivery {
+		return fmt.Errorf("solo se pueden entregar premios con identidad confirmada (estado actual: %s)", c.Status)
+	}
+
+	now := time.Now()
+	c.Status = PrizeClaimStatusDelivered
+	c.DeliveredAt = &now
+	c.resolve(adminID, notes, now)
+	return nil
+}
+
+// Cancel cancela un reclamo abierto
+func (c *PrizeClaim) Cancel(adminID int64, notes string) error {
+	if !c.IsOpen() {
+		return fmt.Errorf("solo se pueden cancelar reclamos abiertos (estado actual: %s)", c.Status)
+	}
+	if notes == "" {
+		return fmt.Errorf("la razón de cancelación es requerida")
+	}
+
+	c.Status = PrizeClaimStatusCancelled
+	c.resolve(adminID, notes, time.Now())
+	return nil
+}
+
+// Expire cierra un reclamo cuyo plazo venció sin confirmación de identidad
+func (c *PrizeClaim) Expire(now time.Time) error {
+	if !c.IsOverdue(now) {
+		return fmt.Errorf("el reclamo no está vencido")
+	}
+
+	c.Status = PrizeClaimStatusExpired
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+func (c *PrizeClaim) resolve(adminID int64, notes string, now time.Time) {
+	c.ResolvedBy = &adminID
+	if notes != "" {
+		c.ResolutionNotes = &notes
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// PrizeClaimFilters filtros para listar reclamos
+type PrizeClaimFilters struct {
+	Status       *PrizeClaimStatus
+	PrizeType    *PrizeType
+	OnlyOpen     bool
+	RaffleID     *int64
+	WinnerUserID *int64
+}
+
+// PrizeClaimRepository define el contrato para el repositorio de reclamos de premios
+type PrizeClaimRepository interface {
+	// Create crea un nuevo reclamo
+	Create(claim *PrizeClaim) error
+
+	// FindByID busca un reclamo por ID
+	FindByID(id int64) (*PrizeClaim, error)
+
+	// FindByRaffleID lista los reclamos de un sorteo ordenados por posición del premio
+	FindByRaffleID(raffleID int64) ([]*PrizeClaim, error)
+
+	// List lista reclamos con filtros (paginado)
+	List(filters PrizeClaimFilters, limit, offset int) ([]*PrizeClaim, int64, error)
+
+	// FindOverdue busca reclamos pendientes de identidad con plazo vencido
+	FindOverdue(now time.Time, limit int) ([]*PrizeClaim, error)
+
+	// SumCashPrizes suma los premios en efectivo que retiene la liquidación de un sorteo: pagados, con
+	// ganador aún sin acreditar y el premio mayor acumulado a la serie, sin el acumulado recibido
+	SumCashPrizes(raffleID int64) (decimal.Decimal, error)
+
+	// Update actualiza un reclamo existente
+	Update(claim *PrizeClaim) error
+}
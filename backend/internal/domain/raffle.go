@@ -29,6 +29,58 @@ const (
 	DrawMethodRandom           DrawMethod = "random"
 )
 
+// PrizeType representa el tipo de premio de un sorteo
+type PrizeType string
+
+const (
+	PrizeTypeCash     PrizeType = "cash"     // Se acredita al saldo de ganancias del ganador
+	PrizeTypePhysical PrizeType = "physical" // Requiere reclamo, verificación de identidad y entrega
+)
+
+// UnsoldWinnerPolicy regla a aplicar cuando el número oficial de la Lotería Nacional no fue vendido
+type UnsoldWinnerPolicy string
+
+const (
+	UnsoldWinnerPolicyRedraw      UnsoldWinnerPolicy = "redraw"       // Sorteo verificable entre los números vendidos
+	UnsoldWinnerPolicyNearestSold UnsoldWinnerPolicy = "nearest_sold" // Número vendido más cercano al número oficial
+	UnsoldWinnerPolicyRollover    UnsoldWinnerPolicy = "rollover"     // El premio mayor se acumula al siguiente sorteo de la serie
+)
+
+// IsValid verifica que la regla sea una de las soportadas
+func (p UnsoldWinnerPolicy) IsValid() bool {
+	switch p {
+	case UnsoldWinnerPolicyRedraw, UnsoldWinnerPolicyNearestSold, UnsoldWinnerPolicyRollover:
+		return true
+	}
+	return false
+}
+
+// LotteryFallback regla de respaldo equivalente al resolver el número oficial
+// redraw y rollover no eligen otro número: el sorteo continúa sin ganador del número oficial
+func (p UnsoldWinnerPolicy) LotteryFallback() LotteryFallback {
+	if p == UnsoldWinnerPolicyNearestSold {
+		return LotteryFallbackNearestSold
+	}
+	return LotteryFallbackManual
+}
+
+// ValidateUnsoldWinnerPolicy valida la regla para el método de sorteo y el tipo del premio mayor
+func ValidateUnsoldWinnerPolicy(policy *UnsoldWinnerPolicy, drawMethod DrawMethod, prizeType PrizeType) error {
+	if policy == nil {
+		return nil
+	}
+	if !policy.IsValid() {
+		return fmt.Errorf("regla de número no vendido inválida: %s", *policy)
+	}
+	if drawMethod != DrawMethodLoteriaCostaRica {
+		return fmt.Errorf("la regla de número no vendido solo aplica a sorteos con la Lotería Nacional")
+	}
+	if *policy == UnsoldWinnerPolicyRollover && prizeType != PrizeTypeCash {
+		return fmt.Errorf("solo un premio mayor en efectivo se puede acumular al siguiente sorteo")
+	}
+	return nil
+}
+
 // RaffleSettlementStatus representa el estado de liquidación de una rifa
 // DEPRECATED: Use Settlement entity instead
 type RaffleSettlementStatus string
@@ -40,6 +92,36 @@ const (
 	RaffleSettlementStatusFailed     RaffleSettlementStatus = "failed"
 )
 
+const (
+	// DefaultMinSalesCutoffHours horas antes del sorteo en que se evalúa el mínimo de ventas por defecto
+	DefaultMinSalesCutoffHours = 24
+	// MaxMinSalesCutoffHours máximo de horas de anticipación del corte del mínimo de ventas
+	MaxMinSalesCutoffHours = 168
+	// MaxMinSalesExtensionDays máximo de días que se puede posponer un sorteo por ventas insuficientes
+	MaxMinSalesExtensionDays = 30
+)
+
+// DrawPendingReason motivo por el que un sorteo vencido no se pudo completar automáticamente
+type DrawPendingReason string
+
+const (
+	DrawPendingLotteryResult  DrawPendingReason = "lottery_result_pending" // Resultado oficial aún no publicado o confirmado (se reintenta)
+	DrawPendingUnsoldNumber   DrawPendingReason = "lottery_number_unsold"  // Número oficial no vendido sin regla automática
+	DrawPendingManualRequired DrawPendingReason = "manual_draw_required"   // Sorteo manual o sin fuente de resultados
+)
+
+// RequiresAdmin indica si solo un sorteo manual del admin puede completar el sorteo
+func (r DrawPendingReason) RequiresAdmin() bool {
+	return r != DrawPendingLotteryResult
+}
+
+const (
+	// DrawRetryBaseDelay espera tras el primer intento fallido del sorteo automático
+	DrawRetryBaseDelay = time.Minute
+	// DrawRetryMaxDelay espera máxima entre intentos del sorteo automático
+	DrawRetryMaxDelay = time.Hour
+)
+
 // Raffle representa un sorteo/rifa en el sistema
 type Raffle struct {
 	ID   int64
@@ -61,13 +143,62 @@ type Raffle struct {
 	MaxNumber           int
 
 	// Draw info
-	DrawDate   time.Time
-	DrawMethod DrawMethod
-
-	// Winner info
+	DrawDate      time.Time
+	DrawMethod    DrawMethod
+	SalesClosedAt *time.Time // Cierre de ventas previo al sorteo
+
+	// Reintentos del sorteo automático con backoff (no bloquean a los sorteos vencidos más recientes)
+	DrawAttempts      int
+	DrawNextAttemptAt *time.Time
+
+	// Sorteo vencido pendiente: visible para admins en el listado de sorteos pendientes
+	DrawPendingReason *DrawPendingReason
+	DrawPendingSince  *time.Time
+
+	// Minimum sales: si no se vende MinSoldCount antes del corte se pospone una vez o se cancela
+	MinSoldCount          *int
+	MinSalesCutoffHours   int        // Horas antes de DrawDate en que se evalúa el mínimo
+	MinSalesExtensionDays *int       // Días a posponer el sorteo (una sola vez); nil cancela directamente
+	MinSalesExtendedAt    *time.Time // Momento en que se pospuso el sorteo por ventas insuficientes
+
+	// Serie recurrente que generó el sorteo (nil si se creó manualmente)
+	SeriesID       *int64
+	SeriesSequence *int
+
+	// Prize info (premio mayor; los premios ordenados están en raffle_prizes)
+	PrizeType        PrizeType
+	PrizeAmount      *decimal.Decimal // Solo premios en efectivo
+	PrizeDescription *string
+
+	// Winner info (ganador del premio mayor; los ganadores por premio están en raffle_winners)
 	WinnerNumber *string
 	WinnerUserID *int64
 
+	// Premios y ganadores (no persistidos en raffles, se cargan desde sus tablas)
+	Prizes  []*RafflePrize  `gorm:"-"`
+	Winners []*RaffleWinner `gorm:"-"`
+
+	// Provably fair draw (commit-reveal)
+	// DrawServerSeed es secreto hasta que se ejecuta el sorteo; solo su hash es público
+	DrawSeedHash        *string
+	DrawServerSeed      *string `json:"-"`
+	DrawSeedCommittedAt *time.Time
+	DrawBeaconRound     *int64 // Ronda del beacon público comprometida como entropía (posterior al cierre de ventas)
+	DrawProof           datatypes.JSON
+
+	// Lotería Nacional: resultado oficial utilizado para el sorteo
+	LotteryResultID *int64
+
+	// Lotería Nacional: regla si el número oficial no fue vendido (nil: regla general de la plataforma)
+	UnsoldWinnerPolicy  *UnsoldWinnerPolicy
+	UnsoldPolicyApplied *UnsoldWinnerPolicy // Regla aplicada en el sorteo (nil si el número oficial se vendió)
+	LotteryMappedNumber *int                // Número oficial llevado al rango del sorteo
+
+	// Premio mayor acumulado entre sorteos de una serie
+	RolloverAmount         *decimal.Decimal // Recibido de sorteos anteriores (incluido en PrizeAmount)
+	RolledOverFromRaffleID *int64
+	RolledOverToRaffleID   *int64 // nil mientras el acumulado espera el siguiente sorteo de la serie
+
 	// Counters
 	SoldCount     int
 	ReservedCount int
@@ -112,6 +243,8 @@ func NewRaffle(userID int64, title string, pricePerNumber decimal.Decimal, total
 		MaxNumber:             totalNumbers - 1,
 		DrawDate:              drawDate,
 		DrawMethod:            DrawMethodLoteriaCostaRica,
+		PrizeType:             PrizeTypePhysical,
+		MinSalesCutoffHours:   DefaultMinSalesCutoffHours,
 		SoldCount:             0,
 		ReservedCount:         0,
 		TotalRevenue:          decimal.Zero,
@@ -171,6 +304,37 @@ func (r *Raffle) Validate() error {
 		return fmt.Errorf("método de sorteo inválido")
 	}
 
+	// Prize validation
+	if r.PrizeType != PrizeTypeCash && r.PrizeType != PrizeTypePhysical {
+		return fmt.Errorf("tipo de premio inválido")
+	}
+	if r.PrizeType == PrizeTypeCash && (r.PrizeAmount == nil || r.PrizeAmount.LessThanOrEqual(decimal.Zero)) {
+		return fmt.Errorf("el monto del premio en efectivo debe ser mayor a 0")
+	}
+
+	// Minimum sales validation
+	if r.MinSoldCount != nil {
+		if *r.MinSoldCount <= 0 || *r.MinSoldCount > r.TotalNumbers {
+			return fmt.Errorf("el mínimo de números vendidos debe estar entre 1 y el total de números")
+		}
+		if r.MinSalesCutoffHours < 0 || r.MinSalesCutoffHours > MaxMinSalesCutoffHours {
+			return fmt.Errorf("el corte del mínimo de ventas debe estar entre 0 y %d horas antes del sorteo", MaxMinSalesCutoffHours)
+		}
+		if r.MinSalesExtensionDays != nil && (*r.MinSalesExtensionDays <= 0 || *r.MinSalesExtensionDays > MaxMinSalesExtensionDays) {
+			return fmt.Errorf("la extensión por ventas insuficientes debe estar entre 1 y %d días", MaxMinSalesExtensionDays)
+		}
+	} else if r.MinSalesExtensionDays != nil {
+		return fmt.Errorf("la extensión por ventas insuficientes requiere un mínimo de números vendidos")
+	}
+
+	// Unsold winner policy validation
+	if err := ValidateUnsoldWinnerPolicy(r.UnsoldWinnerPolicy, r.DrawMethod, r.PrizeType); err != nil {
+		return err
+	}
+	if r.UnsoldWinnerPolicy != nil && *r.UnsoldWinnerPolicy == UnsoldWinnerPolicyRollover && r.SeriesID == nil {
+		return fmt.Errorf("la acumulación del premio mayor solo aplica a sorteos de una serie")
+	}
+
 	// Counters validation
 	if r.SoldCount < 0 || r.SoldCount > r.TotalNumbers {
 		return fmt.Errorf("el contador de vendidos es inválido")
@@ -207,6 +371,11 @@ func (r *Raffle) Publish() error {
 		return fmt.Errorf("la fecha del sorteo debe ser al menos 24 horas en el futuro (fecha mínima: %s)", minDrawDate.Format("2006-01-02 15:04"))
 	}
 
+	// Publicar el compromiso de la semilla del sorteo
+	if err := r.CommitDrawSeed(); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	r.Status = RaffleStatusActive
 	r.PublishedAt = &now
@@ -215,6 +384,180 @@ func (r *Raffle) Publish() error {
 	return nil
 }
 
+// CommitDrawSeed genera la semilla secreta del sorteo, publica su hash y compromete la ronda del beacon
+// Si la rifa ya tiene una semilla comprometida no se reemplaza
+func (r *Raffle) CommitDrawSeed() error {
+	if r.DrawSeedHash != nil && r.DrawServerSeed != nil {
+		return nil
+	}
+
+	seed, seedHash, err := GenerateDrawSeed()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	r.DrawServerSeed = &seed
+	r.DrawSeedHash = &seedHash
+	r.DrawSeedCommittedAt = &now
+	r.scheduleDrawBeacon(now)
+
+	return nil
+}
+
+// scheduleDrawBeacon compromete la primera ronda del beacon emitida al cerrar las ventas
+// Nunca se compromete una ronda anterior al momento del compromiso, cuya aleatoriedad ya sería pública
+func (r *Raffle) scheduleDrawBeacon(now time.Time) {
+	at := r.DrawDate
+	if at.Before(now) {
+		at = now
+	}
+	round := DrawBeaconRoundAt(at)
+	r.DrawBeaconRound = &round
+}
+
+// DrawEntropyRound ronda del beacon cuya aleatoriedad es la entropía pública del sorteo
+// Las rifas publicadas antes del beacon usan la ronda de su fecha de sorteo, posterior a su compromiso
+func (r *Raffle) DrawEntropyRound() int64 {
+	if r.DrawBeaconRound != nil {
+		return *r.DrawBeaconRound
+	}
+	return DrawBeaconRoundAt(r.DrawDate)
+}
+
+// RescheduleDraw cambia la fecha del sorteo antes del cierre de ventas y mueve la ronda comprometida del beacon
+func (r *Raffle) RescheduleDraw(drawDate time.Time) error {
+	now := time.Now()
+	if r.IsSalesClosed() {
+		return fmt.Errorf("no se puede cambiar la fecha de un sorteo con las ventas cerradas")
+	}
+	if !drawDate.After(now) {
+		return fmt.Errorf("la fecha del sorteo debe ser en el futuro")
+	}
+
+	r.DrawDate = drawDate
+	if r.DrawSeedHash != nil {
+		r.scheduleDrawBeacon(now)
+	}
+	r.UpdatedAt = now
+
+	return nil
+}
+
+// HasDrawProof verifica si la rifa tiene una prueba de sorteo registrada
+func (r *Raffle) HasDrawProof() bool {
+	return len(r.DrawProof) > 0
+}
+
+// IsSalesClosed verifica si el sorteo ya no acepta nuevas reservas
+// Las ventas se cierran al llegar la fecha del sorteo aunque el job aún no lo haya registrado
+func (r *Raffle) IsSalesClosed() bool {
+	return r.SalesClosedAt != nil || !r.DrawDate.After(time.Now())
+}
+
+// HasMinimumSales verifica si el sorteo tiene configurado un mínimo de números vendidos
+func (r *Raffle) HasMinimumSales() bool {
+	return r.MinSoldCount != nil
+}
+
+// MinimumSalesMet verifica si el sorteo alcanzó su mínimo de números vendidos (o no tiene mínimo)
+// soldCount debe contarse en raffle_numbers: el contador sold_count del sorteo puede estar desactualizado
+func (r *Raffle) MinimumSalesMet(soldCount int64) bool {
+	return r.MinSoldCount == nil || soldCount >= int64(*r.MinSoldCount)
+}
+
+// MinimumSalesCutoff momento en que se evalúa el mínimo de ventas
+func (r *Raffle) MinimumSalesCutoff() time.Time {
+	return r.DrawDate.Add(-time.Duration(r.MinSalesCutoffHours) * time.Hour)
+}
+
+// IsMinimumSalesDue verifica si el sorteo activo llegó a su corte sin alcanzar el mínimo de ventas
+func (r *Raffle) IsMinimumSalesDue(now time.Time, soldCount int64) bool {
+	return r.IsActive() &&
+		r.WinnerNumber == nil &&
+		!r.MinimumSalesMet(soldCount) &&
+		!now.Before(r.MinimumSalesCutoff())
+}
+
+// CanExtendForMinimumSales verifica si el sorteo aún puede posponerse por ventas insuficientes
+func (r *Raffle) CanExtendForMinimumSales() bool {
+	return r.MinSalesExtensionDays != nil && r.MinSalesExtendedAt == nil
+}
+
+// ExtendForMinimumSales pospone el sorteo los días configurados y reabre las ventas
+func (r *Raffle) ExtendForMinimumSales(now time.Time) error {
+	if !r.CanExtendForMinimumSales() {
+		return fmt.Errorf("el sorteo no puede posponerse por ventas insuficientes")
+	}
+
+	r.DrawDate = r.DrawDate.AddDate(0, 0, *r.MinSalesExtensionDays)
+	if r.DrawDate.Before(now) {
+		r.DrawDate = now.AddDate(0, 0, *r.MinSalesExtensionDays)
+	}
+	r.MinSalesExtendedAt = &now
+	r.SalesClosedAt = nil
+	r.DrawAttempts = 0
+	r.DrawNextAttemptAt = nil
+	r.UpdatedAt = now
+
+	// La ronda comprometida ya pudo publicarse: se compromete la del nuevo cierre de ventas
+	if r.DrawSeedHash != nil {
+		r.scheduleDrawBeacon(now)
+	}
+
+	return nil
+}
+
+// IsDueForDraw verifica si el sorteo activo alcanzó su fecha de sorteo
+func (r *Raffle) IsDueForDraw() bool {
+	return r.IsActive() && r.WinnerNumber == nil && !r.DrawDate.After(time.Now())
+}
+
+// DeferDraw pospone el siguiente intento del sorteo automático con backoff exponencial
+func (r *Raffle) DeferDraw(now time.Time) {
+	delay := DrawRetryBaseDelay
+	for i := 0; i < r.DrawAttempts && delay < DrawRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > DrawRetryMaxDelay {
+		delay = DrawRetryMaxDelay
+	}
+
+	next := now.Add(delay)
+	r.DrawAttempts++
+	r.DrawNextAttemptAt = &next
+}
+
+// MarkDrawPending registra por qué el sorteo vencido no se completó
+// Retorna true si el motivo cambió (el sorteo recién quedó pendiente por este motivo)
+func (r *Raffle) MarkDrawPending(reason DrawPendingReason, now time.Time) bool {
+	if r.DrawPendingReason != nil && *r.DrawPendingReason == reason {
+		return false
+	}
+
+	r.DrawPendingReason = &reason
+	if r.DrawPendingSince == nil {
+		r.DrawPendingSince = &now
+	}
+	return true
+}
+
+// CloseSales cierra las ventas del sorteo antes de ejecutar el sorteo
+func (r *Raffle) CloseSales() error {
+	if r.Status != RaffleStatusActive {
+		return fmt.Errorf("solo se pueden cerrar las ventas de sorteos activos")
+	}
+	if r.SalesClosedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	r.SalesClosedAt = &now
+	r.UpdatedAt = now
+
+	return nil
+}
+
 // Suspend suspende el sorteo
 func (r *Raffle) Suspend() error {
 	if r.Status != RaffleStatusActive {
@@ -244,22 +587,124 @@ func (r *Raffle) Activate() error {
 	return nil
 }
 
-// Complete marca el sorteo como completado
-func (r *Raffle) Complete(winnerNumber string, winnerUserID *int64) error {
+// Complete marca el sorteo como completado con un ganador por premio
+// WinnerNumber/WinnerUserID conservan el ganador del premio mayor
+func (r *Raffle) Complete(winners []*RaffleWinner) error {
 	if r.Status != RaffleStatusActive && r.Status != RaffleStatusSuspended {
 		return fmt.Errorf("solo se pueden completar sorteos activos o suspendidos")
 	}
+	if len(winners) == 0 {
+		return fmt.Errorf("el sorteo debe tener al menos un ganador")
+	}
+
+	seen := make(map[string]bool, len(winners))
+	var first *RaffleWinner
+	for _, winner := range winners {
+		if seen[winner.Number] {
+			return fmt.Errorf("el número %s no puede ganar más de un premio", winner.Number)
+		}
+		seen[winner.Number] = true
+		if winner.Position == 1 {
+			first = winner
+		}
+	}
+	if first == nil {
+		return fmt.Errorf("el premio mayor requiere un ganador")
+	}
 
 	now := time.Now()
 	r.Status = RaffleStatusCompleted
-	r.WinnerNumber = &winnerNumber
-	r.WinnerUserID = winnerUserID
+	r.WinnerNumber = &first.Number
+	r.WinnerUserID = first.UserID
+	r.Winners = winners
 	r.CompletedAt = &now
 	r.UpdatedAt = now
 
 	return nil
 }
 
+// CompleteWithRollover marca el sorteo como completado sin ganador del premio mayor, que se acumula
+// al siguiente sorteo de la serie; los premios secundarios sí tienen ganador
+func (r *Raffle) CompleteWithRollover(winners []*RaffleWinner) error {
+	if r.Status != RaffleStatusActive && r.Status != RaffleStatusSuspended {
+		return fmt.Errorf("solo se pueden completar sorteos activos o suspendidos")
+	}
+	if r.SeriesID == nil || !r.HasCashPrize() {
+		return fmt.Errorf("solo el premio mayor en efectivo de un sorteo de una serie se puede acumular")
+	}
+
+	seen := make(map[string]bool, len(winners))
+	for _, winner := range winners {
+		if winner.Position == 1 {
+			return fmt.Errorf("el premio mayor acumulado no puede tener ganador")
+		}
+		if seen[winner.Number] {
+			return fmt.Errorf("el número %s no puede ganar más de un premio", winner.Number)
+		}
+		seen[winner.Number] = true
+	}
+
+	now := time.Now()
+	applied := UnsoldWinnerPolicyRollover
+	r.Status = RaffleStatusCompleted
+	r.UnsoldPolicyApplied = &applied
+	r.Winners = winners
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+
+	return nil
+}
+
+// IsJackpotRolledOver verifica si el premio mayor del sorteo se acumuló al siguiente sorteo de la serie
+func (r *Raffle) IsJackpotRolledOver() bool {
+	return r.UnsoldPolicyApplied != nil && *r.UnsoldPolicyApplied == UnsoldWinnerPolicyRollover
+}
+
+// WithoutRollover descuenta de un monto del premio mayor el acumulado recibido de sorteos anteriores de la serie
+func (r *Raffle) WithoutRollover(amount *decimal.Decimal) *decimal.Decimal {
+	if amount == nil || r.RolloverAmount == nil {
+		return amount
+	}
+	base := amount.Sub(*r.RolloverAmount)
+	return &base
+}
+
+// SetPrizes asigna los premios ordenados y refleja el premio mayor en los campos del sorteo
+func (r *Raffle) SetPrizes(prizes []*RafflePrize) {
+	r.Prizes = prizes
+	if len(prizes) == 0 {
+		return
+	}
+
+	first := prizes[0]
+	r.PrizeType = first.PrizeType
+	r.PrizeAmount = nil
+	if first.PrizeType == PrizeTypeCash {
+		r.PrizeAmount = first.Value
+	}
+	description := first.Description
+	r.PrizeDescription = &description
+}
+
+// DefaultPrize construye el premio único de los sorteos creados sin lista de premios
+func (r *Raffle) DefaultPrize() *RafflePrize {
+	description := r.Title
+	if r.PrizeDescription != nil && *r.PrizeDescription != "" {
+		description = *r.PrizeDescription
+	}
+	prize := NewRafflePrize(1, description, r.PrizeType, r.PrizeAmount, nil)
+	prize.RaffleID = r.ID
+	return prize
+}
+
+// PrizeCount cantidad de premios a sortear (al menos el premio mayor)
+func (r *Raffle) PrizeCount() int {
+	if len(r.Prizes) == 0 {
+		return 1
+	}
+	return len(r.Prizes)
+}
+
 // Cancel cancela el sorteo
 func (r *Raffle) Cancel() error {
 	if r.Status == RaffleStatusCompleted {
@@ -280,6 +725,11 @@ func (r *Raffle) IsActive() bool {
 	return r.Status == RaffleStatusActive && r.DeletedAt == nil
 }
 
+// HasCashPrize verifica si el premio se paga en efectivo a la billetera del ganador
+func (r *Raffle) HasCashPrize() bool {
+	return r.PrizeType == PrizeTypeCash && r.PrizeAmount != nil && r.PrizeAmount.GreaterThan(decimal.Zero)
+}
+
 // IsDraft verifica si el sorteo está en borrador
 func (r *Raffle) IsDraft() bool {
 	return r.Status == RaffleStatusDraft
@@ -311,9 +761,10 @@ func (r *Raffle) CanBeEdited() bool {
 	return (r.IsDraft() || (r.IsActive() && r.SoldCount == 0)) && r.DeletedAt == nil
 }
 
-// CalculateRevenue calcula los ingresos del sorteo (se ejecuta automáticamente en la DB)
-func (r *Raffle) CalculateRevenue() {
-	r.TotalRevenue = r.PricePerNumber.Mul(decimal.NewFromInt(int64(r.SoldCount)))
+// CalculateRevenue calcula los ingresos del sorteo a partir de lo efectivamente pagado
+// paidAmount ya incluye los descuentos de las reglas de precio y excluye los reembolsos
+func (r *Raffle) CalculateRevenue(paidAmount decimal.Decimal) {
+	r.TotalRevenue = paidAmount.Round(2)
 	r.PlatformFeeAmount = r.TotalRevenue.Mul(r.PlatformFeePercentage.Div(decimal.NewFromInt(100)))
 	r.NetAmount = r.TotalRevenue.Sub(r.PlatformFeeAmount)
 }
@@ -322,7 +773,7 @@ func (r *Raffle) CalculateRevenue() {
 func (r *Raffle) CanBeSettled() bool {
 	return r.IsCompleted() &&
 		r.SettlementStatus == RaffleSettlementStatusPending &&
-		r.WinnerNumber != nil
+		(r.WinnerNumber != nil || r.IsJackpotRolledOver())
 }
 
 // MarkAsSettled marca el sorteo como liquidado
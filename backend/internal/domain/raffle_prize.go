@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxRafflePrizes máximo de premios por sorteo
+const MaxRafflePrizes = 10
+
+// RafflePrize premio de un sorteo
+// Position 1 es el premio mayor; cada premio se sortea con un número ganador distinto
+type RafflePrize struct {
+	ID          int64            `json:"id" gorm:"primaryKey"`
+	RaffleID    int64            `json:"raffle_id" gorm:"not null"`
+	Position    int              `json:"position" gorm:"not null"`
+	Description string           `json:"description" gorm:"not null"`
+	PrizeType   PrizeType        `json:"prize_type" gorm:"type:varchar(20);not null"`
+	Value       *decimal.Decimal `json:"value,omitempty" gorm:"type:decimal(12,2)"` // Monto en efectivo o valor estimado del premio físico
+	ImageURL    *string          `json:"image_url,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// TableName especifica el nombre de la tabla
+func (RafflePrize) TableName() string {
+	return "raffle_prizes"
+}
+
+// NewRafflePrize crea un premio en la posición indicada
+func NewRafflePrize(position int, description string, prizeType PrizeType, value *decimal.Decimal, imageURL *string) *RafflePrize {
+	now := time.Now()
+	return &RafflePrize{
+		Position:    position,
+		Description: description,
+		PrizeType:   prizeType,
+		Value:       value,
+		ImageURL:    imageURL,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate valida el premio
+func (p *RafflePrize) Validate() error {
+	if p.Position < 1 || p.Position > MaxRafflePrizes {
+		return fmt.Errorf("la posición del premio debe estar entre 1 y %d", MaxRafflePrizes)
+	}
+	if p.Description == "" {
+		return fmt.Errorf("la descripción del premio %d es requerida", p.Position)
+	}
+	if p.PrizeType != PrizeTypeCash && p.PrizeType != PrizeTypePhysical {
+		return fmt.Errorf("tipo de premio inválido: %s", p.PrizeType)
+	}
+	if p.PrizeType == PrizeTypeCash && (p.Value == nil || p.Value.LessThanOrEqual(decimal.Zero)) {
+		return fmt.Errorf("el monto del premio %d en efectivo debe ser mayor a 0", p.Position)
+	}
+	if p.Value != nil && p.Value.LessThan(decimal.Zero) {
+		return fmt.Errorf("el valor del premio %d no puede ser negativo", p.Position)
+	}
+	return nil
+}
+
+// IsCash verifica si el premio se paga en efectivo a la billetera del ganador
+func (p *RafflePrize) IsCash() bool {
+	return p.PrizeType == PrizeTypeCash && p.Value != nil && p.Value.GreaterThan(decimal.Zero)
+}
+
+// ValidateRafflePrizes valida la lista ordenada de premios de un sorteo
+// Las posiciones deben ser consecutivas desde 1 y no puede haber más premios que números
+func ValidateRafflePrizes(prizes []*RafflePrize, totalNumbers int) error {
+	if len(prizes) == 0 {
+		return fmt.Errorf("el sorteo debe tener al menos un premio")
+	}
+	if len(prizes) > MaxRafflePrizes {
+		return fmt.Errorf("el sorteo no puede tener más de %d premios", MaxRafflePrizes)
+	}
+	if len(prizes) > totalNumbers {
+		return fmt.Errorf("el sorteo no puede tener más premios que números")
+	}
+
+	for i, prize := range prizes {
+		if prize.Position != i+1 {
+			return fmt.Errorf("las posiciones de los premios deben ser consecutivas desde 1")
+		}
+		if err := prize.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RaffleWinner número ganador de un premio
+// Cada premio tiene a lo sumo un ganador y un número no puede ganar dos premios del mismo sorteo
+type RaffleWinner struct {
+	ID       int64     `json:"id" gorm:"primaryKey"`
+	RaffleID int64     `json:"raffle_id" gorm:"not null"`
+	PrizeID  int64     `json:"prize_id" gorm:"not null"`
+	Position int       `json:"position" gorm:"not null"`
+	Number   string    `json:"number" gorm:"not null"`
+	UserID   *int64    `json:"user_id,omitempty"`
+	DrawnAt  time.Time `json:"drawn_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Premio asociado (no persistido, se carga junto al ganador)
+	Prize *RafflePrize `json:"prize,omitempty" gorm:"-"`
+}
+
+// TableName especifica el nombre de la tabla
+func (RaffleWinner) TableName() string {
+	return "raffle_winners"
+}
+
+// NewRaffleWinner crea el registro del ganador de un premio
+func NewRaffleWinner(raffleID int64, prize *RafflePrize, number string, userID *int64) *RaffleWinner {
+	now := time.Now()
+	return &RaffleWinner{
+		RaffleID:  raffleID,
+		PrizeID:   prize.ID,
+		Position:  prize.Position,
+		Number:    number,
+		UserID:    userID,
+		DrawnAt:   now,
+		CreatedAt: now,
+		Prize:     prize,
+	}
+}
+
+// RafflePrizeRepository define el contrato para el repositorio de premios y ganadores
+type RafflePrizeRepository interface {
+	// ReplaceForRaffle reemplaza los premios de un sorteo (solo antes del sorteo)
+	ReplaceForRaffle(raffleID int64, prizes []*RafflePrize) error
+
+	// FindByRaffleID lista los premios de un sorteo ordenados por posición
+	FindByRaffleID(raffleID int64) ([]*RafflePrize, error)
+
+	// FindByRaffleIDs lista los premios de varios sorteos agrupados por sorteo
+	FindByRaffleIDs(raffleIDs []int64) (map[int64][]*RafflePrize, error)
+
+	// CreateWinners registra los ganadores de un sorteo
+	CreateWinners(winners []*RaffleWinner) error
+
+	// FindWinnersByRaffleID lista los ganadores de un sorteo ordenados por posición
+	FindWinnersByRaffleID(raffleID int64) ([]*RaffleWinner, error)
+
+	// FindWinnersByRaffleIDs lista los ganadores de varios sorteos agrupados por sorteo
+	FindWinnersByRaffleIDs(raffleIDs []int64) (map[int64][]*RaffleWinner, error)
+}
@@ -0,0 +1,303 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RaffleSeriesMode forma en que la serie genera el siguiente sorteo
+type RaffleSeriesMode string
+
+const (
+	RaffleSeriesModeOnCompletion RaffleSeriesMode = "on_completion" // Al completarse el sorteo anterior
+	RaffleSeriesModeCron         RaffleSeriesMode = "cron"          // Según la expresión cron en la zona horaria de la serie
+)
+
+// RaffleSeriesStatus estado de la serie
+type RaffleSeriesStatus string
+
+const (
+	RaffleSeriesStatusActive RaffleSeriesStatus = "active"
+	RaffleSeriesStatusPaused RaffleSeriesStatus = "paused"
+	RaffleSeriesStatusEnded  RaffleSeriesStatus = "ended"
+)
+
+const (
+	// MinSeriesDrawOffsetHours horas mínimas entre la generación y el sorteo (publicar exige 24h de anticipación)
+	MinSeriesDrawOffsetHours = 25
+	// MaxSeriesDrawOffsetHours horas máximas entre la generación y el sorteo (90 días)
+	MaxSeriesDrawOffsetHours = 2160
+	// MinSeriesCronInterval intervalo mínimo entre dos sorteos de una serie con cron
+	MinSeriesCronInterval = 24 * time.Hour
+)
+
+// RaffleSeries serie de sorteos recurrentes generados desde una plantilla
+type RaffleSeries struct {
+	ID         int64     `json:"id" gorm:"primaryKey"`
+	UUID       uuid.UUID `json:"uuid" gorm:"type:uuid;not null"`
+	UserID     int64     `json:"user_id" gorm:"not null"`
+	TemplateID int64     `json:"template_id" gorm:"not null"`
+	Name       string    `json:"name" gorm:"not null"`
+
+	// Recurrencia
+	Mode            RaffleSeriesMode `json:"mode" gorm:"type:varchar(20);not null"`
+	CronExpression  *string          `json:"cron_expression,omitempty"`
+	Timezone        string           `json:"timezone" gorm:"not null"`
+	DrawOffsetHours int              `json:"draw_offset_hours" gorm:"not null"`
+	AutoPublish     bool             `json:"auto_publish" gorm:"not null"`
+	MaxOccurrences  *int             `json:"max_occurrences,omitempty"` // nil = sin límite
+
+	// Estado
+	Status       RaffleSeriesStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Occurrences  int                `json:"occurrences" gorm:"not null"` // Sorteos generados (secuencia del último)
+	NextRunAt    *time.Time         `json:"next_run_at,omitempty"`       // Solo cron
+	LastRaffleID *int64             `json:"last_raffle_id,omitempty"`
+	LastRunAt    *time.Time         `json:"last_run_at,omitempty"`
+	LastError    *string            `json:"last_error,omitempty"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// TableName especifica el nombre de la tabla
+func (RaffleSeries) TableName() string {
+	return "raffle_series"
+}
+
+// NewRaffleSeries crea una serie activa a partir de una plantilla
+func NewRaffleSeries(userID, templateID int64, name string, mode RaffleSeriesMode) *RaffleSeries {
+	now := time.Now()
+	return &RaffleSeries{
+		UUID:        uuid.New(),
+		UserID:      userID,
+		TemplateID:  templateID,
+		Name:        name,
+		Mode:        mode,
+		Timezone:    LotteryTimezone,
+		AutoPublish: true,
+		Status:      RaffleSeriesStatusActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate valida la configuración de la serie
+func (s *RaffleSeries) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("el nombre de la serie es requerido")
+	}
+	if len(s.Name) > 100 {
+		return fmt.Errorf("el nombre de la serie no puede exceder 100 caracteres")
+	}
+	if s.DrawOffsetHours < MinSeriesDrawOffsetHours || s.DrawOffsetHours > MaxSeriesDrawOffsetHours {
+		return fmt.Errorf("las horas hasta el sorteo deben estar entre %d y %d", MinSeriesDrawOffsetHours, MaxSeriesDrawOffsetHours)
+	}
+	if s.MaxOccurrences != nil && *s.MaxOccurrences <= 0 {
+		return fmt.Errorf("el máximo de sorteos de la serie debe ser mayor a 0")
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return fmt.Errorf("zona horaria inválida: %s", s.Timezone)
+	}
+
+	switch s.Mode {
+	case RaffleSeriesModeOnCompletion:
+		if s.CronExpression != nil {
+			return fmt.Errorf("las series al completarse el sorteo anterior no admiten expresión cron")
+		}
+	case RaffleSeriesModeCron:
+		if s.CronExpression == nil {
+			return fmt.Errorf("la expresión cron es requerida")
+		}
+		schedule, err := ParseCronSchedule(*s.CronExpression)
+		if err != nil {
+			return err
+		}
+		if schedule.Next(time.Now(), loc).IsZero() {
+			return fmt.Errorf("la expresión cron no tiene ejecuciones próximas")
+		}
+		if interval := schedule.MinInterval(time.Now(), loc, 10); interval < MinSeriesCronInterval {
+			return fmt.Errorf("la serie no puede generar más de un sorteo cada %d horas", int(MinSeriesCronInterval.Hours()))
+		}
+	default:
+		return fmt.Errorf("modo de serie inválido: %s", s.Mode)
+	}
+
+	return nil
+}
+
+// Location retorna la zona horaria de la serie
+func (s *RaffleSeries) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ScheduleNextRun calcula la próxima ejecución de una serie con cron posterior a after
+// Las series al completarse no tienen próxima ejecución programada
+func (s *RaffleSeries) ScheduleNextRun(after time.Time) error {
+	if s.Mode != RaffleSeriesModeCron || s.Status != RaffleSeriesStatusActive {
+		s.NextRunAt = nil
+		return nil
+	}
+
+	schedule, err := ParseCronSchedule(*s.CronExpression)
+	if err != nil {
+		return err
+	}
+
+	next := schedule.Next(after, s.Location())
+	if next.IsZero() {
+		return fmt.Errorf("la expresión cron no tiene ejecuciones próximas")
+	}
+	next = next.UTC()
+	s.NextRunAt = &next
+	return nil
+}
+
+// HasReachedMaxOccurrences verifica si la serie ya generó todos sus sorteos
+func (s *RaffleSeries) HasReachedMaxOccurrences() bool {
+	return s.MaxOccurrences != nil && s.Occurrences >= *s.MaxOccurrences
+}
+
+// Pause pausa la generación de sorteos
+func (s *RaffleSeries) Pause() error {
+	if s.Status != RaffleSeriesStatusActive {
+		return fmt.Errorf("solo se pueden pausar series activas")
+	}
+	s.Status = RaffleSeriesStatusPaused
+	s.NextRunAt = nil
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Resume reanuda una serie pausada; las series con cron continúan desde la próxima ejecución
+func (s *RaffleSeries) Resume(now time.Time) error {
+	if s.Status != RaffleSeriesStatusPaused {
+		return fmt.Errorf("solo se pueden reanudar series pausadas")
+	}
+	s.Status = RaffleSeriesStatusActive
+	s.UpdatedAt = now
+	return s.ScheduleNextRun(now)
+}
+
+// End finaliza la serie; los sorteos ya generados no se modifican
+func (s *RaffleSeries) End(now time.Time) {
+	s.Status = RaffleSeriesStatusEnded
+	s.NextRunAt = nil
+	s.EndedAt = &now
+	s.UpdatedAt = now
+}
+
+// RecordRaffle registra el sorteo generado y programa la siguiente ejecución
+// La serie finaliza al alcanzar su máximo de sorteos
+func (s *RaffleSeries) RecordRaffle(raffleID int64, sequence int, now time.Time) error {
+	s.Occurrences = sequence
+	s.LastRaffleID = &raffleID
+	s.LastRunAt = &now
+	s.LastError = nil
+	s.UpdatedAt = now
+
+	if s.HasReachedMaxOccurrences() {
+		s.End(now)
+		return nil
+	}
+	return s.ScheduleNextRun(now)
+}
+
+// RaffleSeriesEntry sorteo generado por una serie con sus ventas (estadísticas de la serie)
+type RaffleSeriesEntry struct {
+	RaffleID       int64           `json:"raffle_id"`
+	RaffleUUID     uuid.UUID       `json:"raffle_uuid"`
+	Title          string          `json:"title"`
+	SeriesSequence int             `json:"series_sequence"`
+	Status         RaffleStatus    `json:"status"`
+	DrawDate       time.Time       `json:"draw_date"`
+	TotalNumbers   int             `json:"total_numbers"`
+	SoldCount      int             `json:"sold_count"`
+	PaidRevenue    decimal.Decimal `json:"paid_revenue"`
+}
+
+// RaffleSeriesStats estadísticas agregadas de una serie
+type RaffleSeriesStats struct {
+	TotalRaffles     int                  `json:"total_raffles"`
+	RafflesByStatus  map[RaffleStatus]int `json:"raffles_by_status"`
+	TotalNumbers     int                  `json:"total_numbers"`
+	SoldNumbers      int                  `json:"sold_numbers"`
+	SellThroughRate  decimal.Decimal      `json:"sell_through_rate"` // Porcentaje de números vendidos
+	PaidRevenue      decimal.Decimal      `json:"paid_revenue"`
+	AverageRevenue   decimal.Decimal      `json:"average_revenue"` // Por sorteo completado
+	CompletedRaffles int                  `json:"completed_raffles"`
+}
+
+// SummarizeRaffleSeries calcula las estadísticas de una serie a partir de sus sorteos
+func SummarizeRaffleSeries(entries []*RaffleSeriesEntry) *RaffleSeriesStats {
+	stats := &RaffleSeriesStats{
+		TotalRaffles:    len(entries),
+		RafflesByStatus: map[RaffleStatus]int{},
+		SellThroughRate: decimal.Zero,
+		PaidRevenue:     decimal.Zero,
+		AverageRevenue:  decimal.Zero,
+	}
+
+	completedRevenue := decimal.Zero
+	for _, entry := range entries {
+		stats.RafflesByStatus[entry.Status]++
+		stats.TotalNumbers += entry.TotalNumbers
+		stats.SoldNumbers += entry.SoldCount
+		stats.PaidRevenue = stats.PaidRevenue.Add(entry.PaidRevenue)
+		if entry.Status == RaffleStatusCompleted {
+			stats.CompletedRaffles++
+			completedRevenue = completedRevenue.Add(entry.PaidRevenue)
+		}
+	}
+
+	if stats.TotalNumbers > 0 {
+		stats.SellThroughRate = decimal.NewFromInt(int64(stats.SoldNumbers)).
+			Mul(decimal.NewFromInt(100)).
+			Div(decimal.NewFromInt(int64(stats.TotalNumbers))).
+			Round(2)
+	}
+	if stats.CompletedRaffles > 0 {
+		stats.AverageRevenue = completedRevenue.Div(decimal.NewFromInt(int64(stats.CompletedRaffles))).Round(2)
+	}
+
+	return stats
+}
+
+// RaffleSeriesRepository define el contrato para el repositorio de series de sorteos
+type RaffleSeriesRepository interface {
+	// Create crea una serie
+	Create(series *RaffleSeries) error
+
+	// Update guarda los cambios de una serie
+	Update(series *RaffleSeries) error
+
+	// RecordGeneration guarda el sorteo generado (contador, último sorteo, próxima ejecución, estado y error)
+	// solo si la serie no generó otro desde que se leyó (Occurrences sigue en previousOccurrences);
+	// retorna false si otra ejecución se adelantó
+	RecordGeneration(series *RaffleSeries, previousOccurrences int) (bool, error)
+
+	// FindByID obtiene una serie por ID
+	FindByID(id int64) (*RaffleSeries, error)
+
+	// FindByUserID lista las series de un organizador
+	FindByUserID(userID int64) ([]*RaffleSeries, error)
+
+	// CountActiveByTemplateID cuenta las series activas o pausadas que usan una plantilla
+	CountActiveByTemplateID(templateID int64) (int64, error)
+
+	// FindDue lista las series activas que deben generar su siguiente sorteo:
+	// cron con next_run_at vencido, o al completarse sin sorteos o con el último sorteo completado
+	FindDue(now time.Time, limit int) ([]*RaffleSeries, error)
+
+	// FindEntries lista los sorteos generados por la serie con sus ventas pagadas
+	FindEntries(seriesID int64) ([]*RaffleSeriesEntry, error)
+}
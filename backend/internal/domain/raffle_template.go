@@ -0,0 +1,356 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/datatypes"
+)
+
+// MaxRaffleTemplates máximo de plantillas activas por organizador
+const MaxRaffleTemplates = 50
+
+// RaffleTemplate plantilla de sorteo del organizador
+// Guarda los datos necesarios para crear el mismo sorteo repetidamente (manualmente o desde una serie)
+type RaffleTemplate struct {
+	ID     int64     `json:"id" gorm:"primaryKey"`
+	UUID   uuid.UUID `json:"uuid" gorm:"type:uuid;not null"`
+	UserID int64     `json:"user_id" gorm:"not null"`
+	Name   string    `json:"name" gorm:"not null"`
+
+	// Datos del sorteo
+	Title       string `json:"title" gorm:"not null"`
+	Description string `json:"description"`
+	CategoryID  *int64 `json:"category_id,omitempty"`
+
+	// Precio y rango de números
+	PricePerNumber decimal.Decimal `json:"price_per_number" gorm:"type:decimal(10,2);not null"`
+	TotalNumbers   int             `json:"total_numbers" gorm:"not null"`
+	MinNumber      int             `json:"min_number" gorm:"not null"`
+	MaxNumber      int             `json:"max_number" gorm:"not null"`
+
+	DrawMethod DrawMethod `json:"draw_method" gorm:"type:varchar(50);not null"`
+
+	// Regla si el número oficial no fue vendido (rollover solo aplica a los sorteos generados por una serie)
+	UnsoldWinnerPolicy *UnsoldWinnerPolicy `json:"unsold_winner_policy,omitempty" gorm:"type:varchar(20)"`
+
+	// Premio mayor (los premios ordenados están en Prizes)
+	PrizeType        PrizeType        `json:"prize_type" gorm:"type:varchar(20);not null"`
+	PrizeAmount      *decimal.Decimal `json:"prize_amount,omitempty" gorm:"type:decimal(12,2)"`
+	PrizeDescription *string          `json:"prize_description,omitempty"`
+
+	// Premios, reglas de precio e imágenes (JSON: []TemplatePrize, []TemplatePricingRule, []TemplateImage)
+	Prizes       datatypes.JSON `json:"-" gorm:"type:jsonb;not null"`
+	PricingRules datatypes.JSON `json:"-" gorm:"type:jsonb;not null"`
+	Images       datatypes.JSON `json:"-" gorm:"type:jsonb;not null"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+}
+
+// TableName especifica el nombre de la tabla
+func (RaffleTemplate) TableName() string {
+	return "raffle_templates"
+}
+
+// TemplatePrize premio de la plantilla; la posición es su orden en la lista
+type TemplatePrize struct {
+	Description string           `json:"description"`
+	PrizeType   PrizeType        `json:"prize_type"`
+	Value       *decimal.Decimal `json:"value,omitempty"`
+	ImageURL    *string          `json:"image_url,omitempty"`
+}
+
+// TemplatePricingRule regla de precio de la plantilla
+// La ventana de tiempo se guarda en horas relativas a la creación del sorteo generado
+type TemplatePricingRule struct {
+	Type             PricingRuleType  `json:"type"`
+	Name             string           `json:"name"`
+	Quantity         int              `json:"quantity"`
+	BundlePrice      *decimal.Decimal `json:"bundle_price,omitempty"`
+	DiscountPercent  *decimal.Decimal `json:"discount_percent,omitempty"`
+	StartsAfterHours *int             `json:"starts_after_hours,omitempty"`
+	EndsAfterHours   *int             `json:"ends_after_hours,omitempty"`
+	Active           bool             `json:"active"`
+}
+
+// TemplateImage imagen ya procesada que se asocia a cada sorteo generado
+// Los archivos no se duplican: los sorteos generados referencian las mismas variantes
+type TemplateImage struct {
+	Filename         string  `json:"filename"`
+	OriginalFilename string  `json:"original_filename"`
+	FilePath         string  `json:"file_path"`
+	FileSize         int64   `json:"file_size"`
+	MimeType         string  `json:"mime_type"`
+	Width            *int    `json:"width,omitempty"`
+	Height           *int    `json:"height,omitempty"`
+	AltText          string  `json:"alt_text"`
+	URLOriginal      *string `json:"url_original,omitempty"`
+	URLLarge         *string `json:"url_large,omitempty"`
+	URLMedium        *string `json:"url_medium,omitempty"`
+	URLThumbnail     *string `json:"url_thumbnail,omitempty"`
+	DisplayOrder     int     `json:"display_order"`
+	IsPrimary        bool    `json:"is_primary"`
+}
+
+// NewRaffleTemplate crea una plantilla vacía del organizador
+func NewRaffleTemplate(userID int64, name string) *RaffleTemplate {
+	now := time.Now()
+	return &RaffleTemplate{
+		UUID:         uuid.New(),
+		UserID:       userID,
+		Name:         name,
+		DrawMethod:   DrawMethodLoteriaCostaRica,
+		PrizeType:    PrizeTypePhysical,
+		Prizes:       datatypes.JSON("[]"),
+		PricingRules: datatypes.JSON("[]"),
+		Images:       datatypes.JSON("[]"),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate valida la plantilla con las mismas reglas que un sorteo
+func (t *RaffleTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("el nombre de la plantilla es requerido")
+	}
+	if len(t.Name) > 100 {
+		return fmt.Errorf("el nombre de la plantilla no puede exceder 100 caracteres")
+	}
+
+	// Se valida un sorteo de muestra para no duplicar las reglas de Raffle.Validate
+	raffle := t.sampleRaffle()
+	if err := raffle.Validate(); err != nil {
+		return err
+	}
+	if err := ValidateUnsoldWinnerPolicy(t.UnsoldWinnerPolicy, t.DrawMethod, t.PrizeType); err != nil {
+		return err
+	}
+
+	prizes, err := t.PrizeList()
+	if err != nil {
+		return err
+	}
+	if len(prizes) > 0 {
+		domainPrizes := make([]*RafflePrize, 0, len(prizes))
+		for i, prize := range prizes {
+			domainPrizes = append(domainPrizes, NewRafflePrize(i+1, prize.Description, prize.PrizeType, prize.Value, prize.ImageURL))
+		}
+		if err := ValidateRafflePrizes(domainPrizes, t.TotalNumbers); err != nil {
+			return err
+		}
+	}
+
+	rules, err := t.PricingRulesAt(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := ValidatePricingRules(rules, t.PricePerNumber); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sampleRaffle construye un sorteo con los datos de la plantilla para validarlos
+func (t *RaffleTemplate) sampleRaffle() *Raffle {
+	raffle := NewRaffle(t.UserID, t.Title, t.PricePerNumber, t.TotalNumbers, time.Now().Add(48*time.Hour))
+	raffle.MinNumber = t.MinNumber
+	raffle.MaxNumber = t.MaxNumber
+	raffle.DrawMethod = t.DrawMethod
+	raffle.PrizeType = t.PrizeType
+	raffle.PrizeAmount = t.PrizeAmount
+	raffle.PrizeDescription = t.PrizeDescription
+	return raffle
+}
+
+// PrizeList decodifica los premios de la plantilla
+func (t *RaffleTemplate) PrizeList() ([]TemplatePrize, error) {
+	var prizes []TemplatePrize
+	if err := decodeTemplateJSON(t.Prizes, &prizes); err != nil {
+		return nil, fmt.Errorf("premios de la plantilla inválidos: %w", err)
+	}
+	return prizes, nil
+}
+
+// PricingRuleList decodifica las reglas de precio de la plantilla
+func (t *RaffleTemplate) PricingRuleList() ([]TemplatePricingRule, error) {
+	var rules []TemplatePricingRule
+	if err := decodeTemplateJSON(t.PricingRules, &rules); err != nil {
+		return nil, fmt.Errorf("reglas de precio de la plantilla inválidas: %w", err)
+	}
+	return rules, nil
+}
+
+// ImageList decodifica las imágenes de la plantilla
+func (t *RaffleTemplate) ImageList() ([]TemplateImage, error) {
+	var images []TemplateImage
+	if err := decodeTemplateJSON(t.Images, &images); err != nil {
+		return nil, fmt.Errorf("imágenes de la plantilla inválidas: %w", err)
+	}
+	return images, nil
+}
+
+// SetPrizes reemplaza los premios de la plantilla
+func (t *RaffleTemplate) SetPrizes(prizes []TemplatePrize) error {
+	return encodeTemplateJSON(&t.Prizes, prizes)
+}
+
+// SetPricingRules reemplaza las reglas de precio de la plantilla
+func (t *RaffleTemplate) SetPricingRules(rules []TemplatePricingRule) error {
+	return encodeTemplateJSON(&t.PricingRules, rules)
+}
+
+// SetImages reemplaza las imágenes de la plantilla
+func (t *RaffleTemplate) SetImages(images []TemplateImage) error {
+	return encodeTemplateJSON(&t.Images, images)
+}
+
+// PricingRulesAt convierte las reglas de la plantilla en reglas de un sorteo creado en createdAt
+func (t *RaffleTemplate) PricingRulesAt(createdAt time.Time) ([]*PricingRule, error) {
+	rules, err := t.PricingRuleList()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*PricingRule, 0, len(rules))
+	for _, rule := range rules {
+		pricingRule := &PricingRule{
+			Type:            rule.Type,
+			Name:            rule.Name,
+			Quantity:        rule.Quantity,
+			BundlePrice:     rule.BundlePrice,
+			DiscountPercent: rule.DiscountPercent,
+			Active:          rule.Active,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		}
+		if rule.StartsAfterHours != nil {
+			startsAt := createdAt.Add(time.Duration(*rule.StartsAfterHours) * time.Hour)
+			pricingRule.StartsAt = &startsAt
+		}
+		if rule.EndsAfterHours != nil {
+			endsAt := createdAt.Add(time.Duration(*rule.EndsAfterHours) * time.Hour)
+			pricingRule.EndsAt = &endsAt
+		}
+		result = append(result, pricingRule)
+	}
+	return result, nil
+}
+
+// TemplatePricingRuleFrom convierte una regla de un sorteo en regla de plantilla relativa a createdAt
+func TemplatePricingRuleFrom(rule *PricingRule, createdAt time.Time) TemplatePricingRule {
+	templateRule := TemplatePricingRule{
+		Type:            rule.Type,
+		Name:            rule.Name,
+		Quantity:        rule.Quantity,
+		BundlePrice:     rule.BundlePrice,
+		DiscountPercent: rule.DiscountPercent,
+		Active:          rule.Active,
+	}
+	if rule.StartsAt != nil {
+		hours := hoursSince(createdAt, *rule.StartsAt)
+		templateRule.StartsAfterHours = &hours
+	}
+	if rule.EndsAt != nil {
+		hours := hoursSince(createdAt, *rule.EndsAt)
+		templateRule.EndsAfterHours = &hours
+	}
+	return templateRule
+}
+
+// TemplateImageFrom convierte una imagen de un sorteo en imagen de plantilla
+func TemplateImageFrom(image *RaffleImage) TemplateImage {
+	return TemplateImage{
+		Filename:         image.Filename,
+		OriginalFilename: image.OriginalFilename,
+		FilePath:         image.FilePath,
+		FileSize:         image.FileSize,
+		MimeType:         image.MimeType,
+		Width:            image.Width,
+		Height:           image.Height,
+		AltText:          image.AltText,
+		URLOriginal:      image.URLOriginal,
+		URLLarge:         image.URLLarge,
+		URLMedium:        image.URLMedium,
+		URLThumbnail:     image.URLThumbnail,
+		DisplayOrder:     image.DisplayOrder,
+		IsPrimary:        image.IsPrimary,
+	}
+}
+
+// RaffleImage crea la imagen del sorteo generado a partir de la imagen de la plantilla
+func (i TemplateImage) RaffleImage(raffleID int64) *RaffleImage {
+	now := time.Now()
+	return &RaffleImage{
+		RaffleID:         raffleID,
+		Filename:         i.Filename,
+		OriginalFilename: i.OriginalFilename,
+		FilePath:         i.FilePath,
+		FileSize:         i.FileSize,
+		MimeType:         i.MimeType,
+		Width:            i.Width,
+		Height:           i.Height,
+		AltText:          i.AltText,
+		URLOriginal:      i.URLOriginal,
+		URLLarge:         i.URLLarge,
+		URLMedium:        i.URLMedium,
+		URLThumbnail:     i.URLThumbnail,
+		DisplayOrder:     i.DisplayOrder,
+		IsPrimary:        i.IsPrimary,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// hoursSince horas completas entre from y to (negativas si to es anterior)
+func hoursSince(from, to time.Time) int {
+	return int(to.Sub(from) / time.Hour)
+}
+
+// decodeTemplateJSON decodifica un campo JSON de la plantilla (vacío = lista vacía)
+func decodeTemplateJSON(raw datatypes.JSON, target interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, target)
+}
+
+// encodeTemplateJSON codifica una lista en un campo JSON de la plantilla
+func encodeTemplateJSON(field *datatypes.JSON, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if string(raw) == "null" {
+		raw = []byte("[]")
+	}
+	*field = datatypes.JSON(raw)
+	return nil
+}
+
+// RaffleTemplateRepository define el contrato para el repositorio de plantillas de sorteo
+type RaffleTemplateRepository interface {
+	// Create crea una plantilla
+	Create(template *RaffleTemplate) error
+
+	// Update guarda los cambios de una plantilla
+	Update(template *RaffleTemplate) error
+
+	// SoftDelete elimina lógicamente una plantilla
+	SoftDelete(id int64) error
+
+	// FindByID obtiene una plantilla (no eliminada) por ID
+	FindByID(id int64) (*RaffleTemplate, error)
+
+	// FindByUserID lista las plantillas de un organizador
+	FindByUserID(userID int64) ([]*RaffleTemplate, error)
+
+	// CountByUserID cuenta las plantillas de un organizador
+	CountByUserID(userID int64) (int64, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+)
+
+// CartRepository defines the interface for multi-raffle cart persistence
+type CartRepository interface {
+	// Create stores a new cart
+	Create(ctx context.Context, cart *entities.Cart) error
+
+	// Update updates an existing cart
+	Update(ctx context.Context, cart *entities.Cart) error
+
+	// FindByID retrieves a cart by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Cart, error)
+
+	// FindByIDForUpdate retrieves a cart by ID locking its row until the transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Cart, error)
+
+	// FindByPaymentIntentID retrieves the cart paid by a provider payment intent
+	FindByPaymentIntentID(ctx context.Context, intentID string) (*entities.Cart, error)
+
+	// CountOpenWithReservations counts the open carts containing any of the reservations
+	CountOpenWithReservations(ctx context.Context, reservationIDs []uuid.UUID) (int64, error)
+}
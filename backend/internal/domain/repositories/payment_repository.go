@@ -21,6 +21,9 @@ type PaymentRepository interface {
 	// FindByStripePaymentIntentID retrieves a payment by Stripe Payment Intent ID
 	FindByStripePaymentIntentID(ctx context.Context, intentID string) (*entities.Payment, error)
 
+	// FindByCartID retrieves the payments that pay a cart (one per reservation)
+	FindByCartID(ctx context.Context, cartID uuid.UUID) ([]*entities.Payment, error)
+
 	// FindByUserID retrieves all payments for a user
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Payment, error)
 
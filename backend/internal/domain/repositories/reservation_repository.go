@@ -37,6 +37,9 @@ type ReservationRepository interface {
 	// CountActiveReservationsForNumbers counts active reservations for specific numbers
 	CountActiveReservationsForNumbers(ctx context.Context, raffleID uuid.UUID, numberIDs []string) (int, error)
 
+	// FindExpiringBetween finds pending reservations whose expiration falls in [from, to)
+	FindExpiringBetween(ctx context.Context, from, to time.Time) ([]*entities.Reservation, error)
+
 	// FindExpired finds all pending reservations that have expired
 	FindExpired(ctx context.Context) ([]*entities.Reservation, error)
 
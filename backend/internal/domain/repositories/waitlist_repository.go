@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+)
+
+// WaitlistRepository defines the interface for raffle waitlist persistence
+type WaitlistRepository interface {
+	// Create stores a new waitlist entry
+	Create(ctx context.Context, entry *entities.WaitlistEntry) error
+
+	// Update updates an existing waitlist entry
+	Update(ctx context.Context, entry *entities.WaitlistEntry) error
+
+	// FindActiveByUserAndRaffle finds the waiting or offered entry of a user in a raffle
+	FindActiveByUserAndRaffle(ctx context.Context, userID, raffleID uuid.UUID) (*entities.WaitlistEntry, error)
+
+	// FindWaiting retrieves up to limit waiting entries of a raffle in FIFO order
+	FindWaiting(ctx context.Context, raffleID uuid.UUID, limit int) ([]*entities.WaitlistEntry, error)
+
+	// CountWaitingBefore counts the waiting entries of a raffle created before the given time
+	CountWaitingBefore(ctx context.Context, raffleID uuid.UUID, createdAt time.Time) (int, error)
+
+	// FindExpiredOffers finds offered entries whose hold window has passed
+	FindExpiredOffers(ctx context.Context, before time.Time) ([]*entities.WaitlistEntry, error)
+}
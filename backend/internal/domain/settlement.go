@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -23,12 +24,15 @@ type Settlement struct {
 	// References
 	RaffleID     int64 `json:"raffle_id" gorm:"not null;uniqueIndex"` // FK a raffles - cada rifa solo puede tener un settlement
 	OrganizerID  int64 `json:"organizer_id" gorm:"not null;index"`     // FK a users
+	BatchID      *int64 `json:"batch_id,omitempty"`                    // FK a settlement_batches (liquidaciones programadas)
 
 	// Amounts (calculados automáticamente)
 	GrossRevenue           float64 `json:"gross_revenue" gorm:"type:decimal(12,2);not null"`            // Total vendido
 	PlatformFee            float64 `json:"platform_fee" gorm:"type:decimal(12,2);not null"`             // Comisión de plataforma
 	PlatformFeePercentage  float64 `json:"platform_fee_percentage" gorm:"type:decimal(5,2);not null"`   // % aplicado
+	PrizesWithheld         float64 `json:"prizes_withheld" gorm:"type:decimal(12,2);not null;default:0"` // Premios en efectivo pagados de lo recaudado
 	NetPayout              float64 `json:"net_payout" gorm:"type:decimal(12,2);not null"`               // A pagar al organizador
+	Currency               string  `json:"currency" gorm:"type:varchar(3);not null"`                   // Moneda de los pagos del sorteo
 
 	// Status
 	Status SettlementStatus `json:"status" gorm:"type:settlement_status;default:'pending'"`
@@ -74,6 +78,11 @@ func (s *Settlement) Validate() error {
 		return fmt.Errorf("organizer_id is required")
 	}
 
+	// La moneda es requerida: los asientos contables se registran en ella
+	if len(s.Currency) != 3 {
+		return fmt.Errorf("currency is required")
+	}
+
 	// Amounts deben ser positivos
 	if s.GrossRevenue < 0 {
 		return fmt.Errorf("gross_revenue must be non-negative")
@@ -83,16 +92,20 @@ func (s *Settlement) Validate() error {
 		return fmt.Errorf("platform_fee must be non-negative")
 	}
 
+	if s.PrizesWithheld < 0 {
+		return fmt.Errorf("prizes_withheld must be non-negative")
+	}
+
 	if s.NetPayout < 0 {
 		return fmt.Errorf("net_payout must be non-negative")
 	}
 
-	// Validar que net_payout = gross_revenue - platform_fee
-	expectedNetPayout := s.GrossRevenue - s.PlatformFee
+	// Validar que net_payout = gross_revenue - platform_fee - prizes_withheld
+	expectedNetPayout := s.GrossRevenue - s.PlatformFee - s.PrizesWithheld
 	// Permitir pequeñas diferencias por redondeo (0.01)
 	if abs(s.NetPayout-expectedNetPayout) > 0.01 {
-		return fmt.Errorf("net_payout must equal gross_revenue - platform_fee (%.2f != %.2f - %.2f)",
-			s.NetPayout, s.GrossRevenue, s.PlatformFee)
+		return fmt.Errorf("net_payout must equal gross_revenue - platform_fee - prizes_withheld (%.2f != %.2f - %.2f - %.2f)",
+			s.NetPayout, s.GrossRevenue, s.PlatformFee, s.PrizesWithheld)
 	}
 
 	// Validar platform fee percentage
@@ -161,10 +174,34 @@ func (s *Settlement) CalculateFromRaffle(raffle *Raffle, commissionPercentage fl
 	// Convert decimal.Decimal to float64
 	grossRevenue, _ := raffle.TotalRevenue.Float64()
 
-	s.GrossRevenue = grossRevenue
+	// Redondear a centavos antes de restar para cumplir chk_settlements_net_payout
+	s.GrossRevenue = roundCents(grossRevenue)
 	s.PlatformFeePercentage = commissionPercentage
-	s.PlatformFee = s.GrossRevenue * (commissionPercentage / 100.0)
-	s.NetPayout = s.GrossRevenue - s.PlatformFee
+	s.PlatformFee = roundCents(s.GrossRevenue * (commissionPercentage / 100.0))
+	s.NetPayout = roundCents(s.GrossRevenue - s.PlatformFee)
+}
+
+// WithholdPrizes descuenta del pago al organizador los premios en efectivo que se pagan de lo recaudado
+// Retorna error si lo recaudado neto no alcanza para cubrirlos
+func (s *Settlement) WithholdPrizes(amount float64) error {
+	amount = roundCents(amount)
+	if amount < 0 {
+		return fmt.Errorf("prizes_withheld must be non-negative")
+	}
+
+	netPayout := roundCents(s.GrossRevenue - s.PlatformFee - amount)
+	if netPayout < 0 {
+		return fmt.Errorf("cash prizes (%.2f) exceed net revenue (%.2f)", amount, roundCents(s.GrossRevenue-s.PlatformFee))
+	}
+
+	s.PrizesWithheld = amount
+	s.NetPayout = netPayout
+	return nil
+}
+
+// roundCents redondea un monto a 2 decimales
+func roundCents(x float64) float64 {
+	return math.Round(x*100) / 100
 }
 
 // abs retorna el valor absoluto de un float64
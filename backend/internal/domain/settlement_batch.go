@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+)
+
+// SettlementBatch agrupa las liquidaciones de un organizador creadas en una misma corrida programada
+// Cada sorteo conserva su propio settlement (pendiente de aprobación); el lote es el pago consolidado
+type SettlementBatch struct {
+	ID          int64  `json:"id" gorm:"primaryKey"`
+	UUID        string `json:"uuid" gorm:"type:uuid;unique;not null;default:uuid_generate_v4()"`
+	OrganizerID int64  `json:"organizer_id" gorm:"not null;index"`
+
+	// Período
+	PayoutSchedule PayoutSchedule `json:"payout_schedule" gorm:"type:varchar(20);not null"`
+	PeriodEnd      time.Time      `json:"period_end" gorm:"not null"` // Corte: incluye sorteos completados antes de esta fecha
+
+	// Totales
+	RaffleCount           int     `json:"raffle_count" gorm:"not null"`
+	GrossRevenue          float64 `json:"gross_revenue" gorm:"type:decimal(12,2);not null"`
+	PlatformFee           float64 `json:"platform_fee" gorm:"type:decimal(12,2);not null"`
+	PlatformFeePercentage float64 `json:"platform_fee_percentage" gorm:"type:decimal(5,2);not null"`
+	NetPayout             float64 `json:"net_payout" gorm:"type:decimal(12,2);not null"`
+
+	// Audit
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relación (no se mapea a columna)
+	Settlements []*Settlement `json:"settlements,omitempty" gorm:"foreignKey:BatchID"`
+}
+
+// TableName especifica el nombre de la tabla
+func (SettlementBatch) TableName() string {
+	return "settlement_batches"
+}
+
+// Add suma un settlement a los totales del lote
+func (b *SettlementBatch) Add(settlement *Settlement) {
+	b.RaffleCount++
+	b.GrossRevenue = roundCents(b.GrossRevenue + settlement.GrossRevenue)
+	b.PlatformFee = roundCents(b.PlatformFee + settlement.PlatformFee)
+	b.NetPayout = roundCents(b.NetPayout + settlement.NetPayout)
+}
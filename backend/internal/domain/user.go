@@ -243,10 +243,10 @@ func (u *User) GetFullName() string {
 }
 
 // CanWithdraw verifica si el usuario puede retirar ganancias
-// Requisitos: full_kyc + IBAN configurado
+// Requisitos: al menos cedula_verified + IBAN configurado
 func (u *User) CanWithdraw() bool {
 	return u.IsActive() &&
-		u.KYCLevel == KYCLevelFullKYC &&
+		u.HasMinimumKYC(KYCLevelCedulaVerified) &&
 		u.IBAN != nil &&
 		*u.IBAN != ""
 }
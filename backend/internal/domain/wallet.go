@@ -164,6 +164,39 @@ func (w *Wallet) ConfirmPending(amount decimal.Decimal) error {
 	return nil
 }
 
+// HoldEarnings retiene un monto del saldo de ganancias en el saldo pendiente (retiros en revisión)
+func (w *Wallet) HoldEarnings(amount decimal.Decimal) error {
+	if err := w.DebitEarnings(amount); err != nil {
+		return err
+	}
+
+	w.PendingBalance = w.PendingBalance.Add(amount)
+	return nil
+}
+
+// ReleaseHeldEarnings devuelve al saldo de ganancias un monto retenido (retiro rechazado o cancelado)
+func (w *Wallet) ReleaseHeldEarnings(amount decimal.Decimal) error {
+	if amount.GreaterThan(w.PendingBalance) {
+		return fmt.Errorf("saldo pendiente insuficiente")
+	}
+
+	w.PendingBalance = w.PendingBalance.Sub(amount)
+	w.EarningsBalance = w.EarningsBalance.Add(amount)
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// SettleHeldEarnings descuenta definitivamente un monto retenido (retiro transferido)
+func (w *Wallet) SettleHeldEarnings(amount decimal.Decimal) error {
+	if amount.GreaterThan(w.PendingBalance) {
+		return fmt.Errorf("saldo pendiente insuficiente")
+	}
+
+	w.PendingBalance = w.PendingBalance.Sub(amount)
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
 // Freeze congela la billetera
 func (w *Wallet) Freeze() error {
 	if w.Status == WalletStatusClosed {
@@ -252,6 +285,9 @@ type WalletRepository interface {
 	// Unlock libera un lock
 	Unlock(walletID int64) error
 
+	// PostJournalEntry registra el asiento contable del movimiento en la misma unidad de trabajo
+	PostJournalEntry(entry *JournalEntry) error
+
 	// WithTransaction ejecuta una función dentro de una transacción
 	WithTransaction(fn func(repo WalletRepository) error) error
 }
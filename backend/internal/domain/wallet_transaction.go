@@ -180,6 +180,11 @@ func (wt *WalletTransaction) Validate() error {
 		return fmt.Errorf("balance_after no puede ser negativo")
 	}
 
+	// Una transacción pendiente que aún no movió el saldo (recarga esperando confirmación) no se compara con él
+	if wt.IsPending() && wt.BalanceAfter.Equal(wt.BalanceBefore) {
+		return nil
+	}
+
 	// Validar coherencia de saldos según tipo
 	switch wt.Type {
 	case TransactionTypeDeposit, TransactionTypeRefund, TransactionTypePrizeClaim, TransactionTypeSettlementPayout:
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WithdrawalStatus representa el estado de una solicitud de retiro
+type WithdrawalStatus string
+
+const (
+	WithdrawalStatusPending    WithdrawalStatus = "pending"    // Solicitado, esperando aprobación
+	WithdrawalStatusApproved   WithdrawalStatus = "approved"   // Aprobado, esperando exportación al banco
+	WithdrawalStatusProcessing WithdrawalStatus = "processing" // Incluido en un lote bancario exportado
+	WithdrawalStatusCompleted  WithdrawalStatus = "completed"  // Transferencia confirmada por el banco
+	WithdrawalStatusRejected   WithdrawalStatus = "rejected"   // Rechazado por admin o por el banco
+	WithdrawalStatusCancelled  WithdrawalStatus = "cancelled"  // Cancelado por el usuario
+)
+
+// DefaultMinWithdrawalAmount monto mínimo de retiro por defecto (CRC)
+const DefaultMinWithdrawalAmount = 5000.0
+
+// Withdrawal solicitud de retiro del saldo de ganancias a una cuenta IBAN
+// Mientras está abierta, el monto permanece retenido en pending_balance
+type Withdrawal struct {
+	ID       int64  `json:"id" gorm:"primaryKey"`
+	UUID     string `json:"uuid" gorm:"type:uuid;unique;not null"`
+	UserID   int64  `json:"user_id" gorm:"not null"`
+	WalletID int64  `json:"wallet_id" gorm:"not null"`
+
+	// Monto y destino
+	Amount          decimal.Decimal `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Currency        string          `json:"currency" gorm:"type:varchar(3);not null"`
+	IBAN            string          `json:"iban" gorm:"column:iban;not null"`
+	AccountHolder   string          `json:"account_holder" gorm:"not null"`
+	AccountHolderID *string         `json:"account_holder_id,omitempty"` // Cédula del titular
+
+	// Transacción de billetera (pendiente hasta la confirmación bancaria)
+	TransactionID *int64 `json:"transaction_id,omitempty"`
+
+	// Estado
+	Status WithdrawalStatus `json:"status" gorm:"type:varchar(20);not null"`
+
+	// Revisión
+	ReviewedBy      *int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	RejectionReason *string    `json:"rejection_reason,omitempty"`
+
+	// Lote bancario
+	BatchID       *string    `json:"batch_id,omitempty"`
+	ExportedAt    *time.Time `json:"exported_at,omitempty"`
+	BankReference *string    `json:"bank_reference,omitempty"`
+
+	// Auditoría
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName especifica el nombre de la tabla
+func (Withdrawal) TableName() string {
+	return "withdrawals"
+}
+
+// NewWithdrawal crea una nueva solicitud de retiro pendiente de aprobación
+func NewWithdrawal(user *User, wallet *Wallet, amount decimal.Decimal) *Withdrawal {
+	now := time.Now()
+	withdrawal := &Withdrawal{
+		UserID:          user.ID,
+		WalletID:        wallet.ID,
+		Amount:          amount,
+		Currency:        wallet.Currency,
+		AccountHolder:   user.GetFullName(),
+		AccountHolderID: user.Cedula,
+		Status:          WithdrawalStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if user.IBAN != nil {
+		withdrawal.IBAN = *user.IBAN
+	}
+	return withdrawal
+}
+
+// Validate valida la solicitud
+func (w *Withdrawal) Validate() error {
+	if w.UserID <= 0 || w.WalletID <= 0 {
+		return fmt.Errorf("user_id y wallet_id son requeridos")
+	}
+
+	if w.Amount.LessThanOrEqual(decimal.Zero) {
+		return fmt.Errorf("el monto debe ser mayor a cero")
+	}
+
+	if err := ValidateIBAN(w.IBAN); err != nil {
+		return err
+	}
+
+	if w.AccountHolder == "" {
+		return fmt.Errorf("el titular de la cuenta es requerido")
+	}
+
+	return nil
+}
+
+// IsOpen verifica si el retiro mantiene fondos retenidos
+func (w *Withdrawal) IsOpen() bool {
+	return w.Status == WithdrawalStatusPending ||
+		w.Status == WithdrawalStatusApproved ||
+		w.Status == WithdrawalStatusProcessing
+}
+
+// Approve aprueba el retiro para incluirlo en el próximo lote bancario
+func (w *Withdrawal) Approve(adminID int64) error {
+	if w.Status != WithdrawalStatusPending {
+		return fmt.Errorf("solo se pueden aprobar retiros pendientes (estado actual: %s)", w.Status)
+	}
+
+	now := time.Now()
+	w.Status = WithdrawalStatusApproved
+	w.ReviewedBy = &adminID
+	w.ReviewedAt = &now
+	w.UpdatedAt = now
+	return nil
+}
+
+// MarkExported registra la inclusión del retiro en un lote bancario
+func (w *Withdrawal) MarkExported(batchID string) error {
+	if w.Status != WithdrawalStatusApproved {
+		return fmt.Errorf("solo se pueden exportar retiros aprobados (estado actual: %s)", w.Status)
+	}
+
+	now := time.Now()
+	w.Status = WithdrawalStatusProcessing
+	w.BatchID = &batchID
+	w.ExportedAt = &now
+	w.UpdatedAt = now
+	return nil
+}
+
+// Complete registra la confirmación de la transferencia por el banco
+func (w *Withdrawal) Complete(bankReference string) error {
+	if w.Status != WithdrawalStatusProcessing {
+		return fmt.Errorf("solo se pueden confirmar retiros exportados al banco (estado actual: %s)", w.Status)
+	}
+
+	now := time.Now()
+	w.Status = WithdrawalStatusCompleted
+	if bankReference != "" {
+		w.BankReference = &bankReference
+	}
+	w.CompletedAt = &now
+	w.UpdatedAt = now
+	return nil
+}
+
+// Reject rechaza el retiro (por revisión o porque el banco devolvió la transferencia)
+func (w *Withdrawal) Reject(adminID int64, reason string) error {
+	if !w.IsOpen() {
+		return fmt.Errorf("solo se pueden rechazar retiros abiertos (estado actual: %s)", w.Status)
+	}
+	if reason == "" {
+		return fmt.Errorf("la razón de rechazo es requerida")
+	}
+
+	now := time.Now()
+	w.Status = WithdrawalStatusRejected
+	w.ReviewedBy = &adminID
+	w.ReviewedAt = &now
+	w.RejectionReason = &reason
+	w.CompletedAt = &now
+	w.UpdatedAt = now
+	return nil
+}
+
+// Cancel cancela el retiro a solicitud del usuario (solo antes de la aprobación)
+func (w *Withdrawal) Cancel() error {
+	if w.Status != WithdrawalStatusPending {
+		return fmt.Errorf("solo se pueden cancelar retiros pendientes de aprobación (estado actual: %s)", w.Status)
+	}
+
+	now := time.Now()
+	w.Status = WithdrawalStatusCancelled
+	w.CompletedAt = &now
+	w.UpdatedAt = now
+	return nil
+}
+
+// WithdrawalFilters filtros para listar retiros
+type WithdrawalFilters struct {
+	Status  *WithdrawalStatus
+	UserID  *int64
+	BatchID *string
+}
+
+// WithdrawalRepository define el contrato para el repositorio de retiros
+type WithdrawalRepository interface {
+	// Create crea una nueva solicitud
+	Create(withdrawal *Withdrawal) error
+
+	// FindByID busca un retiro por ID
+	FindByID(id int64) (*Withdrawal, error)
+
+	// FindByIDForUpdate busca un retiro por ID con lock pesimista (usar dentro de una transacción)
+	FindByIDForUpdate(id int64) (*Withdrawal, error)
+
+	// FindByUUID busca un retiro por UUID
+	FindByUUID(uuid string) (*Withdrawal, error)
+
+	// List lista retiros con filtros (paginado)
+	List(filters WithdrawalFilters, limit, offset int) ([]*Withdrawal, int64, error)
+
+	// FindByStatus busca retiros por estado (los más antiguos primero)
+	FindByStatus(status WithdrawalStatus, limit int) ([]*Withdrawal, error)
+
+	// Update actualiza un retiro existente
+	Update(withdrawal *Withdrawal) error
+}
@@ -0,0 +1,99 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRoundNotAvailable la ronda solicitada aún no fue publicada por el beacon
+	ErrRoundNotAvailable = errors.New("beacon round not available yet")
+)
+
+// DefaultDrandURL endpoint público de la red drand (League of Entropy)
+const DefaultDrandURL = "https://api.drand.sh"
+
+// Round ronda publicada por el beacon
+type Round struct {
+	Round      int64  `json:"round"`
+	Randomness string `json:"randomness"` // Hex, SHA-256 de la firma de la ronda
+	Signature  string `json:"signature"`
+}
+
+// DrandClient obtiene rondas públicas de una cadena drand
+// La aleatoriedad de cada ronda se publica al llegar su hora y nadie puede conocerla antes
+type DrandClient struct {
+	baseURL    string
+	chainHash  string
+	httpClient *http.Client
+}
+
+// NewDrandClient crea un nuevo cliente para la cadena indicada
+func NewDrandClient(baseURL, chainHash string, timeout time.Duration) *DrandClient {
+	if baseURL == "" {
+		baseURL = DefaultDrandURL
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &DrandClient{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		chainHash: chainHash,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// FetchRound obtiene la ronda indicada
+// Retorna ErrRoundNotAvailable si la ronda aún no se publicó
+func (c *DrandClient) FetchRound(ctx context.Context, round int64) (*Round, error) {
+	endpoint := fmt.Sprintf("%s/%s/public/%d", c.baseURL, c.chainHash, round)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating beacon request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching beacon round: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drand responde 404 (o 425 Too Early en algunas versiones) para rondas futuras
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusTooEarly {
+		return nil, ErrRoundNotAvailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("beacon returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return nil, fmt.Errorf("error reading beacon response: %w", err)
+	}
+
+	var result Round
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding beacon response: %w", err)
+	}
+
+	if result.Round != round {
+		return nil, fmt.Errorf("beacon round mismatch: expected %d, got %d", round, result.Round)
+	}
+	if _, err := hex.DecodeString(result.Randomness); err != nil || result.Randomness == "" {
+		return nil, fmt.Errorf("beacon round %d without valid randomness", round)
+	}
+
+	return &result, nil
+}
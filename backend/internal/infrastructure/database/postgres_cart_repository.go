@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+)
+
+// PostgresCartRepository implements CartRepository using PostgreSQL
+type PostgresCartRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresCartRepository creates a new PostgreSQL cart repository
+func NewPostgresCartRepository(db *gorm.DB) repositories.CartRepository {
+	return &PostgresCartRepository{db: db}
+}
+
+// Create stores a new cart
+func (r *PostgresCartRepository) Create(ctx context.Context, cart *entities.Cart) error {
+	return r.db.WithContext(ctx).Create(cart).Error
+}
+
+// Update updates an existing cart
+func (r *PostgresCartRepository) Update(ctx context.Context, cart *entities.Cart) error {
+	cart.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(cart).Error
+}
+
+// FindByID retrieves a cart by ID
+func (r *PostgresCartRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Cart, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByIDForUpdate retrieves a cart by ID locking its row until the transaction ends
+func (r *PostgresCartRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Cart, error) {
+	return r.findOne(r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id))
+}
+
+// FindByPaymentIntentID retrieves the cart paid by a provider payment intent
+func (r *PostgresCartRepository) FindByPaymentIntentID(ctx context.Context, intentID string) (*entities.Cart, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("payment_intent_id = ?", intentID))
+}
+
+// CountOpenWithReservations counts the open carts containing any of the reservations
+func (r *PostgresCartRepository) CountOpenWithReservations(ctx context.Context, reservationIDs []uuid.UUID) (int64, error) {
+	ids := make([]string, len(reservationIDs))
+	for i, id := range reservationIDs {
+		ids[i] = id.String()
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.Cart{}).
+		Where("status = ? AND reservation_ids && ?", entities.CartStatusOpen, pq.StringArray(ids)).
+		Count(&count).Error
+	return count, err
+}
+
+// findOne returns the first cart matching the query or nil if there is none
+func (r *PostgresCartRepository) findOne(query *gorm.DB) (*entities.Cart, error) {
+	var cart entities.Cart
+	if err := query.First(&cart).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cart, nil
+}
@@ -66,6 +66,16 @@ func (r *PostgresPaymentRepository) FindByStripePaymentIntentID(ctx context.Cont
 	return &payment, nil
 }
 
+// FindByCartID retrieves the payments that pay a cart (one per reservation)
+func (r *PostgresPaymentRepository) FindByCartID(ctx context.Context, cartID uuid.UUID) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	err := r.db.WithContext(ctx).
+		Where("cart_id = ?", cartID).
+		Order("created_at ASC").
+		Find(&payments).Error
+	return payments, err
+}
+
 // FindByUserID retrieves all payments for a user
 func (r *PostgresPaymentRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Payment, error) {
 	var payments []*entities.Payment
@@ -79,7 +79,9 @@ func (r *PostgresReservationRepository) FindExpiredPending(ctx context.Context,
 	var reservations []*entities.Reservation
 	err := r.db.WithContext(ctx).
 		Model(&entities.Reservation{}).
-		Where("status = ? AND expires_at < ?", entities.ReservationStatusPending, before).
+		Where("status = ?", entities.ReservationStatusPending).
+		Where("expires_at < ? OR (phase = ? AND checkout_started_at < ?)",
+			before, entities.ReservationPhaseCheckout, before.Add(-entities.ReservationCheckoutTimeout)).
 		Find(&reservations).Error
 	return reservations, err
 }
@@ -117,11 +119,14 @@ func (r *PostgresReservationRepository) CountActiveReservationsForNumbers(ctx co
 }
 
 // FindExpired finds all pending reservations that have expired (alias for FindExpiredPending)
+// Reservations in checkout expire after the checkout timeout even if expires_at is later
 func (r *PostgresReservationRepository) FindExpired(ctx context.Context) ([]*entities.Reservation, error) {
 	var reservations []*entities.Reservation
+	now := time.Now()
 	err := r.db.WithContext(ctx).
 		Where("status = ?", entities.ReservationStatusPending).
-		Where("expires_at < ?", time.Now()).
+		Where("expires_at < ? OR (phase = ? AND checkout_started_at < ?)",
+			now, entities.ReservationPhaseCheckout, now.Add(-entities.ReservationCheckoutTimeout)).
 		Order("expires_at ASC").
 		Limit(100). // Process max 100 per execution
 		Find(&reservations).Error
@@ -133,6 +138,22 @@ func (r *PostgresReservationRepository) FindExpired(ctx context.Context) ([]*ent
 	return reservations, nil
 }
 
+// FindExpiringBetween finds pending reservations whose expiration falls in [from, to)
+func (r *PostgresReservationRepository) FindExpiringBetween(ctx context.Context, from, to time.Time) ([]*entities.Reservation, error) {
+	var reservations []*entities.Reservation
+	err := r.db.WithContext(ctx).
+		Where("status = ?", entities.ReservationStatusPending).
+		Where("expires_at >= ? AND expires_at < ?", from, to).
+		Order("expires_at ASC").
+		Find(&reservations).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
 // FindActiveByUserAndRaffle finds an active reservation for a user in a specific raffle
 func (r *PostgresReservationRepository) FindActiveByUserAndRaffle(ctx context.Context, userID uuid.UUID, raffleID uuid.UUID) (*entities.Reservation, error) {
 	var reservation entities.Reservation
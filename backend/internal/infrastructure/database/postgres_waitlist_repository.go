@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+)
+
+// PostgresWaitlistRepository implements WaitlistRepository using PostgreSQL
+type PostgresWaitlistRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresWaitlistRepository creates a new PostgreSQL waitlist repository
+func NewPostgresWaitlistRepository(db *gorm.DB) repositories.WaitlistRepository {
+	return &PostgresWaitlistRepository{db: db}
+}
+
+// Create stores a new waitlist entry
+func (r *PostgresWaitlistRepository) Create(ctx context.Context, entry *entities.WaitlistEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// Update updates an existing waitlist entry
+func (r *PostgresWaitlistRepository) Update(ctx context.Context, entry *entities.WaitlistEntry) error {
+	entry.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(entry).Error
+}
+
+// FindActiveByUserAndRaffle finds the waiting or offered entry of a user in a raffle
+func (r *PostgresWaitlistRepository) FindActiveByUserAndRaffle(ctx context.Context, userID, raffleID uuid.UUID) (*entities.WaitlistEntry, error) {
+	var entry entities.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND raffle_id = ?", userID, raffleID).
+		Where("status IN ?", []entities.WaitlistStatus{
+			entities.WaitlistStatusWaiting,
+			entities.WaitlistStatusOffered,
+		}).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindWaiting retrieves up to limit waiting entries of a raffle in FIFO order
+func (r *PostgresWaitlistRepository) FindWaiting(ctx context.Context, raffleID uuid.UUID, limit int) ([]*entities.WaitlistEntry, error) {
+	var entries []*entities.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Where("raffle_id = ? AND status = ?", raffleID, entities.WaitlistStatusWaiting).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// CountWaitingBefore counts the waiting entries of a raffle created before the given time
+func (r *PostgresWaitlistRepository) CountWaitingBefore(ctx context.Context, raffleID uuid.UUID, createdAt time.Time) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.WaitlistEntry{}).
+		Where("raffle_id = ? AND status = ? AND created_at < ?", raffleID, entities.WaitlistStatusWaiting, createdAt).
+		Count(&count).Error
+	return int(count), err
+}
+
+// FindExpiredOffers finds offered entries whose hold window has passed
+func (r *PostgresWaitlistRepository) FindExpiredOffers(ctx context.Context, before time.Time) ([]*entities.WaitlistEntry, error) {
+	var entries []*entities.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND offer_expires_at < ?", entities.WaitlistStatusOffered, before).
+		Order("offer_expires_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,53 @@
+package lottery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// FileSource obtiene resultados desde un archivo JSON con una lista de OfficialResult
+// El archivo se relee en cada consulta para poder actualizarlo sin reiniciar el servidor
+type FileSource struct {
+	path string
+}
+
+// NewFileSource crea una nueva fuente basada en archivo
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+// Name identifica la fuente
+func (s *FileSource) Name() string {
+	return "file"
+}
+
+// FetchResult obtiene el resultado del sorteo de una fecha
+func (s *FileSource) FetchResult(ctx context.Context, drawDate time.Time) (*OfficialResult, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrResultNotAvailable
+		}
+		return nil, fmt.Errorf("error reading lottery results file: %w", err)
+	}
+
+	var results []OfficialResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("error decoding lottery results file: %w", err)
+	}
+
+	date := drawDate.Format("2006-01-02")
+	for i := range results {
+		if results[i].DrawDate == date {
+			if err := results[i].validate(drawDate); err != nil {
+				return nil, err
+			}
+			return &results[i], nil
+		}
+	}
+
+	return nil, ErrResultNotAvailable
+}
@@ -0,0 +1,85 @@
+package lottery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPSource obtiene resultados desde un endpoint HTTP
+// El endpoint recibe ?date=YYYY-MM-DD y responde un OfficialResult en JSON (404 si aún no existe)
+// En desarrollo puede apuntarse a un stub local
+type HTTPSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPSource crea una nueva fuente HTTP
+func NewHTTPSource(baseURL string, timeout time.Duration) *HTTPSource {
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+
+	return &HTTPSource{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Name identifica la fuente
+func (s *HTTPSource) Name() string {
+	return "http"
+}
+
+// FetchResult obtiene el resultado del sorteo de una fecha
+func (s *HTTPSource) FetchResult(ctx context.Context, drawDate time.Time) (*OfficialResult, error) {
+	endpoint, err := url.Parse(s.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lottery source URL: %w", err)
+	}
+
+	query := endpoint.Query()
+	query.Set("date", drawDate.Format("2006-01-02"))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating lottery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching lottery result: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrResultNotAvailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lottery source returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error reading lottery response: %w", err)
+	}
+
+	var result OfficialResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding lottery response: %w", err)
+	}
+
+	if err := result.validate(drawDate); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
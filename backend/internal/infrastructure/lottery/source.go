@@ -0,0 +1,70 @@
+package lottery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrResultNotAvailable el resultado aún no está publicado para la fecha solicitada
+	ErrResultNotAvailable = errors.New("lottery result not available yet")
+)
+
+// OfficialResult resultado oficial del premio mayor publicado por la fuente
+type OfficialResult struct {
+	DrawDate      string `json:"draw_date"`             // Fecha del sorteo (YYYY-MM-DD, hora de Costa Rica)
+	DrawNumber    string `json:"draw_number,omitempty"` // Número de sorteo de la JPS
+	WinningNumber string `json:"winning_number"`        // Número del premio mayor
+	Series        string `json:"series,omitempty"`      // Serie del premio mayor
+}
+
+// ResultSource fuente pluggable de resultados de la Lotería Nacional
+type ResultSource interface {
+	// Name identifica la fuente ("http", "file")
+	Name() string
+
+	// FetchResult obtiene el resultado del sorteo de una fecha
+	// Retorna ErrResultNotAvailable si aún no se ha publicado
+	FetchResult(ctx context.Context, drawDate time.Time) (*OfficialResult, error)
+}
+
+// Config configuración de la fuente de resultados
+type Config struct {
+	Source   string        // "http", "file" o vacío (solo ingreso manual)
+	URL      string        // Endpoint HTTP (source = http)
+	FilePath string        // Archivo JSON con resultados (source = file)
+	Timeout  time.Duration // Timeout de las llamadas HTTP
+}
+
+// NewResultSource crea la fuente configurada; retorna nil si solo se usa ingreso manual
+func NewResultSource(cfg Config) (ResultSource, error) {
+	switch cfg.Source {
+	case "":
+		return nil, nil
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("lottery http source requires a URL")
+		}
+		return NewHTTPSource(cfg.URL, cfg.Timeout), nil
+	case "file":
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("lottery file source requires a file path")
+		}
+		return NewFileSource(cfg.FilePath), nil
+	default:
+		return nil, fmt.Errorf("unknown lottery source: %s", cfg.Source)
+	}
+}
+
+// validate verifica que el resultado publicado corresponda a la fecha solicitada
+func (r *OfficialResult) validate(drawDate time.Time) error {
+	if r.WinningNumber == "" {
+		return fmt.Errorf("lottery result without winning number")
+	}
+	if r.DrawDate != drawDate.Format("2006-01-02") {
+		return fmt.Errorf("lottery result date mismatch: expected %s, got %s", drawDate.Format("2006-01-02"), r.DrawDate)
+	}
+	return nil
+}
@@ -2,8 +2,36 @@ package payment
 
 import (
 	"context"
+	"errors"
 )
 
+var (
+	// ErrAlreadyRefunded the provider reports the payment as already refunded
+	ErrAlreadyRefunded = errors.New("payment already refunded at provider")
+	// ErrRefundRejected the provider definitively rejected the refund, no money was returned
+	ErrRefundRejected = errors.New("refund rejected by provider")
+)
+
+// Refund statuses normalized across providers
+const (
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusPending   = "pending" // Accepted by the provider, funds not yet returned
+	RefundStatusFailed    = "failed"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey attaches an idempotency key to provider calls made with ctx
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// idempotencyKeyFromContext returns the idempotency key attached to ctx, if any
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key
+}
+
 // PaymentProvider defines the interface for payment processing
 type PaymentProvider interface {
 	// CreatePaymentIntent creates a new payment intent
@@ -20,6 +48,14 @@ type PaymentProvider interface {
 
 	// ConstructWebhookEvent constructs and verifies a webhook event
 	ConstructWebhookEvent(payload []byte, signature string, secret string) (*WebhookEvent, error)
+
+	// Refund refunds amount (in cents) of a captured payment intent
+	// Use WithIdempotencyKey on ctx so retries never refund twice
+	// Errors wrap ErrAlreadyRefunded or ErrRefundRejected when the provider answered definitively
+	Refund(ctx context.Context, paymentIntentID string, amount int64) (*Refund, error)
+
+	// GetRefund retrieves the current provider status of a refund
+	GetRefund(ctx context.Context, refundID string) (*Refund, error)
 }
 
 // CreatePaymentIntentInput represents input for creating a payment intent
@@ -48,3 +84,14 @@ type WebhookEvent struct {
 	Type string      // e.g., "payment_intent.succeeded"
 	Data interface{} // Event data (type varies by event type)
 }
+
+// Refund represents a refund at the payment provider
+type Refund struct {
+	ID            string
+	PaymentIntent string
+	Amount        int64
+	Currency      string
+	Status        string // succeeded, pending, failed (normalized)
+	RawStatus     string // Status as reported by the provider
+	FailureReason string
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/plutov/paypal/v4"
@@ -16,6 +17,8 @@ var (
 	ErrPayPalGetOrder    = errors.New("failed to get PayPal order")
 	ErrPayPalCapture     = errors.New("failed to capture PayPal order")
 	ErrPayPalCancel      = errors.New("failed to cancel PayPal order")
+	ErrPayPalRefund      = errors.New("failed to refund PayPal capture")
+	ErrPayPalNoCapture   = errors.New("PayPal order has no capture to refund")
 )
 
 // PayPalProvider implements PaymentProvider using PayPal
@@ -279,3 +282,113 @@ func (p *PayPalProvider) ConstructWebhookEvent(payload []byte, signature string,
 		Data: event.Resource,
 	}, nil
 }
+
+// Refund refunds the capture of a PayPal Order (paymentIntentID is the order ID)
+func (p *PayPalProvider) Refund(ctx context.Context, paymentIntentID string, amount int64) (*Refund, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	// Refunds are issued against the capture, not the order
+	order, err := p.client.GetOrder(ctx, paymentIntentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPayPalGetOrder, err)
+	}
+
+	var captureID, currency string
+	for _, unit := range order.PurchaseUnits {
+		if unit.Payments == nil {
+			continue
+		}
+		for _, capture := range unit.Payments.Captures {
+			captureID = capture.ID
+			if capture.Amount != nil {
+				currency = capture.Amount.Currency
+			}
+			break
+		}
+		if captureID != "" {
+			break
+		}
+	}
+	if captureID == "" {
+		return nil, ErrPayPalNoCapture
+	}
+
+	request := paypal.RefundCaptureRequest{
+		Amount: &paypal.Money{
+			Currency: currency,
+			Value:    fmt.Sprintf("%.2f", float64(amount)/100),
+		},
+	}
+
+	resp, err := p.client.RefundCaptureWithPaypalRequestId(ctx, captureID, request, idempotencyKeyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", payPalRefundError(err), err)
+	}
+
+	return toPayPalRefund(resp, paymentIntentID), nil
+}
+
+// GetRefund retrieves a PayPal v2 refund
+func (p *PayPalProvider) GetRefund(ctx context.Context, refundID string) (*Refund, error) {
+	req, err := p.client.NewRequest(ctx, "GET", fmt.Sprintf("%s/v2/payments/refunds/%s", p.client.APIBase, refundID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPayPalRefund, err)
+	}
+
+	resp := &paypal.RefundResponse{}
+	if err := p.client.SendWithAuth(req, resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPayPalRefund, err)
+	}
+
+	return toPayPalRefund(resp, ""), nil
+}
+
+// payPalRefundError classifies a refund error: already refunded, rejected or retryable
+func payPalRefundError(err error) error {
+	var paypalErr *paypal.ErrorResponse
+	if !errors.As(err, &paypalErr) || paypalErr.Response == nil {
+		return ErrPayPalRefund
+	}
+
+	for _, detail := range paypalErr.Details {
+		if detail.Issue == "CAPTURE_FULLY_REFUNDED" {
+			return ErrAlreadyRefunded
+		}
+	}
+
+	status := paypalErr.Response.StatusCode
+	if status >= 400 && status < 500 && status != 409 && status != 429 {
+		return ErrRefundRejected
+	}
+	return ErrPayPalRefund
+}
+
+// toPayPalRefund maps a PayPal refund to the provider-agnostic Refund
+func toPayPalRefund(resp *paypal.RefundResponse, orderID string) *Refund {
+	status := RefundStatusPending
+	switch resp.Status {
+	case "COMPLETED":
+		status = RefundStatusSucceeded
+	case "CANCELLED", "FAILED":
+		status = RefundStatusFailed
+	}
+
+	var amount int64
+	var currency string
+	if resp.Amount != nil {
+		amountFloat, _ := strconv.ParseFloat(resp.Amount.Value, 64)
+		amount = int64(math.Round(amountFloat * 100))
+		currency = resp.Amount.Currency
+	}
+
+	return &Refund{
+		ID:            resp.ID,
+		PaymentIntent: orderID,
+		Amount:        amount,
+		Currency:      currency,
+		Status:        status,
+		RawStatus:     resp.Status,
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stripe/stripe-go/v76"
 	"github.com/stripe/stripe-go/v76/paymentintent"
+	"github.com/stripe/stripe-go/v76/refund"
 	"github.com/stripe/stripe-go/v76/webhook"
 )
 
@@ -149,3 +150,75 @@ func (p *StripeProvider) ConstructWebhookEvent(payload []byte, signature string,
 		Data: event.Data.Raw,
 	}, nil
 }
+
+// Refund creates a Stripe Refund for a Payment Intent
+func (p *StripeProvider) Refund(ctx context.Context, paymentIntentID string, amount int64) (*Refund, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(paymentIntentID),
+		Amount:        stripe.Int64(amount),
+		Reason:        stripe.String(string(stripe.RefundReasonRequestedByCustomer)),
+	}
+	params.Context = ctx
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		params.SetIdempotencyKey(key)
+	}
+
+	r, err := refund.New(params)
+	if err != nil {
+		var stripeErr *stripe.Error
+		if errors.As(err, &stripeErr) {
+			switch {
+			case stripeErr.Code == stripe.ErrorCodeChargeAlreadyRefunded:
+				return nil, fmt.Errorf("%w: %v", ErrAlreadyRefunded, err)
+			case stripeErr.HTTPStatusCode >= 400 && stripeErr.HTTPStatusCode < 500 &&
+				stripeErr.HTTPStatusCode != 409 && stripeErr.HTTPStatusCode != 429:
+				// 409 (idempotency conflict) and 429 (rate limit) are retryable
+				return nil, fmt.Errorf("%w: %v", ErrRefundRejected, err)
+			}
+		}
+		return nil, fmt.Errorf("stripe create refund error: %w", err)
+	}
+
+	return toStripeRefund(r), nil
+}
+
+// GetRefund retrieves a Stripe Refund
+func (p *StripeProvider) GetRefund(ctx context.Context, refundID string) (*Refund, error) {
+	params := &stripe.RefundParams{}
+	params.Context = ctx
+
+	r, err := refund.Get(refundID, params)
+	if err != nil {
+		return nil, fmt.Errorf("stripe get refund error: %w", err)
+	}
+
+	return toStripeRefund(r), nil
+}
+
+// toStripeRefund maps a Stripe Refund to the provider-agnostic Refund
+func toStripeRefund(r *stripe.Refund) *Refund {
+	status := RefundStatusPending
+	switch r.Status {
+	case stripe.RefundStatusSucceeded:
+		status = RefundStatusSucceeded
+	case stripe.RefundStatusFailed, stripe.RefundStatusCanceled:
+		status = RefundStatusFailed
+	}
+
+	result := &Refund{
+		ID:            r.ID,
+		Amount:        r.Amount,
+		Currency:      string(r.Currency),
+		Status:        status,
+		RawStatus:     string(r.Status),
+		FailureReason: string(r.FailureReason),
+	}
+	if r.PaymentIntent != nil {
+		result.PaymentIntent = r.PaymentIntent.ID
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -37,8 +38,12 @@ type Lock struct {
 // Returns a Lock object if successful, or an error if the lock is already held
 func (s *LockService) AcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
 	// Generate a unique value for this lock acquisition
-	value := fmt.Sprintf("%d", time.Now().UnixNano())
+	return s.AcquireLockWithOwner(ctx, key, fmt.Sprintf("%d", time.Now().UnixNano()), ttl)
+}
 
+// AcquireLockWithOwner attempts to acquire a lock owned by a known value (e.g. a reservation ID)
+// so later requests can extend or release it through OwnedLock
+func (s *LockService) AcquireLockWithOwner(ctx context.Context, key, value string, ttl time.Duration) (*Lock, error) {
 	// Try to set the key with NX (only if it doesn't exist) and expiration
 	success, err := s.client.SetNX(ctx, key, value, ttl).Result()
 	if err != nil {
@@ -79,6 +84,35 @@ func (s *LockService) AcquireMultipleLocks(ctx context.Context, keys []string, t
 	return locks, nil
 }
 
+// AcquireMultipleLocksWithOwner attempts to acquire multiple locks owned by the same value
+// If any lock cannot be acquired, all locks are released and an error is returned
+func (s *LockService) AcquireMultipleLocksWithOwner(ctx context.Context, keys []string, value string, ttl time.Duration) ([]*Lock, error) {
+	locks := make([]*Lock, 0, len(keys))
+
+	for _, key := range keys {
+		lock, err := s.AcquireLockWithOwner(ctx, key, value, ttl)
+		if err != nil {
+			for _, acquiredLock := range locks {
+				_ = acquiredLock.Release(ctx) // Ignore errors during cleanup
+			}
+			return nil, fmt.Errorf("failed to acquire lock for key %s: %w", key, err)
+		}
+		locks = append(locks, lock)
+	}
+
+	return locks, nil
+}
+
+// OwnedLock returns a handle on a lock previously acquired with AcquireLockWithOwner
+// Extend and Release only succeed while the lock is still held by that owner
+func (s *LockService) OwnedLock(key, value string) *Lock {
+	return &Lock{
+		key:    key,
+		value:  value,
+		client: s.client,
+	}
+}
+
 // Release releases the lock
 func (l *Lock) Release(ctx context.Context) error {
 	if l.released {
@@ -160,6 +194,58 @@ func ReservationLockKey(raffleID, numberID string) string {
 	return fmt.Sprintf("lock:reservation:%s:%s", raffleID, numberID)
 }
 
+// LockInfo describes a lock currently held in Redis
+type LockInfo struct {
+	Owner string        // Lock value (reservation ID for owned locks)
+	TTL   time.Duration // Remaining time to live
+}
+
+// ReservationLocks returns the number locks currently held for a raffle, keyed by number
+func (s *LockService) ReservationLocks(ctx context.Context, raffleID string) (map[string]LockInfo, error) {
+	prefix := ReservationLockKey(raffleID, "")
+	locks := make(map[string]LockInfo)
+
+	iter := s.client.Scan(ctx, 0, prefix+"*", 500).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+
+		owner, err := s.client.Get(ctx, key).Result()
+		if err == redis.Nil {
+			continue // Expired between SCAN and GET
+		}
+		if err != nil {
+			return nil, fmt.Errorf("redis get error: %w", err)
+		}
+
+		ttl, err := s.client.PTTL(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("redis pttl error: %w", err)
+		}
+
+		locks[strings.TrimPrefix(key, prefix)] = LockInfo{Owner: owner, TTL: ttl}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("redis scan error: %w", err)
+	}
+
+	return locks, nil
+}
+
+// DrawLockKey generates a lock key for executing a raffle draw
+func DrawLockKey(raffleID string) string {
+	return fmt.Sprintf("lock:draw:%s", raffleID)
+}
+
+// RaffleSeriesLockKey generates a lock key for generating the next raffle of a series
+func RaffleSeriesLockKey(seriesID string) string {
+	return fmt.Sprintf("lock:raffle_series:%s", seriesID)
+}
+
+// CreditReconciliationLockKey lock key for reconciling credit purchases against Pagadito
+func CreditReconciliationLockKey() string {
+	return "lock:credit_reconciliation"
+}
+
 // ForceReleaseLock forcefully releases a lock without verifying ownership
 // Use this only for administrative operations like cancellation or expiration
 func (s *LockService) ForceReleaseLock(ctx context.Context, key string) error {
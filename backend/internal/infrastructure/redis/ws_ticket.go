@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WSTicketTTL tiempo de vida de un ticket de conexión WebSocket
+const WSTicketTTL = 30 * time.Second
+
+// ErrInvalidWSTicket el ticket no existe, expiró o ya fue usado
+var ErrInvalidWSTicket = errors.New("invalid or expired websocket ticket")
+
+// WSTicketService emite tickets de un solo uso para autenticar conexiones WebSocket
+// (los navegadores no permiten enviar el header Authorization al abrir un WebSocket)
+type WSTicketService struct {
+	client *redis.Client
+}
+
+// NewWSTicketService crea una nueva instancia del servicio
+func NewWSTicketService(client *redis.Client) *WSTicketService {
+	return &WSTicketService{
+		client: client,
+	}
+}
+
+// Issue emite un ticket para el usuario (UUID) válido por WSTicketTTL
+func (s *WSTicketService) Issue(ctx context.Context, userID string) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("error generating websocket ticket: %w", err)
+	}
+	ticket := hex.EncodeToString(bytes)
+
+	if err := s.client.Set(ctx, wsTicketKey(ticket), userID, WSTicketTTL).Err(); err != nil {
+		return "", fmt.Errorf("error storing websocket ticket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// Redeem consume el ticket y retorna el usuario (UUID) al que fue emitido
+func (s *WSTicketService) Redeem(ctx context.Context, ticket string) (string, error) {
+	if ticket == "" {
+		return "", ErrInvalidWSTicket
+	}
+
+	// GETDEL: el ticket solo puede usarse una vez
+	userID, err := s.client.GetDel(ctx, wsTicketKey(ticket)).Result()
+	if err == redis.Nil {
+		return "", ErrInvalidWSTicket
+	}
+	if err != nil {
+		return "", fmt.Errorf("error redeeming websocket ticket: %w", err)
+	}
+
+	return userID, nil
+}
+
+// wsTicketKey genera la key de Redis para un ticket
+func wsTicketKey(ticket string) string {
+	return fmt.Sprintf("ws:ticket:%s", ticket)
+}
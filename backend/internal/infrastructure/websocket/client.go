@@ -26,13 +26,19 @@ const (
 type Client struct {
 	ID       string
 	Hub      *Hub
-	Conn     *websocket.Conn
+	Conn     *websocket.Conn // Nil for stream clients
 	Send     chan []byte
-	RaffleID string
+	RaffleID string  // Empty for the user's private channel
 	UserID   *string // Optional: for authenticated connections
 }
 
+// IsPrivate reports whether the client is subscribed to the user's private channel
+func (c *Client) IsPrivate() bool {
+	return c.RaffleID == "" && c.UserID != nil
+}
+
 // ReadPump pumps messages from the WebSocket connection to the hub
+// Must not be started before the client is registered (resume replies are only sent to registered clients)
 func (c *Client) ReadPump() {
 	defer func() {
 		c.Hub.Unregister <- c
@@ -47,14 +53,16 @@ func (c *Client) ReadPump() {
 	})
 
 	for {
-		_, _, err := c.Conn.ReadMessage()
+		_, message, err := c.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("[WebSocket Client %s] Unexpected close error: %v", c.ID, err)
 			}
 			break
 		}
-		// We don't process messages from clients, this is just to keep connection alive
+
+		// Clients only send control messages: {"type":"resume","last_seq":N}
+		c.Hub.handleClientMessage(c, message)
 	}
 }
 
@@ -100,7 +108,7 @@ func (c *Client) WritePump() {
 	}
 }
 
-// NewClient creates a new WebSocket client
+// NewClient creates a new WebSocket client subscribed to a raffle
 func NewClient(hub *Hub, conn *websocket.Conn, raffleID string, userID *string) *Client {
 	return &Client{
 		ID:       uuid.New().String(),
@@ -111,3 +119,14 @@ func NewClient(hub *Hub, conn *websocket.Conn, raffleID string, userID *string)
 		UserID:   userID,
 	}
 }
+
+// NewStreamClient creates a client subscribed to a raffle without a WebSocket connection
+// The caller consumes Send itself (e.g. Server-Sent Events) instead of running the pumps
+func NewStreamClient(hub *Hub, raffleID string) *Client {
+	return NewClient(hub, nil, raffleID, nil)
+}
+
+// NewUserClient creates a new WebSocket client subscribed to the user's private channel
+func NewUserClient(hub *Hub, conn *websocket.Conn, userID string) *Client {
+	return NewClient(hub, conn, "", &userID)
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // MessageType represents the type of WebSocket message
@@ -13,13 +16,38 @@ const (
 	MessageTypeNumberUpdate       MessageType = "number_update"
 	MessageTypeReservationExpired MessageType = "reservation_expired"
 	MessageTypeReservationCreated MessageType = "reservation_created"
+	MessageTypeSalesClosed        MessageType = "sales_closed"
+	MessageTypeRaffleDrawn        MessageType = "raffle_drawn"
+	MessageTypeDrawPostponed      MessageType = "draw_postponed"
+	MessageTypeRaffleCancelled    MessageType = "raffle_cancelled"
 	MessageTypeError              MessageType = "error"
+
+	// Live draw room events (also persisted as the draw timeline)
+	MessageTypeDrawScheduled  MessageType = "draw_scheduled"
+	MessageTypeDrawStarted    MessageType = "draw_started"
+	MessageTypeDrawStep       MessageType = "draw_step"
+	MessageTypeWinnerRevealed MessageType = "winner_revealed"
+	MessageTypeDrawAborted    MessageType = "draw_aborted"
+
+	// Full number availability sent to a resuming client that missed too many messages
+	MessageTypeSnapshot MessageType = "snapshot"
+
+	// Private events (delivered only to the user's channel)
+	MessageTypeReservationCheckout MessageType = "reservation_checkout"
+	MessageTypeReservationExpiring MessageType = "reservation_expiring"
+	MessageTypePaymentSucceeded    MessageType = "payment_succeeded"
+	MessageTypePaymentFailed       MessageType = "payment_failed"
+	MessageTypeWaitlistOffer       MessageType = "waitlist_offer"
 )
 
 // Message represents a WebSocket message
+// Messages with UserID are private: they go to that user's channel instead of the raffle channel
+// Raffle messages carry a per-raffle, monotonically increasing Seq used to resume after a reconnect
 type Message struct {
+	Seq      int64                  `json:"seq,omitempty"`
 	Type     MessageType            `json:"type"`
 	RaffleID string                 `json:"raffle_id"`
+	UserID   string                 `json:"user_id,omitempty"`
 	Data     map[string]interface{} `json:"data"`
 }
 
@@ -27,27 +55,68 @@ type Message struct {
 type Hub struct {
 	// Clients organized by raffle_id -> set of clients
 	raffles map[string]map[*Client]bool
-	mu      sync.RWMutex
+
+	// Private channel clients organized by user_id -> set of clients
+	users map[string]map[*Client]bool
+
+	mu sync.RWMutex
 
 	// Channels for hub operations (exported for external use)
 	Broadcast  chan *Message
 	Register   chan *Client
 	Unregister chan *Client
+
+	// Replayed messages and snapshots addressed to a single client
+	direct chan *directMessage
+
+	// Sequences and replay buffers of a single instance hub (the broker keeps them in Redis)
+	replay *memoryReplay
+
+	// Full availability of a raffle for clients whose gap exceeds the replay buffer
+	snapshot SnapshotProvider
+
+	// Multi-instance fan-out (nil = single instance, messages stay in process memory)
+	broker *redisBroker
+	remote chan *Message
 }
 
-// NewHub creates a new WebSocket hub
+// NewHub creates a new WebSocket hub for a single API instance
 func NewHub() *Hub {
 	return &Hub{
 		raffles:    make(map[string]map[*Client]bool),
+		users:      make(map[string]map[*Client]bool),
 		Broadcast:  make(chan *Message, 256),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
+		direct:     make(chan *directMessage, 64),
+		replay:     newMemoryReplay(),
 	}
 }
 
+// NewRedisHub creates a WebSocket hub that fans messages out to every API replica
+// through Redis pub/sub (one channel per raffle) and aggregates connection stats across the cluster
+func NewRedisHub(rdb *redis.Client) *Hub {
+	h := NewHub()
+	h.broker = newRedisBroker(rdb)
+	h.replay = nil
+	h.remote = make(chan *Message, 256)
+	return h
+}
+
 // Run starts the hub's main loop (should be called in a goroutine)
 func (h *Hub) Run() {
 	log.Println("[WebSocket Hub] Starting...")
+
+	var statsTick <-chan time.Time
+	if h.broker != nil {
+		log.Printf("[WebSocket Hub] Redis fan-out enabled (instance %s)", h.broker.instanceID)
+		go h.broker.listen(h.remote)
+
+		ticker := time.NewTicker(statsInterval)
+		defer ticker.Stop()
+		statsTick = ticker.C
+	}
+
 	for {
 		select {
 		case client := <-h.Register:
@@ -57,55 +126,111 @@ func (h *Hub) Run() {
 			h.unregisterClient(client)
 
 		case message := <-h.Broadcast:
-			h.broadcastToRaffle(message)
+			h.dispatch(message)
+
+		case message := <-h.remote:
+			h.deliver(message)
+
+		case direct := <-h.direct:
+			h.deliverDirect(direct)
+
+		case <-statsTick:
+			h.broker.publishStats(h.localStats())
 		}
 	}
 }
 
-// registerClient registers a new client to a raffle
+// Close removes this instance from the cluster (no-op for a single instance hub)
+func (h *Hub) Close() error {
+	if h.broker == nil {
+		return nil
+	}
+	return h.broker.close()
+}
+
+// dispatch delivers a message to the raffle (or user) clients of every replica
+// Published messages come back through the subscription, so local clients are served from there
+// Raffle messages are sequenced before leaving the hub
+func (h *Hub) dispatch(message *Message) {
+	if h.broker == nil {
+		h.sequence(message)
+		h.deliver(message)
+		return
+	}
+
+	if err := h.broker.publish(message); err != nil {
+		// Redis unavailable: at least serve the clients connected to this replica (unsequenced)
+		log.Printf("[WebSocket Hub] Error publishing %s to %s, delivering locally: %v",
+			message.Type, messageChannel(message), err)
+		h.deliver(message)
+	}
+}
+
+// clientSets returns the set of clients a client belongs to and its key
+// (the user's private channel or the raffle channel)
+func (h *Hub) clientSets(client *Client) (map[string]map[*Client]bool, string, string) {
+	if client.IsPrivate() {
+		return h.users, *client.UserID, userChannel(*client.UserID)
+	}
+	return h.raffles, client.RaffleID, raffleChannel(client.RaffleID)
+}
+
+// registerClient registers a new client to a raffle or to the user's private channel
 func (h *Hub) registerClient(client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if h.raffles[client.RaffleID] == nil {
-		h.raffles[client.RaffleID] = make(map[*Client]bool)
+	sets, key, channel := h.clientSets(client)
+	if sets[key] == nil {
+		sets[key] = make(map[*Client]bool)
+		if h.broker != nil {
+			h.broker.subscribe(channel)
+		}
 	}
 
-	h.raffles[client.RaffleID][client] = true
+	sets[key][client] = true
 
-	log.Printf("[WebSocket Hub] Client %s registered to raffle %s (total: %d)",
-		client.ID, client.RaffleID, len(h.raffles[client.RaffleID]))
+	log.Printf("[WebSocket Hub] Client %s registered to %s (total: %d)",
+		client.ID, channel, len(sets[key]))
 }
 
-// unregisterClient removes a client from a raffle
+// unregisterClient removes a client from a raffle or from the user's private channel
 func (h *Hub) unregisterClient(client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if clients, ok := h.raffles[client.RaffleID]; ok {
+	sets, key, channel := h.clientSets(client)
+	if clients, ok := sets[key]; ok {
 		if _, exists := clients[client]; exists {
 			delete(clients, client)
 			close(client.Send)
 
-			// If no clients remain for this raffle, remove the raffle entry
+			// If no clients remain for this channel, remove the entry
 			if len(clients) == 0 {
-				delete(h.raffles, client.RaffleID)
+				delete(sets, key)
+				if h.broker != nil {
+					h.broker.unsubscribe(channel)
+				}
 			}
 
-			log.Printf("[WebSocket Hub] Client %s unregistered from raffle %s (remaining: %d)",
-				client.ID, client.RaffleID, len(clients))
+			log.Printf("[WebSocket Hub] Client %s unregistered from %s (remaining: %d)",
+				client.ID, channel, len(clients))
 		}
 	}
 }
 
-// broadcastToRaffle sends a message to all clients connected to a specific raffle
-func (h *Hub) broadcastToRaffle(message *Message) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+// deliver sends a message to the local clients of its raffle or, for private messages, of its user
+func (h *Hub) deliver(message *Message) {
+	// Write lock: clients with a full channel are removed
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
 	clients, ok := h.raffles[message.RaffleID]
+	if message.UserID != "" {
+		clients, ok = h.users[message.UserID]
+	}
 	if !ok || len(clients) == 0 {
-		// No clients connected to this raffle
+		// No clients connected to this channel
 		return
 	}
 
@@ -115,7 +240,7 @@ func (h *Hub) broadcastToRaffle(message *Message) {
 		return
 	}
 
-	// Send to all clients in this raffle
+	// Send to all clients in this channel
 	for client := range clients {
 		select {
 		case client.Send <- messageJSON:
@@ -128,8 +253,8 @@ func (h *Hub) broadcastToRaffle(message *Message) {
 		}
 	}
 
-	log.Printf("[WebSocket Hub] Broadcast %s to raffle %s (%d clients)",
-		message.Type, message.RaffleID, len(clients))
+	log.Printf("[WebSocket Hub] Broadcast %s to %s (%d clients)",
+		message.Type, messageChannel(message), len(clients))
 }
 
 // BroadcastNumberUpdate notifies all clients about a number status change
@@ -173,33 +298,193 @@ func (h *Hub) BroadcastReservationCreated(raffleID string, numberIDs []string, u
 	}
 }
 
-// GetConnectedClients returns the number of clients connected to a raffle
-func (h *Hub) GetConnectedClients(raffleID string) int {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+// BroadcastSalesClosed notifies all clients that the raffle no longer accepts reservations
+func (h *Hub) BroadcastSalesClosed(raffleID string, drawDate time.Time) {
+	h.Broadcast <- &Message{
+		Type:     MessageTypeSalesClosed,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"draw_date": drawDate,
+		},
+	}
+}
+
+// BroadcastDrawPostponed notifies all clients that the draw date moved and sales reopened
+func (h *Hub) BroadcastDrawPostponed(raffleID string, drawDate time.Time, reason string) {
+	h.Broadcast <- &Message{
+		Type:     MessageTypeDrawPostponed,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"draw_date": drawDate,
+			"reason":    reason,
+		},
+	}
+}
 
-	if clients, ok := h.raffles[raffleID]; ok {
-		return len(clients)
+// BroadcastRaffleCancelled notifies all clients that the raffle was cancelled and payments are being refunded
+func (h *Hub) BroadcastRaffleCancelled(raffleID, reason string) {
+	h.Broadcast <- &Message{
+		Type:     MessageTypeRaffleCancelled,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"reason": reason,
+		},
 	}
-	return 0
 }
 
-// GetTotalClients returns the total number of connected clients across all raffles
-func (h *Hub) GetTotalClients() int {
+// BroadcastRaffleDrawn notifies all clients about the draw result
+func (h *Hub) BroadcastRaffleDrawn(raffleID, winnerNumber string, seedHash *string) {
+	data := map[string]interface{}{
+		"winner_number": winnerNumber,
+	}
+
+	if seedHash != nil {
+		data["draw_seed_hash"] = *seedHash
+	}
+
+	h.Broadcast <- &Message{
+		Type:     MessageTypeRaffleDrawn,
+		RaffleID: raffleID,
+		Data:     data,
+	}
+}
+
+// BroadcastDrawEvent notifies all clients of a raffle about a live draw room event
+func (h *Hub) BroadcastDrawEvent(raffleID string, eventType MessageType, data map[string]interface{}) {
+	h.Broadcast <- &Message{
+		Type:     eventType,
+		RaffleID: raffleID,
+		Data:     data,
+	}
+}
+
+// SendToUser delivers a private message to every connection of a user
+func (h *Hub) SendToUser(userID string, message *Message) {
+	message.UserID = userID
+	h.Broadcast <- message
+}
+
+// NotifyReservationCheckout tells the user that the reservation moved to checkout
+// and starts the client's checkout countdown
+func (h *Hub) NotifyReservationCheckout(userID, raffleID, reservationID string, expiresAt time.Time) {
+	h.SendToUser(userID, &Message{
+		Type:     MessageTypeReservationCheckout,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"reservation_id":    reservationID,
+			"phase":             "checkout",
+			"expires_at":        expiresAt,
+			"seconds_remaining": int(time.Until(expiresAt).Seconds()),
+		},
+	})
+}
+
+// NotifyReservationExpiring warns the user that the reservation is about to expire
+func (h *Hub) NotifyReservationExpiring(userID, raffleID, reservationID string, expiresAt time.Time) {
+	h.SendToUser(userID, &Message{
+		Type:     MessageTypeReservationExpiring,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"reservation_id":    reservationID,
+			"expires_at":        expiresAt,
+			"seconds_remaining": int(time.Until(expiresAt).Seconds()),
+		},
+	})
+}
+
+// NotifyPaymentSucceeded tells the user that the payment was captured and the numbers are theirs
+func (h *Hub) NotifyPaymentSucceeded(userID, raffleID, reservationID, paymentID string, numberIDs []string) {
+	h.SendToUser(userID, &Message{
+		Type:     MessageTypePaymentSucceeded,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"reservation_id": reservationID,
+			"payment_id":     paymentID,
+			"number_ids":     numberIDs,
+		},
+	})
+}
+
+// NotifyPaymentFailed tells the user that the payment failed (the reservation can still be paid)
+func (h *Hub) NotifyPaymentFailed(userID, raffleID, reservationID, paymentID, reason string) {
+	h.SendToUser(userID, &Message{
+		Type:     MessageTypePaymentFailed,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"reservation_id": reservationID,
+			"payment_id":     paymentID,
+			"reason":         reason,
+		},
+	})
+}
+
+// NotifyWaitlistOffer tells a waitlisted user that released numbers are held for them until expiresAt
+func (h *Hub) NotifyWaitlistOffer(userID, raffleID, entryID string, numberIDs []string, expiresAt time.Time) {
+	h.SendToUser(userID, &Message{
+		Type:     MessageTypeWaitlistOffer,
+		RaffleID: raffleID,
+		Data: map[string]interface{}{
+			"waitlist_entry_id": entryID,
+			"number_ids":        numberIDs,
+			"expires_at":        expiresAt,
+			"seconds_remaining": int(time.Until(expiresAt).Seconds()),
+		},
+	})
+}
+
+// localStats returns raffle_id -> clients connected to this instance
+func (h *Hub) localStats() map[string]int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	stats := make(map[string]int, len(h.raffles))
+	for raffleID, clients := range h.raffles {
+		stats[raffleID] = len(clients)
+	}
+	return stats
+}
+
+// clusterStats returns raffle_id -> clients connected to any replica
+// Falls back to this instance's stats if Redis is unavailable
+func (h *Hub) clusterStats() map[string]int {
+	stats := h.localStats()
+	if h.broker == nil {
+		return stats
+	}
+
+	remote, err := h.broker.remoteStats()
+	if err != nil {
+		log.Printf("[WebSocket Hub] Error reading cluster stats, using local stats: %v", err)
+		return stats
+	}
+
+	for raffleID, count := range remote {
+		stats[raffleID] += count
+	}
+	return stats
+}
+
+// GetConnectedClients returns the number of clients connected to a raffle across the cluster
+func (h *Hub) GetConnectedClients(raffleID string) int {
+	return h.clusterStats()[raffleID]
+}
+
+// GetTotalClients returns the total number of connected clients across all raffles and replicas
+func (h *Hub) GetTotalClients() int {
 	total := 0
-	for _, clients := range h.raffles {
-		total += len(clients)
+	for _, count := range h.clusterStats() {
+		total += count
 	}
 	return total
 }
 
-// GetActiveRaffles returns the number of raffles with active connections
+// GetActiveRaffles returns the number of raffles with active connections on any replica
 func (h *Hub) GetActiveRaffles() int {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
-
-	return len(h.raffles)
+	active := 0
+	for _, count := range h.clusterStats() {
+		if count > 0 {
+			active++
+		}
+	}
+	return active
 }
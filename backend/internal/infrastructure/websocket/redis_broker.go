@@ -0,0 +1,260 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Interval between connection stats snapshots written to Redis
+	statsInterval = 5 * time.Second
+
+	// Stats of an instance that stops reporting expire after this TTL
+	statsTTL = 3 * statsInterval
+
+	// Timeout for Redis operations issued from the hub loop
+	redisTimeout = 2 * time.Second
+
+	// Set of instance IDs that reported connection stats
+	instancesKey = "ws:instances"
+)
+
+// redisBroker relays hub messages between API replicas through Redis pub/sub
+// (one channel per raffle and per user) and shares per-instance connection stats
+type redisBroker struct {
+	rdb        *redis.Client
+	pubsub     *redis.PubSub
+	instanceID string
+}
+
+// newRedisBroker creates a broker with an empty subscription (channels are added as clients connect)
+func newRedisBroker(rdb *redis.Client) *redisBroker {
+	hostname, _ := os.Hostname()
+	return &redisBroker{
+		rdb:        rdb,
+		pubsub:     rdb.Subscribe(context.Background()),
+		instanceID: fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+	}
+}
+
+// raffleChannel returns the pub/sub channel of a raffle
+func raffleChannel(raffleID string) string {
+	return fmt.Sprintf("ws:raffle:%s", raffleID)
+}
+
+// userChannel returns the pub/sub channel of a user's private events
+func userChannel(userID string) string {
+	return fmt.Sprintf("ws:user:%s", userID)
+}
+
+// messageChannel returns the channel a message is published to
+func messageChannel(message *Message) string {
+	if message.UserID != "" {
+		return userChannel(message.UserID)
+	}
+	return raffleChannel(message.RaffleID)
+}
+
+// raffleSeqKey returns the key of the last sequence assigned to a raffle's messages
+func raffleSeqKey(raffleID string) string {
+	return fmt.Sprintf("ws:raffle:%s:seq", raffleID)
+}
+
+// raffleReplayKey returns the sorted set (score = seq) of the last messages of a raffle
+func raffleReplayKey(raffleID string) string {
+	return fmt.Sprintf("ws:raffle:%s:replay", raffleID)
+}
+
+// publishSequencedScript assigns the next raffle sequence, stores the message in the replay buffer
+// and publishes it atomically, so every replica receives a raffle's messages in sequence order
+// ARGV[1] is the message JSON without seq, which is prepended to the object
+var publishSequencedScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+local payload = '{"seq":' .. seq .. ',' .. string.sub(ARGV[1], 2)
+redis.call('ZADD', KEYS[2], seq, payload)
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -(tonumber(ARGV[2]) + 1))
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+redis.call('PUBLISH', ARGV[4], payload)
+return seq
+`)
+
+// instanceStatsKey returns the hash raffle_id -> connected clients of an instance
+func instanceStatsKey(instanceID string) string {
+	return fmt.Sprintf("ws:instance:%s:raffles", instanceID)
+}
+
+// publish sends a message to every replica subscribed to its channel (including this one)
+func (b *redisBroker) publish(message *Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	// Private events are not sequenced
+	if message.UserID != "" {
+		return b.rdb.Publish(ctx, messageChannel(message), payload).Err()
+	}
+
+	return publishSequencedScript.Run(ctx, b.rdb,
+		[]string{raffleSeqKey(message.RaffleID), raffleReplayKey(message.RaffleID)},
+		string(payload), replayBufferSize, int(replayTTL.Seconds()), messageChannel(message),
+	).Err()
+}
+
+// missedSince returns the current sequence of a raffle and the buffered messages after lastSeq
+func (b *redisBroker) missedSince(raffleID string, lastSeq int64) (int64, []*Message, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	pipe := b.rdb.Pipeline()
+	seqCmd := pipe.Get(ctx, raffleSeqKey(raffleID))
+	replayCmd := pipe.ZRangeByScore(ctx, raffleReplayKey(raffleID), &redis.ZRangeBy{
+		Min: fmt.Sprintf("(%d", lastSeq),
+		Max: "+inf",
+	})
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, nil, err
+	}
+
+	current, err := seqCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return 0, nil, err
+	}
+
+	missed := make([]*Message, 0, len(replayCmd.Val()))
+	for _, payload := range replayCmd.Val() {
+		var message Message
+		if err := json.Unmarshal([]byte(payload), &message); err != nil {
+			return 0, nil, err
+		}
+		missed = append(missed, &message)
+	}
+
+	return current, missed, nil
+}
+
+// subscribe starts receiving messages of a raffle or user channel (first local client connected)
+func (b *redisBroker) subscribe(channel string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := b.pubsub.Subscribe(ctx, channel); err != nil {
+		log.Printf("[WebSocket Hub] Error subscribing to %s: %v", channel, err)
+	}
+}
+
+// unsubscribe stops receiving messages of a channel (last local client disconnected)
+func (b *redisBroker) unsubscribe(channel string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := b.pubsub.Unsubscribe(ctx, channel); err != nil {
+		log.Printf("[WebSocket Hub] Error unsubscribing from %s: %v", channel, err)
+	}
+}
+
+// listen forwards messages received from Redis to the hub loop until the subscription is closed
+func (b *redisBroker) listen(out chan<- *Message) {
+	for msg := range b.pubsub.Channel() {
+		var message Message
+		if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+			log.Printf("[WebSocket Hub] Error unmarshaling message from %s: %v", msg.Channel, err)
+			continue
+		}
+		out <- &message
+	}
+}
+
+// publishStats replaces the connection stats snapshot of this instance
+func (b *redisBroker) publishStats(counts map[string]int) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	key := instanceStatsKey(b.instanceID)
+	_, err := b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(counts) > 0 {
+			values := make(map[string]interface{}, len(counts))
+			for raffleID, count := range counts {
+				values[raffleID] = count
+			}
+			pipe.HSet(ctx, key, values)
+			pipe.Expire(ctx, key, statsTTL)
+		}
+		pipe.SAdd(ctx, instancesKey, b.instanceID)
+		return nil
+	})
+	if err != nil {
+		log.Printf("[WebSocket Hub] Error publishing connection stats: %v", err)
+	}
+}
+
+// remoteStats returns raffle_id -> connected clients summed over the other live instances
+// Instances whose snapshot expired are removed from the instance set
+func (b *redisBroker) remoteStats() (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	instanceIDs, err := b.rdb.SMembers(ctx, instancesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := b.rdb.Pipeline()
+	snapshots := make(map[string]*redis.MapStringStringCmd, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		if instanceID == b.instanceID {
+			continue
+		}
+		snapshots[instanceID] = pipe.HGetAll(ctx, instanceStatsKey(instanceID))
+	}
+	if len(snapshots) == 0 {
+		return map[string]int{}, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]int)
+	var stale []interface{}
+	for instanceID, cmd := range snapshots {
+		values := cmd.Val()
+		if len(values) == 0 {
+			stale = append(stale, instanceID)
+			continue
+		}
+		for raffleID, value := range values {
+			count, _ := strconv.Atoi(value)
+			stats[raffleID] += count
+		}
+	}
+
+	if len(stale) > 0 {
+		b.rdb.SRem(ctx, instancesKey, stale...)
+	}
+
+	return stats, nil
+}
+
+// close removes this instance from the cluster stats and closes the subscription
+func (b *redisBroker) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	b.rdb.Del(ctx, instanceStatsKey(b.instanceID))
+	b.rdb.SRem(ctx, instancesKey, b.instanceID)
+
+	return b.pubsub.Close()
+}
@@ -0,0 +1,220 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// Number of raffle messages kept per raffle to replay after a reconnect
+	replayBufferSize = 200
+
+	// Sequence and replay buffer of a raffle without activity are dropped after this TTL (Redis only)
+	replayTTL = 7 * 24 * time.Hour
+
+	// Time allowed to build an availability snapshot
+	snapshotTimeout = 5 * time.Second
+)
+
+// SnapshotProvider returns the full number availability of a raffle
+// Used when a resuming client missed more messages than the replay buffer holds
+type SnapshotProvider func(ctx context.Context, raffleID string) (map[string]interface{}, error)
+
+// clientMessage is a message sent by the client over the WebSocket
+type clientMessage struct {
+	Type    string `json:"type"`
+	LastSeq int64  `json:"last_seq"`
+}
+
+// directMessage is a list of payloads addressed to a single client (replay or snapshot)
+type directMessage struct {
+	client   *Client
+	payloads [][]byte
+}
+
+// memoryReplay sequences raffle messages and keeps the last replayBufferSize of each raffle
+// in process memory (single instance hub)
+type memoryReplay struct {
+	mu       sync.Mutex
+	seqs     map[string]int64
+	messages map[string][]*Message
+}
+
+func newMemoryReplay() *memoryReplay {
+	return &memoryReplay{
+		seqs:     make(map[string]int64),
+		messages: make(map[string][]*Message),
+	}
+}
+
+// append assigns the next sequence of the raffle to the message and stores it
+func (r *memoryReplay) append(message *Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seqs[message.RaffleID]++
+	message.Seq = r.seqs[message.RaffleID]
+
+	buffer := append(r.messages[message.RaffleID], message)
+	if len(buffer) > replayBufferSize {
+		buffer = buffer[len(buffer)-replayBufferSize:]
+	}
+	r.messages[message.RaffleID] = buffer
+}
+
+// since returns the current sequence of the raffle and the buffered messages after lastSeq
+func (r *memoryReplay) since(raffleID string, lastSeq int64) (int64, []*Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var missed []*Message
+	for _, message := range r.messages[raffleID] {
+		if message.Seq > lastSeq {
+			missed = append(missed, message)
+		}
+	}
+	return r.seqs[raffleID], missed
+}
+
+// SetSnapshotProvider sets the provider of full availability snapshots for resuming clients
+// Must be called before clients connect
+func (h *Hub) SetSnapshotProvider(provider SnapshotProvider) {
+	h.snapshot = provider
+}
+
+// sequence assigns the next raffle sequence to a message delivered by this instance
+func (h *Hub) sequence(message *Message) {
+	if message.UserID == "" {
+		h.replay.append(message)
+	}
+}
+
+// missedSince returns the current sequence of a raffle and the messages after lastSeq still buffered
+func (h *Hub) missedSince(raffleID string, lastSeq int64) (int64, []*Message, error) {
+	if h.broker != nil {
+		return h.broker.missedSince(raffleID, lastSeq)
+	}
+	current, missed := h.replay.since(raffleID, lastSeq)
+	return current, missed, nil
+}
+
+// handleClientMessage processes a message sent by a client (currently only resume)
+func (h *Hub) handleClientMessage(client *Client, raw []byte) {
+	var msg clientMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		log.Printf("[WebSocket Hub] Invalid message from client %s: %v", client.ID, err)
+		return
+	}
+
+	switch msg.Type {
+	case "resume":
+		if client.IsPrivate() {
+			// Private events are not sequenced
+			return
+		}
+		h.Resume(client, msg.LastSeq)
+	default:
+		log.Printf("[WebSocket Hub] Unknown message type %q from client %s", msg.Type, client.ID)
+	}
+}
+
+// Resume sends a reconnected client the raffle messages it missed after lastSeq
+// When the gap is larger than the replay buffer the client gets a full availability snapshot instead
+func (h *Hub) Resume(client *Client, lastSeq int64) {
+	current, missed, err := h.missedSince(client.RaffleID, lastSeq)
+	if err != nil {
+		log.Printf("[WebSocket Hub] Error reading replay buffer of raffle %s: %v", client.RaffleID, err)
+		h.sendSnapshot(client, 0)
+		return
+	}
+
+	// Nothing missed
+	if lastSeq == current {
+		return
+	}
+
+	// The sequence restarted or the oldest missed message was already dropped from the buffer
+	if lastSeq > current || len(missed) == 0 || missed[0].Seq != lastSeq+1 {
+		h.sendSnapshot(client, current)
+		return
+	}
+
+	payloads := make([][]byte, 0, len(missed))
+	for _, message := range missed {
+		payload, err := json.Marshal(message)
+		if err != nil {
+			log.Printf("[WebSocket Hub] Error marshaling message: %v", err)
+			return
+		}
+		payloads = append(payloads, payload)
+	}
+
+	log.Printf("[WebSocket Hub] Replaying %d messages of raffle %s to client %s (from seq %d)",
+		len(payloads), client.RaffleID, client.ID, lastSeq+1)
+	h.direct <- &directMessage{client: client, payloads: payloads}
+}
+
+// sendSnapshot sends the full number availability of the client's raffle as of sequence seq
+// Messages after seq keep arriving live, so the client resumes from there
+// Without a provider the client is told to reload the raffle through the REST API
+func (h *Hub) sendSnapshot(client *Client, seq int64) {
+	message := &Message{
+		Type:     MessageTypeSnapshot,
+		RaffleID: client.RaffleID,
+		Seq:      seq,
+	}
+
+	if h.snapshot == nil {
+		message.Type = MessageTypeError
+		message.Data = map[string]interface{}{"code": "resync_required"}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+		data, err := h.snapshot(ctx, client.RaffleID)
+		cancel()
+
+		if err != nil {
+			log.Printf("[WebSocket Hub] Error building snapshot of raffle %s: %v", client.RaffleID, err)
+			message.Type = MessageTypeError
+			message.Data = map[string]interface{}{"code": "resync_required"}
+		} else {
+			message.Data = data
+		}
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("[WebSocket Hub] Error marshaling message: %v", err)
+		return
+	}
+
+	log.Printf("[WebSocket Hub] Sending %s of raffle %s to client %s (seq %d)",
+		message.Type, client.RaffleID, client.ID, seq)
+	h.direct <- &directMessage{client: client, payloads: [][]byte{payload}}
+}
+
+// deliverDirect sends replayed messages to a single client if it is still connected
+func (h *Hub) deliverDirect(direct *directMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sets, key, _ := h.clientSets(direct.client)
+	clients := sets[key]
+	if !clients[direct.client] {
+		// Client disconnected meanwhile
+		return
+	}
+
+	for _, payload := range direct.payloads {
+		select {
+		case direct.client.Send <- payload:
+		default:
+			log.Printf("[WebSocket Hub] Client %s channel full, closing", direct.client.ID)
+			close(direct.client.Send)
+			delete(clients, direct.client)
+			return
+		}
+	}
+}
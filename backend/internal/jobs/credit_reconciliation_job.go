@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	creditsuc "github.com/sorteos-platform/backend/internal/usecase/credits"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// CreditReconciliationJob job nocturno que concilia las compras de créditos pendientes contra Pagadito
+type CreditReconciliationJob struct {
+	useCase  *creditsuc.ReconcilePurchasesUseCase
+	logger   *logger.Logger
+	hour     int // Hora local de ejecución (0-23)
+	stopChan chan struct{}
+}
+
+// NewCreditReconciliationJob crea un nuevo job de conciliación que se ejecuta una vez al día a la hora indicada
+func NewCreditReconciliationJob(
+	useCase *creditsuc.ReconcilePurchasesUseCase,
+	logger *logger.Logger,
+	hour int,
+) *CreditReconciliationJob {
+	return &CreditReconciliationJob{
+		useCase:  useCase,
+		logger:   logger,
+		hour:     hour,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *CreditReconciliationJob) Start() {
+	for {
+		next := j.nextRun(time.Now())
+		j.logger.Info("Credit reconciliation job scheduled", zap.Time("next_run", next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			j.run()
+		case <-j.stopChan:
+			timer.Stop()
+			j.logger.Info("Credit reconciliation job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *CreditReconciliationJob) Stop() {
+	close(j.stopChan)
+}
+
+// nextRun próxima ejecución a la hora configurada (hoy si aún no pasó, si no mañana)
+func (j *CreditReconciliationJob) nextRun(now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), j.hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// run concilia las compras y registra el resumen del reporte
+func (j *CreditReconciliationJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	report, err := j.useCase.Execute(ctx, &creditsuc.ReconcilePurchasesInput{})
+	duration := time.Since(start)
+
+	if err == creditsuc.ErrReconciliationInProgress {
+		j.logger.Info("Credit reconciliation already running elsewhere, skipping")
+		return
+	}
+	if err != nil {
+		j.logger.Error("Failed to reconcile credit purchases",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	j.logger.Info("Credit purchases reconciled",
+		zap.Int64("reconciliation_id", report.ID),
+		zap.Int("checked", report.Checked),
+		zap.Int("credited", report.Credited),
+		zap.Int("failed", report.Failed),
+		zap.Int("expired", report.Expired),
+		zap.Int("still_pending", report.StillPending),
+		zap.Int("discrepancies", report.Discrepancies),
+		zap.Duration("duration", duration),
+	)
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// DrawRafflesJob job para cerrar ventas y ejecutar sorteos programados
+type DrawRafflesJob struct {
+	executeScheduledDraws *raffleuc.ExecuteScheduledDrawsUseCase
+	logger                *logger.Logger
+	interval              time.Duration
+	stopChan              chan struct{}
+}
+
+// NewDrawRafflesJob crea un nuevo job de sorteos programados
+func NewDrawRafflesJob(
+	executeScheduledDraws *raffleuc.ExecuteScheduledDrawsUseCase,
+	logger *logger.Logger,
+	interval time.Duration,
+) *DrawRafflesJob {
+	return &DrawRafflesJob{
+		executeScheduledDraws: executeScheduledDraws,
+		logger:                logger,
+		interval:              interval,
+		stopChan:              make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *DrawRafflesJob) Start() {
+	j.logger.Info("Starting draw raffles job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Draw raffles job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *DrawRafflesJob) Stop() {
+	close(j.stopChan)
+}
+
+// run ejecuta el proceso de sorteos programados
+func (j *DrawRafflesJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.executeScheduledDraws.Execute(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to execute scheduled draws",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.SalesClosed > 0 || output.Drawn > 0 || output.Cancelled > 0 {
+		j.logger.Info("Scheduled draws executed",
+			zap.Int("sales_closed", output.SalesClosed),
+			zap.Int("drawn", output.Drawn),
+			zap.Int("cancelled", output.Cancelled),
+			zap.Int("skipped", output.Skipped),
+			zap.Duration("duration", duration),
+		)
+	}
+}
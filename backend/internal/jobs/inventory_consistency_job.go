@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	consistencyuc "github.com/sorteos-platform/backend/internal/usecase/consistency"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// InventoryConsistencyJob job que verifica periódicamente reservas, raffle_numbers, locks de Redis y contadores
+type InventoryConsistencyJob struct {
+	repairer *consistencyuc.InventoryRepairer
+	repair   bool
+	logger   *logger.Logger
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewInventoryConsistencyJob crea un nuevo job de consistencia de inventario
+// Con repair=false solo reporta las divergencias
+func NewInventoryConsistencyJob(
+	repairer *consistencyuc.InventoryRepairer,
+	repair bool,
+	logger *logger.Logger,
+	interval time.Duration,
+) *InventoryConsistencyJob {
+	return &InventoryConsistencyJob{
+		repairer: repairer,
+		repair:   repair,
+		logger:   logger,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *InventoryConsistencyJob) Start() {
+	j.logger.Info("Starting inventory consistency job",
+		zap.Duration("interval", j.interval),
+		zap.Bool("repair", j.repair),
+	)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Inventory consistency job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *InventoryConsistencyJob) Stop() {
+	close(j.stopChan)
+}
+
+// run verifica los sorteos activos (el repairer registra el detalle de las divergencias)
+func (j *InventoryConsistencyJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	report, err := j.repairer.Run(ctx, &consistencyuc.RepairInput{Repair: j.repair})
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to check inventory consistency",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	j.logger.Info("Inventory consistency checked",
+		zap.Bool("consistent", report.Consistent),
+		zap.Int("raffles_checked", report.RafflesChecked),
+		zap.Int("divergences", report.DivergenceCount),
+		zap.Int("fixed", report.FixedCount),
+		zap.Duration("duration", duration),
+	)
+}
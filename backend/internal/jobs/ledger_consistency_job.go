@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	ledgeruc "github.com/sorteos-platform/backend/internal/usecase/ledger"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// LedgerConsistencyJob job que verifica periódicamente los saldos de las billeteras contra el libro mayor
+type LedgerConsistencyJob struct {
+	checker  *ledgeruc.ConsistencyChecker
+	logger   *logger.Logger
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewLedgerConsistencyJob crea un nuevo job de verificación del libro mayor
+func NewLedgerConsistencyJob(
+	checker *ledgeruc.ConsistencyChecker,
+	logger *logger.Logger,
+	interval time.Duration,
+) *LedgerConsistencyJob {
+	return &LedgerConsistencyJob{
+		checker:  checker,
+		logger:   logger,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *LedgerConsistencyJob) Start() {
+	j.logger.Info("Starting ledger consistency job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Ledger consistency job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *LedgerConsistencyJob) Stop() {
+	close(j.stopChan)
+}
+
+// run verifica el libro mayor (el checker registra el detalle de las inconsistencias)
+func (j *LedgerConsistencyJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	report, err := j.checker.Check(ctx, ledgeruc.DefaultReportLimit)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to check ledger consistency",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	j.logger.Info("Ledger consistency checked",
+		zap.Bool("consistent", report.Consistent),
+		zap.Int64("wallets_checked", report.WalletsChecked),
+		zap.Int64("wallet_discrepancies", report.DiscrepancyCount),
+		zap.Int("unbalanced_entries", len(report.UnbalancedEntries)),
+		zap.Duration("duration", duration),
+	)
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// MinimumSalesJob job que pospone o cancela los sorteos que no alcanzaron su mínimo de ventas al corte
+type MinimumSalesJob struct {
+	enforceMinimumSales *raffleuc.EnforceMinimumSalesUseCase
+	logger              *logger.Logger
+	interval            time.Duration
+	stopChan            chan struct{}
+}
+
+// NewMinimumSalesJob crea un nuevo job de mínimo de ventas
+func NewMinimumSalesJob(
+	enforceMinimumSales *raffleuc.EnforceMinimumSalesUseCase,
+	logger *logger.Logger,
+	interval time.Duration,
+) *MinimumSalesJob {
+	return &MinimumSalesJob{
+		enforceMinimumSales: enforceMinimumSales,
+		logger:              logger,
+		interval:            interval,
+		stopChan:            make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *MinimumSalesJob) Start() {
+	j.logger.Info("Starting minimum sales job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Minimum sales job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *MinimumSalesJob) Stop() {
+	close(j.stopChan)
+}
+
+// run evalúa los sorteos que llegaron a su corte sin el mínimo de ventas
+func (j *MinimumSalesJob) run() {
+	// La cancelación solicita un reembolso por pago al proveedor
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.enforceMinimumSales.Execute(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to enforce raffle minimum sales",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.Postponed > 0 || output.Cancelled > 0 {
+		j.logger.Info("Raffle minimum sales enforced",
+			zap.Int("postponed", output.Postponed),
+			zap.Int("cancelled", output.Cancelled),
+			zap.Int("skipped", output.Skipped),
+			zap.Duration("duration", duration),
+		)
+	}
+}
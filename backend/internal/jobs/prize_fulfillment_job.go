@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	prizeuc "github.com/sorteos-platform/backend/internal/usecase/prize"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// prizeFulfillmentBatchSize sorteos y reclamos procesados por ejecución
+const prizeFulfillmentBatchSize = 50
+
+// PrizeFulfillmentJob job que entrega los premios pendientes y expira reclamos vencidos
+// Cubre sorteos completados manualmente o cuyo handler post-sorteo falló
+type PrizeFulfillmentJob struct {
+	prizeFulfillment *prizeuc.PrizeFulfillment
+	logger           *logger.Logger
+	interval         time.Duration
+	stopChan         chan struct{}
+}
+
+// NewPrizeFulfillmentJob crea un nuevo job de entrega de premios
+func NewPrizeFulfillmentJob(
+	prizeFulfillment *prizeuc.PrizeFulfillment,
+	logger *logger.Logger,
+	interval time.Duration,
+) *PrizeFulfillmentJob {
+	return &PrizeFulfillmentJob{
+		prizeFulfillment: prizeFulfillment,
+		logger:           logger,
+		interval:         interval,
+		stopChan:         make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *PrizeFulfillmentJob) Start() {
+	j.logger.Info("Starting prize fulfillment job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Prize fulfillment job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *PrizeFulfillmentJob) Stop() {
+	close(j.stopChan)
+}
+
+// run entrega los premios pendientes
+func (j *PrizeFulfillmentJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.prizeFulfillment.FulfillPending(ctx, prizeFulfillmentBatchSize)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to fulfill pending prizes",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.Paid > 0 || output.Opened > 0 || output.Expired > 0 || output.Failed > 0 {
+		j.logger.Info("Pending prizes fulfilled",
+			zap.Int("paid", output.Paid),
+			zap.Int("opened", output.Opened),
+			zap.Int("expired", output.Expired),
+			zap.Int("failed", output.Failed),
+			zap.Duration("duration", duration),
+		)
+	}
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RaffleSeriesJob job que genera y publica el siguiente sorteo de las series recurrentes
+type RaffleSeriesJob struct {
+	generateSeriesRaffles *raffleuc.GenerateSeriesRafflesUseCase
+	logger                *logger.Logger
+	interval              time.Duration
+	stopChan              chan struct{}
+}
+
+// NewRaffleSeriesJob crea un nuevo job de series de sorteos
+func NewRaffleSeriesJob(
+	generateSeriesRaffles *raffleuc.GenerateSeriesRafflesUseCase,
+	logger *logger.Logger,
+	interval time.Duration,
+) *RaffleSeriesJob {
+	return &RaffleSeriesJob{
+		generateSeriesRaffles: generateSeriesRaffles,
+		logger:                logger,
+		interval:              interval,
+		stopChan:              make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *RaffleSeriesJob) Start() {
+	j.logger.Info("Starting raffle series job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Raffle series job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *RaffleSeriesJob) Stop() {
+	close(j.stopChan)
+}
+
+// run genera los sorteos de las series pendientes
+func (j *RaffleSeriesJob) run() {
+	// Cada sorteo generado crea todos sus números
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.generateSeriesRaffles.Execute(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to generate series raffles",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.Generated > 0 || output.Failed > 0 {
+		j.logger.Info("Series raffles generated",
+			zap.Int("generated", output.Generated),
+			zap.Int("failed", output.Failed),
+			zap.Int("skipped", output.Skipped),
+			zap.Duration("duration", duration),
+		)
+	}
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	refunduc "github.com/sorteos-platform/backend/internal/usecase/refund"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// retryRefundsBatchSize reembolsos procesados por ejecución
+const retryRefundsBatchSize = 100
+
+// RetryRefundsJob job para reintentar reembolsos fallidos y confirmar los pendientes en el proveedor
+type RetryRefundsJob struct {
+	refundProcessor *refunduc.RefundProcessor
+	logger          *logger.Logger
+	interval        time.Duration
+	stopChan        chan struct{}
+}
+
+// NewRetryRefundsJob crea un nuevo job de reintento de reembolsos
+func NewRetryRefundsJob(
+	refundProcessor *refunduc.RefundProcessor,
+	logger *logger.Logger,
+	interval time.Duration,
+) *RetryRefundsJob {
+	return &RetryRefundsJob{
+		refundProcessor: refundProcessor,
+		logger:          logger,
+		interval:        interval,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *RetryRefundsJob) Start() {
+	j.logger.Info("Starting retry refunds job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Retry refunds job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *RetryRefundsJob) Stop() {
+	close(j.stopChan)
+}
+
+// run procesa los reembolsos con reintento o consulta vencidos
+func (j *RetryRefundsJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.refundProcessor.ProcessDue(ctx, retryRefundsBatchSize)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to process due refunds",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.Processed > 0 {
+		j.logger.Info("Due refunds processed",
+			zap.Int("processed", output.Processed),
+			zap.Int("succeeded", output.Succeeded),
+			zap.Int("failed", output.Failed),
+			zap.Duration("duration", duration),
+		)
+	}
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	settlementuc "github.com/sorteos-platform/backend/internal/usecase/settlement"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ScheduledSettlementsJob job que crea los lotes de liquidación según el payout_schedule de cada organizador
+type ScheduledSettlementsJob struct {
+	scheduledSettlements *settlementuc.ScheduledSettlements
+	logger               *logger.Logger
+	interval             time.Duration
+	stopChan             chan struct{}
+}
+
+// NewScheduledSettlementsJob crea un nuevo job de liquidaciones programadas
+func NewScheduledSettlementsJob(
+	scheduledSettlements *settlementuc.ScheduledSettlements,
+	logger *logger.Logger,
+	interval time.Duration,
+) *ScheduledSettlementsJob {
+	return &ScheduledSettlementsJob{
+		scheduledSettlements: scheduledSettlements,
+		logger:               logger,
+		interval:             interval,
+		stopChan:             make(chan struct{}),
+	}
+}
+
+// Start inicia el job en background
+func (j *ScheduledSettlementsJob) Start() {
+	j.logger.Info("Starting scheduled settlements job", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// Ejecutar inmediatamente al iniciar
+	j.run()
+
+	// Ejecutar periódicamente
+	for {
+		select {
+		case <-ticker.C:
+			j.run()
+		case <-j.stopChan:
+			j.logger.Info("Scheduled settlements job stopped")
+			return
+		}
+	}
+}
+
+// Stop detiene el job
+func (j *ScheduledSettlementsJob) Stop() {
+	close(j.stopChan)
+}
+
+// run crea los lotes de los períodos vencidos
+func (j *ScheduledSettlementsJob) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	output, err := j.scheduledSettlements.Run(ctx, start)
+	duration := time.Since(start)
+
+	if err != nil {
+		j.logger.Error("Failed to create scheduled settlements",
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	if output.Batches > 0 || output.Failed > 0 {
+		j.logger.Info("Scheduled settlements created",
+			zap.Int("organizers", output.Organizers),
+			zap.Int("batches", output.Batches),
+			zap.Int("settlements", output.Settlements),
+			zap.Float64("net_payout", output.NetPayout),
+			zap.Int("failed", output.Failed),
+			zap.Duration("duration", duration),
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package credits
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ListReconciliationsInput datos de entrada
+type ListReconciliationsInput struct {
+	Page     int
+	PageSize int
+}
+
+// ListReconciliationsOutput resultado
+type ListReconciliationsOutput struct {
+	Reconciliations []*domain.CreditReconciliation `json:"reconciliations"`
+	Total           int64                          `json:"total"`
+	Page            int                            `json:"page"`
+	PageSize        int                            `json:"page_size"`
+	TotalPages      int                            `json:"total_pages"`
+}
+
+// ListReconciliationsUseCase caso de uso para listar las conciliaciones de compras de créditos
+type ListReconciliationsUseCase struct {
+	reconciliationRepo domain.CreditReconciliationRepository
+	log                *logger.Logger
+}
+
+// NewListReconciliationsUseCase crea una nueva instancia
+func NewListReconciliationsUseCase(gormDB *gorm.DB, log *logger.Logger) *ListReconciliationsUseCase {
+	return &ListReconciliationsUseCase{
+		reconciliationRepo: db.NewCreditReconciliationRepository(gormDB, log),
+		log:                log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListReconciliationsUseCase) Execute(ctx context.Context, input *ListReconciliationsInput, adminID int64) (*ListReconciliationsOutput, error) {
+	// Validar paginación
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+
+	reconciliations, total, err := uc.reconciliationRepo.List(input.PageSize, offset)
+	if err != nil {
+		uc.log.Error("Error listing credit reconciliations", logger.Error(err))
+		return nil, err
+	}
+
+	totalPages := int(total) / input.PageSize
+	if int(total)%input.PageSize > 0 {
+		totalPages++
+	}
+
+	return &ListReconciliationsOutput{
+		Reconciliations: reconciliations,
+		Total:           total,
+		Page:            input.Page,
+		PageSize:        input.PageSize,
+		TotalPages:      totalPages,
+	}, nil
+}
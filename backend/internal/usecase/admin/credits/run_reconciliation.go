@@ -0,0 +1,59 @@
+package credits
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	creditsuc "github.com/sorteos-platform/backend/internal/usecase/credits"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ErrPagaditoNotConfigured Pagadito no está configurado o está deshabilitado
+var ErrPagaditoNotConfigured = errors.New("PAGADITO_NOT_CONFIGURED", "Pagadito no está configurado", http.StatusServiceUnavailable, nil)
+
+// RunReconciliationInput datos de entrada
+type RunReconciliationInput struct {
+	Limit int // Compras a revisar (0 = límite por defecto)
+}
+
+// RunReconciliationUseCase caso de uso para ejecutar la conciliación con Pagadito sin esperar al job nocturno
+type RunReconciliationUseCase struct {
+	reconciler *creditsuc.ReconcilePurchasesUseCase
+	log        *logger.Logger
+}
+
+// NewRunReconciliationUseCase crea una nueva instancia
+// reconciler es nil si Pagadito no está configurado
+func NewRunReconciliationUseCase(reconciler *creditsuc.ReconcilePurchasesUseCase, log *logger.Logger) *RunReconciliationUseCase {
+	return &RunReconciliationUseCase{
+		reconciler: reconciler,
+		log:        log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RunReconciliationUseCase) Execute(ctx context.Context, input *RunReconciliationInput, adminID int64) (*domain.CreditReconciliation, error) {
+	if uc.reconciler == nil {
+		return nil, ErrPagaditoNotConfigured
+	}
+
+	reconciliation, err := uc.reconciler.Execute(ctx, &creditsuc.ReconcilePurchasesInput{
+		Limit:       input.Limit,
+		TriggeredBy: &adminID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Admin reconciled credit purchases",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("reconciliation_id", reconciliation.ID),
+		logger.Int("checked", reconciliation.Checked),
+		logger.Int("credited", reconciliation.Credited),
+		logger.Int("discrepancies", reconciliation.Discrepancies),
+		logger.String("action", "admin_reconcile_credit_purchases"))
+
+	return reconciliation, nil
+}
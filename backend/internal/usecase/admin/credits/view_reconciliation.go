@@ -0,0 +1,29 @@
+package credits
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ViewReconciliationUseCase caso de uso para ver el reporte de diferencias de una conciliación
+type ViewReconciliationUseCase struct {
+	reconciliationRepo domain.CreditReconciliationRepository
+	log                *logger.Logger
+}
+
+// NewViewReconciliationUseCase crea una nueva instancia
+func NewViewReconciliationUseCase(gormDB *gorm.DB, log *logger.Logger) *ViewReconciliationUseCase {
+	return &ViewReconciliationUseCase{
+		reconciliationRepo: db.NewCreditReconciliationRepository(gormDB, log),
+		log:                log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ViewReconciliationUseCase) Execute(ctx context.Context, reconciliationID int64, adminID int64) (*domain.CreditReconciliation, error) {
+	return uc.reconciliationRepo.FindByID(reconciliationID)
+}
@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	consistencyuc "github.com/sorteos-platform/backend/internal/usecase/consistency"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RepairInventoryInput datos de entrada
+type RepairInventoryInput struct {
+	RaffleID *int64 // Sorteo a revisar (nil: todos los sorteos activos)
+	Repair   bool   // false: solo reporte
+}
+
+// RepairInventoryUseCase caso de uso para verificar y reparar reservas, raffle_numbers, locks y contadores
+type RepairInventoryUseCase struct {
+	repairer *consistencyuc.InventoryRepairer
+	log      *logger.Logger
+}
+
+// NewRepairInventoryUseCase crea una nueva instancia
+func NewRepairInventoryUseCase(db *gorm.DB, lockService *redis.LockService, wsHub *websocket.Hub, log *logger.Logger) *RepairInventoryUseCase {
+	return &RepairInventoryUseCase{
+		repairer: consistencyuc.NewInventoryRepairer(db, lockService, wsHub, log),
+		log:      log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RepairInventoryUseCase) Execute(ctx context.Context, input *RepairInventoryInput, adminID int64) (*consistencyuc.RepairReport, error) {
+	report, err := uc.repairer.Run(ctx, &consistencyuc.RepairInput{
+		RaffleID: input.RaffleID,
+		Repair:   input.Repair,
+		AdminID:  &adminID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	action := "admin_check_inventory_consistency"
+	if input.Repair {
+		action = "admin_repair_inventory"
+	}
+
+	uc.log.Info("Admin checked raffle inventory",
+		logger.Int64("admin_id", adminID),
+		logger.Bool("consistent", report.Consistent),
+		logger.Int("raffles_checked", report.RafflesChecked),
+		logger.Int("divergences", report.DivergenceCount),
+		logger.Int("fixed", report.FixedCount),
+		logger.String("action", action))
+
+	return report, nil
+}
@@ -0,0 +1,56 @@
+package ledger
+
+import (
+	"context"
+
+	ledgeruc "github.com/sorteos-platform/backend/internal/usecase/ledger"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// maxReportLimit máximo de diferencias detalladas que puede pedir un admin
+const maxReportLimit = 1000
+
+// CheckConsistencyInput datos de entrada
+type CheckConsistencyInput struct {
+	Limit int // Diferencias detalladas a incluir en el reporte
+}
+
+// CheckConsistencyUseCase caso de uso para verificar el libro mayor contra los saldos de las billeteras
+type CheckConsistencyUseCase struct {
+	checker *ledgeruc.ConsistencyChecker
+	log     *logger.Logger
+}
+
+// NewCheckConsistencyUseCase crea una nueva instancia
+func NewCheckConsistencyUseCase(db *gorm.DB, log *logger.Logger) *CheckConsistencyUseCase {
+	return &CheckConsistencyUseCase{
+		checker: ledgeruc.NewConsistencyChecker(db, log),
+		log:     log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CheckConsistencyUseCase) Execute(ctx context.Context, input *CheckConsistencyInput, adminID int64) (*ledgeruc.ConsistencyReport, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = ledgeruc.DefaultReportLimit
+	}
+	if limit > maxReportLimit {
+		limit = maxReportLimit
+	}
+
+	report, err := uc.checker.Check(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Admin checked ledger consistency",
+		logger.Int64("admin_id", adminID),
+		logger.Bool("consistent", report.Consistent),
+		logger.Int64("wallet_discrepancies", report.DiscrepancyCount),
+		logger.Int("unbalanced_entries", len(report.UnbalancedEntries)),
+		logger.String("action", "admin_check_ledger_consistency"))
+
+	return report, nil
+}
@@ -0,0 +1,64 @@
+package lottery
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ConfirmLotteryResultInput datos de entrada
+type ConfirmLotteryResultInput struct {
+	ResultID int64
+}
+
+// ConfirmLotteryResultUseCase caso de uso para confirmar un resultado ingresado manualmente
+// Las rifas loteria_nacional_cr de esa fecha se completan en la siguiente ejecución del job de sorteos
+type ConfirmLotteryResultUseCase struct {
+	resultRepo domain.LotteryResultRepository
+	log        *logger.Logger
+}
+
+// NewConfirmLotteryResultUseCase crea una nueva instancia
+func NewConfirmLotteryResultUseCase(gormDB *gorm.DB, log *logger.Logger) *ConfirmLotteryResultUseCase {
+	return &ConfirmLotteryResultUseCase{
+		resultRepo: db.NewLotteryResultRepository(gormDB, log),
+		log:        log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ConfirmLotteryResultUseCase) Execute(ctx context.Context, input *ConfirmLotteryResultInput, adminID int64) (*domain.LotteryResult, error) {
+	result, err := uc.resultRepo.FindByID(input.ResultID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("LOTTERY_RESULT_NOT_FOUND", "lottery result not found", 404, nil)
+		}
+		return nil, err
+	}
+
+	// Doble confirmación: el dominio rechaza al mismo admin que registró el resultado
+	if err := result.Confirm(adminID); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+	}
+
+	if err := uc.resultRepo.Update(result); err != nil {
+		uc.log.Error("Error confirming lottery result",
+			logger.Int64("lottery_result_id", result.ID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin confirmed lottery result",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("lottery_result_id", result.ID),
+		logger.String("draw_date", result.DrawDate.Format("2006-01-02")),
+		logger.String("winning_number", result.WinningNumber),
+		logger.String("action", "admin_confirm_lottery_result"))
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package lottery
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ListLotteryResultsInput datos de entrada
+type ListLotteryResultsInput struct {
+	Page     int
+	PageSize int
+	Status   *domain.LotteryResultStatus
+}
+
+// ListLotteryResultsOutput resultado
+type ListLotteryResultsOutput struct {
+	Results    []*domain.LotteryResult `json:"results"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// ListLotteryResultsUseCase caso de uso para listar resultados de lotería
+type ListLotteryResultsUseCase struct {
+	resultRepo domain.LotteryResultRepository
+	log        *logger.Logger
+}
+
+// NewListLotteryResultsUseCase crea una nueva instancia
+func NewListLotteryResultsUseCase(gormDB *gorm.DB, log *logger.Logger) *ListLotteryResultsUseCase {
+	return &ListLotteryResultsUseCase{
+		resultRepo: db.NewLotteryResultRepository(gormDB, log),
+		log:        log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListLotteryResultsUseCase) Execute(ctx context.Context, input *ListLotteryResultsInput, adminID int64) (*ListLotteryResultsOutput, error) {
+	// Validar paginación
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+
+	results, total, err := uc.resultRepo.List(input.Status, input.PageSize, offset)
+	if err != nil {
+		uc.log.Error("Error listing lottery results", logger.Error(err))
+		return nil, err
+	}
+
+	totalPages := int(total) / input.PageSize
+	if int(total)%input.PageSize > 0 {
+		totalPages++
+	}
+
+	return &ListLotteryResultsOutput{
+		Results:    results,
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
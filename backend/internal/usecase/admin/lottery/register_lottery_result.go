@@ -0,0 +1,66 @@
+package lottery
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// RegisterLotteryResultInput datos de entrada
+type RegisterLotteryResultInput struct {
+	DrawDate      string // YYYY-MM-DD (hora de Costa Rica)
+	WinningNumber string
+	DrawNumber    string
+	Series        string
+}
+
+// RegisterLotteryResultUseCase caso de uso para el ingreso manual de un resultado oficial
+// El resultado queda pendiente hasta que un segundo admin lo confirme
+type RegisterLotteryResultUseCase struct {
+	resultRepo domain.LotteryResultRepository
+	log        *logger.Logger
+}
+
+// NewRegisterLotteryResultUseCase crea una nueva instancia
+func NewRegisterLotteryResultUseCase(gormDB *gorm.DB, log *logger.Logger) *RegisterLotteryResultUseCase {
+	return &RegisterLotteryResultUseCase{
+		resultRepo: db.NewLotteryResultRepository(gormDB, log),
+		log:        log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RegisterLotteryResultUseCase) Execute(ctx context.Context, input *RegisterLotteryResultInput, adminID int64) (*domain.LotteryResult, error) {
+	drawDate, err := time.Parse("2006-01-02", input.DrawDate)
+	if err != nil {
+		return nil, errors.New("VALIDATION_FAILED", "draw_date must be in YYYY-MM-DD format", 400, nil)
+	}
+
+	result := domain.NewManualLotteryResult(drawDate, strings.TrimSpace(input.WinningNumber), adminID)
+	if drawNumber := strings.TrimSpace(input.DrawNumber); drawNumber != "" {
+		result.DrawNumber = &drawNumber
+	}
+	if series := strings.TrimSpace(input.Series); series != "" {
+		result.Series = &series
+	}
+
+	if err := uc.resultRepo.Create(result); err != nil {
+		return nil, err
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin registered lottery result",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("lottery_result_id", result.ID),
+		logger.String("draw_date", input.DrawDate),
+		logger.String("winning_number", result.WinningNumber),
+		logger.String("action", "admin_register_lottery_result"))
+
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package lottery
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// RejectLotteryResultInput datos de entrada
+type RejectLotteryResultInput struct {
+	ResultID int64
+	Reason   string
+}
+
+// RejectLotteryResultUseCase caso de uso para rechazar un resultado ingresado por error
+// Al rechazarlo se libera la fecha para registrar el resultado correcto
+type RejectLotteryResultUseCase struct {
+	resultRepo domain.LotteryResultRepository
+	log        *logger.Logger
+}
+
+// NewRejectLotteryResultUseCase crea una nueva instancia
+func NewRejectLotteryResultUseCase(gormDB *gorm.DB, log *logger.Logger) *RejectLotteryResultUseCase {
+	return &RejectLotteryResultUseCase{
+		resultRepo: db.NewLotteryResultRepository(gormDB, log),
+		log:        log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RejectLotteryResultUseCase) Execute(ctx context.Context, input *RejectLotteryResultInput, adminID int64) (*domain.LotteryResult, error) {
+	if input.Reason == "" {
+		return nil, errors.New("VALIDATION_FAILED", "reason is required", 400, nil)
+	}
+
+	result, err := uc.resultRepo.FindByID(input.ResultID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("LOTTERY_RESULT_NOT_FOUND", "lottery result not found", 404, nil)
+		}
+		return nil, err
+	}
+
+	if err := result.Reject(adminID, input.Reason); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+	}
+
+	if err := uc.resultRepo.Update(result); err != nil {
+		uc.log.Error("Error rejecting lottery result",
+			logger.Int64("lottery_result_id", result.ID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin rejected lottery result",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("lottery_result_id", result.ID),
+		logger.String("reason", input.Reason),
+		logger.String("action", "admin_reject_lottery_result"))
+
+	return result, nil
+}
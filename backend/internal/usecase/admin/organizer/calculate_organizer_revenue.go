@@ -190,7 +190,7 @@ func (uc *CalculateOrganizerRevenueUseCase) calculateRevenueForPeriod(ctx contex
 		SELECT
 			COUNT(*) as total_raffles,
 			COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_raffles,
-			COALESCE(SUM(CASE WHEN status = 'completed' THEN price_per_number * sold_count ELSE 0 END), 0) as gross_revenue
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN ` + db.PaidRevenueSQL + ` ELSE 0 END), 0) as gross_revenue
 		FROM raffles
 		WHERE user_id = ?
 			AND deleted_at IS NULL
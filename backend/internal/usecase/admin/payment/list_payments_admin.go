@@ -27,6 +27,7 @@ type Payment struct {
 	PaidAt                *time.Time `json:"paid_at,omitempty"`
 	// Campos adicionales para admin (pueden no estar en la tabla actual)
 	Provider     string     `json:"provider,omitempty"` // stripe, paypal, etc
+	RefundedAmount float64  `json:"refunded_amount"`
 	RefundedAt   *time.Time `json:"refunded_at,omitempty"`
 	RefundedBy   *int64     `json:"refunded_by,omitempty"`
 	AdminNotes   string     `json:"admin_notes,omitempty"`
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/usecase/refund"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
@@ -22,23 +24,28 @@ type ProcessRefundInput struct {
 // ProcessRefundOutput resultado
 type ProcessRefundOutput struct {
 	PaymentID     string
+	RefundID      int64
 	RefundAmount  float64
 	RefundType    string // "full" o "partial"
+	RefundStatus  string // pending, processing, succeeded, failed
+	RefundMethod  string // provider, wallet_credit
 	Success       bool
 	FailureReason string
 }
 
 // ProcessRefundUseCase caso de uso para procesar reembolsos
 type ProcessRefundUseCase struct {
-	db  *gorm.DB
-	log *logger.Logger
+	db              *gorm.DB
+	refundProcessor *refund.RefundProcessor
+	log             *logger.Logger
 }
 
 // NewProcessRefundUseCase crea una nueva instancia
-func NewProcessRefundUseCase(db *gorm.DB, log *logger.Logger) *ProcessRefundUseCase {
+func NewProcessRefundUseCase(db *gorm.DB, refundProcessor *refund.RefundProcessor, log *logger.Logger) *ProcessRefundUseCase {
 	return &ProcessRefundUseCase{
-		db:  db,
-		log: log,
+		db:              db,
+		refundProcessor: refundProcessor,
+		log:             log,
 	}
 }
 
@@ -59,55 +66,53 @@ func (uc *ProcessRefundUseCase) Execute(ctx context.Context, input *ProcessRefun
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
-	// Validar que el pago esté succeeded
+	// Validar que el pago esté succeeded (los reembolsos parciales lo mantienen succeeded)
 	if payment.Status != "succeeded" {
 		return nil, errors.New("VALIDATION_FAILED",
 			fmt.Sprintf("cannot refund payment with status %s", payment.Status), 400, nil)
 	}
 
-	// Validar que no esté ya refunded
-	if payment.Status == "refunded" {
-		return nil, errors.New("VALIDATION_FAILED", "payment is already refunded", 400, nil)
+	// Determinar tipo de refund: total si cubre todo lo que falta por reembolsar
+	refundType := "full"
+	if input.Amount != nil && *input.Amount < payment.Amount-payment.RefundedAmount {
+		refundType = "partial"
 	}
 
-	// Determinar tipo de refund y amount
-	refundAmount := payment.Amount
-	refundType := "full"
+	reason := fmt.Sprintf("Refunded by admin ID %d. Reason: %s", adminID, input.Reason)
+	if input.Notes != "" {
+		reason += fmt.Sprintf(". Notes: %s", input.Notes)
+	}
 
-	if input.Amount != nil {
-		if *input.Amount <= 0 || *input.Amount > payment.Amount {
-			return nil, errors.New("VALIDATION_FAILED",
-				fmt.Sprintf("invalid refund amount: must be between 0 and %.2f", payment.Amount), 400, nil)
-		}
-		refundAmount = *input.Amount
-		refundType = "partial"
+	// Ejecutar reembolso real (proveedor o billetera); si falla queda en reintento
+	paymentRefund, err := uc.refundProcessor.Request(ctx, &refund.RequestRefundInput{
+		PaymentID:   input.PaymentID,
+		Amount:      input.Amount,
+		Reason:      reason,
+		RequestedBy: &adminID,
+	})
+	if paymentRefund == nil {
+		return nil, err
 	}
 
+	refundAmount, _ := paymentRefund.Amount.Float64()
 	output := &ProcessRefundOutput{
 		PaymentID:    input.PaymentID,
+		RefundID:     paymentRefund.ID,
 		RefundAmount: refundAmount,
 		RefundType:   refundType,
+		RefundStatus: string(paymentRefund.Status),
+		RefundMethod: string(paymentRefund.Method),
+		Success:      paymentRefund.Status == domain.PaymentRefundStatusSucceeded,
+	}
+	if paymentRefund.LastError != nil && !output.Success {
+		output.FailureReason = *paymentRefund.LastError
 	}
 
-	// TODO: Integrar con payment provider real
-	// Por ahora, simulamos el proceso
-	var refundError error
-
-	// Simular llamada a Stripe/PayPal
-	// if payment.StripePaymentIntent != nil {
-	//     refundError = uc.stripeService.Refund(*payment.StripePaymentIntent, refundAmount)
-	// } else if payment.PayPalOrderID != nil {
-	//     refundError = uc.paypalService.Refund(*payment.PayPalOrderID, refundAmount)
-	// }
-
-	if refundError != nil {
-		output.Success = false
-		output.FailureReason = refundError.Error()
-
+	if paymentRefund.Status == domain.PaymentRefundStatusFailed {
 		uc.log.Error("Refund failed",
 			logger.String("payment_id", input.PaymentID),
 			logger.Float64("amount", refundAmount),
-			logger.Error(refundError))
+			logger.String("reason", output.FailureReason))
 
 		return output, nil
 	}
@@ -120,32 +125,7 @@ func (uc *ProcessRefundUseCase) Execute(ctx context.Context, input *ProcessRefun
 		}
 	}()
 
-	// Actualizar pago
 	now := time.Now()
-	updates := map[string]interface{}{
-		"status":      "refunded",
-		"refunded_at": now,
-		"refunded_by": adminID,
-		"updated_at":  now,
-	}
-
-	// Guardar razón y notas en metadata o admin_notes
-	adminNotes := fmt.Sprintf("Refunded by admin ID %d. Reason: %s", adminID, input.Reason)
-	if input.Notes != "" {
-		adminNotes += fmt.Sprintf(". Notes: %s", input.Notes)
-	}
-	if refundType == "partial" {
-		adminNotes += fmt.Sprintf(". Partial refund: $%.2f of $%.2f", refundAmount, payment.Amount)
-	}
-	updates["admin_notes"] = adminNotes
-
-	if err := tx.Table("payments").
-		Where("id = ?", input.PaymentID).
-		Updates(updates).Error; err != nil {
-		tx.Rollback()
-		uc.log.Error("Error updating payment status", logger.Error(err))
-		return nil, errors.Wrap(errors.ErrDatabaseError, err)
-	}
 
 	// Si es refund completo, liberar números
 	// NOTA: raffle_numbers usa UUIDs para raffle_id y user_id
@@ -190,8 +170,6 @@ func (uc *ProcessRefundUseCase) Execute(ctx context.Context, input *ProcessRefun
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
-	output.Success = true
-
 	// Log auditoría crítica
 	uc.log.Error("Admin processed refund",
 		logger.Int64("admin_id", adminID),
@@ -200,6 +178,8 @@ func (uc *ProcessRefundUseCase) Execute(ctx context.Context, input *ProcessRefun
 		logger.String("raffle_id", payment.RaffleID),
 		logger.Float64("amount", refundAmount),
 		logger.String("type", refundType),
+		logger.String("refund_status", output.RefundStatus),
+		logger.String("refund_method", output.RefundMethod),
 		logger.String("reason", input.Reason),
 		logger.String("action", "admin_process_refund"),
 		logger.String("severity", "critical"))
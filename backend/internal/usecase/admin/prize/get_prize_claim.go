@@ -0,0 +1,38 @@
+package prize
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// GetPrizeClaimUseCase caso de uso para obtener el detalle de un reclamo de premio
+type GetPrizeClaimUseCase struct {
+	claimRepo domain.PrizeClaimRepository
+	log       *logger.Logger
+}
+
+// NewGetPrizeClaimUseCase crea una nueva instancia
+func NewGetPrizeClaimUseCase(gormDB *gorm.DB, log *logger.Logger) *GetPrizeClaimUseCase {
+	return &GetPrizeClaimUseCase{
+		claimRepo: db.NewPrizeClaimRepository(gormDB, log),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetPrizeClaimUseCase) Execute(ctx context.Context, claimID int64, adminID int64) (*domain.PrizeClaim, error) {
+	claim, err := uc.claimRepo.FindByID(claimID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("PRIZE_CLAIM_NOT_FOUND", "prize claim not found", 404, nil)
+		}
+		return nil, err
+	}
+
+	return claim, nil
+}
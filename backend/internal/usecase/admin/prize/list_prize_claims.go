@@ -0,0 +1,80 @@
+package prize
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ListPrizeClaimsInput datos de entrada
+type ListPrizeClaimsInput struct {
+	Page      int
+	PageSize  int
+	Status    *domain.PrizeClaimStatus
+	PrizeType *domain.PrizeType
+	OnlyOpen  bool
+	RaffleID  *int64
+}
+
+// ListPrizeClaimsOutput resultado
+type ListPrizeClaimsOutput struct {
+	Claims     []*domain.PrizeClaim `json:"claims"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	PageSize   int                  `json:"page_size"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+// ListPrizeClaimsUseCase caso de uso para listar reclamos de premios
+type ListPrizeClaimsUseCase struct {
+	claimRepo domain.PrizeClaimRepository
+	log       *logger.Logger
+}
+
+// NewListPrizeClaimsUseCase crea una nueva instancia
+func NewListPrizeClaimsUseCase(gormDB *gorm.DB, log *logger.Logger) *ListPrizeClaimsUseCase {
+	return &ListPrizeClaimsUseCase{
+		claimRepo: db.NewPrizeClaimRepository(gormDB, log),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListPrizeClaimsUseCase) Execute(ctx context.Context, input *ListPrizeClaimsInput, adminID int64) (*ListPrizeClaimsOutput, error) {
+	// Validar paginación
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+
+	claims, total, err := uc.claimRepo.List(domain.PrizeClaimFilters{
+		Status:    input.Status,
+		PrizeType: input.PrizeType,
+		OnlyOpen:  input.OnlyOpen,
+		RaffleID:  input.RaffleID,
+	}, input.PageSize, offset)
+	if err != nil {
+		uc.log.Error("Error listing prize claims", logger.Error(err))
+		return nil, err
+	}
+
+	totalPages := int(total) / input.PageSize
+	if int(total)%input.PageSize > 0 {
+		totalPages++
+	}
+
+	return &ListPrizeClaimsOutput{
+		Claims:     claims,
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package prize
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ResolveAction acción de seguimiento sobre un reclamo de premio físico
+type ResolveAction string
+
+const (
+	ResolveActionConfirmIdentity ResolveAction = "confirm_identity"
+	ResolveActionShip            ResolveAction = "ship"
+	ResolveActionDeliver         ResolveAction = "deliver"
+	ResolveActionCancel          ResolveAction = "cancel"
+)
+
+// ResolvePrizeClaimInput datos de entrada
+type ResolvePrizeClaimInput struct {
+	ClaimID         int64
+	Action          ResolveAction
+	DeliveryAddress string // confirm_identity
+	Carrier         string // ship
+	TrackingNumber  string // ship
+	Notes           string // Requerido para cancel
+}
+
+// ResolvePrizeClaimUseCase caso de uso para avanzar o cerrar un reclamo de premio físico
+type ResolvePrizeClaimUseCase struct {
+	claimRepo domain.PrizeClaimRepository
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewResolvePrizeClaimUseCase crea una nueva instancia
+func NewResolvePrizeClaimUseCase(gormDB *gorm.DB, log *logger.Logger) *ResolvePrizeClaimUseCase {
+	return &ResolvePrizeClaimUseCase{
+		claimRepo: db.NewPrizeClaimRepository(gormDB, log),
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ResolvePrizeClaimUseCase) Execute(ctx context.Context, input *ResolvePrizeClaimInput, adminID int64) (*domain.PrizeClaim, error) {
+	claim, err := uc.claimRepo.FindByID(input.ClaimID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("PRIZE_CLAIM_NOT_FOUND", "prize claim not found", 404, nil)
+		}
+		return nil, err
+	}
+
+	if claim.PrizeType != domain.PrizeTypePhysical {
+		return nil, errors.New("VALIDATION_FAILED", "only physical prize claims can be resolved", 400, nil)
+	}
+
+	previousStatus := claim.Status
+
+	switch input.Action {
+	case ResolveActionConfirmIdentity:
+		err = claim.ConfirmIdentity(adminID, input.DeliveryAddress, input.Notes)
+	case ResolveActionShip:
+		err = claim.MarkShipped(input.Carrier, input.TrackingNumber)
+	case ResolveActionDeliver:
+		err = claim.MarkDelivered(adminID, input.Notes)
+	case ResolveActionCancel:
+		err = claim.Cancel(adminID, input.Notes)
+	default:
+		return nil, errors.New("VALIDATION_FAILED", fmt.Sprintf("invalid action: %s", input.Action), 400, nil)
+	}
+	if err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+	}
+
+	if err := uc.claimRepo.Update(claim); err != nil {
+		uc.log.Error("Error resolving prize claim",
+			logger.Int64("prize_claim_id", claim.ID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionPrizeClaimResolved).
+		WithAdmin(adminID).
+		WithUser(claim.WinnerUserID).
+		WithEntity("prize_claim", claim.ID).
+		WithDescription(fmt.Sprintf("Reclamo de premio: %s -> %s", previousStatus, claim.Status)).
+		WithMetadata(map[string]interface{}{
+			"raffle_id":       claim.RaffleID,
+			"action":          input.Action,
+			"previous_status": previousStatus,
+			"status":          claim.Status,
+			"notes":           input.Notes,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin resolved prize claim",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("prize_claim_id", claim.ID),
+		logger.String("resolve_action", string(input.Action)),
+		logger.String("status", string(claim.Status)),
+		logger.String("action", "admin_resolve_prize_claim"))
+
+	return claim, nil
+}
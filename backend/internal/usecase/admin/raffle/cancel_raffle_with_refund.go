@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/usecase/refund"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
@@ -21,25 +22,40 @@ type CancelRaffleWithRefundInput struct {
 type CancelRaffleWithRefundOutput struct {
 	RaffleID         int64
 	TotalPayments    int
-	RefundsInitiated int
+	RefundsInitiated int // Reembolsos creados junto con la cancelación (incluye los que quedaron en reintento)
+	RefundsCompleted int // Reembolsos confirmados por el proveedor o acreditados a billetera
+	RefundsPending   int // En proceso en el proveedor o esperando reintento
 	RefundsFailed    int
 	TotalRefunded    float64
 }
 
+// RaffleCancellationMailer envía el aviso de cancelación a compradores y organizador
+type RaffleCancellationMailer interface {
+	SendRaffleCancelledEmail(email, raffleTitle, reason string) error
+}
+
 // CancelRaffleWithRefundUseCase caso de uso para cancelar rifa con reembolsos
 type CancelRaffleWithRefundUseCase struct {
-	db  *gorm.DB
-	log *logger.Logger
+	db              *gorm.DB
+	refundProcessor *refund.RefundProcessor
+	mailer          RaffleCancellationMailer // nil: no se envían avisos
+	log             *logger.Logger
 }
 
 // NewCancelRaffleWithRefundUseCase crea una nueva instancia
-func NewCancelRaffleWithRefundUseCase(db *gorm.DB, log *logger.Logger) *CancelRaffleWithRefundUseCase {
+func NewCancelRaffleWithRefundUseCase(db *gorm.DB, refundProcessor *refund.RefundProcessor, log *logger.Logger) *CancelRaffleWithRefundUseCase {
 	return &CancelRaffleWithRefundUseCase{
-		db:  db,
-		log: log,
+		db:              db,
+		refundProcessor: refundProcessor,
+		log:             log,
 	}
 }
 
+// SetMailer configura el envío de avisos de cancelación
+func (uc *CancelRaffleWithRefundUseCase) SetMailer(mailer RaffleCancellationMailer) {
+	uc.mailer = mailer
+}
+
 // Execute ejecuta el caso de uso
 func (uc *CancelRaffleWithRefundUseCase) Execute(ctx context.Context, input *CancelRaffleWithRefundInput, adminID int64) (*CancelRaffleWithRefundOutput, error) {
 	// Validar razón
@@ -47,6 +63,61 @@ func (uc *CancelRaffleWithRefundUseCase) Execute(ctx context.Context, input *Can
 		return nil, errors.New("VALIDATION_FAILED", "reason is required for cancellation with refund", 400, nil)
 	}
 
+	output, err := uc.cancel(ctx, input, &adminID,
+		fmt.Sprintf("Cancelled by admin ID %d with refunds. Reason: %s", adminID, input.Reason),
+		fmt.Sprintf("Raffle %d cancelled by admin: %s", input.RaffleID, input.Reason))
+	if err != nil {
+		return nil, err
+	}
+
+	// Log auditoría crítica
+	uc.log.Error("Admin cancelled raffle with refunds",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("raffle_id", input.RaffleID),
+		logger.Int("total_payments", output.TotalPayments),
+		logger.Int("refunds_initiated", output.RefundsInitiated),
+		logger.Int("refunds_completed", output.RefundsCompleted),
+		logger.Int("refunds_pending", output.RefundsPending),
+		logger.Int("refunds_failed", output.RefundsFailed),
+		logger.Float64("total_refunded", output.TotalRefunded),
+		logger.String("reason", input.Reason),
+		logger.String("action", "admin_cancel_raffle_with_refund"),
+		logger.String("severity", "critical"))
+
+	return output, nil
+}
+
+// CancelAutomatically cancela la rifa con reembolsos por un proceso del sistema (sin admin)
+// Lo usa el job de mínimo de ventas; los reembolsos quedan sin solicitante
+func (uc *CancelRaffleWithRefundUseCase) CancelAutomatically(ctx context.Context, raffleID int64, reason string) error {
+	input := &CancelRaffleWithRefundInput{
+		RaffleID: raffleID,
+		Reason:   reason,
+	}
+	output, err := uc.cancel(ctx, input, nil,
+		fmt.Sprintf("Cancelled automatically with refunds. Reason: %s", reason),
+		fmt.Sprintf("Raffle %d cancelled automatically: %s", raffleID, reason))
+	if err != nil {
+		return err
+	}
+
+	uc.log.Warn("Raffle cancelled automatically with refunds",
+		logger.Int64("raffle_id", input.RaffleID),
+		logger.Int("total_payments", output.TotalPayments),
+		logger.Int("refunds_initiated", output.RefundsInitiated),
+		logger.Int("refunds_completed", output.RefundsCompleted),
+		logger.Int("refunds_pending", output.RefundsPending),
+		logger.Int("refunds_failed", output.RefundsFailed),
+		logger.Float64("total_refunded", output.TotalRefunded),
+		logger.String("reason", input.Reason),
+		logger.String("action", "auto_cancel_raffle_with_refund"))
+
+	return nil
+}
+
+// cancel cancela la rifa, libera sus números, reembolsa los pagos y avisa a los involucrados
+// requestedBy es nil cuando la cancelación la ejecuta el sistema
+func (uc *CancelRaffleWithRefundUseCase) cancel(ctx context.Context, input *CancelRaffleWithRefundInput, requestedBy *int64, adminNotes, refundReason string) (*CancelRaffleWithRefundOutput, error) {
 	// Obtener rifa
 	var raffle domain.Raffle
 	if err := uc.db.Where("id = ?", input.RaffleID).First(&raffle).Error; err != nil {
@@ -75,81 +146,27 @@ func (uc *CancelRaffleWithRefundUseCase) Execute(ctx context.Context, input *Can
 		}
 	}()
 
-	// Obtener todos los pagos succeeded de esta rifa
-	var payments []struct {
-		ID                  int64
-		UserID              int64
-		Amount              float64
-		StripePaymentIntent *string
-		PayPalOrderID       *string
+	// Actualizar rifa a cancelled (condicional: otra cancelación o el sorteo pudieron adelantarse)
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      domain.RaffleStatusCancelled,
+		"updated_at":  now,
+		"deleted_at":  now, // Soft delete
+		"admin_notes": adminNotes,
 	}
 
-	if err := tx.Table("payments").
-		Select("id, user_id, amount, stripe_payment_intent, paypal_order_id").
-		Where("raffle_id = ? AND status = ?", input.RaffleID, "succeeded").
-		Find(&payments).Error; err != nil {
+	result := tx.Model(&domain.Raffle{}).
+		Where("id = ? AND status NOT IN ?", input.RaffleID,
+			[]domain.RaffleStatus{domain.RaffleStatusCompleted, domain.RaffleStatusCancelled}).
+		Updates(updates)
+	if result.Error != nil {
 		tx.Rollback()
-		uc.log.Error("Error getting payments for refund", logger.Error(err))
-		return nil, errors.Wrap(errors.ErrDatabaseError, err)
-	}
-
-	output := &CancelRaffleWithRefundOutput{
-		RaffleID:      input.RaffleID,
-		TotalPayments: len(payments),
-	}
-
-	// Procesar reembolsos
-	// NOTA: En producción, esto debería integrarse con Stripe/PayPal API
-	// Por ahora, solo marcamos los pagos como refunded
-	for _, payment := range payments {
-		// TODO: Integrar con payment provider real
-		// if payment.StripePaymentIntent != nil {
-		//     err := stripe.Refund(*payment.StripePaymentIntent, payment.Amount)
-		// } else if payment.PayPalOrderID != nil {
-		//     err := paypal.Refund(*payment.PayPalOrderID, payment.Amount)
-		// }
-
-		// Marcar pago como refunded
-		if err := tx.Table("payments").
-			Where("id = ?", payment.ID).
-			Updates(map[string]interface{}{
-				"status":     "refunded",
-				"updated_at": time.Now(),
-			}).Error; err != nil {
-			uc.log.Error("Error marking payment as refunded",
-				logger.Int64("payment_id", payment.ID),
-				logger.Error(err))
-			output.RefundsFailed++
-			continue
-		}
-
-		output.RefundsInitiated++
-		output.TotalRefunded += payment.Amount
-
-		uc.log.Info("Payment marked as refunded",
-			logger.Int64("payment_id", payment.ID),
-			logger.Int64("user_id", payment.UserID),
-			logger.Float64("amount", payment.Amount))
+		uc.log.Error("Error cancelling raffle", logger.Error(result.Error))
+		return nil, errors.Wrap(errors.ErrDatabaseError, result.Error)
 	}
-
-	// Actualizar rifa a cancelled
-	now := time.Now()
-	updates := map[string]interface{}{
-		"status":     domain.RaffleStatusCancelled,
-		"updated_at": now,
-		"deleted_at": now, // Soft delete
-		"admin_notes": fmt.Sprintf(
-			"Cancelled by admin ID %d with refunds. Reason: %s. Refunds: %d/%d successful",
-			adminID, input.Reason, output.RefundsInitiated, output.TotalPayments,
-		),
-	}
-
-	if err := tx.Model(&domain.Raffle{}).
-		Where("id = ?", input.RaffleID).
-		Updates(updates).Error; err != nil {
+	if result.RowsAffected == 0 {
 		tx.Rollback()
-		uc.log.Error("Error cancelling raffle", logger.Error(err))
-		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		return nil, errors.New("VALIDATION_FAILED", "raffle is already completed or cancelled", 400, nil)
 	}
 
 	// Liberar números reservados/vendidos
@@ -165,27 +182,102 @@ func (uc *CancelRaffleWithRefundUseCase) Execute(ctx context.Context, input *Can
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
+	// Obtener todos los pagos succeeded de esta rifa (payments referencia raffles.uuid)
+	// Se consultan tras marcar la rifa cancelada para no dejar fuera ventas concurrentes
+	var paymentIDs []string
+	if err := tx.Table("payments").
+		Where("raffle_id = ? AND status = ?", raffle.UUID, "succeeded").
+		Order("created_at ASC").
+		Pluck("id", &paymentIDs).Error; err != nil {
+		tx.Rollback()
+		uc.log.Error("Error getting payments for refund", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	// Registrar los reembolsos pendientes junto con la cancelación: si el proceso cae antes de
+	// ejecutarlos, el job de reembolsos los encuentra y los completa
+	refunds := make([]*domain.PaymentRefund, 0, len(paymentIDs))
+	for _, paymentID := range paymentIDs {
+		paymentRefund, err := uc.refundProcessor.Prepare(ctx, tx, &refund.RequestRefundInput{
+			PaymentID:   paymentID,
+			Reason:      refundReason,
+			RequestedBy: requestedBy,
+		})
+		if err != nil {
+			tx.Rollback()
+			uc.log.Error("Error creating refund",
+				logger.String("payment_id", paymentID),
+				logger.Error(err))
+			return nil, err
+		}
+		refunds = append(refunds, paymentRefund)
+	}
+
 	// Commit transacción
 	if err := tx.Commit().Error; err != nil {
 		uc.log.Error("Error committing transaction", logger.Error(err))
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
-	// Log auditoría crítica
-	uc.log.Error("Admin cancelled raffle with refunds",
-		logger.Int64("admin_id", adminID),
-		logger.Int64("raffle_id", input.RaffleID),
-		logger.Int("total_payments", output.TotalPayments),
-		logger.Int("refunds_initiated", output.RefundsInitiated),
-		logger.Int("refunds_failed", output.RefundsFailed),
-		logger.Float64("total_refunded", output.TotalRefunded),
-		logger.String("reason", input.Reason),
-		logger.String("action", "admin_cancel_raffle_with_refund"),
-		logger.String("severity", "critical"))
+	output := &CancelRaffleWithRefundOutput{
+		RaffleID:         input.RaffleID,
+		TotalPayments:    len(paymentIDs),
+		RefundsInitiated: len(refunds),
+	}
+
+	// Ejecutar el primer intento de cada reembolso (la rifa ya no acepta ventas)
+	// Los que fallen quedan pendientes y los reintenta el job de reembolsos
+	for _, paymentRefund := range refunds {
+		if err := uc.refundProcessor.Process(ctx, paymentRefund); err != nil {
+			uc.log.Error("Error processing refund",
+				logger.Int64("refund_id", paymentRefund.ID),
+				logger.String("payment_id", paymentRefund.PaymentID),
+				logger.Error(err))
+		}
+
+		switch paymentRefund.Status {
+		case domain.PaymentRefundStatusSucceeded:
+			output.RefundsCompleted++
+			amount, _ := paymentRefund.Amount.Float64()
+			output.TotalRefunded += amount
+		case domain.PaymentRefundStatusFailed:
+			output.RefundsFailed++
+		default:
+			output.RefundsPending++
+		}
+	}
 
-	// TODO: Enviar emails de notificación
-	// - A todos los participantes (confirmación de reembolso)
-	// - Al organizador (notificación de cancelación)
+	// Avisar a compradores y organizador
+	uc.notify(&raffle, paymentIDs, input.Reason)
 
 	return output, nil
 }
+
+// notify envía el aviso de cancelación a los compradores de los pagos reembolsados y al organizador
+// Los errores de envío se registran sin afectar la cancelación
+func (uc *CancelRaffleWithRefundUseCase) notify(raffle *domain.Raffle, paymentIDs []string, reason string) {
+	if uc.mailer == nil {
+		return
+	}
+
+	buyers := uc.db.Table("payments").Select("user_id").Where("id IN ?", paymentIDs)
+	var emails []string
+	if err := uc.db.Table("users").
+		Where("deleted_at IS NULL").
+		Where("uuid IN (?) OR id = ?", buyers, raffle.UserID).
+		Pluck("email", &emails).Error; err != nil {
+		uc.log.Error("Error getting raffle cancellation recipients",
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Error(err))
+		return
+	}
+
+	for _, email := range emails {
+		if err := uc.mailer.SendRaffleCancelledEmail(email, raffle.Title, reason); err != nil {
+			uc.log.Error("Error sending raffle cancellation email",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.String("email", email),
+				logger.Error(err))
+		}
+	}
+}
@@ -35,6 +35,8 @@ type ListRafflesAdminInput struct {
 	DateTo       *string
 	OrderBy      string
 	IncludeAll   bool // Si true, incluye rifas eliminadas (deleted_at)
+	PendingDraw  bool // Si true, solo rifas activas con sorteo vencido pendiente
+	PendingReason *domain.DrawPendingReason
 }
 
 // ListRafflesAdminOutput resultado
@@ -111,6 +113,14 @@ func (uc *ListRafflesAdminUseCase) Execute(ctx context.Context, input *ListRaffl
 		query = query.Where("raffles.created_at <= ?", *input.DateTo)
 	}
 
+	// Sorteos vencidos que no pudieron completarse (los que requieren admin salen del job)
+	if input.PendingDraw || input.PendingReason != nil {
+		query = query.Where("raffles.status = ? AND raffles.draw_pending_reason IS NOT NULL", domain.RaffleStatusActive)
+		if input.PendingReason != nil {
+			query = query.Where("raffles.draw_pending_reason = ?", *input.PendingReason)
+		}
+	}
+
 	// Contar total
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -120,6 +130,9 @@ func (uc *ListRafflesAdminUseCase) Execute(ctx context.Context, input *ListRaffl
 
 	// Aplicar ordenamiento
 	orderBy := "raffles.created_at DESC"
+	if input.PendingDraw || input.PendingReason != nil {
+		orderBy = "raffles.draw_pending_since ASC"
+	}
 	if input.OrderBy != "" {
 		orderBy = input.OrderBy
 	}
@@ -2,12 +2,12 @@ package raffle
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
-	"math/big"
 	"time"
 
+	"github.com/sorteos-platform/backend/internal/adapters/db"
 	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
@@ -15,9 +15,9 @@ import (
 
 // ManualDrawWinnerInput datos de entrada
 type ManualDrawWinnerInput struct {
-	RaffleID      int64
-	WinnerNumber  *string // Si es nil, se selecciona aleatoriamente
-	Reason        string  // Razón del sorteo manual
+	RaffleID     int64
+	WinnerNumber *string // Si es nil, se selecciona aleatoriamente
+	Reason       string  // Razón del sorteo manual
 }
 
 // ManualDrawWinnerOutput resultado
@@ -26,24 +26,32 @@ type ManualDrawWinnerOutput struct {
 	WinnerUserID *int64
 	WinnerName   *string
 	WinnerEmail  *string
+	Winners      []*domain.RaffleWinner // Ganador de cada premio (el premio mayor es el primero)
+	DrawProof    *domain.DrawProof      // Solo para premios sorteados aleatoriamente
 }
 
 // ManualDrawWinnerUseCase caso de uso para ejecutar sorteo manual
 type ManualDrawWinnerUseCase struct {
-	db  *gorm.DB
-	log *logger.Logger
+	db          *gorm.DB
+	drawRoom    *raffleuc.DrawRoomService
+	drawEntropy *raffleuc.DrawEntropyResolver
+	log         *logger.Logger
 }
 
 // NewManualDrawWinnerUseCase crea una nueva instancia
-func NewManualDrawWinnerUseCase(db *gorm.DB, log *logger.Logger) *ManualDrawWinnerUseCase {
+// El sorteo se transmite por etapas en la sala en vivo de la rifa y su entropía
+// es la ronda del beacon comprometida al publicarla (el admin no puede elegirla)
+func NewManualDrawWinnerUseCase(db *gorm.DB, drawRoom *raffleuc.DrawRoomService, drawEntropy *raffleuc.DrawEntropyResolver, log *logger.Logger) *ManualDrawWinnerUseCase {
 	return &ManualDrawWinnerUseCase{
-		db:  db,
-		log: log,
+		db:          db,
+		drawRoom:    drawRoom,
+		drawEntropy: drawEntropy,
+		log:         log,
 	}
 }
 
 // Execute ejecuta el caso de uso
-func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDrawWinnerInput, adminID int64) (*ManualDrawWinnerOutput, error) {
+func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDrawWinnerInput, adminID int64) (output *ManualDrawWinnerOutput, err error) {
 	// Validar razón
 	if input.Reason == "" {
 		return nil, errors.New("VALIDATION_FAILED", "reason is required for manual draw", 400, nil)
@@ -70,46 +78,122 @@ func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDra
 		return nil, errors.New("VALIDATION_FAILED", "raffle already has a winner", 400, nil)
 	}
 
-	// Determinar número ganador
-	var winnerNumber string
+	// Abrir la sala en vivo: si el sorteo no se completa se registra como abortado
+	session := uc.drawRoom.Start(&raffle, map[string]interface{}{
+		"manual": true,
+	})
+	defer func() {
+		if err != nil {
+			session.Abort("El sorteo manual no se pudo completar")
+		}
+	}()
+
+	// Premios a sortear: si hay menos números vendidos que premios, quedan sin sortear los de menor categoría
+	prizes, err := raffleuc.LoadDrawPrizes(db.NewRafflePrizeRepository(uc.db, uc.log), &raffle)
+	if err != nil {
+		return nil, err
+	}
+
+	soldNumbers, err := uc.listSoldNumbers(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(soldNumbers) == 0 {
+		return nil, errors.New("VALIDATION_FAILED", "no sold numbers available for draw", 400, nil)
+	}
+	if len(prizes) > len(soldNumbers) {
+		prizes = prizes[:len(soldNumbers)]
+	}
+
+	// Determinar número ganador de cada premio
+	var drawProof *domain.DrawProof
+	winnerNumbers := make(map[int]string, len(prizes))
 	if input.WinnerNumber != nil && *input.WinnerNumber != "" {
-		// Usar número especificado por admin
-		winnerNumber = *input.WinnerNumber
+		// Usar número especificado por admin para el premio mayor (solo entre los vendidos)
+		if !containsNumber(soldNumbers, *input.WinnerNumber) {
+			return nil, errors.New("VALIDATION_FAILED",
+				fmt.Sprintf("number %s not found or not sold", *input.WinnerNumber), 400, nil)
+		}
+		winnerNumbers[1] = *input.WinnerNumber
+		session.Step(ctx, "admin_selection", nil)
 
-		// Validar que el número esté en el rango válido
-		// (Aquí asumimos que los números son strings, podría ser necesario convertir)
-		// TODO: Validar que el número esté vendido
+		// Los premios secundarios se sortean de forma verificable entre los demás números vendidos
+		if len(prizes) > 1 {
+			remaining := make([]string, 0, len(soldNumbers))
+			for _, number := range soldNumbers {
+				if number != *input.WinnerNumber {
+					remaining = append(remaining, number)
+				}
+			}
+			if len(prizes)-1 > len(remaining) {
+				prizes = prizes[:len(remaining)+1]
+			}
+		}
+		if len(prizes) > 1 {
+			proof, err := uc.drawProvablyFair(ctx, &raffle, soldNumbers, []string{*input.WinnerNumber}, len(prizes)-1)
+			if err != nil {
+				return nil, err
+			}
+			drawProof = proof
+		}
 	} else {
-		// Seleccionar aleatoriamente de los números vendidos
-		selectedNumber, err := uc.selectRandomSoldNumber(input.RaffleID)
+		// Seleccionar aleatoriamente de los números vendidos (sorteo verificable)
+		proof, err := uc.drawProvablyFair(ctx, &raffle, soldNumbers, nil, len(prizes))
 		if err != nil {
 			return nil, err
 		}
-		winnerNumber = selectedNumber
+		drawProof = proof
 	}
 
-	// Obtener información del ganador desde raffle_numbers
-	var raffleNumber struct {
-		UserID *int64
+	if drawProof != nil {
+		for position, number := range drawProof.WinnerNumbers() {
+			winnerNumbers[position] = number
+		}
+
+		session.Step(ctx, "proof_computed", map[string]interface{}{
+			"candidates_count": len(drawProof.Candidates),
+			"public_entropy":   drawProof.PublicEntropy,
+			"beacon_round":     drawProof.BeaconRound,
+			"candidates_hash":  drawProof.CandidatesHash,
+			"winner_index":     drawProof.WinnerIndex,
+			"prizes_count":     len(drawProof.Winners),
+		})
 	}
 
-	if err := uc.db.Table("raffle_numbers").
-		Select("user_id").
-		Where("raffle_id = ? AND number = ?", input.RaffleID, winnerNumber).
-		First(&raffleNumber).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("VALIDATION_FAILED",
-				fmt.Sprintf("number %s not found or not sold", winnerNumber), 400, nil)
+	// Obtener el dueño de cada número ganador desde raffle_numbers
+	winners := make([]*domain.RaffleWinner, 0, len(prizes))
+	for _, prize := range prizes {
+		number := winnerNumbers[prize.Position]
+
+		var raffleNumber struct {
+			UserID *int64
 		}
-		uc.log.Error("Error finding winner number", logger.Error(err))
-		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		if err := uc.db.Table("raffle_numbers").
+			Select("user_id").
+			Where("raffle_id = ? AND number = ? AND status = ?", input.RaffleID, number, domain.RaffleNumberStatusSold).
+			First(&raffleNumber).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.New("VALIDATION_FAILED",
+					fmt.Sprintf("number %s not found or not sold", number), 400, nil)
+			}
+			uc.log.Error("Error finding winner number", logger.Error(err))
+			return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		winners = append(winners, domain.NewRaffleWinner(raffle.ID, prize, number, raffleNumber.UserID))
+	}
+
+	if err := raffle.Complete(winners); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
 	}
+	winnerNumber := *raffle.WinnerNumber
+	winnerUserID := raffle.WinnerUserID
 
-	// Obtener info del usuario ganador si existe
+	// Obtener info del usuario ganador del premio mayor si existe
 	var winnerName, winnerEmail *string
-	if raffleNumber.UserID != nil {
+	if winnerUserID != nil {
 		var user domain.User
-		if err := uc.db.Where("id = ?", *raffleNumber.UserID).First(&user).Error; err == nil {
+		if err := uc.db.Where("id = ?", *winnerUserID).First(&user).Error; err == nil {
 			name := user.GetFullName()
 			winnerName = &name
 			winnerEmail = &user.Email
@@ -121,19 +205,42 @@ func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDra
 	// Actualizar rifa con ganador y marcar como completed
 	updates := map[string]interface{}{
 		"winner_number": winnerNumber,
-		"winner_user_id": raffleNumber.UserID,
+		"winner_user_id": winnerUserID,
 		"status": domain.RaffleStatusCompleted,
 		"completed_at": now,
 		"updated_at": now,
 		"admin_notes": fmt.Sprintf("Manual draw by admin ID %d. Reason: %s", adminID, input.Reason),
+		"draw_pending_reason": nil,
+		"draw_pending_since": nil,
+	}
+
+	if drawProof != nil {
+		proofJSON, err := drawProof.ToJSON()
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServer, err)
+		}
+		updates["draw_proof"] = proofJSON
+		updates["draw_seed_hash"] = raffle.DrawSeedHash
+		updates["draw_server_seed"] = raffle.DrawServerSeed
+		updates["draw_seed_committed_at"] = raffle.DrawSeedCommittedAt
+		updates["draw_beacon_round"] = raffle.DrawBeaconRound
 	}
 
-	if err := uc.db.Model(&domain.Raffle{}).
-		Where("id = ?", input.RaffleID).
-		Updates(updates).Error; err != nil {
+	// La rifa y sus ganadores por premio se guardan juntos
+	if err := uc.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Raffle{}).
+			Where("id = ?", input.RaffleID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		return db.NewRafflePrizeRepository(tx, uc.log).CreateWinners(winners)
+	}); err != nil {
 		uc.log.Error("Error updating raffle with winner",
 			logger.Int64("raffle_id", input.RaffleID),
 			logger.Error(err))
+		if err == db.ErrRaffleWinnerExists {
+			return nil, err
+		}
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
@@ -146,6 +253,24 @@ func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDra
 		logger.String("action", "admin_manual_draw_winner"),
 		logger.String("severity", "critical"))
 
+	// Revelar los ganadores en la sala en vivo (premios secundarios primero)
+	winnersData := raffleuc.DrawWinnersData(winners)
+	for i := len(winners) - 1; i >= 0; i-- {
+		if winners[i].Position == 1 {
+			continue
+		}
+		session.Step(ctx, "prize_winner_revealed", winnersData[i])
+	}
+
+	var seedHash *string
+	if drawProof != nil {
+		seedHash = raffle.DrawSeedHash
+	}
+	session.Reveal(ctx, winnerNumber, map[string]interface{}{
+		"draw_seed_hash": seedHash,
+		"winners":        winnersData,
+	})
+
 	// TODO: Enviar emails
 	// - Al ganador
 	// - Al organizador
@@ -153,35 +278,82 @@ func (uc *ManualDrawWinnerUseCase) Execute(ctx context.Context, input *ManualDra
 
 	return &ManualDrawWinnerOutput{
 		WinnerNumber: winnerNumber,
-		WinnerUserID: raffleNumber.UserID,
+		WinnerUserID: winnerUserID,
 		WinnerName:   winnerName,
 		WinnerEmail:  winnerEmail,
+		Winners:      winners,
+		DrawProof:    drawProof,
 	}, nil
 }
 
-// selectRandomSoldNumber selecciona aleatoriamente un número vendido
-func (uc *ManualDrawWinnerUseCase) selectRandomSoldNumber(raffleID int64) (string, error) {
-	// Obtener todos los números vendidos
+// drawProvablyFair sortea count premios entre los números vendidos con el esquema commit-reveal
+// La semilla comprometida al publicar la rifa se revela y se combina con la ronda del beacon comprometida
+// Los números excluidos (ganador elegido por el admin) no participan y los premios se sortean desde la posición siguiente
+func (uc *ManualDrawWinnerUseCase) drawProvablyFair(ctx context.Context, raffle *domain.Raffle, soldNumbers, excluded []string, count int) (*domain.DrawProof, error) {
+	candidates := soldNumbers
+	if len(excluded) > 0 {
+		skip := make(map[string]bool, len(excluded))
+		for _, number := range excluded {
+			skip[number] = true
+		}
+		candidates = make([]string, 0, len(soldNumbers))
+		for _, number := range soldNumbers {
+			if !skip[number] {
+				candidates = append(candidates, number)
+			}
+		}
+	}
+
+	// Rifas publicadas antes del sorteo verificable no tienen semilla comprometida:
+	// se compromete ahora con una ronda del beacon aún no publicada y el admin reintenta al publicarse
+	if raffle.DrawServerSeed == nil {
+		if err := raffle.CommitDrawSeed(); err != nil {
+			uc.log.Error("Error generating draw seed", logger.Error(err))
+			return nil, errors.Wrap(errors.ErrInternalServer, err)
+		}
+		if _, err := db.NewRaffleRepository(uc.db).CommitDrawSeed(raffle); err != nil {
+			return nil, err
+		}
+		return nil, raffleuc.ErrDrawEntropyNotAvailable
+	}
+
+	entropy, err := uc.drawEntropy.Resolve(ctx, raffle)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := domain.NewPrizesDrawProof(*raffle.DrawServerSeed, entropy.Value, candidates, len(excluded)+1, count, raffle.DrawSeedCommittedAt)
+	if err != nil {
+		uc.log.Error("Error computing draw proof", logger.Error(err))
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	entropy.Apply(proof)
+	proof.Excluded = excluded
+
+	return proof, nil
+}
+
+// listSoldNumbers obtiene los números vendidos de la rifa en orden canónico
+func (uc *ManualDrawWinnerUseCase) listSoldNumbers(raffleID int64) ([]string, error) {
 	var soldNumbers []string
 	if err := uc.db.Table("raffle_numbers").
 		Select("number").
-		Where("raffle_id = ? AND user_id IS NOT NULL", raffleID).
+		Where("raffle_id = ? AND status = ?", raffleID, domain.RaffleNumberStatusSold).
+		Order("number ASC").
 		Pluck("number", &soldNumbers).Error; err != nil {
 		uc.log.Error("Error getting sold numbers", logger.Error(err))
-		return "", errors.Wrap(errors.ErrDatabaseError, err)
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
-	if len(soldNumbers) == 0 {
-		return "", errors.New("VALIDATION_FAILED", "no sold numbers available for draw", 400, nil)
-	}
+	return soldNumbers, nil
+}
 
-	// Seleccionar aleatoriamente
-	maxBig := big.NewInt(int64(len(soldNumbers)))
-	randomIndex, err := rand.Int(rand.Reader, maxBig)
-	if err != nil {
-		uc.log.Error("Error generating random number", logger.Error(err))
-		return "", errors.Wrap(errors.ErrInternalServer, err)
+// containsNumber verifica si el número está en la lista
+func containsNumber(numbers []string, number string) bool {
+	for _, n := range numbers {
+		if n == number {
+			return true
+		}
 	}
-
-	return soldNumbers[randomIndex.Int64()], nil
+	return false
 }
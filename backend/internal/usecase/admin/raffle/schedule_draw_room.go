@@ -0,0 +1,84 @@
+package raffle
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	raffleuc "github.com/sorteos-platform/backend/internal/usecase/raffle"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ScheduleDrawRoomInput datos de entrada
+type ScheduleDrawRoomInput struct {
+	RaffleID    int64
+	ScheduledAt *time.Time // Si es nil, la cuenta regresiva apunta a la fecha de sorteo de la rifa
+	StreamURL   *string    // Transmisión en vivo opcional (http/https)
+}
+
+// ScheduleDrawRoomUseCase caso de uso para programar la cuenta regresiva y la transmisión del sorteo en vivo
+type ScheduleDrawRoomUseCase struct {
+	db       *gorm.DB
+	drawRoom *raffleuc.DrawRoomService
+	log      *logger.Logger
+}
+
+// NewScheduleDrawRoomUseCase crea una nueva instancia
+func NewScheduleDrawRoomUseCase(db *gorm.DB, drawRoom *raffleuc.DrawRoomService, log *logger.Logger) *ScheduleDrawRoomUseCase {
+	return &ScheduleDrawRoomUseCase{
+		db:       db,
+		drawRoom: drawRoom,
+		log:      log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ScheduleDrawRoomUseCase) Execute(ctx context.Context, input *ScheduleDrawRoomInput, adminID int64) (*domain.DrawRoom, error) {
+	// Validar URL de la transmisión
+	if input.StreamURL != nil {
+		if *input.StreamURL == "" {
+			input.StreamURL = nil
+		} else if parsed, err := url.Parse(*input.StreamURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, errors.New("VALIDATION_FAILED", "stream_url must be a valid http(s) URL", 400, nil)
+		}
+	}
+
+	// Obtener rifa
+	var raffle domain.Raffle
+	if err := uc.db.Where("id = ?", input.RaffleID).First(&raffle).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRaffleNotFound
+		}
+		uc.log.Error("Error finding raffle", logger.Int64("raffle_id", input.RaffleID), logger.Error(err))
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	// Solo rifas que aún no se sortean
+	if raffle.Status != domain.RaffleStatusActive && raffle.Status != domain.RaffleStatusSuspended {
+		return nil, errors.New("VALIDATION_FAILED",
+			fmt.Sprintf("raffle must be active or suspended to schedule its draw room, current status: %s", raffle.Status), 400, nil)
+	}
+
+	scheduledAt := raffle.DrawDate
+	if input.ScheduledAt != nil {
+		scheduledAt = *input.ScheduledAt
+	}
+
+	room, err := uc.drawRoom.Schedule(&raffle, scheduledAt, input.StreamURL)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Admin scheduled raffle draw room",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("raffle_id", input.RaffleID),
+		logger.String("scheduled_at", scheduledAt.Format(time.RFC3339)),
+		logger.Bool("has_stream", input.StreamURL != nil),
+		logger.String("action", "admin_schedule_draw_room"))
+
+	return room, nil
+}
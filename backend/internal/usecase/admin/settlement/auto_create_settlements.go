@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sorteos-platform/backend/internal/adapters/db"
+	settlementuc "github.com/sorteos-platform/backend/internal/usecase/settlement"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
@@ -92,12 +93,13 @@ func (uc *AutoCreateSettlementsUseCase) Execute(ctx context.Context, input *Auto
 		Title          string
 		PricePerNumber float64
 		SoldCount      int
+		PaidRevenue    float64
 		CompletedAt    *time.Time
 	}
 
 	result := uc.db.WithContext(ctx).
 		Table("raffles").
-		Select("id, user_id, title, price_per_number, sold_count, completed_at").
+		Select("id, user_id, title, price_per_number, sold_count, completed_at, "+db.PaidRevenueSQL+" AS paid_revenue").
 		Where("status = ?", "completed").
 		Where("completed_at IS NOT NULL").
 		Where("completed_at <= ?", cutoffDate).
@@ -129,6 +131,7 @@ func (uc *AutoCreateSettlementsUseCase) Execute(ctx context.Context, input *Auto
 		Title          string
 		PricePerNumber float64
 		SoldCount      int
+		PaidRevenue    float64
 		CompletedAt    *time.Time
 	})
 
@@ -137,16 +140,45 @@ func (uc *AutoCreateSettlementsUseCase) Execute(ctx context.Context, input *Auto
 	}
 
 	// Procesar cada organizador
+	claimRepo := db.NewPrizeClaimRepository(uc.db.WithContext(ctx), uc.log)
+	raffleRepo := db.NewRaffleRepository(uc.db.WithContext(ctx))
 	for organizerID, raffles := range rafflesByOrganizer {
 		// Obtener comisión del organizador
 		platformFeePercent := uc.getPlatformFeePercent(ctx, organizerID)
 
 		// Procesar cada rifa
 		for _, raffle := range raffles {
-			totalRevenue := raffle.PricePerNumber * float64(raffle.SoldCount)
+			// Ingresos reales: pagos exitosos con descuentos aplicados, netos de reembolsos
+			totalRevenue := raffle.PaidRevenue
 			platformFee := totalRevenue * (platformFeePercent / 100)
 			netAmount := totalRevenue - platformFee
 
+			// Los premios en efectivo se pagan de lo recaudado y no se liquidan al organizador
+			cashPrizes, err := claimRepo.SumCashPrizes(raffle.ID)
+			if err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("Failed to sum cash prizes for raffle %d: %v", raffle.ID, err))
+				continue
+			}
+			prizesWithheld, _ := cashPrizes.Float64()
+			if prizesWithheld > netAmount {
+				errMsg := fmt.Sprintf("Cash prizes (%.2f) of raffle %d exceed its net revenue (%.2f)", prizesWithheld, raffle.ID, netAmount)
+				output.Errors = append(output.Errors, errMsg)
+				uc.log.Error("Raffle cash prizes are not funded by its revenue, skipping settlement",
+					logger.Int64("raffle_id", raffle.ID),
+					logger.Int64("organizer_id", organizerID),
+					logger.Float64("cash_prizes", prizesWithheld),
+					logger.Float64("net_amount", netAmount))
+				continue
+			}
+			netAmount -= prizesWithheld
+
+			// Se liquida en la moneda en que se registraron las compras del sorteo
+			currencies, err := raffleRepo.GetPaidCurrencies(raffle.ID)
+			if err != nil || len(currencies) != 1 {
+				output.Errors = append(output.Errors, fmt.Sprintf("Raffle %d payments are not in a single currency", raffle.ID))
+				continue
+			}
+
 			// Si es dry run, solo simular
 			if input.DryRun {
 				summary := &SettlementSummary{
@@ -169,14 +201,16 @@ func (uc *AutoCreateSettlementsUseCase) Execute(ctx context.Context, input *Auto
 			// Crear settlement real
 			now := time.Now()
 			settlement := map[string]interface{}{
-				"organizer_id":  organizerID,
-				"raffle_id":     raffle.ID,
-				"total_revenue": totalRevenue,
-				"platform_fee":  platformFee,
-				"net_amount":    netAmount,
-				"status":        "pending",
-				"created_at":    now,
-				"updated_at":    now,
+				"organizer_id":    organizerID,
+				"raffle_id":       raffle.ID,
+				"total_revenue":   totalRevenue,
+				"platform_fee":    platformFee,
+				"prizes_withheld": prizesWithheld,
+				"net_amount":      netAmount,
+				"currency":        currencies[0],
+				"status":          "pending",
+				"created_at":      now,
+				"updated_at":      now,
 			}
 
 			result := uc.db.WithContext(ctx).
@@ -202,7 +236,7 @@ func (uc *AutoCreateSettlementsUseCase) Execute(ctx context.Context, input *Auto
 				Scan(&settlementID)
 
 			// Actualizar organizer_profile (incrementar pending_payout)
-			err := uc.updateOrganizerProfile(ctx, organizerID, netAmount)
+			err = uc.updateOrganizerProfile(ctx, organizerID, netAmount)
 			if err != nil {
 				uc.log.Error("Error updating organizer profile",
 					logger.Int64("organizer_id", organizerID),
@@ -273,27 +307,7 @@ func (uc *AutoCreateSettlementsUseCase) validateInput(input *AutoCreateSettlemen
 // getPlatformFeePercent obtiene el porcentaje de comisión del organizador
 // Prioridad: commission_override del organizador > platform_fee_percentage global > 10.0
 func (uc *AutoCreateSettlementsUseCase) getPlatformFeePercent(ctx context.Context, organizerID int64) float64 {
-	var commissionOverride *float64
-
-	uc.db.WithContext(ctx).
-		Table("organizer_profiles").
-		Select("commission_override").
-		Where("user_id = ?", organizerID).
-		Scan(&commissionOverride)
-
-	if commissionOverride != nil && *commissionOverride > 0 {
-		return *commissionOverride
-	}
-
-	// Obtener platform_fee_percentage desde system_parameters
-	platformFee, err := uc.systemParamRepo.GetFloat("platform_fee_percentage", 10.0)
-	if err != nil {
-		uc.log.Error("Error getting platform_fee_percentage, using default",
-			logger.Error(err))
-		return 10.0
-	}
-
-	return platformFee
+	return settlementuc.OrganizerCommission(ctx, uc.db, uc.systemParamRepo, organizerID)
 }
 
 // updateOrganizerProfile actualiza las métricas del organizador
@@ -75,23 +75,55 @@ func (uc *CreateSettlementUseCase) Execute(ctx context.Context, input *CreateSet
 	// Crear settlements
 	var settlementIDs []int64
 	var totalRevenue, totalNetAmount float64
+	claimRepo := db.NewPrizeClaimRepository(uc.db.WithContext(ctx), uc.log)
+	raffleRepo := db.NewRaffleRepository(uc.db.WithContext(ctx))
 
 	for _, raffle := range raffles {
-		// Calcular montos
-		grossRevenue := raffle.PricePerNumber * float64(raffle.SoldCount)
+		// Calcular montos sobre lo efectivamente pagado (con descuentos, sin reembolsos)
+		grossRevenue := raffle.PaidRevenue
 		platformFee := grossRevenue * (platformFeePercent / 100.0)
 		netAmount := grossRevenue - platformFee
 
+		// Los premios en efectivo se pagan de lo recaudado y no se liquidan al organizador
+		cashPrizes, err := claimRepo.SumCashPrizes(raffle.ID)
+		if err != nil {
+			uc.log.Error("Error summing raffle cash prizes, skipping settlement",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+			continue
+		}
+		prizesWithheld, _ := cashPrizes.Float64()
+		if prizesWithheld > netAmount {
+			uc.log.Error("Raffle cash prizes are not funded by its revenue, skipping settlement",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Float64("cash_prizes", prizesWithheld),
+				logger.Float64("net_amount", netAmount))
+			continue
+		}
+		netAmount -= prizesWithheld
+
+		// Se liquida en la moneda en que se registraron las compras del sorteo
+		currencies, err := raffleRepo.GetPaidCurrencies(raffle.ID)
+		if err != nil || len(currencies) != 1 {
+			uc.log.Error("Raffle payments are not in a single currency, skipping settlement",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Int("currencies", len(currencies)),
+				logger.Error(err))
+			continue
+		}
+
 		// Crear settlement
 		settlement := map[string]interface{}{
-			"organizer_id":  input.OrganizerID,
-			"raffle_id":     raffle.ID,
-			"total_revenue": grossRevenue,
-			"platform_fee":  platformFee,
-			"net_amount":    netAmount,
-			"status":        "pending",
-			"created_at":    time.Now(),
-			"updated_at":    time.Now(),
+			"organizer_id":    input.OrganizerID,
+			"raffle_id":       raffle.ID,
+			"total_revenue":   grossRevenue,
+			"platform_fee":    platformFee,
+			"prizes_withheld": prizesWithheld,
+			"net_amount":      netAmount,
+			"currency":        currencies[0],
+			"status":          "pending",
+			"created_at":      time.Now(),
+			"updated_at":      time.Now(),
 		}
 
 		var settlementID int64
@@ -184,7 +216,7 @@ func (uc *CreateSettlementUseCase) getEligibleRaffles(ctx context.Context, input
 
 	query := uc.db.WithContext(ctx).
 		Table("raffles").
-		Select("id, title, user_id, price_per_number, sold_count, completed_at").
+		Select("id, title, user_id, price_per_number, sold_count, completed_at, "+db.PaidRevenueSQL+" AS paid_revenue").
 		Where("user_id = ?", input.OrganizerID).
 		Where("status = ?", "completed")
 
@@ -212,5 +244,6 @@ type RaffleForSettlement struct {
 	UserID         int64
 	PricePerNumber float64
 	SoldCount      int
+	PaidRevenue    float64 // Pagos exitosos netos de reembolsos
 	CompletedAt    *time.Time
 }
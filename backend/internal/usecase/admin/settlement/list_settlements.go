@@ -17,6 +17,9 @@ type SettlementWithDetails struct {
 	OrganizerID       int64      `json:"organizer_id"`
 	TotalRevenue      float64    `json:"total_revenue"`
 	PlatformFee       float64    `json:"platform_fee"`
+	FeePercentage     float64    `json:"platform_fee_percentage" gorm:"column:platform_fee_percentage"` // % aplicado al liquidar
+	PrizesWithheld    float64    `json:"prizes_withheld"`
+	Currency          string     `json:"currency"`
 	NetAmount         float64    `json:"net_amount"`
 	Status            string     `json:"status"`
 	CalculatedAt      time.Time  `json:"created_at"`
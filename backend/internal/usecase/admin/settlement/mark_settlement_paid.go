@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	settlementuc "github.com/sorteos-platform/backend/internal/usecase/settlement"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
 	"gorm.io/gorm"
@@ -60,6 +62,7 @@ func (uc *MarkSettlementPaidUseCase) Execute(ctx context.Context, input *MarkSet
 		TotalRevenue  float64
 		PlatformFee   float64
 		NetAmount     float64
+		Currency      string
 		Status        string
 		ApprovedBy    *int64
 		ApprovedAt    *time.Time
@@ -68,7 +71,7 @@ func (uc *MarkSettlementPaidUseCase) Execute(ctx context.Context, input *MarkSet
 
 	result := uc.db.WithContext(ctx).
 		Table("settlements").
-		Select("id, organizer_id, raffle_id, total_revenue, platform_fee, net_amount, status, approved_by, approved_at, created_at").
+		Select("id, organizer_id, raffle_id, gross_revenue AS total_revenue, platform_fee, net_payout AS net_amount, currency, status, approved_by, approved_at, created_at AS calculated_at").
 		Where("id = ?", input.SettlementID).
 		First(&settlement)
 
@@ -103,23 +106,31 @@ func (uc *MarkSettlementPaidUseCase) Execute(ctx context.Context, input *MarkSet
 		"payment_method":    input.PaymentMethod,
 		"payment_reference": input.PaymentReference,
 		"paid_at":           now,
-		"paid_by":           adminID,
 		"notes":             input.Notes,
 		"updated_at":        now,
 	}
 
-	result = uc.db.WithContext(ctx).
-		Table("settlements").
-		Where("id = ?", input.SettlementID).
-		Updates(updates)
+	// El pago y su asiento contable se registran juntos
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("settlements").
+			Where("id = ?", input.SettlementID).
+			Updates(updates).Error; err != nil {
+			uc.log.Error("Error updating settlement", logger.Error(err))
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
 
-	if result.Error != nil {
-		uc.log.Error("Error updating settlement", logger.Error(result.Error))
-		return nil, errors.Wrap(errors.ErrDatabaseError, result.Error)
+		payoutEntry := settlementuc.PayoutJournalEntry(settlement.ID, settlement.OrganizerID, settlement.NetAmount, settlement.Currency)
+		if payoutEntry.IsEmpty() {
+			return nil
+		}
+		return db.NewLedgerRepository(tx, uc.log).Post(payoutEntry)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Actualizar organizer_profile (total_payouts, pending_payout)
-	err := uc.updateOrganizerProfile(ctx, settlement.OrganizerID, settlement.NetAmount)
+	err = uc.updateOrganizerProfile(ctx, settlement.OrganizerID, settlement.NetAmount)
 	if err != nil {
 		uc.log.Error("Error updating organizer profile", logger.Error(err))
 		// No fallar la operación, solo loguear
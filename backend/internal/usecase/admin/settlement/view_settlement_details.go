@@ -124,7 +124,8 @@ func (uc *ViewSettlementDetailsUseCase) Execute(ctx context.Context, settlementI
 		Where("raffle_id = (SELECT uuid FROM raffles WHERE id = ?)", settlement.RaffleID).
 		Scan(&paymentStats)
 
-	platformFeePercent := 10.0 // TODO: Obtener de configuración
+	// Comisión vigente al liquidar (la del organizador pudo cambiar después)
+	platformFeePercent := settlement.FeePercentage
 	platformFeeAmount := paymentStats.TotalRevenue * platformFeePercent / 100.0
 	netRevenue := paymentStats.TotalRevenue - platformFeeAmount - paymentStats.TotalRefunded
 
@@ -154,9 +155,11 @@ func (uc *ViewSettlementDetailsUseCase) Execute(ctx context.Context, settlementI
 		Timestamp: settlement.CalculatedAt,
 		Details:   "Settlement calculated automatically",
 		Metadata: map[string]interface{}{
-			"total_revenue": settlement.TotalRevenue,
-			"platform_fee":  settlement.PlatformFee,
-			"net_amount":    settlement.NetAmount,
+			"total_revenue":           settlement.TotalRevenue,
+			"platform_fee":            settlement.PlatformFee,
+			"platform_fee_percentage": settlement.FeePercentage,
+			"prizes_withheld":         settlement.PrizesWithheld,
+			"net_amount":              settlement.NetAmount,
 		},
 	})
 
@@ -0,0 +1,98 @@
+package withdrawal
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ApproveWithdrawalInput datos de entrada
+type ApproveWithdrawalInput struct {
+	WithdrawalID int64
+}
+
+// ApproveWithdrawalUseCase caso de uso para aprobar un retiro (queda listo para el lote bancario)
+type ApproveWithdrawalUseCase struct {
+	db        *gorm.DB
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewApproveWithdrawalUseCase crea una nueva instancia
+func NewApproveWithdrawalUseCase(gormDB *gorm.DB, log *logger.Logger) *ApproveWithdrawalUseCase {
+	return &ApproveWithdrawalUseCase{
+		db:        gormDB,
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ApproveWithdrawalUseCase) Execute(ctx context.Context, input *ApproveWithdrawalInput, adminID int64) (*domain.Withdrawal, error) {
+	var withdrawal *domain.Withdrawal
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+
+		var err error
+		withdrawal, err = findWithdrawalForUpdate(withdrawalRepo, input.WithdrawalID)
+		if err != nil {
+			return err
+		}
+
+		// El solicitante debe seguir cumpliendo los requisitos de retiro
+		user, err := db.NewUserRepository(tx).FindByID(withdrawal.UserID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive() || !user.HasMinimumKYC(domain.KYCLevelCedulaVerified) {
+			return errors.New("VALIDATION_FAILED", "user no longer meets withdrawal requirements (active, cedula_verified)", 400, nil)
+		}
+
+		if err := withdrawal.Approve(adminID); err != nil {
+			return errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+		}
+		return withdrawalRepo.Update(withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalApproved).
+		WithAdmin(adminID).
+		WithUser(withdrawal.UserID).
+		WithEntity("withdrawal", withdrawal.ID).
+		WithDescription("Retiro aprobado").
+		WithMetadata(map[string]interface{}{
+			"amount": withdrawal.Amount.String(),
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin approved withdrawal",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("withdrawal_id", withdrawal.ID),
+		logger.Int64("user_id", withdrawal.UserID),
+		logger.String("amount", withdrawal.Amount.String()),
+		logger.String("action", "admin_approve_withdrawal"))
+
+	return withdrawal, nil
+}
+
+// findWithdrawalForUpdate busca un retiro con lock y traduce el not found
+func findWithdrawalForUpdate(withdrawalRepo domain.WithdrawalRepository, withdrawalID int64) (*domain.Withdrawal, error) {
+	withdrawal, err := withdrawalRepo.FindByIDForUpdate(withdrawalID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("WITHDRAWAL_NOT_FOUND", "withdrawal not found", 404, nil)
+		}
+		return nil, err
+	}
+	return withdrawal, nil
+}
@@ -0,0 +1,93 @@
+package withdrawal
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// CompleteWithdrawalInput datos de entrada
+type CompleteWithdrawalInput struct {
+	WithdrawalID  int64
+	BankReference string // Comprobante de la transferencia
+}
+
+// CompleteWithdrawalUseCase caso de uso para confirmar la transferencia bancaria de un retiro
+// Registra la transacción de retiro como completada y libera la retención de pending_balance
+type CompleteWithdrawalUseCase struct {
+	db        *gorm.DB
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewCompleteWithdrawalUseCase crea una nueva instancia
+func NewCompleteWithdrawalUseCase(gormDB *gorm.DB, log *logger.Logger) *CompleteWithdrawalUseCase {
+	return &CompleteWithdrawalUseCase{
+		db:        gormDB,
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CompleteWithdrawalUseCase) Execute(ctx context.Context, input *CompleteWithdrawalInput, adminID int64) (*domain.Withdrawal, error) {
+	if input.BankReference == "" {
+		return nil, errors.New("VALIDATION_FAILED", "bank_reference is required", 400, nil)
+	}
+
+	var withdrawal *domain.Withdrawal
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+
+		var err error
+		withdrawal, err = findWithdrawalForUpdate(withdrawalRepo, input.WithdrawalID)
+		if err != nil {
+			return err
+		}
+
+		if err := withdrawal.Complete(input.BankReference); err != nil {
+			return errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+		}
+
+		if err := withdrawaluc.SettleHold(tx, withdrawal, uc.log); err != nil {
+			return err
+		}
+		return withdrawalRepo.Update(withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalCompleted).
+		WithAdmin(adminID).
+		WithUser(withdrawal.UserID).
+		WithEntity("withdrawal", withdrawal.ID).
+		WithDescription("Transferencia de retiro confirmada").
+		WithMetadata(map[string]interface{}{
+			"amount":         withdrawal.Amount.String(),
+			"batch_id":       withdrawal.BatchID,
+			"bank_reference": input.BankReference,
+			"transaction_id": withdrawal.TransactionID,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	// Log auditoría crítica
+	uc.log.Info("Admin confirmed withdrawal transfer",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("withdrawal_id", withdrawal.ID),
+		logger.Int64("user_id", withdrawal.UserID),
+		logger.String("amount", withdrawal.Amount.String()),
+		logger.String("bank_reference", input.BankReference),
+		logger.String("action", "admin_complete_withdrawal"),
+		logger.String("severity", "critical"))
+
+	return withdrawal, nil
+}
@@ -0,0 +1,191 @@
+package withdrawal
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxBatchSize retiros por lote bancario
+const maxBatchSize = 500
+
+// ExportWithdrawalBatchInput datos de entrada
+type ExportWithdrawalBatchInput struct {
+	BatchID *string // Si se indica, regenera el archivo de un lote ya exportado
+}
+
+// ExportWithdrawalBatchOutput resultado
+type ExportWithdrawalBatchOutput struct {
+	BatchID     string          `json:"batch_id"`
+	FileName    string          `json:"file_name"`
+	Count       int             `json:"count"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	Content     []byte          `json:"-"`
+}
+
+// ExportWithdrawalBatchUseCase genera el archivo de transferencias para el banco con los retiros aprobados
+// Formato: CSV de transferencias a cuentas IBAN costarricenses (SINPE), una fila por retiro
+type ExportWithdrawalBatchUseCase struct {
+	db        *gorm.DB
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewExportWithdrawalBatchUseCase crea una nueva instancia
+func NewExportWithdrawalBatchUseCase(gormDB *gorm.DB, log *logger.Logger) *ExportWithdrawalBatchUseCase {
+	return &ExportWithdrawalBatchUseCase{
+		db:        gormDB,
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ExportWithdrawalBatchUseCase) Execute(ctx context.Context, input *ExportWithdrawalBatchInput, adminID int64) (*ExportWithdrawalBatchOutput, error) {
+	if input.BatchID != nil && *input.BatchID != "" {
+		return uc.regenerate(ctx, *input.BatchID)
+	}
+
+	batchID := fmt.Sprintf("WD-%s", time.Now().Format("20060102-150405"))
+
+	var withdrawals []*domain.Withdrawal
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Tomar los retiros aprobados con lock para que no se exporten en dos lotes
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", domain.WithdrawalStatusApproved).
+			Order("created_at ASC").
+			Limit(maxBatchSize).
+			Find(&withdrawals).Error; err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		if len(withdrawals) == 0 {
+			return errors.New("NO_APPROVED_WITHDRAWALS", "there are no approved withdrawals to export", 400, nil)
+		}
+
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+		for _, withdrawal := range withdrawals {
+			if err := withdrawal.MarkExported(batchID); err != nil {
+				return errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+			}
+			if err := withdrawalRepo.Update(withdrawal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := buildBatchFile(batchID, withdrawals)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalExported).
+		WithAdmin(adminID).
+		WithDescription(fmt.Sprintf("Lote bancario %s exportado", batchID)).
+		WithMetadata(map[string]interface{}{
+			"batch_id":     batchID,
+			"count":        output.Count,
+			"total_amount": output.TotalAmount.String(),
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin exported withdrawal batch",
+		logger.Int64("admin_id", adminID),
+		logger.String("batch_id", batchID),
+		logger.Int("count", output.Count),
+		logger.String("total_amount", output.TotalAmount.String()),
+		logger.String("action", "admin_export_withdrawal_batch"))
+
+	return output, nil
+}
+
+// regenerate vuelve a generar el archivo de un lote exportado (sin cambiar estados)
+func (uc *ExportWithdrawalBatchUseCase) regenerate(ctx context.Context, batchID string) (*ExportWithdrawalBatchOutput, error) {
+	var withdrawals []*domain.Withdrawal
+	if err := uc.db.WithContext(ctx).
+		Where("batch_id = ?", batchID).
+		Order("created_at ASC").
+		Find(&withdrawals).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	if len(withdrawals) == 0 {
+		return nil, errors.New("BATCH_NOT_FOUND", "withdrawal batch not found", 404, nil)
+	}
+
+	output, err := buildBatchFile(batchID, withdrawals)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	return output, nil
+}
+
+// buildBatchFile genera el CSV de transferencias del lote
+func buildBatchFile(batchID string, withdrawals []*domain.Withdrawal) (*ExportWithdrawalBatchOutput, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write([]string{
+		"cuenta_iban",
+		"identificacion",
+		"beneficiario",
+		"monto",
+		"moneda",
+		"referencia",
+		"detalle",
+	}); err != nil {
+		return nil, err
+	}
+
+	total := decimal.Zero
+	for _, withdrawal := range withdrawals {
+		identification := ""
+		if withdrawal.AccountHolderID != nil {
+			identification = *withdrawal.AccountHolderID
+		}
+
+		if err := writer.Write([]string{
+			withdrawal.IBAN,
+			identification,
+			withdrawal.AccountHolder,
+			withdrawal.Amount.StringFixed(2),
+			withdrawal.Currency,
+			withdrawal.UUID,
+			fmt.Sprintf("Retiro de ganancias %s", batchID),
+		}); err != nil {
+			return nil, err
+		}
+		total = total.Add(withdrawal.Amount)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return &ExportWithdrawalBatchOutput{
+		BatchID:     batchID,
+		FileName:    fmt.Sprintf("retiros_%s.csv", batchID),
+		Count:       len(withdrawals),
+		TotalAmount: total,
+		Content:     buf.Bytes(),
+	}, nil
+}
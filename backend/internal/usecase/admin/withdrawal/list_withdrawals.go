@@ -0,0 +1,78 @@
+package withdrawal
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ListWithdrawalsInput datos de entrada
+type ListWithdrawalsInput struct {
+	Page     int
+	PageSize int
+	Status   *domain.WithdrawalStatus
+	UserID   *int64
+	BatchID  *string
+}
+
+// ListWithdrawalsOutput resultado
+type ListWithdrawalsOutput struct {
+	Withdrawals []*domain.Withdrawal `json:"withdrawals"`
+	Total       int64                `json:"total"`
+	Page        int                  `json:"page"`
+	PageSize    int                  `json:"page_size"`
+	TotalPages  int                  `json:"total_pages"`
+}
+
+// ListWithdrawalsUseCase caso de uso para la cola de aprobación de retiros
+type ListWithdrawalsUseCase struct {
+	withdrawalRepo domain.WithdrawalRepository
+	log            *logger.Logger
+}
+
+// NewListWithdrawalsUseCase crea una nueva instancia
+func NewListWithdrawalsUseCase(gormDB *gorm.DB, log *logger.Logger) *ListWithdrawalsUseCase {
+	return &ListWithdrawalsUseCase{
+		withdrawalRepo: db.NewWithdrawalRepository(gormDB, log),
+		log:            log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListWithdrawalsUseCase) Execute(ctx context.Context, input *ListWithdrawalsInput, adminID int64) (*ListWithdrawalsOutput, error) {
+	// Validar paginación
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+
+	withdrawals, total, err := uc.withdrawalRepo.List(domain.WithdrawalFilters{
+		Status:  input.Status,
+		UserID:  input.UserID,
+		BatchID: input.BatchID,
+	}, input.PageSize, offset)
+	if err != nil {
+		uc.log.Error("Error listing withdrawals", logger.Error(err))
+		return nil, err
+	}
+
+	totalPages := int(total) / input.PageSize
+	if int(total)%input.PageSize > 0 {
+		totalPages++
+	}
+
+	return &ListWithdrawalsOutput{
+		Withdrawals: withdrawals,
+		Total:       total,
+		Page:        input.Page,
+		PageSize:    input.PageSize,
+		TotalPages:  totalPages,
+	}, nil
+}
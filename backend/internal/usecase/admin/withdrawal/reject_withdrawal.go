@@ -0,0 +1,95 @@
+package withdrawal
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	withdrawaluc "github.com/sorteos-platform/backend/internal/usecase/withdrawal"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// RejectWithdrawalInput datos de entrada
+type RejectWithdrawalInput struct {
+	WithdrawalID int64
+	Reason       string
+}
+
+// RejectWithdrawalUseCase caso de uso para rechazar un retiro (en revisión o devuelto por el banco)
+// El monto retenido vuelve al saldo de ganancias del usuario
+type RejectWithdrawalUseCase struct {
+	db        *gorm.DB
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewRejectWithdrawalUseCase crea una nueva instancia
+func NewRejectWithdrawalUseCase(gormDB *gorm.DB, log *logger.Logger) *RejectWithdrawalUseCase {
+	return &RejectWithdrawalUseCase{
+		db:        gormDB,
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RejectWithdrawalUseCase) Execute(ctx context.Context, input *RejectWithdrawalInput, adminID int64) (*domain.Withdrawal, error) {
+	if input.Reason == "" {
+		return nil, errors.New("VALIDATION_FAILED", "reason is required", 400, nil)
+	}
+
+	var withdrawal *domain.Withdrawal
+	var previousStatus domain.WithdrawalStatus
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+
+		var err error
+		withdrawal, err = findWithdrawalForUpdate(withdrawalRepo, input.WithdrawalID)
+		if err != nil {
+			return err
+		}
+
+		previousStatus = withdrawal.Status
+		if err := withdrawal.Reject(adminID, input.Reason); err != nil {
+			return errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+		}
+
+		// Revertir la retención
+		if err := withdrawaluc.ReleaseHold(tx, withdrawal, input.Reason, uc.log); err != nil {
+			return err
+		}
+		return withdrawalRepo.Update(withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalRejected).
+		WithAdmin(adminID).
+		WithUser(withdrawal.UserID).
+		WithSeverity(domain.AuditSeverityWarning).
+		WithEntity("withdrawal", withdrawal.ID).
+		WithDescription("Retiro rechazado: " + input.Reason).
+		WithMetadata(map[string]interface{}{
+			"amount":          withdrawal.Amount.String(),
+			"previous_status": previousStatus,
+			"batch_id":        withdrawal.BatchID,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	// Log auditoría
+	uc.log.Info("Admin rejected withdrawal",
+		logger.Int64("admin_id", adminID),
+		logger.Int64("withdrawal_id", withdrawal.ID),
+		logger.Int64("user_id", withdrawal.UserID),
+		logger.String("previous_status", string(previousStatus)),
+		logger.String("reason", input.Reason),
+		logger.String("action", "admin_reject_withdrawal"))
+
+	return withdrawal, nil
+}
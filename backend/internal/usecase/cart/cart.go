@@ -0,0 +1,180 @@
+package cart
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// ReferenceTypeCart tipo de referencia de los débitos de billetera que pagan un carrito
+const ReferenceTypeCart = "cart"
+
+var (
+	errCartNotFound        = errors.New("CART_NOT_FOUND", "carrito no encontrado", 404, nil)
+	errReservationNotFound = errors.New("RESERVATION_NOT_FOUND", "reserva no encontrada", 404, nil)
+	errSalesClosed         = errors.New("SALES_CLOSED", "las ventas del sorteo están cerradas", 409, nil)
+	errPaymentInProgress   = errors.New("PAYMENT_ALREADY_EXISTS", "ya existe un pago en curso para esta reserva", 409, nil)
+)
+
+// CartPurchaseKey clave de idempotencia del débito único de un carrito
+func CartPurchaseKey(cartID uuid.UUID) string {
+	return "cart:" + cartID.String() + ":purchase"
+}
+
+// findUserCart obtiene el carrito del usuario (con lock de fila si forUpdate)
+func findUserCart(ctx context.Context, tx *gorm.DB, cartID uuid.UUID, userUUID string, forUpdate bool) (*entities.Cart, error) {
+	cartRepo := db.NewCartRepository(tx)
+
+	var cart *entities.Cart
+	var err error
+	if forUpdate {
+		cart, err = cartRepo.FindByIDForUpdate(ctx, cartID)
+	} else {
+		cart, err = cartRepo.FindByID(ctx, cartID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if cart == nil || cart.UserID.String() != userUUID {
+		return nil, errCartNotFound
+	}
+	return cart, nil
+}
+
+// lockReservations obtiene las reservas del carrito con lock de fila, en el orden del carrito
+// Se bloquean ordenadas por ID para evitar deadlocks entre carritos concurrentes
+func lockReservations(tx *gorm.DB, cart *entities.Cart) ([]*entities.Reservation, error) {
+	var locked []*entities.Reservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", cart.ReservationUUIDs()).
+		Order("id").
+		Find(&locked).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	byID := make(map[string]*entities.Reservation, len(locked))
+	for _, reservation := range locked {
+		byID[reservation.ID.String()] = reservation
+	}
+
+	reservations := make([]*entities.Reservation, 0, len(cart.ReservationIDs))
+	for _, id := range cart.ReservationIDs {
+		reservation, ok := byID[id]
+		if !ok {
+			return nil, errReservationNotFound
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
+
+// validatePayable valida que la reserva pueda pagarse y que su sorteo siga vendiendo
+func validatePayable(ctx context.Context, tx *gorm.DB, reservation *entities.Reservation) (*domain.Raffle, error) {
+	if err := reservation.CanCheckout(); err != nil {
+		return nil, reservationStateError(err)
+	}
+
+	raffle, err := db.NewRaffleRepository(tx).FindByUUID(reservation.RaffleID.String())
+	if err != nil {
+		return nil, err
+	}
+	if raffle.Status != domain.RaffleStatusActive || raffle.IsSalesClosed() {
+		return nil, errSalesClosed
+	}
+
+	// Un pago individual en curso no puede convivir con el pago del carrito
+	existingPayment, err := db.NewPaymentRepository(tx).FindByReservationID(ctx, reservation.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if existingPayment != nil && existingPayment.CartID == nil &&
+		existingPayment.Status != entities.PaymentStatusFailed &&
+		existingPayment.Status != entities.PaymentStatusCancelled {
+		return nil, errPaymentInProgress
+	}
+
+	return raffle, nil
+}
+
+// confirmReservation confirma la reserva y marca sus números como vendidos
+func confirmReservation(ctx context.Context, tx *gorm.DB, reservation *entities.Reservation, raffle *domain.Raffle, userID int64) error {
+	if err := reservation.Confirm(); err != nil {
+		return reservationStateError(err)
+	}
+	if err := db.NewReservationRepository(tx).Update(ctx, reservation); err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	raffleNumberRepo := db.NewRaffleNumberRepository(tx)
+	for _, numberStr := range reservation.NumberIDs {
+		raffleNumber, err := raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberStr)
+		if err != nil {
+			return err
+		}
+		if err := raffleNumberRepo.MarkAsSold(raffleNumber.ID, userID, int64(reservation.ID.ID())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyPaid notifica vía WebSocket los números vendidos y el pago de cada reserva (después del commit)
+func notifyPaid(wsHub *websocket.Hub, reservations []*entities.Reservation, payments []*entities.Payment) {
+	for i, reservation := range reservations {
+		userIDStr := reservation.UserID.String()
+		for _, numberID := range reservation.NumberIDs {
+			wsHub.BroadcastNumberUpdate(
+				reservation.RaffleID.String(),
+				numberID,
+				"sold",
+				&userIDStr,
+			)
+		}
+		wsHub.NotifyPaymentSucceeded(
+			userIDStr,
+			reservation.RaffleID.String(),
+			reservation.ID.String(),
+			payments[i].ID.String(),
+			reservation.NumberIDs,
+		)
+	}
+}
+
+// reservationStateError traduce los errores de estado de la reserva a errores de aplicación
+func reservationStateError(err error) error {
+	switch {
+	case stderrors.Is(err, entities.ErrReservationAlreadyPaid):
+		return errors.New("RESERVATION_ALREADY_PAID", "la reserva ya fue pagada", 409, err)
+	case stderrors.Is(err, entities.ErrReservationCancelled):
+		return errors.New("RESERVATION_CANCELLED", "la reserva fue cancelada", 409, err)
+	case stderrors.Is(err, entities.ErrReservationExpired):
+		return errors.New("RESERVATION_EXPIRED", "la reserva expiró", 409, err)
+	case stderrors.Is(err, entities.ErrInvalidReservationPhase):
+		return errors.New("INVALID_RESERVATION_PHASE", "la reserva no está en una fase que permita el pago", 409, err)
+	default:
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+}
+
+// cartStateError traduce los errores de estado del carrito a errores de aplicación
+func cartStateError(err error) error {
+	switch {
+	case stderrors.Is(err, entities.ErrCartNotOpen):
+		return errors.New("CART_NOT_OPEN", "el carrito ya no admite pagos", 409, err)
+	case stderrors.Is(err, entities.ErrCartEmpty),
+		stderrors.Is(err, entities.ErrCartTooManyReservations),
+		stderrors.Is(err, entities.ErrCartDuplicateRaffle):
+		return errors.New("INVALID_CART", err.Error(), 400, err)
+	default:
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+}
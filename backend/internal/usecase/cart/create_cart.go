@@ -0,0 +1,152 @@
+package cart
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/usecases"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// CreateCartInput representa los datos de entrada para crear un carrito
+type CreateCartInput struct {
+	UserID         int64
+	ReservationIDs []uuid.UUID
+}
+
+// CartOutput representa un carrito con sus reservas
+type CartOutput struct {
+	Cart         *entities.Cart          `json:"cart"`
+	Reservations []*entities.Reservation `json:"reservations"`
+}
+
+// CreateCartUseCase agrupa reservas de varios sorteos en un carrito con un solo checkout
+// Las reservas pasan a la fase de checkout: sus números quedan congelados hasta el pago
+type CreateCartUseCase struct {
+	db           *gorm.DB
+	reservations *usecases.ReservationUseCases
+	logger       *logger.Logger
+}
+
+// NewCreateCartUseCase crea una nueva instancia del use case
+func NewCreateCartUseCase(gormDB *gorm.DB, reservations *usecases.ReservationUseCases, logger *logger.Logger) *CreateCartUseCase {
+	return &CreateCartUseCase{
+		db:           gormDB,
+		reservations: reservations,
+		logger:       logger,
+	}
+}
+
+// Execute ejecuta el caso de uso de creación de carrito
+func (uc *CreateCartUseCase) Execute(ctx context.Context, input *CreateCartInput) (*CartOutput, error) {
+	// 1. Validar cantidad de reservas (sin duplicados)
+	reservationIDs := make([]uuid.UUID, 0, len(input.ReservationIDs))
+	seen := make(map[uuid.UUID]bool, len(input.ReservationIDs))
+	for _, id := range input.ReservationIDs {
+		if !seen[id] {
+			seen[id] = true
+			reservationIDs = append(reservationIDs, id)
+		}
+	}
+	if len(reservationIDs) == 0 {
+		return nil, cartStateError(entities.ErrCartEmpty)
+	}
+	if len(reservationIDs) > entities.MaxReservationsPerCart {
+		return nil, cartStateError(entities.ErrCartTooManyReservations)
+	}
+
+	user, err := db.NewUserRepository(uc.db).FindByID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userUUID, err := uuid.Parse(user.UUID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+
+	// 2. Validar que cada reserva sea del usuario y pueda pagarse
+	reservationRepo := db.NewReservationRepository(uc.db)
+	reservations := make([]*entities.Reservation, 0, len(reservationIDs))
+	for _, id := range reservationIDs {
+		reservation, err := reservationRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		}
+		if reservation == nil || reservation.UserID != userUUID {
+			return nil, errReservationNotFound
+		}
+		if _, err := validatePayable(ctx, uc.db, reservation); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	// 3. Una reserva solo puede estar en un carrito abierto
+	cartRepo := db.NewCartRepository(uc.db)
+	openCarts, err := cartRepo.CountOpenWithReservations(ctx, reservationIDs)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if openCarts > 0 {
+		return nil, errors.New("CART_CONFLICT", "una de las reservas ya está en otro carrito abierto", 409, nil)
+	}
+
+	// 4. Construir el carrito (una reserva por sorteo)
+	cart, err := entities.NewCart(userUUID, reservations, "USD")
+	if err != nil {
+		return nil, cartStateError(err)
+	}
+
+	// 5. Iniciar el checkout de las reservas en selección (congela números y extiende locks)
+	for i, reservation := range reservations {
+		if reservation.Phase != entities.ReservationPhaseSelection {
+			continue
+		}
+		if err := uc.reservations.MoveToCheckout(ctx, reservation.ID); err != nil {
+			return nil, checkoutError(err)
+		}
+
+		updated, err := reservationRepo.FindByID(ctx, reservation.ID)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		}
+		reservations[i] = updated
+	}
+
+	// 6. Guardar el carrito
+	if err := cartRepo.Create(ctx, cart); err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	uc.logger.Info("Carrito creado",
+		logger.String("cart_id", cart.ID.String()),
+		logger.Int64("user_id", input.UserID),
+		logger.Int("reservations", len(reservations)),
+		logger.String("total_amount", decimal.NewFromFloat(cart.TotalAmount).StringFixed(2)))
+
+	return &CartOutput{
+		Cart:         cart,
+		Reservations: reservations,
+	}, nil
+}
+
+// checkoutError traduce los errores del inicio de checkout a errores de aplicación
+func checkoutError(err error) error {
+	switch {
+	case stderrors.Is(err, usecases.ErrRaffleSalesClosed):
+		return errSalesClosed
+	case stderrors.Is(err, usecases.ErrReservationLocksLost):
+		return errors.New("RESERVATION_LOCKS_LOST", "los números de la reserva ya no están retenidos", 409, err)
+	case stderrors.Is(err, entities.ErrNotInSelectionPhase):
+		return reservationStateError(entities.ErrInvalidReservationPhase)
+	default:
+		return reservationStateError(err)
+	}
+}
@@ -0,0 +1,177 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/payment"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// CreateCartPaymentIntentInput representa los datos de entrada para pagar un carrito con tarjeta
+type CreateCartPaymentIntentInput struct {
+	CartID uuid.UUID
+	UserID int64
+}
+
+// CreateCartPaymentIntentOutput representa los datos de salida
+type CreateCartPaymentIntentOutput struct {
+	CartID       uuid.UUID `json:"cart_id"`
+	ClientSecret string    `json:"client_secret"`
+	Amount       float64   `json:"amount"`
+	Currency     string    `json:"currency"`
+}
+
+// CreateCartPaymentIntentUseCase crea un único payment intent por el total del carrito
+// Se registra un pago pendiente por reserva que comparte el payment intent; el webhook
+// del proveedor confirma o libera todas las reservas juntas
+type CreateCartPaymentIntentUseCase struct {
+	db       *gorm.DB
+	provider payment.PaymentProvider
+	logger   *logger.Logger
+}
+
+// NewCreateCartPaymentIntentUseCase crea una nueva instancia del use case
+func NewCreateCartPaymentIntentUseCase(gormDB *gorm.DB, provider payment.PaymentProvider, logger *logger.Logger) *CreateCartPaymentIntentUseCase {
+	return &CreateCartPaymentIntentUseCase{
+		db:       gormDB,
+		provider: provider,
+		logger:   logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+// Es idempotente por carrito: si ya existe un payment intent se devuelve su client secret
+func (uc *CreateCartPaymentIntentUseCase) Execute(ctx context.Context, input *CreateCartPaymentIntentInput) (*CreateCartPaymentIntentOutput, error) {
+	var output *CreateCartPaymentIntentOutput
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Obtener carrito con lock (evita crear dos payment intents para el mismo carrito)
+		user, err := db.NewUserRepository(tx).FindByID(input.UserID)
+		if err != nil {
+			return err
+		}
+		cart, err := findUserCart(ctx, tx, input.CartID, user.UUID, true)
+		if err != nil {
+			return err
+		}
+		if !cart.IsOpen() {
+			return cartStateError(entities.ErrCartNotOpen)
+		}
+
+		paymentRepo := db.NewPaymentRepository(tx)
+
+		// 2. Idempotencia: el payment intent ya fue creado
+		if cart.PaymentIntentID != "" {
+			payments, err := paymentRepo.FindByCartID(ctx, cart.ID)
+			if err != nil {
+				return errors.Wrap(errors.ErrDatabaseError, err)
+			}
+			if len(payments) == 0 {
+				return errors.Wrap(errors.ErrInternalServer, fmt.Errorf("cart %s has no payments for intent %s", cart.ID, cart.PaymentIntentID))
+			}
+
+			output = &CreateCartPaymentIntentOutput{
+				CartID:       cart.ID,
+				ClientSecret: payments[0].StripeClientSecret,
+				Amount:       cart.TotalAmount,
+				Currency:     payments[0].Currency,
+			}
+			return nil
+		}
+
+		// 3. Obtener reservas con lock y validar que todas puedan pagarse
+		reservations, err := lockReservations(tx, cart)
+		if err != nil {
+			return err
+		}
+
+		raffles := make([]*domain.Raffle, len(reservations))
+		total := decimal.Zero
+		for i, reservation := range reservations {
+			raffle, err := validatePayable(ctx, tx, reservation)
+			if err != nil {
+				return err
+			}
+			raffles[i] = raffle
+			total = total.Add(decimal.NewFromFloat(reservation.TotalAmount).Round(2))
+		}
+
+		// 4. Crear un solo payment intent por el total del carrito
+		intent, err := uc.provider.CreatePaymentIntent(ctx, payment.CreatePaymentIntentInput{
+			Amount:      total.Shift(2).IntPart(),
+			Currency:    strings.ToLower(cart.Currency),
+			Description: fmt.Sprintf("Carrito: %d sorteo(s)", len(reservations)),
+			Metadata: map[string]string{
+				"cart_id":           cart.ID.String(),
+				"user_id":           cart.UserID.String(),
+				"reservation_count": fmt.Sprintf("%d", len(reservations)),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(errors.ErrStripeError, err)
+		}
+
+		// 5. Registrar un pago pendiente por reserva que comparte el payment intent
+		for i, reservation := range reservations {
+			pay, err := entities.NewPayment(
+				reservation.ID,
+				reservation.UserID,
+				reservation.RaffleID,
+				intent.ID,
+				intent.ClientSecret,
+				reservation.TotalAmount,
+				intent.Currency,
+			)
+			if err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			pay.CartID = &cart.ID
+			if err := pay.SetMetadata(entities.PaymentMetadata{
+				NumberCount:    len(reservation.NumberIDs),
+				NumberIDs:      reservation.NumberIDs,
+				RaffleTitle:    raffles[i].Title,
+				PriceBreakdown: reservation.PriceBreakdown,
+			}); err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			if err := paymentRepo.Create(ctx, pay); err != nil {
+				return errors.Wrap(errors.ErrDatabaseError, err)
+			}
+		}
+
+		// 6. Asociar el payment intent al carrito
+		if err := cart.SetPaymentIntent("card", intent.ID); err != nil {
+			return cartStateError(err)
+		}
+		if err := db.NewCartRepository(tx).Update(ctx, cart); err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		output = &CreateCartPaymentIntentOutput{
+			CartID:       cart.ID,
+			ClientSecret: intent.ClientSecret,
+			Amount:       cart.TotalAmount,
+			Currency:     intent.Currency,
+		}
+		return nil
+	})
+	if err != nil {
+		uc.logger.Error("Error creando payment intent del carrito",
+			logger.String("cart_id", input.CartID.String()),
+			logger.Int64("user_id", input.UserID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,54 @@
+package cart
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// GetCartUseCase consulta un carrito del usuario con sus reservas
+type GetCartUseCase struct {
+	db *gorm.DB
+}
+
+// NewGetCartUseCase crea una nueva instancia del use case
+func NewGetCartUseCase(gormDB *gorm.DB) *GetCartUseCase {
+	return &GetCartUseCase{
+		db: gormDB,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetCartUseCase) Execute(ctx context.Context, cartID uuid.UUID, userID int64) (*CartOutput, error) {
+	user, err := db.NewUserRepository(uc.db).FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := findUserCart(ctx, uc.db, cartID, user.UUID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	reservationRepo := db.NewReservationRepository(uc.db)
+	reservations := make([]*entities.Reservation, 0, len(cart.ReservationIDs))
+	for _, id := range cart.ReservationUUIDs() {
+		reservation, err := reservationRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrDatabaseError, err)
+		}
+		if reservation != nil {
+			reservations = append(reservations, reservation)
+		}
+	}
+
+	return &CartOutput{
+		Cart:         cart,
+		Reservations: reservations,
+	}, nil
+}
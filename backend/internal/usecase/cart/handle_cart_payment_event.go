@@ -0,0 +1,345 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/internal/usecase/refund"
+	"github.com/sorteos-platform/backend/internal/usecases"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// Eventos del proveedor de pagos que resuelven el payment intent de un carrito
+const (
+	EventPaymentSucceeded = "payment_intent.succeeded"
+	EventPaymentFailed    = "payment_intent.payment_failed"
+	EventPaymentCanceled  = "payment_intent.canceled"
+)
+
+// HandleCartPaymentEventUseCase aplica el resultado del payment intent de un carrito
+// Un pago exitoso confirma todas las reservas en una sola transacción; un pago fallido
+// o cancelado libera todas las reservas del carrito. Si el cobro se capturó pero alguna
+// reserva ya no puede confirmarse, el pago se reembolsa y el carrito se libera
+type HandleCartPaymentEventUseCase struct {
+	db              *gorm.DB
+	reservations    *usecases.ReservationUseCases
+	refundProcessor *refund.RefundProcessor
+	wsHub           *websocket.Hub
+	logger          *logger.Logger
+}
+
+// NewHandleCartPaymentEventUseCase crea una nueva instancia del use case
+func NewHandleCartPaymentEventUseCase(gormDB *gorm.DB, reservations *usecases.ReservationUseCases, refundProcessor *refund.RefundProcessor, wsHub *websocket.Hub, logger *logger.Logger) *HandleCartPaymentEventUseCase {
+	return &HandleCartPaymentEventUseCase{
+		db:              gormDB,
+		reservations:    reservations,
+		refundProcessor: refundProcessor,
+		wsHub:           wsHub,
+		logger:          logger,
+	}
+}
+
+// HandlePaymentEvent procesa un evento del webhook para el payment intent de un carrito
+func (uc *HandleCartPaymentEventUseCase) HandlePaymentEvent(ctx context.Context, eventType string, paymentIntentID string) error {
+	switch eventType {
+	case EventPaymentSucceeded:
+		return uc.confirmCart(ctx, paymentIntentID)
+	case EventPaymentFailed:
+		return uc.releaseCart(ctx, paymentIntentID, entities.CartStatusFailed, "Payment failed")
+	case EventPaymentCanceled:
+		return uc.releaseCart(ctx, paymentIntentID, entities.CartStatusCancelled, "Payment canceled")
+	default:
+		return nil
+	}
+}
+
+// confirmCart marca los pagos como exitosos y confirma todas las reservas del carrito
+// Si alguna reserva venció o su sorteo dejó de vender, el cobro ya capturado se registra
+// y se reembolsa (el webhook no falla: reintentarlo no cambiaría el resultado)
+func (uc *HandleCartPaymentEventUseCase) confirmCart(ctx context.Context, paymentIntentID string) error {
+	var reservations []*entities.Reservation
+	var payments []*entities.Payment
+	var refunds []*domain.PaymentRefund
+	var releaseReason string
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		cart, err := uc.lockCart(ctx, tx, paymentIntentID)
+		if err != nil {
+			return err
+		}
+
+		// Reintento del webhook: el carrito ya fue confirmado
+		if cart.Status == entities.CartStatusPaid {
+			return nil
+		}
+		if !cart.IsOpen() {
+			return fmt.Errorf("payment %s succeeded for %s cart %s", paymentIntentID, cart.Status, cart.ID)
+		}
+
+		user, err := db.NewUserRepository(tx).FindByUUID(cart.UserID.String())
+		if err != nil {
+			return err
+		}
+
+		reservations, err = lockReservations(tx, cart)
+		if err != nil {
+			return err
+		}
+		payments, err = uc.intentPayments(ctx, tx, cart, paymentIntentID, reservations)
+		if err != nil {
+			return err
+		}
+
+		raffleRepo := db.NewRaffleRepository(tx)
+		raffles := make([]*domain.Raffle, len(reservations))
+		for i, reservation := range reservations {
+			raffles[i], err = raffleRepo.FindByUUID(reservation.RaffleID.String())
+			if err != nil {
+				return err
+			}
+			if releaseReason == "" {
+				releaseReason = unconfirmableReason(reservation, raffles[i])
+			}
+		}
+
+		paymentRepo := db.NewPaymentRepository(tx)
+		ledgerRepo := db.NewLedgerRepository(tx, uc.logger)
+		for i, reservation := range reservations {
+			pay := payments[i]
+			if err := pay.MarkAsSucceeded("card"); err != nil {
+				return fmt.Errorf("error marking payment as succeeded: %w", err)
+			}
+			if err := paymentRepo.Update(ctx, pay); err != nil {
+				return fmt.Errorf("error updating payment: %w", err)
+			}
+
+			// Asiento contable: fondos en el procesador a pagar al organizador de cada sorteo
+			amount := decimal.NewFromFloat(pay.Amount).Round(2)
+			entry := domain.NewJournalEntry(domain.JournalEntryPurchase, "payment:"+pay.ID.String()+":capture", strings.ToUpper(pay.Currency)).
+				WithReference("payment", pay.ID).
+				Debit(domain.PlatformAccount(domain.LedgerAccountProcessorClearing), amount).
+				Credit(domain.OrganizerPayableAccount(raffles[i].UserID), amount)
+			if err := ledgerRepo.Post(entry); err != nil {
+				return fmt.Errorf("error posting purchase to ledger: %w", err)
+			}
+
+			if releaseReason != "" {
+				continue
+			}
+			if err := confirmReservation(ctx, tx, reservation, raffles[i], user.ID); err != nil {
+				return err
+			}
+		}
+
+		if releaseReason != "" {
+			// El cobro no puede aplicarse: los reembolsos se registran junto con el pago para
+			// que el job de reembolsos los complete si el proceso cae antes de ejecutarlos
+			for _, pay := range payments {
+				paymentRefund, err := uc.refundProcessor.Prepare(ctx, tx, &refund.RequestRefundInput{
+					PaymentID: pay.ID.String(),
+					Reason:    releaseReason,
+				})
+				if err != nil {
+					return err
+				}
+				refunds = append(refunds, paymentRefund)
+			}
+
+			if err := cart.MarkAsFailed(entities.CartStatusFailed, releaseReason); err != nil {
+				return err
+			}
+			return db.NewCartRepository(tx).Update(ctx, cart)
+		}
+
+		if err := cart.MarkAsPaid("card", paymentIntentID); err != nil {
+			return err
+		}
+		return db.NewCartRepository(tx).Update(ctx, cart)
+	})
+	if err != nil {
+		uc.logger.Error("Error confirmando carrito",
+			logger.String("payment_intent_id", paymentIntentID),
+			logger.Error(err))
+		return err
+	}
+
+	if releaseReason != "" {
+		uc.refundCart(ctx, paymentIntentID, reservations, payments, refunds, releaseReason)
+		return nil
+	}
+
+	// Notificar vía WebSocket (después del commit; nada que notificar en un reintento)
+	if len(reservations) > 0 {
+		notifyPaid(uc.wsHub, reservations, payments)
+		uc.logger.Info("Carrito pagado con tarjeta",
+			logger.String("payment_intent_id", paymentIntentID),
+			logger.Int("reservations", len(reservations)))
+	}
+
+	return nil
+}
+
+// unconfirmableReason indica por qué una reserva pagada ya no puede confirmarse ("" si puede)
+func unconfirmableReason(reservation *entities.Reservation, raffle *domain.Raffle) string {
+	if err := reservation.CanBePaid(); err != nil {
+		return "Reservation can no longer be confirmed: " + err.Error()
+	}
+	if raffle.Status != domain.RaffleStatusActive {
+		return "Raffle no longer accepts sales"
+	}
+	return ""
+}
+
+// refundCart ejecuta los reembolsos de un carrito cobrado que no pudo confirmarse y libera sus reservas
+// Los reembolsos que fallen quedan pendientes y los reintenta el job de reembolsos
+func (uc *HandleCartPaymentEventUseCase) refundCart(ctx context.Context, paymentIntentID string, reservations []*entities.Reservation, payments []*entities.Payment, refunds []*domain.PaymentRefund, reason string) {
+	uc.logger.Warn("Carrito cobrado sin reservas confirmables, reembolsando",
+		logger.String("payment_intent_id", paymentIntentID),
+		logger.Int("reservations", len(reservations)),
+		logger.String("reason", reason))
+
+	for _, paymentRefund := range refunds {
+		if err := uc.refundProcessor.Process(ctx, paymentRefund); err != nil {
+			uc.logger.Error("Error procesando reembolso del carrito",
+				logger.Int64("refund_id", paymentRefund.ID),
+				logger.String("payment_id", paymentRefund.PaymentID),
+				logger.Error(err))
+		}
+	}
+
+	for i, reservation := range reservations {
+		if err := uc.reservations.CancelReservation(ctx, reservation.ID); err != nil {
+			uc.logger.Error("Error liberando reserva del carrito",
+				logger.String("reservation_id", reservation.ID.String()),
+				logger.Error(err))
+		}
+
+		uc.wsHub.NotifyPaymentFailed(
+			reservation.UserID.String(),
+			reservation.RaffleID.String(),
+			reservation.ID.String(),
+			payments[i].ID.String(),
+			reason,
+		)
+	}
+}
+
+// releaseCart marca los pagos y el carrito como fallidos y libera todas sus reservas
+func (uc *HandleCartPaymentEventUseCase) releaseCart(ctx context.Context, paymentIntentID string, status entities.CartStatus, reason string) error {
+	var reservations []*entities.Reservation
+	var payments []*entities.Payment
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		cart, err := uc.lockCart(ctx, tx, paymentIntentID)
+		if err != nil {
+			return err
+		}
+
+		// Reintento del webhook o carrito ya resuelto
+		if !cart.IsOpen() {
+			return nil
+		}
+
+		reservations, err = lockReservations(tx, cart)
+		if err != nil {
+			return err
+		}
+		payments, err = uc.intentPayments(ctx, tx, cart, paymentIntentID, reservations)
+		if err != nil {
+			return err
+		}
+
+		paymentRepo := db.NewPaymentRepository(tx)
+		for _, pay := range payments {
+			if status == entities.CartStatusCancelled {
+				err = pay.Cancel()
+			} else {
+				err = pay.MarkAsFailed(reason)
+			}
+			if err != nil {
+				return fmt.Errorf("error updating payment status: %w", err)
+			}
+			if err := paymentRepo.Update(ctx, pay); err != nil {
+				return fmt.Errorf("error updating payment: %w", err)
+			}
+		}
+
+		if err := cart.MarkAsFailed(status, reason); err != nil {
+			return err
+		}
+		return db.NewCartRepository(tx).Update(ctx, cart)
+	})
+	if err != nil {
+		uc.logger.Error("Error liberando carrito",
+			logger.String("payment_intent_id", paymentIntentID),
+			logger.Error(err))
+		return err
+	}
+
+	// Liberar todas las reservas: números, locks y lista de espera (después del commit)
+	for i, reservation := range reservations {
+		if err := uc.reservations.CancelReservation(ctx, reservation.ID); err != nil {
+			uc.logger.Error("Error liberando reserva del carrito",
+				logger.String("reservation_id", reservation.ID.String()),
+				logger.Error(err))
+		}
+
+		uc.wsHub.NotifyPaymentFailed(
+			reservation.UserID.String(),
+			reservation.RaffleID.String(),
+			reservation.ID.String(),
+			payments[i].ID.String(),
+			reason,
+		)
+	}
+
+	return nil
+}
+
+// lockCart obtiene con lock el carrito pagado por el payment intent
+func (uc *HandleCartPaymentEventUseCase) lockCart(ctx context.Context, tx *gorm.DB, paymentIntentID string) (*entities.Cart, error) {
+	cartRepo := db.NewCartRepository(tx)
+
+	cart, err := cartRepo.FindByPaymentIntentID(ctx, paymentIntentID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cart: %w", err)
+	}
+	if cart == nil {
+		return nil, fmt.Errorf("no cart for payment intent %s", paymentIntentID)
+	}
+
+	return cartRepo.FindByIDForUpdate(ctx, cart.ID)
+}
+
+// intentPayments devuelve los pagos del payment intent en el orden de las reservas
+func (uc *HandleCartPaymentEventUseCase) intentPayments(ctx context.Context, tx *gorm.DB, cart *entities.Cart, paymentIntentID string, reservations []*entities.Reservation) ([]*entities.Payment, error) {
+	cartPayments, err := db.NewPaymentRepository(tx).FindByCartID(ctx, cart.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cart payments: %w", err)
+	}
+
+	byReservation := make(map[string]*entities.Payment, len(cartPayments))
+	for _, pay := range cartPayments {
+		if pay.StripePaymentIntentID == paymentIntentID {
+			byReservation[pay.ReservationID.String()] = pay
+		}
+	}
+
+	payments := make([]*entities.Payment, len(reservations))
+	for i, reservation := range reservations {
+		pay, ok := byReservation[reservation.ID.String()]
+		if !ok {
+			return nil, fmt.Errorf("no payment for reservation %s in cart %s", reservation.ID, cart.ID)
+		}
+		payments[i] = pay
+	}
+	return payments, nil
+}
@@ -0,0 +1,253 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	walletuc "github.com/sorteos-platform/backend/internal/usecase/wallet"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// PayCartWithWalletInput representa los datos de entrada para pagar un carrito con saldo
+type PayCartWithWalletInput struct {
+	CartID uuid.UUID
+	UserID int64
+}
+
+// PayCartWithWalletOutput representa los datos de salida
+type PayCartWithWalletOutput struct {
+	Cart         *entities.Cart            `json:"cart"`
+	Reservations []*entities.Reservation   `json:"reservations"`
+	Payments     []*entities.Payment       `json:"payments"`
+	Transaction  *domain.WalletTransaction `json:"transaction"`
+	NewBalance   decimal.Decimal           `json:"new_balance"`
+	AlreadyPaid  bool                      `json:"already_paid"` // true si el carrito ya estaba pagado (reintento)
+}
+
+// PayCartWithWalletUseCase paga todas las reservas de un carrito con un solo débito de billetera
+// El débito, los pagos, la confirmación de las reservas y la venta de los números se ejecutan
+// en una sola transacción: o se confirman todas las reservas o ninguna
+type PayCartWithWalletUseCase struct {
+	db     *gorm.DB
+	wsHub  *websocket.Hub
+	logger *logger.Logger
+}
+
+// NewPayCartWithWalletUseCase crea una nueva instancia del use case
+func NewPayCartWithWalletUseCase(gormDB *gorm.DB, wsHub *websocket.Hub, logger *logger.Logger) *PayCartWithWalletUseCase {
+	return &PayCartWithWalletUseCase{
+		db:     gormDB,
+		wsHub:  wsHub,
+		logger: logger,
+	}
+}
+
+// Execute ejecuta el caso de uso de pago de carrito con saldo
+// Es idempotente por carrito: un reintento sobre un carrito ya pagado con saldo devuelve los pagos existentes
+func (uc *PayCartWithWalletUseCase) Execute(ctx context.Context, input *PayCartWithWalletInput) (*PayCartWithWalletOutput, error) {
+	var output *PayCartWithWalletOutput
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Obtener carrito con lock (serializa pagos concurrentes del mismo carrito)
+		user, err := db.NewUserRepository(tx).FindByID(input.UserID)
+		if err != nil {
+			return err
+		}
+		cart, err := findUserCart(ctx, tx, input.CartID, user.UUID, true)
+		if err != nil {
+			return err
+		}
+
+		walletRepo := db.NewWalletRepository(tx, uc.logger)
+		transactionRepo := db.NewWalletTransactionRepository(tx, uc.logger)
+		paymentRepo := db.NewPaymentRepository(tx)
+
+		// 2. Idempotencia: el carrito ya fue pagado con saldo
+		if cart.Status == entities.CartStatusPaid && cart.PaymentMethod == entities.PaymentMethodWallet {
+			transaction, err := transactionRepo.FindByIdempotencyKey(CartPurchaseKey(cart.ID))
+			if err != nil {
+				return err
+			}
+			wallet, err := walletRepo.FindByUserID(input.UserID)
+			if err != nil {
+				return err
+			}
+			reservations, err := lockReservations(tx, cart)
+			if err != nil {
+				return err
+			}
+			payments, err := paymentRepo.FindByCartID(ctx, cart.ID)
+			if err != nil {
+				return errors.Wrap(errors.ErrDatabaseError, err)
+			}
+
+			output = &PayCartWithWalletOutput{
+				Cart:         cart,
+				Reservations: reservations,
+				Payments:     payments,
+				Transaction:  transaction,
+				NewBalance:   wallet.BalanceAvailable,
+				AlreadyPaid:  true,
+			}
+			return nil
+		}
+
+		// 3. Validar estado del carrito (un payment intent con tarjeta en curso excluye el pago con saldo)
+		if !cart.IsOpen() {
+			return cartStateError(entities.ErrCartNotOpen)
+		}
+		if cart.PaymentIntentID != "" {
+			return errors.New("PAYMENT_ALREADY_EXISTS", "ya existe un pago con tarjeta en curso para este carrito", 409, nil)
+		}
+
+		// 4. Obtener reservas con lock y validar que todas puedan pagarse
+		reservations, err := lockReservations(tx, cart)
+		if err != nil {
+			return err
+		}
+
+		raffles := make([]*domain.Raffle, len(reservations))
+		amounts := make([]decimal.Decimal, len(reservations))
+		total := decimal.Zero
+		for i, reservation := range reservations {
+			raffle, err := validatePayable(ctx, tx, reservation)
+			if err != nil {
+				return err
+			}
+			raffles[i] = raffle
+			amounts[i] = decimal.NewFromFloat(reservation.TotalAmount).Round(2)
+			total = total.Add(amounts[i])
+		}
+
+		// 5. Debitar el total del carrito en un solo movimiento
+		debitFunds := walletuc.NewDebitFundsUseCase(
+			walletRepo,
+			transactionRepo,
+			db.NewUserRepository(tx),
+			db.NewAuditLogRepository(tx),
+			uc.logger,
+		)
+
+		referenceType := ReferenceTypeCart
+		notes := fmt.Sprintf("Compra de carrito con %d sorteo(s)", len(reservations))
+		debit, err := debitFunds.Execute(ctx, &walletuc.DebitFundsInput{
+			UserID:         input.UserID,
+			Amount:         total,
+			IdempotencyKey: CartPurchaseKey(cart.ID),
+			ReferenceType:  &referenceType,
+			Notes:          &notes,
+			Metadata: map[string]interface{}{
+				"cart_id":         cart.ID.String(),
+				"reservation_ids": []string(cart.ReservationIDs),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		wallet, err := walletRepo.FindByUserID(input.UserID)
+		if err != nil {
+			return err
+		}
+
+		// 6. Por cada reserva: asiento contable, pago, confirmación y venta de números
+		payments := make([]*entities.Payment, len(reservations))
+		for i, reservation := range reservations {
+			raffle := raffles[i]
+			description := fmt.Sprintf("Compra de %d número(s) - %s", len(reservation.NumberIDs), raffle.Title)
+
+			// Asiento contable: el saldo del comprador pasa a la cuenta por pagar de cada organizador
+			purchaseEntry := domain.NewJournalEntry(domain.JournalEntryPurchase, walletuc.ReservationPurchaseKey(reservation.ID), wallet.Currency).
+				WithReference(walletuc.ReferenceTypeReservation, reservation.ID).
+				WithWalletTransaction(debit.Transaction.ID).
+				WithDescription(description).
+				Debit(domain.UserAvailableAccount(input.UserID), amounts[i]).
+				Credit(domain.OrganizerPayableAccount(raffle.UserID), amounts[i])
+			if err := walletRepo.PostJournalEntry(purchaseEntry); err != nil {
+				return err
+			}
+
+			pay, err := entities.NewPayment(
+				reservation.ID,
+				reservation.UserID,
+				reservation.RaffleID,
+				entities.PaymentMethodWallet+":"+debit.Transaction.UUID,
+				"",
+				reservation.TotalAmount,
+				wallet.Currency,
+			)
+			if err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			pay.CartID = &cart.ID
+			if err := pay.MarkAsSucceeded(entities.PaymentMethodWallet); err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			if err := pay.SetMetadata(entities.PaymentMetadata{
+				NumberCount:    len(reservation.NumberIDs),
+				NumberIDs:      reservation.NumberIDs,
+				RaffleTitle:    raffle.Title,
+				PriceBreakdown: reservation.PriceBreakdown,
+			}); err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			if err := paymentRepo.Create(ctx, pay); err != nil {
+				return errors.Wrap(errors.ErrDatabaseError, err)
+			}
+			payments[i] = pay
+
+			if err := confirmReservation(ctx, tx, reservation, raffle, input.UserID); err != nil {
+				return err
+			}
+		}
+
+		// 7. Marcar el carrito como pagado
+		if err := cart.MarkAsPaid(entities.PaymentMethodWallet, entities.PaymentMethodWallet+":"+debit.Transaction.UUID); err != nil {
+			return cartStateError(err)
+		}
+		if err := db.NewCartRepository(tx).Update(ctx, cart); err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		output = &PayCartWithWalletOutput{
+			Cart:         cart,
+			Reservations: reservations,
+			Payments:     payments,
+			Transaction:  debit.Transaction,
+			NewBalance:   debit.NewBalance,
+		}
+		return nil
+	})
+	if err != nil {
+		uc.logger.Error("Error pagando carrito con saldo",
+			logger.String("cart_id", input.CartID.String()),
+			logger.Int64("user_id", input.UserID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	if output.AlreadyPaid {
+		return output, nil
+	}
+
+	// Notificar vía WebSocket (después del commit)
+	notifyPaid(uc.wsHub, output.Reservations, output.Payments)
+
+	uc.logger.Info("Carrito pagado con saldo",
+		logger.String("cart_id", output.Cart.ID.String()),
+		logger.Int("reservations", len(output.Reservations)),
+		logger.Int64("tx_id", output.Transaction.ID),
+		logger.Int64("user_id", input.UserID),
+		logger.String("new_balance", output.NewBalance.String()))
+
+	return output, nil
+}
@@ -0,0 +1,542 @@
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// DivergenceType clase de divergencia entre reservas, raffle_numbers, locks de Redis y contadores
+type DivergenceType string
+
+const (
+	DivergenceNumberNotSold          DivergenceType = "number_not_sold"          // Reserva confirmada con número sin vender
+	DivergenceNumberNotReserved      DivergenceType = "number_not_reserved"      // Reserva vigente con número disponible
+	DivergenceOrphanReservedNumber   DivergenceType = "orphan_reserved_number"   // Número reservado sin reserva vigente
+	DivergenceSoldWithoutReservation DivergenceType = "sold_without_reservation" // Número vendido sin reserva confirmada (solo reporte)
+	DivergenceNumberConflict         DivergenceType = "number_conflict"          // Número de una reserva tomado por otro usuario (solo reporte)
+	DivergenceMissingLock            DivergenceType = "missing_lock"             // Reserva vigente sin lock en Redis
+	DivergenceLockOwnerMismatch      DivergenceType = "lock_owner_mismatch"      // Lock de una reserva vigente con otro dueño (solo reporte)
+	DivergenceStaleLock              DivergenceType = "stale_lock"               // Lock en Redis sin reserva ni oferta vigente
+	DivergenceSoldCount              DivergenceType = "sold_count_mismatch"      // Raffle.SoldCount distinto a los números vendidos
+	DivergenceReservedCount          DivergenceType = "reserved_count_mismatch"  // Raffle.ReservedCount distinto a los números reservados
+)
+
+// recentChangeGrace cambios más recientes que esto se omiten: pueden ser reservas en plena creación
+const recentChangeGrace = time.Minute
+
+// Divergence diferencia detectada en un sorteo
+type Divergence struct {
+	Type          DivergenceType `json:"type"`
+	RaffleID      int64          `json:"raffle_id"`
+	Number        string         `json:"number,omitempty"`
+	ReservationID string         `json:"reservation_id,omitempty"`
+	Expected      string         `json:"expected"`
+	Actual        string         `json:"actual"`
+	Fixable       bool           `json:"fixable"`
+	Fixed         bool           `json:"fixed"`
+	FixError      string         `json:"fix_error,omitempty"`
+	Applied       string         `json:"applied,omitempty"` // Valor escrito al reparar (recalculado en la misma escritura)
+}
+
+// RepairInput datos de entrada de una ejecución
+type RepairInput struct {
+	RaffleID *int64 // Sorteo a revisar (nil: todos los sorteos activos)
+	Repair   bool   // false: solo reporte
+	AdminID  *int64 // Admin que ejecuta la reparación (nil: job)
+}
+
+// RepairReport resultado de la verificación del inventario de números
+type RepairReport struct {
+	CheckedAt       time.Time     `json:"checked_at"`
+	Repair          bool          `json:"repair"`
+	Consistent      bool          `json:"consistent"`
+	RafflesChecked  int           `json:"raffles_checked"`
+	DivergenceCount int           `json:"divergence_count"`
+	FixedCount      int           `json:"fixed_count"`
+	Divergences     []*Divergence `json:"divergences"`
+}
+
+// InventoryRepairer detecta y repara divergencias entre reservas, raffle_numbers, locks de Redis
+// y contadores de los sorteos. La reserva es la fuente de verdad: los números de reservas
+// confirmadas deben estar vendidos y los de reservas vigentes reservados y con lock
+type InventoryRepairer struct {
+	db          *gorm.DB
+	lockService *redis.LockService
+	wsHub       *websocket.Hub
+	auditRepo   domain.AuditLogRepository
+	log         *logger.Logger
+}
+
+// NewInventoryRepairer crea una nueva instancia
+func NewInventoryRepairer(gormDB *gorm.DB, lockService *redis.LockService, wsHub *websocket.Hub, log *logger.Logger) *InventoryRepairer {
+	return &InventoryRepairer{
+		db:          gormDB,
+		lockService: lockService,
+		wsHub:       wsHub,
+		auditRepo:   db.NewAuditLogRepository(gormDB),
+		log:         log,
+	}
+}
+
+// Run revisa los sorteos y, si input.Repair, corrige las divergencias reparables
+// Cada corrección queda registrada en el audit log
+func (r *InventoryRepairer) Run(ctx context.Context, input *RepairInput) (*RepairReport, error) {
+	report := &RepairReport{
+		CheckedAt:   time.Now(),
+		Repair:      input.Repair,
+		Divergences: []*Divergence{},
+	}
+
+	var raffles []*domain.Raffle
+	if input.RaffleID != nil {
+		raffle, err := db.NewRaffleRepository(r.db).FindByID(*input.RaffleID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrRaffleNotFound
+			}
+			return nil, err
+		}
+		raffles = append(raffles, raffle)
+	} else if err := r.db.WithContext(ctx).
+		Where("status = ? AND deleted_at IS NULL", domain.RaffleStatusActive).
+		Find(&raffles).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	for _, raffle := range raffles {
+		divergences, err := r.checkRaffle(ctx, raffle, input)
+		if err != nil {
+			return nil, err
+		}
+		report.Divergences = append(report.Divergences, divergences...)
+		report.RafflesChecked++
+	}
+
+	report.DivergenceCount = len(report.Divergences)
+	for _, divergence := range report.Divergences {
+		if divergence.Fixed {
+			report.FixedCount++
+		}
+	}
+	report.Consistent = report.DivergenceCount == 0
+
+	if !report.Consistent {
+		r.log.Error("Raffle inventory inconsistency detected",
+			logger.Int("raffles_checked", report.RafflesChecked),
+			logger.Int("divergences", report.DivergenceCount),
+			logger.Int("fixed", report.FixedCount),
+			logger.Bool("repair", input.Repair))
+	}
+
+	return report, nil
+}
+
+// raffleCheck estado de la revisión de un sorteo
+type raffleCheck struct {
+	raffle      *domain.Raffle
+	input       *RepairInput
+	divergences []*Divergence
+}
+
+// checkRaffle compara las reservas del sorteo con raffle_numbers, los locks y los contadores
+func (r *InventoryRepairer) checkRaffle(ctx context.Context, raffle *domain.Raffle, input *RepairInput) ([]*Divergence, error) {
+	check := &raffleCheck{raffle: raffle, input: input}
+	raffleNumberRepo := db.NewRaffleNumberRepository(r.db)
+	now := time.Now()
+
+	// 1. Números reservados o vendidos (se leen antes que las reservas para no ver reservas a medio crear como huérfanas)
+	var numbers []*domain.RaffleNumber
+	if err := r.db.WithContext(ctx).
+		Where("raffle_id = ? AND status <> ?", raffle.ID, domain.RaffleNumberStatusAvailable).
+		Find(&numbers).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	byNumber := make(map[string]*domain.RaffleNumber, len(numbers))
+	for _, number := range numbers {
+		byNumber[number.Number] = number
+	}
+
+	// 2. Reservas pendientes y confirmadas (fuente de verdad)
+	var reservations []*entities.Reservation
+	if err := r.db.WithContext(ctx).
+		Where("raffle_id = ? AND status IN ?", raffle.UUID, []entities.ReservationStatus{
+			entities.ReservationStatusPending,
+			entities.ReservationStatusConfirmed,
+		}).
+		Order("created_at ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	userIDs, err := r.userIDs(ctx, reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Locks de Redis (al final: un lock recién tomado todavía no tiene reserva)
+	locks, err := r.lockService.ReservationLocks(ctx, raffle.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Dueños legítimos de locks: reservas y ofertas de lista de espera vigentes
+	owners, err := r.offerOwners(ctx, raffle, now)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make(map[string]bool)
+	for _, reservation := range reservations {
+		owners[reservation.ID.String()] = true
+		for _, numberStr := range reservation.NumberIDs {
+			claimed[numberStr] = true
+		}
+
+		// Las reservas pendientes vencidas las libera el job de expiración
+		if reservation.Status == entities.ReservationStatusPending && reservation.IsExpired() {
+			continue
+		}
+
+		userID, ok := userIDs[reservation.UserID.String()]
+		if !ok {
+			r.log.Error("Reservation user not found during inventory check",
+				logger.String("reservation_id", reservation.ID.String()),
+				logger.String("user_id", reservation.UserID.String()))
+			continue
+		}
+
+		for _, numberStr := range reservation.NumberIDs {
+			if reservation.Status == entities.ReservationStatusConfirmed {
+				r.checkConfirmedNumber(ctx, check, raffleNumberRepo, reservation, userID, numberStr, byNumber[numberStr])
+			} else {
+				r.checkPendingNumber(ctx, check, raffleNumberRepo, reservation, userID, numberStr, byNumber[numberStr], locks, now)
+			}
+		}
+	}
+
+	// 4. Números reservados o vendidos que ninguna reserva respalda
+	for _, number := range numbers {
+		if claimed[number.Number] {
+			continue
+		}
+
+		switch number.Status {
+		case domain.RaffleNumberStatusReserved:
+			if number.ReservedAt != nil && now.Sub(*number.ReservedAt) < recentChangeGrace {
+				continue
+			}
+			orphan := number
+			r.record(ctx, check, &Divergence{
+				Type:     DivergenceOrphanReservedNumber,
+				Number:   number.Number,
+				Expected: string(domain.RaffleNumberStatusAvailable),
+				Actual:   string(number.Status),
+				Fixable:  true,
+			}, func() error {
+				if err := raffleNumberRepo.CancelReservation(orphan.ID); err != nil {
+					return err
+				}
+				r.wsHub.BroadcastNumberUpdate(raffle.UUID.String(), orphan.Number, "available", nil)
+				return nil
+			})
+		case domain.RaffleNumberStatusSold:
+			r.record(ctx, check, &Divergence{
+				Type:     DivergenceSoldWithoutReservation,
+				Number:   number.Number,
+				Expected: "confirmed reservation",
+				Actual:   "none",
+			}, nil)
+		}
+	}
+
+	// 5. Locks sin reserva ni oferta vigente
+	for numberStr, lock := range locks {
+		if owners[lock.Owner] {
+			continue
+		}
+		if lock.TTL > entities.ReservationSelectionTimeout-recentChangeGrace {
+			continue
+		}
+
+		key := redis.ReservationLockKey(raffle.UUID.String(), numberStr)
+		owner := lock.Owner
+		r.record(ctx, check, &Divergence{
+			Type:     DivergenceStaleLock,
+			Number:   numberStr,
+			Expected: "no lock",
+			Actual:   "lock owned by " + owner,
+			Fixable:  true,
+		}, func() error {
+			return r.lockService.OwnedLock(key, owner).Release(ctx)
+		})
+	}
+
+	// 6. Contadores del sorteo contra raffle_numbers (después de las correcciones de números)
+	if err := r.checkCounters(ctx, check, raffleNumberRepo); err != nil {
+		return nil, err
+	}
+
+	return check.divergences, nil
+}
+
+// checkConfirmedNumber verifica que un número de una reserva confirmada esté vendido al comprador
+func (r *InventoryRepairer) checkConfirmedNumber(ctx context.Context, check *raffleCheck, raffleNumberRepo db.RaffleNumberRepository, reservation *entities.Reservation, userID int64, numberStr string, number *domain.RaffleNumber) {
+	switch {
+	case number != nil && number.Status == domain.RaffleNumberStatusSold:
+		if number.UserID != nil && *number.UserID != userID {
+			r.recordConflict(ctx, check, reservation, numberStr, "sold to user "+formatUserID(number.UserID))
+		}
+		return
+	case number != nil && number.ReservedBy != nil && *number.ReservedBy != userID:
+		r.recordConflict(ctx, check, reservation, numberStr, "reserved by user "+formatUserID(number.ReservedBy))
+		return
+	}
+
+	actual := string(domain.RaffleNumberStatusAvailable)
+	if number != nil {
+		actual = string(number.Status)
+	}
+
+	raffle := check.raffle
+	r.record(ctx, check, &Divergence{
+		Type:          DivergenceNumberNotSold,
+		Number:        numberStr,
+		ReservationID: reservation.ID.String(),
+		Expected:      string(domain.RaffleNumberStatusSold),
+		Actual:        actual,
+		Fixable:       true,
+	}, func() error {
+		raffleNumber, err := raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberStr)
+		if err != nil {
+			return err
+		}
+		if err := raffleNumberRepo.MarkAsSold(raffleNumber.ID, userID, int64(reservation.ID.ID())); err != nil {
+			return err
+		}
+		userIDStr := reservation.UserID.String()
+		r.wsHub.BroadcastNumberUpdate(raffle.UUID.String(), numberStr, "sold", &userIDStr)
+		return nil
+	})
+}
+
+// checkPendingNumber verifica que un número de una reserva vigente esté reservado para el usuario y con lock
+func (r *InventoryRepairer) checkPendingNumber(ctx context.Context, check *raffleCheck, raffleNumberRepo db.RaffleNumberRepository, reservation *entities.Reservation, userID int64, numberStr string, number *domain.RaffleNumber, locks map[string]redis.LockInfo, now time.Time) {
+	raffle := check.raffle
+	recent := now.Sub(reservation.CreatedAt) < recentChangeGrace
+
+	switch {
+	case number == nil:
+		if !recent {
+			r.record(ctx, check, &Divergence{
+				Type:          DivergenceNumberNotReserved,
+				Number:        numberStr,
+				ReservationID: reservation.ID.String(),
+				Expected:      string(domain.RaffleNumberStatusReserved),
+				Actual:        string(domain.RaffleNumberStatusAvailable),
+				Fixable:       true,
+			}, func() error {
+				if err := raffleNumberRepo.ReserveNumbers(raffle.ID, []string{numberStr}, userID, 0, time.Until(reservation.ExpiresAt)); err != nil {
+					return err
+				}
+				userIDStr := reservation.UserID.String()
+				r.wsHub.BroadcastNumberUpdate(raffle.UUID.String(), numberStr, "reserved", &userIDStr)
+				return nil
+			})
+		}
+	case number.Status == domain.RaffleNumberStatusSold:
+		r.recordConflict(ctx, check, reservation, numberStr, "sold to user "+formatUserID(number.UserID))
+	case number.ReservedBy != nil && *number.ReservedBy != userID:
+		r.recordConflict(ctx, check, reservation, numberStr, "reserved by user "+formatUserID(number.ReservedBy))
+	}
+
+	lock, locked := locks[numberStr]
+	switch {
+	case !locked && !recent:
+		key := redis.ReservationLockKey(raffle.UUID.String(), numberStr)
+		r.record(ctx, check, &Divergence{
+			Type:          DivergenceMissingLock,
+			Number:        numberStr,
+			ReservationID: reservation.ID.String(),
+			Expected:      "lock owned by " + reservation.ID.String(),
+			Actual:        "no lock",
+			Fixable:       true,
+		}, func() error {
+			_, err := r.lockService.AcquireLockWithOwner(ctx, key, reservation.ID.String(), time.Until(reservation.ExpiresAt))
+			return err
+		})
+	case locked && lock.Owner != reservation.ID.String():
+		r.record(ctx, check, &Divergence{
+			Type:          DivergenceLockOwnerMismatch,
+			Number:        numberStr,
+			ReservationID: reservation.ID.String(),
+			Expected:      "lock owned by " + reservation.ID.String(),
+			Actual:        "lock owned by " + lock.Owner,
+		}, nil)
+	}
+}
+
+// checkCounters compara SoldCount y ReservedCount del sorteo con los estados de raffle_numbers
+func (r *InventoryRepairer) checkCounters(ctx context.Context, check *raffleCheck, raffleNumberRepo db.RaffleNumberRepository) error {
+	raffle := check.raffle
+
+	sold, err := raffleNumberRepo.CountByStatus(raffle.ID, domain.RaffleNumberStatusSold)
+	if err != nil {
+		return err
+	}
+	reserved, err := raffleNumberRepo.CountByStatus(raffle.ID, domain.RaffleNumberStatusReserved)
+	if err != nil {
+		return err
+	}
+
+	counters := []struct {
+		divergence DivergenceType
+		column     string
+		status     domain.RaffleNumberStatus
+		actual     int
+		expected   int64
+	}{
+		{DivergenceSoldCount, "sold_count", domain.RaffleNumberStatusSold, raffle.SoldCount, sold},
+		{DivergenceReservedCount, "reserved_count", domain.RaffleNumberStatusReserved, raffle.ReservedCount, reserved},
+	}
+
+	for _, counter := range counters {
+		if int64(counter.actual) == counter.expected {
+			continue
+		}
+
+		// El contador se recalcula dentro del UPDATE: una venta o reserva confirmada después del
+		// conteo no se pierde; el valor observado queda en Expected y el escrito en Applied
+		column, status := counter.column, counter.status
+		divergence := &Divergence{
+			Type:     counter.divergence,
+			Expected: fmt.Sprintf("%d", counter.expected),
+			Actual:   fmt.Sprintf("%d", counter.actual),
+			Fixable:  true,
+		}
+		r.record(ctx, check, divergence, func() error {
+			var applied int64
+			if err := r.db.WithContext(ctx).Raw(
+				"UPDATE raffles SET "+column+" = (SELECT COUNT(*) FROM raffle_numbers WHERE raffle_id = ? AND status = ?) WHERE id = ? RETURNING "+column,
+				raffle.ID, status, raffle.ID).
+				Scan(&applied).Error; err != nil {
+				return errors.Wrap(errors.ErrDatabaseError, err)
+			}
+			divergence.Applied = fmt.Sprintf("%d", applied)
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// recordConflict registra un número de una reserva tomado por otro usuario (requiere revisión manual)
+func (r *InventoryRepairer) recordConflict(ctx context.Context, check *raffleCheck, reservation *entities.Reservation, numberStr, actual string) {
+	r.record(ctx, check, &Divergence{
+		Type:          DivergenceNumberConflict,
+		Number:        numberStr,
+		ReservationID: reservation.ID.String(),
+		Expected:      "held by reservation user",
+		Actual:        actual,
+	}, nil)
+}
+
+// record agrega la divergencia al reporte y, si corresponde, aplica la corrección y la audita
+func (r *InventoryRepairer) record(ctx context.Context, check *raffleCheck, divergence *Divergence, fix func() error) {
+	divergence.RaffleID = check.raffle.ID
+	divergence.Fixable = divergence.Fixable && fix != nil
+	check.divergences = append(check.divergences, divergence)
+
+	if !check.input.Repair || !divergence.Fixable {
+		return
+	}
+
+	if err := fix(); err != nil {
+		divergence.FixError = err.Error()
+		r.log.Error("Error repairing raffle inventory divergence",
+			logger.String("type", string(divergence.Type)),
+			logger.Int64("raffle_id", divergence.RaffleID),
+			logger.String("number", divergence.Number),
+			logger.Error(err))
+		return
+	}
+	divergence.Fixed = true
+
+	auditLog := domain.NewAuditLog(domain.AuditActionInventoryRepaired).
+		WithSeverity(domain.AuditSeverityWarning).
+		WithDescription(fmt.Sprintf("Divergencia %s reparada en el sorteo %d", divergence.Type, divergence.RaffleID)).
+		WithEntity("raffle", divergence.RaffleID).
+		WithMetadata(divergence)
+	if check.input.AdminID != nil {
+		auditLog = auditLog.WithAdmin(*check.input.AdminID)
+	}
+	if err := r.auditRepo.Create(auditLog.Build()); err != nil {
+		r.log.Error("Error creating inventory repair audit log", logger.Error(err))
+	}
+}
+
+// userIDs resuelve el ID numérico de los usuarios de las reservas (UUID → ID)
+func (r *InventoryRepairer) userIDs(ctx context.Context, reservations []*entities.Reservation) (map[string]int64, error) {
+	uuids := make([]string, 0, len(reservations))
+	for _, reservation := range reservations {
+		uuids = append(uuids, reservation.UserID.String())
+	}
+
+	ids := make(map[string]int64, len(uuids))
+	if len(uuids) == 0 {
+		return ids, nil
+	}
+
+	var users []struct {
+		ID   int64
+		UUID string
+	}
+	if err := r.db.WithContext(ctx).
+		Table("users").
+		Select("id, uuid").
+		Where("uuid IN ?", uuids).
+		Scan(&users).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	for _, user := range users {
+		ids[user.UUID] = user.ID
+	}
+	return ids, nil
+}
+
+// offerOwners devuelve los IDs de reserva de las ofertas de lista de espera vigentes (dueñas de locks)
+func (r *InventoryRepairer) offerOwners(ctx context.Context, raffle *domain.Raffle, now time.Time) (map[string]bool, error) {
+	var offers []*entities.WaitlistEntry
+	if err := r.db.WithContext(ctx).
+		Where("raffle_id = ? AND status = ? AND offer_expires_at > ?", raffle.UUID, entities.WaitlistStatusOffered, now).
+		Find(&offers).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	owners := make(map[string]bool, len(offers))
+	for _, offer := range offers {
+		if offer.OfferReservationID != nil {
+			owners[offer.OfferReservationID.String()] = true
+		}
+	}
+	return owners, nil
+}
+
+// formatUserID formatea un ID de usuario opcional
+func formatUserID(userID *int64) string {
+	if userID == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d", *userID)
+}
@@ -172,11 +172,16 @@ func (uc *ProcessPagaditoCallbackUseCase) processCompleted(
 			"credit_purchase_id": purchase.ID,
 			"ern":                purchase.ERN,
 			"pagadito_reference": statusResp.Reference,
-			"charge_amount":      purchase.ChargeAmount.String(),
+			walletuc.DepositMetadataChargeAmount: purchase.ChargeAmount.String(),
+			walletuc.DepositMetadataProcessorFee: purchase.FixedFee.Add(purchase.ProcessorFee).String(),
 		},
 	}
 
 	addFundsOutput, err := uc.addFundsUC.Execute(ctx, addFundsInput)
+	if err == nil && addFundsOutput.Transaction.IsPending() {
+		// El pago ya fue verificado con Pagadito: acreditar el saldo y registrar el asiento contable
+		addFundsOutput, err = uc.confirmDeposit(ctx, purchase.UserID, addFundsOutput.Transaction.ID)
+	}
 	if err != nil {
 		uc.logger.Error("Error acreditando fondos a billetera",
 			logger.Int64("purchase_id", purchase.ID),
@@ -212,7 +217,7 @@ func (uc *ProcessPagaditoCallbackUseCase) processCompleted(
 	})
 	uc.auditRepo.Create(&domain.AuditLog{
 		UserID:     &purchase.UserID,
-		Action:     "credit_purchase_completed",
+		Action:     domain.AuditActionCreditPurchaseCompleted,
 		EntityType: &entityType,
 		EntityID:   &purchase.ID,
 		Metadata:   metadataBytes,
@@ -232,6 +237,28 @@ func (uc *ProcessPagaditoCallbackUseCase) processCompleted(
 	}, nil
 }
 
+// confirmDeposit acredita la transacción de recarga pendiente y retorna el saldo resultante
+func (uc *ProcessPagaditoCallbackUseCase) confirmDeposit(ctx context.Context, userID, transactionID int64) (*walletuc.AddFundsOutput, error) {
+	if err := uc.addFundsUC.ConfirmAddFunds(ctx, transactionID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := uc.transactionRepo.FindByID(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := uc.walletRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &walletuc.AddFundsOutput{
+		Transaction: transaction,
+		NewBalance:  wallet.BalanceAvailable,
+	}, nil
+}
+
 // processVerifying procesa un pago en verificación
 func (uc *ProcessPagaditoCallbackUseCase) processVerifying(
 	ctx context.Context,
@@ -287,7 +314,7 @@ func (uc *ProcessPagaditoCallbackUseCase) processFailed(
 	})
 	uc.auditRepo.Create(&domain.AuditLog{
 		UserID:     &purchase.UserID,
-		Action:     "credit_purchase_failed",
+		Action:     domain.AuditActionCreditPurchaseFailed,
 		EntityType: &entityType,
 		EntityID:   &purchase.ID,
 		Metadata:   metadataBytes,
@@ -0,0 +1,281 @@
+package credits
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/pagadito"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const (
+	// DefaultReconciliationStaleMinutes minutos sin actualizar antes de consultar una compra en Pagadito
+	DefaultReconciliationStaleMinutes = 60
+
+	// DefaultReconciliationLimit máximo de compras revisadas por ejecución
+	DefaultReconciliationLimit = 500
+
+	// reconciliationLockTTL duración del lock de la conciliación (cubre el timeout del job)
+	reconciliationLockTTL = 35 * time.Minute
+)
+
+// ErrReconciliationInProgress otra réplica o un admin ya está conciliando las compras
+var ErrReconciliationInProgress = errors.New("CREDIT_RECONCILIATION_IN_PROGRESS",
+	"Ya hay una conciliación de compras de créditos en curso", http.StatusConflict, nil)
+
+// ReconciliationLocker lock distribuido que impide dos conciliaciones simultáneas
+// Lock retorna redis.ErrLockNotAcquired si otra réplica o un admin ya lo tiene
+type ReconciliationLocker interface {
+	Lock(ctx context.Context) (release func(), err error)
+}
+
+// redisReconciliationLocker ReconciliationLocker sobre el lock distribuido de Redis
+type redisReconciliationLocker struct {
+	lockService *redis.LockService
+}
+
+// NewRedisReconciliationLocker crea el lock de la conciliación sobre Redis
+func NewRedisReconciliationLocker(lockService *redis.LockService) ReconciliationLocker {
+	return &redisReconciliationLocker{lockService: lockService}
+}
+
+// Lock adquiere el lock de la conciliación
+func (l *redisReconciliationLocker) Lock(ctx context.Context) (func(), error) {
+	lock, err := l.lockService.AcquireLock(ctx, redis.CreditReconciliationLockKey(), reconciliationLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	return func() { lock.Release(ctx) }, nil
+}
+
+// ReconcilePurchasesInput datos de entrada
+type ReconcilePurchasesInput struct {
+	Limit       int    // Compras a revisar (0 = DefaultReconciliationLimit)
+	TriggeredBy *int64 // Admin que la ejecuta manualmente (nil = job)
+}
+
+// ReconcilePurchasesUseCase concilia las compras de créditos que quedaron pendientes o en proceso
+// (usuario que cerró la pestaña antes del callback) consultando su estado en Pagadito:
+// acredita las pagadas, cierra las rechazadas, expira las abandonadas y reporta las diferencias
+type ReconcilePurchasesUseCase struct {
+	purchaseRepo       domain.CreditPurchaseRepository
+	reconciliationRepo domain.CreditReconciliationRepository
+	systemParamRepo    domain.SystemParameterRepository
+	auditRepo          domain.AuditLogRepository
+	pagaditoClient     pagadito.Client
+	callbackUC         *ProcessPagaditoCallbackUseCase
+	locker             ReconciliationLocker
+	logger             *logger.Logger
+}
+
+// NewReconcilePurchasesUseCase crea una nueva instancia
+// Reutiliza el procesamiento del callback para que la acreditación sea idempotente
+func NewReconcilePurchasesUseCase(
+	purchaseRepo domain.CreditPurchaseRepository,
+	reconciliationRepo domain.CreditReconciliationRepository,
+	systemParamRepo domain.SystemParameterRepository,
+	auditRepo domain.AuditLogRepository,
+	pagaditoClient pagadito.Client,
+	callbackUC *ProcessPagaditoCallbackUseCase,
+	locker ReconciliationLocker,
+	logger *logger.Logger,
+) *ReconcilePurchasesUseCase {
+	return &ReconcilePurchasesUseCase{
+		purchaseRepo:       purchaseRepo,
+		reconciliationRepo: reconciliationRepo,
+		systemParamRepo:    systemParamRepo,
+		auditRepo:          auditRepo,
+		pagaditoClient:     pagaditoClient,
+		callbackUC:         callbackUC,
+		locker:             locker,
+		logger:             logger,
+	}
+}
+
+// Execute revisa las compras sin actualizar y guarda el reporte de la conciliación
+// Retorna ErrReconciliationInProgress si otra réplica o un admin ya está conciliando
+func (uc *ReconcilePurchasesUseCase) Execute(ctx context.Context, input *ReconcilePurchasesInput) (*domain.CreditReconciliation, error) {
+	// Lock distribuido: una sola conciliación a la vez entre réplicas y ejecuciones manuales
+	release, err := uc.locker.Lock(ctx)
+	if err != nil {
+		if stderrors.Is(err, redis.ErrLockNotAcquired) {
+			return nil, ErrReconciliationInProgress
+		}
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	defer release()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultReconciliationLimit
+	}
+
+	staleMinutes, _ := uc.systemParamRepo.GetInt("credit_reconciliation_stale_minutes", DefaultReconciliationStaleMinutes)
+	if staleMinutes <= 0 {
+		staleMinutes = DefaultReconciliationStaleMinutes
+	}
+
+	now := time.Now()
+	reconciliation := &domain.CreditReconciliation{
+		TriggeredBy: input.TriggeredBy,
+		StaleBefore: now.Add(-time.Duration(staleMinutes) * time.Minute),
+		StartedAt:   now,
+	}
+
+	purchases, err := uc.purchaseRepo.FindStale(reconciliation.StaleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(purchases) > 0 {
+		if err := uc.pagaditoClient.Connect(); err != nil {
+			uc.logger.Error("Error conectando con Pagadito para conciliar compras",
+				logger.Int("purchases", len(purchases)),
+				logger.Error(err))
+			return nil, errors.New("PAGADITO_UNAVAILABLE", "No se pudo conectar con Pagadito", http.StatusBadGateway, err)
+		}
+	}
+
+	for _, purchase := range purchases {
+		if ctx.Err() != nil {
+			break
+		}
+		reconciliation.Add(uc.reconcile(ctx, purchase))
+	}
+
+	finishedAt := time.Now()
+	reconciliation.FinishedAt = &finishedAt
+
+	if err := uc.reconciliationRepo.Create(reconciliation); err != nil {
+		return nil, err
+	}
+
+	if reconciliation.Discrepancies > 0 {
+		uc.logger.Warn("Discrepancias en la conciliación de compras de créditos",
+			logger.Int64("reconciliation_id", reconciliation.ID),
+			logger.Int("discrepancies", reconciliation.Discrepancies))
+	}
+
+	return reconciliation, nil
+}
+
+// reconcile aplica el estado de Pagadito a una compra y retorna el detalle con el estado local previo
+func (uc *ReconcilePurchasesUseCase) reconcile(ctx context.Context, purchase *domain.CreditPurchase) *domain.CreditReconciliationItem {
+	item := domain.NewCreditReconciliationItem(purchase, domain.CreditReconciliationStillPending)
+	overdue := time.Now().After(purchase.ExpiresAt)
+
+	// Sin token el usuario nunca llegó a Pagadito: no hay nada que consultar
+	if purchase.PagaditoToken == nil {
+		if overdue {
+			uc.expire(purchase, item, "Compra abandonada antes de llegar a Pagadito")
+		}
+		return item
+	}
+
+	statusResp, err := uc.pagaditoClient.GetStatus(*purchase.PagaditoToken)
+	if err != nil {
+		uc.logger.Error("Error consultando estado en Pagadito durante conciliación",
+			logger.Int64("purchase_id", purchase.ID),
+			logger.Error(err))
+		return uc.flag(item, domain.CreditReconciliationError, fmt.Sprintf("Error consultando Pagadito: %v", err))
+	}
+
+	pagaditoStatus := statusResp.Status
+	item.PagaditoStatus = &pagaditoStatus
+	if !statusResp.Amount.IsZero() {
+		amount := statusResp.Amount
+		item.PagaditoAmount = &amount
+	}
+
+	switch domain.PagaditoStatus(statusResp.Status) {
+	case domain.PagaditoStatusCompleted:
+		// Un monto distinto al cobrado no se acredita automáticamente
+		if item.PagaditoAmount != nil && !item.PagaditoAmount.Equal(purchase.ChargeAmount) {
+			return uc.flag(item, domain.CreditReconciliationNeedsReview,
+				fmt.Sprintf("Monto pagado en Pagadito (%s) distinto al cobro esperado (%s)",
+					item.PagaditoAmount.String(), purchase.ChargeAmount.String()))
+		}
+
+		output, err := uc.callbackUC.processCompleted(ctx, purchase, statusResp)
+		if err != nil || output.Status != "COMPLETED" {
+			detail := "Pagada en Pagadito pero no se pudieron acreditar los créditos"
+			if err != nil {
+				detail = fmt.Sprintf("%s: %v", detail, err)
+			}
+			return uc.flag(item, domain.CreditReconciliationError, detail)
+		}
+		item.Action = domain.CreditReconciliationCredited
+
+	case domain.PagaditoStatusFailed, domain.PagaditoStatusRevoked:
+		if _, err := uc.callbackUC.processFailed(ctx, purchase, statusResp); err != nil {
+			return uc.flag(item, domain.CreditReconciliationError, err.Error())
+		}
+		item.Action = domain.CreditReconciliationFailed
+
+	case domain.PagaditoStatusRegistered:
+		// Transacción registrada pero sin pago: el usuario aún puede pagar hasta que expire
+		if overdue {
+			uc.expire(purchase, item, "Compra abandonada en Pagadito sin completar el pago")
+		}
+
+	case domain.PagaditoStatusVerifying:
+		if _, err := uc.callbackUC.processVerifying(ctx, purchase, statusResp); err != nil {
+			return uc.flag(item, domain.CreditReconciliationError, err.Error())
+		}
+		if overdue {
+			return uc.flag(item, domain.CreditReconciliationNeedsReview,
+				"Pago en verificación en Pagadito después del vencimiento de la compra")
+		}
+
+	default:
+		return uc.flag(item, domain.CreditReconciliationNeedsReview,
+			fmt.Sprintf("Estado desconocido de Pagadito: %s", statusResp.Status))
+	}
+
+	return item
+}
+
+// expire marca una compra abandonada como expirada
+// La actualización es condicional: si el callback la avanzó mientras se conciliaba, la compra no se toca
+func (uc *ReconcilePurchasesUseCase) expire(purchase *domain.CreditPurchase, item *domain.CreditReconciliationItem, reason string) {
+	expired, err := uc.purchaseRepo.ExpireIfPending(purchase.ID)
+	if err != nil {
+		uc.flag(item, domain.CreditReconciliationError, fmt.Sprintf("Error expirando compra: %v", err))
+		return
+	}
+	if !expired {
+		uc.logger.Info("Credit purchase changed during reconciliation, not expired",
+			logger.Int64("purchase_id", purchase.ID))
+		return
+	}
+	purchase.Status = domain.CreditPurchaseStatusExpired
+
+	item.Action = domain.CreditReconciliationExpired
+	item.Detail = &reason
+
+	if err := uc.auditRepo.Create(domain.NewAuditLog(domain.AuditActionCreditPurchaseExpired).
+		WithUser(purchase.UserID).
+		WithEntity("credit_purchase", purchase.ID).
+		WithDescription(reason).
+		WithMetadata(map[string]interface{}{
+			"ern":           purchase.ERN,
+			"charge_amount": purchase.ChargeAmount.String(),
+			"expires_at":    purchase.ExpiresAt,
+		}).
+		Build()); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+}
+
+// flag registra una diferencia que requiere atención de un admin
+func (uc *ReconcilePurchasesUseCase) flag(item *domain.CreditReconciliationItem, action domain.CreditReconciliationAction, detail string) *domain.CreditReconciliationItem {
+	item.Action = action
+	item.Detail = &detail
+	return item
+}
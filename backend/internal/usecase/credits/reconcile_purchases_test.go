@@ -0,0 +1,334 @@
+package credits
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/pagadito"
+	walletuc "github.com/sorteos-platform/backend/internal/usecase/wallet"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const testUserID int64 = 7
+
+// fakePagaditoClient responde GetStatus con el estado o el error configurado para cada token
+type fakePagaditoClient struct {
+	statuses map[string]*pagadito.StatusResponse
+	errs     map[string]error
+}
+
+func (c *fakePagaditoClient) Connect() error { return nil }
+
+func (c *fakePagaditoClient) CreateTransaction(req *pagadito.TransactionRequest) (*pagadito.TransactionResponse, error) {
+	return nil, stderrors.New("not implemented")
+}
+
+func (c *fakePagaditoClient) GetStatus(token string) (*pagadito.StatusResponse, error) {
+	if err := c.errs[token]; err != nil {
+		return nil, err
+	}
+	if status, ok := c.statuses[token]; ok {
+		return status, nil
+	}
+	return nil, stderrors.New("unknown token")
+}
+
+// fakePurchaseRepo guarda copias de las compras como lo haría la base de datos
+type fakePurchaseRepo struct {
+	domain.CreditPurchaseRepository
+	purchases  map[int64]domain.CreditPurchase
+	failUpdate bool
+}
+
+func (r *fakePurchaseRepo) FindStale(updatedBefore time.Time, limit int) ([]*domain.CreditPurchase, error) {
+	var stale []*domain.CreditPurchase
+	for _, purchase := range r.purchases {
+		if purchase.IsPending() || purchase.IsProcessing() {
+			purchase := purchase
+			stale = append(stale, &purchase)
+		}
+	}
+	return stale, nil
+}
+
+func (r *fakePurchaseRepo) Update(purchase *domain.CreditPurchase) error {
+	if r.failUpdate {
+		return stderrors.New("update failed")
+	}
+	r.purchases[purchase.ID] = *purchase
+	return nil
+}
+
+func (r *fakePurchaseRepo) ExpireIfPending(id int64) (bool, error) {
+	purchase := r.purchases[id]
+	if !purchase.IsPending() && !purchase.IsProcessing() {
+		return false, nil
+	}
+	purchase.Status = domain.CreditPurchaseStatusExpired
+	r.purchases[id] = purchase
+	return true, nil
+}
+
+type fakeReconciliationRepo struct {
+	domain.CreditReconciliationRepository
+	created []*domain.CreditReconciliation
+}
+
+func (r *fakeReconciliationRepo) Create(reconciliation *domain.CreditReconciliation) error {
+	r.created = append(r.created, reconciliation)
+	return nil
+}
+
+type fakeSystemParamRepo struct {
+	domain.SystemParameterRepository
+}
+
+func (r *fakeSystemParamRepo) GetInt(key string, defaultValue int64) (int64, error) {
+	return defaultValue, nil
+}
+
+type fakeAuditRepo struct {
+	domain.AuditLogRepository
+}
+
+func (r *fakeAuditRepo) Create(log *domain.AuditLog) error { return nil }
+
+type fakeUserRepo struct {
+	domain.UserRepository
+}
+
+func (r *fakeUserRepo) FindByID(id int64) (*domain.User, error) {
+	return &domain.User{ID: id, Status: domain.UserStatusActive}, nil
+}
+
+// fakeWalletRepo billetera única del usuario de prueba con los asientos registrados
+type fakeWalletRepo struct {
+	domain.WalletRepository
+	wallet  *domain.Wallet
+	entries []*domain.JournalEntry
+}
+
+func (r *fakeWalletRepo) FindByID(id int64) (*domain.Wallet, error) { return r.wallet, nil }
+
+func (r *fakeWalletRepo) FindByUserID(userID int64) (*domain.Wallet, error) { return r.wallet, nil }
+
+func (r *fakeWalletRepo) Lock(walletID int64) error { return nil }
+
+func (r *fakeWalletRepo) Update(wallet *domain.Wallet) error {
+	r.wallet = wallet
+	return nil
+}
+
+func (r *fakeWalletRepo) PostJournalEntry(entry *domain.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeWalletRepo) WithTransaction(fn func(repo domain.WalletRepository) error) error {
+	return fn(r)
+}
+
+type fakeTransactionRepo struct {
+	domain.WalletTransactionRepository
+	transactions []*domain.WalletTransaction
+}
+
+func (r *fakeTransactionRepo) Create(tx *domain.WalletTransaction) error {
+	tx.ID = int64(len(r.transactions) + 1)
+	r.transactions = append(r.transactions, tx)
+	return nil
+}
+
+func (r *fakeTransactionRepo) FindByID(id int64) (*domain.WalletTransaction, error) {
+	for _, tx := range r.transactions {
+		if tx.ID == id {
+			return tx, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeTransactionRepo) FindByIdempotencyKey(key string) (*domain.WalletTransaction, error) {
+	for _, tx := range r.transactions {
+		if tx.IdempotencyKey == key {
+			return tx, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeTransactionRepo) Update(tx *domain.WalletTransaction) error { return nil }
+
+type fakeLocker struct{}
+
+func (fakeLocker) Lock(ctx context.Context) (func(), error) { return func() {}, nil }
+
+// reconcileFixture conciliación con todas sus dependencias en memoria
+type reconcileFixture struct {
+	client          *fakePagaditoClient
+	purchaseRepo    *fakePurchaseRepo
+	walletRepo      *fakeWalletRepo
+	transactionRepo *fakeTransactionRepo
+	uc              *ReconcilePurchasesUseCase
+}
+
+func newReconcileFixture(purchases ...domain.CreditPurchase) *reconcileFixture {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	f := &reconcileFixture{
+		client: &fakePagaditoClient{
+			statuses: map[string]*pagadito.StatusResponse{},
+			errs:     map[string]error{},
+		},
+		purchaseRepo: &fakePurchaseRepo{purchases: map[int64]domain.CreditPurchase{}},
+		walletRepo: &fakeWalletRepo{wallet: &domain.Wallet{
+			ID:       1,
+			UserID:   testUserID,
+			Currency: "CRC",
+			Status:   domain.WalletStatusActive,
+		}},
+		transactionRepo: &fakeTransactionRepo{},
+	}
+	for _, purchase := range purchases {
+		f.purchaseRepo.purchases[purchase.ID] = purchase
+	}
+
+	auditRepo := &fakeAuditRepo{}
+	addFundsUC := walletuc.NewAddFundsUseCase(f.walletRepo, f.transactionRepo, &fakeUserRepo{}, auditRepo, log)
+	callbackUC := NewProcessPagaditoCallbackUseCase(f.purchaseRepo, f.walletRepo, f.transactionRepo, auditRepo, f.client, addFundsUC, log)
+	f.uc = NewReconcilePurchasesUseCase(f.purchaseRepo, &fakeReconciliationRepo{}, &fakeSystemParamRepo{}, auditRepo,
+		f.client, callbackUC, fakeLocker{}, log)
+	return f
+}
+
+func (f *reconcileFixture) run(t *testing.T) *domain.CreditReconciliation {
+	t.Helper()
+	reconciliation, err := f.uc.Execute(context.Background(), &ReconcilePurchasesInput{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	return reconciliation
+}
+
+func newTestPurchase(id int64, token string, expiresAt time.Time) domain.CreditPurchase {
+	return domain.CreditPurchase{
+		ID:            id,
+		UserID:        testUserID,
+		WalletID:      1,
+		DesiredCredit: decimal.NewFromInt(1000),
+		ChargeAmount:  decimal.NewFromInt(1100),
+		FixedFee:      decimal.NewFromInt(50),
+		ProcessorFee:  decimal.NewFromInt(30),
+		Currency:      "CRC",
+		ERN:           token,
+		PagaditoToken: &token,
+		Status:        domain.CreditPurchaseStatusProcessing,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func singleItem(t *testing.T, reconciliation *domain.CreditReconciliation) *domain.CreditReconciliationItem {
+	t.Helper()
+	if len(reconciliation.Items) != 1 {
+		t.Fatalf("expected 1 reconciliation item, got %d", len(reconciliation.Items))
+	}
+	return reconciliation.Items[0]
+}
+
+func TestReconcileCreditsCompletedPurchaseOnce(t *testing.T) {
+	f := newReconcileFixture(newTestPurchase(1, "ern-1", time.Now().Add(time.Hour)))
+	f.client.statuses["ern-1"] = &pagadito.StatusResponse{
+		Status:    string(domain.PagaditoStatusCompleted),
+		Reference: "nap-1",
+		Amount:    decimal.NewFromInt(1100),
+	}
+
+	// La compra no se puede marcar como completada: la siguiente ejecución la vuelve a revisar
+	f.purchaseRepo.failUpdate = true
+	reconciliation := f.run(t)
+	if reconciliation.Credited != 1 || singleItem(t, reconciliation).Action != domain.CreditReconciliationCredited {
+		t.Fatalf("expected purchase credited, got %+v", reconciliation)
+	}
+
+	reconciliation = f.run(t)
+	if reconciliation.Credited != 1 || reconciliation.Discrepancies != 0 {
+		t.Fatalf("expected re-run to report the purchase credited, got %+v", reconciliation)
+	}
+
+	if !f.walletRepo.wallet.BalanceAvailable.Equal(decimal.NewFromInt(1000)) {
+		t.Fatalf("expected balance 1000 after two runs, got %s", f.walletRepo.wallet.BalanceAvailable)
+	}
+	if len(f.transactionRepo.transactions) != 1 || len(f.walletRepo.entries) != 1 {
+		t.Fatalf("expected one deposit and one journal entry, got %d and %d",
+			len(f.transactionRepo.transactions), len(f.walletRepo.entries))
+	}
+
+	// Con la compra marcada como completada ya no vuelve a conciliarse
+	f.purchaseRepo.failUpdate = false
+	f.run(t)
+	reconciliation = f.run(t)
+	if reconciliation.Checked != 0 {
+		t.Fatalf("expected completed purchase to be skipped, got %d checked", reconciliation.Checked)
+	}
+	if got := f.purchaseRepo.purchases[1].Status; got != domain.CreditPurchaseStatusCompleted {
+		t.Fatalf("expected purchase completed, got %s", got)
+	}
+	if !f.walletRepo.wallet.BalanceAvailable.Equal(decimal.NewFromInt(1000)) {
+		t.Fatalf("expected balance to stay 1000, got %s", f.walletRepo.wallet.BalanceAvailable)
+	}
+}
+
+func TestReconcileExpiresOverdueRegisteredPurchase(t *testing.T) {
+	f := newReconcileFixture(newTestPurchase(2, "ern-2", time.Now().Add(-time.Hour)))
+	f.client.statuses["ern-2"] = &pagadito.StatusResponse{Status: string(domain.PagaditoStatusRegistered)}
+
+	reconciliation := f.run(t)
+	if reconciliation.Expired != 1 || singleItem(t, reconciliation).Action != domain.CreditReconciliationExpired {
+		t.Fatalf("expected purchase expired, got %+v", reconciliation)
+	}
+	if got := f.purchaseRepo.purchases[2].Status; got != domain.CreditPurchaseStatusExpired {
+		t.Fatalf("expected purchase status expired, got %s", got)
+	}
+}
+
+func TestReconcileFlagsAmountMismatchForReview(t *testing.T) {
+	f := newReconcileFixture(newTestPurchase(3, "ern-3", time.Now().Add(time.Hour)))
+	f.client.statuses["ern-3"] = &pagadito.StatusResponse{
+		Status:    string(domain.PagaditoStatusCompleted),
+		Reference: "nap-3",
+		Amount:    decimal.NewFromInt(900),
+	}
+
+	reconciliation := f.run(t)
+	if reconciliation.Discrepancies != 1 || singleItem(t, reconciliation).Action != domain.CreditReconciliationNeedsReview {
+		t.Fatalf("expected purchase flagged for review, got %+v", reconciliation)
+	}
+	if !f.walletRepo.wallet.BalanceAvailable.IsZero() || len(f.transactionRepo.transactions) != 0 {
+		t.Fatalf("expected no credit for a mismatched amount")
+	}
+	if got := f.purchaseRepo.purchases[3].Status; got != domain.CreditPurchaseStatusProcessing {
+		t.Fatalf("expected purchase to stay processing, got %s", got)
+	}
+}
+
+func TestReconcileReportsStatusError(t *testing.T) {
+	f := newReconcileFixture(newTestPurchase(4, "ern-4", time.Now().Add(-time.Hour)))
+	f.client.errs["ern-4"] = stderrors.New("pagadito unavailable")
+
+	reconciliation := f.run(t)
+	if reconciliation.Discrepancies != 1 || singleItem(t, reconciliation).Action != domain.CreditReconciliationError {
+		t.Fatalf("expected status error reported, got %+v", reconciliation)
+	}
+	if got := f.purchaseRepo.purchases[4].Status; got != domain.CreditPurchaseStatusProcessing {
+		t.Fatalf("expected purchase untouched, got %s", got)
+	}
+}
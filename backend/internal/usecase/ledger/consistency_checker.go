@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// DefaultReportLimit máximo de diferencias detalladas en un reporte
+const DefaultReportLimit = 100
+
+// ConsistencyReport resultado de la verificación del libro mayor
+type ConsistencyReport struct {
+	CheckedAt         time.Time                         `json:"checked_at"`
+	Consistent        bool                              `json:"consistent"`
+	WalletsChecked    int64                             `json:"wallets_checked"`
+	DiscrepancyCount  int64                             `json:"discrepancy_count"`
+	Discrepancies     []*domain.WalletLedgerDiscrepancy `json:"discrepancies"`
+	UnbalancedEntries []int64                           `json:"unbalanced_entries"`
+	TotalDebits       decimal.Decimal                   `json:"total_debits"`
+	TotalCredits      decimal.Decimal                   `json:"total_credits"`
+}
+
+// ConsistencyChecker verifica que los saldos de las billeteras coincidan con el libro mayor
+// y que todos los asientos estén balanceados
+type ConsistencyChecker struct {
+	db         *gorm.DB
+	ledgerRepo domain.LedgerRepository
+	log        *logger.Logger
+}
+
+// NewConsistencyChecker crea una nueva instancia
+func NewConsistencyChecker(gormDB *gorm.DB, log *logger.Logger) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		db:         gormDB,
+		ledgerRepo: db.NewLedgerRepository(gormDB, log),
+		log:        log,
+	}
+}
+
+// Check compara cada billetera (disponible, ganancias y pendiente) con la suma de sus cuentas en el libro mayor
+func (c *ConsistencyChecker) Check(ctx context.Context, limit int) (*ConsistencyReport, error) {
+	if limit <= 0 {
+		limit = DefaultReportLimit
+	}
+
+	report := &ConsistencyReport{CheckedAt: time.Now()}
+
+	if err := c.db.WithContext(ctx).Model(&domain.Wallet{}).Count(&report.WalletsChecked).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	discrepancies, total, err := c.ledgerRepo.FindWalletDiscrepancies(limit)
+	if err != nil {
+		return nil, err
+	}
+	report.Discrepancies = discrepancies
+	report.DiscrepancyCount = total
+
+	unbalanced, err := c.ledgerRepo.FindUnbalancedEntries(limit)
+	if err != nil {
+		return nil, err
+	}
+	report.UnbalancedEntries = unbalanced
+
+	report.TotalDebits, report.TotalCredits, err = c.ledgerRepo.Totals()
+	if err != nil {
+		return nil, err
+	}
+
+	report.Consistent = report.DiscrepancyCount == 0 &&
+		len(report.UnbalancedEntries) == 0 &&
+		report.TotalDebits.Equal(report.TotalCredits)
+
+	if !report.Consistent {
+		c.log.Error("Ledger inconsistency detected",
+			logger.Int64("wallet_discrepancies", report.DiscrepancyCount),
+			logger.Int("unbalanced_entries", len(report.UnbalancedEntries)),
+			logger.String("total_debits", report.TotalDebits.String()),
+			logger.String("total_credits", report.TotalCredits.String()))
+	}
+
+	return report, nil
+}
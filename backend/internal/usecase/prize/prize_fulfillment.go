@@ -0,0 +1,342 @@
+package prize
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// pendingFulfillmentWindow antigüedad máxima de los sorteos completados que se revisan sin premio procesado
+// (cubre sorteos manuales y fallos del handler post-sorteo sin reprocesar el histórico)
+const pendingFulfillmentWindow = 7 * 24 * time.Hour
+
+// ReferenceTypeRaffle tipo de referencia de las transacciones de premio
+const ReferenceTypeRaffle = "raffle"
+
+// FulfillPendingOutput resultado de una ejecución del barrido de premios
+type FulfillPendingOutput struct {
+	Paid    int // Premios en efectivo acreditados
+	Opened  int // Reclamos de premios físicos abiertos
+	Expired int // Reclamos vencidos sin confirmación de identidad
+	Failed  int
+}
+
+// PrizeFulfillment entrega los premios de los sorteos completados (un reclamo por premio con ganador)
+// Premios en efectivo: se acreditan al saldo de ganancias del ganador con una transacción prize_claim
+// Premios físicos: se abre un reclamo con fecha límite, verificación de identidad y seguimiento de entrega
+type PrizeFulfillment struct {
+	db              *gorm.DB
+	claimRepo       domain.PrizeClaimRepository
+	prizeRepo       domain.RafflePrizeRepository
+	systemParamRepo *db.PostgresSystemParameterRepository
+	auditRepo       domain.AuditLogRepository
+	log             *logger.Logger
+}
+
+// NewPrizeFulfillment crea una nueva instancia
+func NewPrizeFulfillment(gormDB *gorm.DB, log *logger.Logger) *PrizeFulfillment {
+	return &PrizeFulfillment{
+		db:              gormDB,
+		claimRepo:       db.NewPrizeClaimRepository(gormDB, log),
+		prizeRepo:       db.NewRafflePrizeRepository(gormDB, log),
+		systemParamRepo: db.NewSystemParameterRepository(gormDB, log),
+		auditRepo:       db.NewAuditLogRepository(gormDB),
+		log:             log,
+	}
+}
+
+// OnDrawCompleted implementa raffle.DrawCompletedHandler
+func (f *PrizeFulfillment) OnDrawCompleted(ctx context.Context, raffle *domain.Raffle) error {
+	_, err := f.Fulfill(ctx, raffle)
+	return err
+}
+
+// Fulfill procesa los premios de un sorteo completado
+// Es idempotente: los premios que ya tienen reclamo no se reprocesan
+// Retorna los reclamos creados en esta ejecución (vacío si el sorteo no tiene ganadores pendientes)
+func (f *PrizeFulfillment) Fulfill(ctx context.Context, raffle *domain.Raffle) ([]*domain.PrizeClaim, error) {
+	// Con el premio mayor acumulado al siguiente sorteo de la serie solo se entregan los premios secundarios
+	if raffle.Status != domain.RaffleStatusCompleted || (raffle.WinnerNumber == nil && !raffle.IsJackpotRolledOver()) {
+		return nil, nil
+	}
+
+	winners, err := f.prizeRepo.FindWinnersByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := f.claimRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+	claimed := make(map[int]bool, len(existing))
+	for _, claim := range existing {
+		claimed[claim.PrizePosition] = true
+	}
+
+	var claims []*domain.PrizeClaim
+	for _, winner := range winners {
+		if claimed[winner.Position] || winner.UserID == nil || winner.Prize == nil {
+			continue
+		}
+
+		var claim *domain.PrizeClaim
+		if winner.Prize.IsCash() {
+			claim, err = f.payCashPrize(raffle, winner)
+		} else {
+			claim, err = f.openPhysicalClaim(raffle, winner)
+		}
+		if err != nil {
+			return claims, err
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// FulfillPending procesa los sorteos completados recientemente que aún no tienen premio
+// y expira los reclamos físicos cuyo plazo venció
+func (f *PrizeFulfillment) FulfillPending(ctx context.Context, limit int) (*FulfillPendingOutput, error) {
+	var raffles []*domain.Raffle
+	if err := f.db.
+		Where("status = ? AND completed_at >= ?",
+			domain.RaffleStatusCompleted, time.Now().Add(-pendingFulfillmentWindow)).
+		Where(`EXISTS (
+			SELECT 1 FROM raffle_winners rw
+			WHERE rw.raffle_id = raffles.id AND rw.user_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM prize_claims pc WHERE pc.raffle_id = rw.raffle_id AND pc.prize_position = rw.position)
+		)`).
+		Order("completed_at ASC").
+		Limit(limit).
+		Find(&raffles).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	output := &FulfillPendingOutput{}
+	for _, raffle := range raffles {
+		claims, err := f.Fulfill(ctx, raffle)
+		if err != nil {
+			f.log.Error("Error fulfilling prize",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+			output.Failed++
+		}
+		for _, claim := range claims {
+			if claim.PrizeType == domain.PrizeTypeCash {
+				output.Paid++
+			} else {
+				output.Opened++
+			}
+		}
+	}
+
+	expired, err := f.expireOverdue(limit)
+	if err != nil {
+		return output, err
+	}
+	output.Expired = expired
+
+	return output, nil
+}
+
+// payCashPrize acredita el premio al saldo de ganancias del ganador y registra el reclamo en una transacción
+func (f *PrizeFulfillment) payCashPrize(raffle *domain.Raffle, winner *domain.RaffleWinner) (*domain.PrizeClaim, error) {
+	amount := winner.Prize.Value.Round(2)
+	claim := domain.NewCashPrizeClaim(winner.Prize, winner, amount)
+	winnerUserID := *winner.UserID
+
+	var transaction *domain.WalletTransaction
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		walletRepo := db.NewWalletRepository(tx, f.log)
+		transactionRepo := db.NewWalletTransactionRepository(tx, f.log)
+
+		// Idempotencia: una sola acreditación por premio
+		idempotencyKey := prizeIdempotencyKey(raffle.ID, winner.Position)
+		existingTx, err := transactionRepo.FindByIdempotencyKey(idempotencyKey)
+		if err != nil && err != errors.ErrNotFound {
+			return err
+		}
+
+		if existingTx == nil {
+			wallet, err := walletRepo.FindByUserID(winnerUserID)
+			if err != nil {
+				return err
+			}
+			if err := walletRepo.Lock(wallet.ID); err != nil {
+				return err
+			}
+			wallet, err = walletRepo.FindByID(wallet.ID)
+			if err != nil {
+				return err
+			}
+
+			// Los premios son retirables: se acreditan a earnings_balance y los snapshots corresponden a ese saldo
+			balanceBefore := wallet.EarningsBalance
+			if err := wallet.CreditEarnings(amount); err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+
+			referenceType := ReferenceTypeRaffle
+			referenceID := raffle.ID
+			notes := fmt.Sprintf("Premio %d del sorteo %s (número %s)", winner.Position, raffle.Title, winner.Number)
+			now := time.Now()
+			existingTx = &domain.WalletTransaction{
+				UUID:           uuid.New().String(),
+				WalletID:       wallet.ID,
+				UserID:         winnerUserID,
+				Type:           domain.TransactionTypePrizeClaim,
+				Amount:         amount,
+				Status:         domain.TransactionStatusCompleted,
+				BalanceBefore:  balanceBefore,
+				BalanceAfter:   wallet.EarningsBalance,
+				ReferenceType:  &referenceType,
+				ReferenceID:    &referenceID,
+				IdempotencyKey: idempotencyKey,
+				Notes:          &notes,
+				CompletedAt:    &now,
+			}
+
+			if err := walletRepo.Update(wallet); err != nil {
+				return err
+			}
+			if err := transactionRepo.Create(existingTx); err != nil {
+				return err
+			}
+
+			// Asiento contable: el premio se paga de lo recaudado por el organizador
+			prizeEntry := domain.NewJournalEntry(domain.JournalEntryPrize, idempotencyKey, wallet.Currency).
+				WithReference(ReferenceTypeRaffle, raffle.ID).
+				WithWalletTransaction(existingTx.ID).
+				WithDescription(notes).
+				Debit(domain.OrganizerPayableAccount(raffle.UserID), amount).
+				Credit(domain.UserEarningsAccount(winnerUserID), amount)
+			if err := walletRepo.PostJournalEntry(prizeEntry); err != nil {
+				return err
+			}
+		}
+
+		transaction = existingTx
+		claim.WalletTransactionID = &existingTx.ID
+		now := time.Now()
+		claim.CompletedAt = &now
+
+		return db.NewPrizeClaimRepository(tx, f.log).Create(claim)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.audit(domain.NewAuditLog(domain.AuditActionPrizePaid).
+		WithUser(winnerUserID).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(fmt.Sprintf("Premio en efectivo acreditado: %s", amount.String())).
+		WithMetadata(map[string]interface{}{
+			"prize_claim_id":        claim.ID,
+			"wallet_transaction_id": transaction.ID,
+			"amount":                amount.String(),
+			"winner_number":         claim.WinnerNumber,
+			"prize_position":        claim.PrizePosition,
+		}).
+		Build())
+
+	f.log.Info("Cash prize credited to winner",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.Int64("winner_user_id", winnerUserID),
+		logger.Int("prize_position", winner.Position),
+		logger.Int64("tx_id", transaction.ID),
+		logger.String("amount", amount.String()))
+
+	return claim, nil
+}
+
+// openPhysicalClaim abre el reclamo de un premio físico con su fecha límite
+func (f *PrizeFulfillment) openPhysicalClaim(raffle *domain.Raffle, winner *domain.RaffleWinner) (*domain.PrizeClaim, error) {
+	deadlineDays, _ := f.systemParamRepo.GetInt("prize_claim_deadline_days", domain.DefaultPrizeClaimDeadlineDays)
+	if deadlineDays <= 0 {
+		deadlineDays = domain.DefaultPrizeClaimDeadlineDays
+	}
+
+	claim := domain.NewPhysicalPrizeClaim(winner.Prize, winner, time.Now().AddDate(0, 0, int(deadlineDays)))
+	if err := f.claimRepo.Create(claim); err != nil {
+		return nil, err
+	}
+
+	f.audit(domain.NewAuditLog(domain.AuditActionPrizeClaimOpened).
+		WithUser(claim.WinnerUserID).
+		WithEntity("raffle", raffle.ID).
+		WithDescription("Reclamo de premio físico abierto").
+		WithMetadata(map[string]interface{}{
+			"prize_claim_id": claim.ID,
+			"claim_deadline": claim.ClaimDeadline,
+			"winner_number":  claim.WinnerNumber,
+			"prize_position": claim.PrizePosition,
+		}).
+		Build())
+
+	f.log.Info("Physical prize claim opened",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.Int64("prize_claim_id", claim.ID),
+		logger.Int64("winner_user_id", claim.WinnerUserID),
+		logger.Int("prize_position", claim.PrizePosition))
+
+	return claim, nil
+}
+
+// prizeIdempotencyKey clave de la acreditación de un premio
+// El premio mayor conserva la clave anterior a los sorteos con varios premios
+func prizeIdempotencyKey(raffleID int64, position int) string {
+	if position == 1 {
+		return fmt.Sprintf("prize:raffle:%d", raffleID)
+	}
+	return fmt.Sprintf("prize:raffle:%d:%d", raffleID, position)
+}
+
+// expireOverdue cierra los reclamos físicos cuyo plazo de confirmación venció
+func (f *PrizeFulfillment) expireOverdue(limit int) (int, error) {
+	now := time.Now()
+	claims, err := f.claimRepo.FindOverdue(now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, claim := range claims {
+		if err := claim.Expire(now); err != nil {
+			continue
+		}
+		if err := f.claimRepo.Update(claim); err != nil {
+			f.log.Error("Error expiring prize claim",
+				logger.Int64("prize_claim_id", claim.ID),
+				logger.Error(err))
+			continue
+		}
+
+		f.audit(domain.NewAuditLog(domain.AuditActionPrizeClaimResolved).
+			WithUser(claim.WinnerUserID).
+			WithEntity("prize_claim", claim.ID).
+			WithDescription("Reclamo de premio vencido sin confirmación de identidad").
+			WithMetadata(map[string]interface{}{
+				"raffle_id": claim.RaffleID,
+				"status":    claim.Status,
+			}).
+			Build())
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (f *PrizeFulfillment) audit(auditLog *domain.AuditLog) {
+	if err := f.auditRepo.Create(auditLog); err != nil {
+		f.log.Warn("Error creating audit log", logger.Error(err))
+	}
+}
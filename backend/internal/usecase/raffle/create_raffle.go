@@ -20,6 +20,7 @@ type CreateRaffleInput struct {
 	// Basic info
 	Title       string
 	Description string
+	CategoryID  *int64
 
 	// Pricing
 	PricePerNumber decimal.Decimal
@@ -31,8 +32,42 @@ type CreateRaffleInput struct {
 	DrawDate   time.Time
 	DrawMethod domain.DrawMethod
 
+	// UnsoldWinnerPolicy regla si el número oficial de la lotería no fue vendido (opcional)
+	// rollover solo aplica a sorteos generados por una serie
+	UnsoldWinnerPolicy *domain.UnsoldWinnerPolicy
+
 	// Platform fee (opcional, usa default 10%)
 	PlatformFeePercentage *decimal.Decimal
+
+	// Prize (opcional, por defecto premio físico)
+	PrizeType        domain.PrizeType
+	PrizeAmount      *decimal.Decimal
+	PrizeDescription *string
+
+	// Prizes premios ordenados (el primero es el premio mayor)
+	// Si se omite, el sorteo tiene un único premio con los campos Prize*
+	Prizes []RafflePrizeInput
+
+	// Minimum sales (opcional): si al corte no se vendió MinSoldCount, el sorteo se pospone una vez
+	// (MinSalesExtensionDays) o se cancela con reembolsos
+	MinSoldCount          *int
+	MinSalesCutoffHours   *int // Horas antes del sorteo (por defecto 24)
+	MinSalesExtensionDays *int
+
+	// PricingRules paquetes y descuentos (opcional, sin reglas se cobra PricePerNumber por número)
+	PricingRules []PricingRuleInput
+
+	// Series (solo sorteos generados por una serie recurrente)
+	SeriesID       *int64
+	SeriesSequence *int
+}
+
+// RafflePrizeInput premio del sorteo; la posición es su orden en la lista
+type RafflePrizeInput struct {
+	Description string
+	PrizeType   domain.PrizeType // Por defecto premio físico
+	Value       *decimal.Decimal
+	ImageURL    *string
 }
 
 // CreateRaffleOutput representa el resultado de crear un sorteo
@@ -45,6 +80,8 @@ type CreateRaffleOutput struct {
 type CreateRaffleUseCase struct {
 	raffleRepo       db.RaffleRepository
 	raffleNumberRepo db.RaffleNumberRepository
+	prizeRepo        domain.RafflePrizeRepository
+	pricingRuleRepo  domain.PricingRuleRepository
 	userRepo         domain.UserRepository
 	auditRepo        domain.AuditLogRepository
 	systemParamRepo  *db.PostgresSystemParameterRepository
@@ -63,6 +100,8 @@ func NewCreateRaffleUseCase(
 	return &CreateRaffleUseCase{
 		raffleRepo:       raffleRepo,
 		raffleNumberRepo: raffleNumberRepo,
+		prizeRepo:        db.NewRafflePrizeRepository(gormDB, logger),
+		pricingRuleRepo:  db.NewPricingRuleRepository(gormDB, logger),
 		userRepo:         userRepo,
 		auditRepo:        auditRepo,
 		systemParamRepo:  db.NewSystemParameterRepository(gormDB, logger),
@@ -107,6 +146,9 @@ func (uc *CreateRaffleUseCase) Execute(ctx context.Context, input *CreateRaffleI
 	if input.Description != "" {
 		raffle.Description = input.Description
 	}
+	raffle.CategoryID = input.CategoryID
+	raffle.SeriesID = input.SeriesID
+	raffle.SeriesSequence = input.SeriesSequence
 
 	if input.MinNumber != 0 || input.MaxNumber != 0 {
 		raffle.MinNumber = input.MinNumber
@@ -116,6 +158,39 @@ func (uc *CreateRaffleUseCase) Execute(ctx context.Context, input *CreateRaffleI
 	if input.DrawMethod != "" {
 		raffle.DrawMethod = input.DrawMethod
 	}
+	raffle.UnsoldWinnerPolicy = input.UnsoldWinnerPolicy
+
+	if input.PrizeType != "" {
+		raffle.PrizeType = input.PrizeType
+	}
+	raffle.PrizeAmount = input.PrizeAmount
+	raffle.PrizeDescription = input.PrizeDescription
+
+	// Premios ordenados: el premio mayor se refleja en los campos Prize* del sorteo
+	if len(input.Prizes) > 0 {
+		prizes := make([]*domain.RafflePrize, 0, len(input.Prizes))
+		for i, prizeInput := range input.Prizes {
+			prizeType := prizeInput.PrizeType
+			if prizeType == "" {
+				prizeType = domain.PrizeTypePhysical
+			}
+			prizes = append(prizes, domain.NewRafflePrize(i+1, prizeInput.Description, prizeType, prizeInput.Value, prizeInput.ImageURL))
+		}
+		raffle.SetPrizes(prizes)
+	} else {
+		raffle.SetPrizes([]*domain.RafflePrize{raffle.DefaultPrize()})
+	}
+
+	if err := domain.ValidateRafflePrizes(raffle.Prizes, raffle.TotalNumbers); err != nil {
+		return nil, fmt.Errorf("validación fallida: %w", err)
+	}
+
+	applyMinimumSales(raffle, input.MinSoldCount, input.MinSalesCutoffHours, input.MinSalesExtensionDays)
+
+	pricingRules, err := buildPricingRules(input.PricingRules, raffle.PricePerNumber)
+	if err != nil {
+		return nil, fmt.Errorf("validación fallida: %w", err)
+	}
 
 	// Establecer platform fee percentage
 	if input.PlatformFeePercentage != nil {
@@ -137,6 +212,20 @@ func (uc *CreateRaffleUseCase) Execute(ctx context.Context, input *CreateRaffleI
 		return nil, fmt.Errorf("error al crear el sorteo")
 	}
 
+	// Guardar premios
+	if err := uc.prizeRepo.ReplaceForRaffle(raffle.ID, raffle.Prizes); err != nil {
+		uc.logger.Error("Error guardando premios", logger.Int64("raffle_id", raffle.ID), logger.Error(err))
+		return nil, fmt.Errorf("error al guardar los premios")
+	}
+
+	// Guardar reglas de precio
+	if len(pricingRules) > 0 {
+		if err := uc.pricingRuleRepo.ReplaceForRaffle(raffle.ID, pricingRules); err != nil {
+			uc.logger.Error("Error guardando reglas de precio", logger.Int64("raffle_id", raffle.ID), logger.Error(err))
+			return nil, fmt.Errorf("error al guardar las reglas de precio")
+		}
+	}
+
 	// Generar números del sorteo
 	numbers, err := uc.generateNumbers(raffle)
 	if err != nil {
@@ -159,6 +248,10 @@ func (uc *CreateRaffleUseCase) Execute(ctx context.Context, input *CreateRaffleI
 			"title":         raffle.Title,
 			"total_numbers": raffle.TotalNumbers,
 			"price":         raffle.PricePerNumber.String(),
+			"prizes":        len(raffle.Prizes),
+			"pricing_rules": len(pricingRules),
+			"series_id":     raffle.SeriesID,
+			"unsold_policy": raffle.UnsoldWinnerPolicy,
 		}).
 		Build()
 
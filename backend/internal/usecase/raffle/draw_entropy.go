@@ -0,0 +1,61 @@
+package raffle
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/beacon"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// ErrDrawEntropyNotAvailable la ronda del beacon comprometida para el sorteo aún no se publicó
+var ErrDrawEntropyNotAvailable = errors.New("DRAW_ENTROPY_NOT_AVAILABLE",
+	"La entropía pública comprometida para el sorteo aún no se publicó; intente de nuevo en unos segundos", 409, nil)
+
+// DrawEntropy entropía pública de un sorteo verificable
+type DrawEntropy struct {
+	Value     string
+	ChainHash string
+	Round     int64
+}
+
+// Apply registra en la prueba el origen de la entropía
+func (e *DrawEntropy) Apply(proof *domain.DrawProof) {
+	proof.BeaconChain = e.ChainHash
+	proof.BeaconRound = e.Round
+}
+
+// DrawEntropyResolver obtiene la entropía pública comprometida al publicar la rifa
+// La entropía es la aleatoriedad de una ronda del beacon emitida al cerrar las ventas,
+// desconocida para todos mientras la semilla del servidor está comprometida
+type DrawEntropyResolver struct {
+	client *beacon.DrandClient
+}
+
+// NewDrawEntropyResolver crea una nueva instancia
+func NewDrawEntropyResolver(client *beacon.DrandClient) *DrawEntropyResolver {
+	return &DrawEntropyResolver{
+		client: client,
+	}
+}
+
+// Resolve obtiene la entropía de la ronda comprometida por la rifa
+// Retorna ErrDrawEntropyNotAvailable si la ronda aún no se publicó
+func (r *DrawEntropyResolver) Resolve(ctx context.Context, raffle *domain.Raffle) (*DrawEntropy, error) {
+	round := raffle.DrawEntropyRound()
+
+	result, err := r.client.FetchRound(ctx, round)
+	if err != nil {
+		if stderrors.Is(err, beacon.ErrRoundNotAvailable) {
+			return nil, ErrDrawEntropyNotAvailable
+		}
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+
+	return &DrawEntropy{
+		Value:     result.Randomness,
+		ChainHash: domain.DrawBeaconChainHash,
+		Round:     round,
+	}, nil
+}
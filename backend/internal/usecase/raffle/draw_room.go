@@ -0,0 +1,216 @@
+package raffle
+
+import (
+	"context"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// DefaultDrawStepDelay pausa entre eventos del sorteo en vivo para que los espectadores sigan cada etapa
+const DefaultDrawStepDelay = 3 * time.Second
+
+// DrawRoomService administra la sala del sorteo en vivo: cuenta regresiva, eventos por etapa
+// transmitidos por el hub y el timeline persistido para quienes entran tarde
+// La sala es solo presentación: sus errores se registran pero nunca interrumpen el sorteo
+type DrawRoomService struct {
+	roomRepo  domain.DrawRoomRepository
+	wsHub     *websocket.Hub
+	stepDelay time.Duration
+	logger    *logger.Logger
+}
+
+// NewDrawRoomService crea una nueva instancia
+func NewDrawRoomService(
+	roomRepo domain.DrawRoomRepository,
+	wsHub *websocket.Hub,
+	stepDelay time.Duration,
+	logger *logger.Logger,
+) *DrawRoomService {
+	return &DrawRoomService{
+		roomRepo:  roomRepo,
+		wsHub:     wsHub,
+		stepDelay: stepDelay,
+		logger:    logger,
+	}
+}
+
+// Schedule programa la cuenta regresiva y la transmisión opcional de la sala
+func (s *DrawRoomService) Schedule(raffle *domain.Raffle, scheduledAt time.Time, streamURL *string) (*domain.DrawRoom, error) {
+	room, err := s.findOrCreate(raffle)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Status != domain.DrawRoomStatusScheduled {
+		return nil, errors.New("DRAW_ROOM_NOT_SCHEDULED", "El sorteo ya inició o finalizó", 409, nil)
+	}
+
+	room.ScheduledAt = scheduledAt
+	room.StreamURL = streamURL
+	room.UpdatedAt = time.Now()
+	if err := s.roomRepo.Save(room); err != nil {
+		return nil, err
+	}
+
+	s.emit(raffle, room, domain.DrawRoomEventScheduled, 0, map[string]interface{}{
+		"scheduled_at": room.ScheduledAt,
+		"stream_url":   room.StreamURL,
+	})
+
+	return room, nil
+}
+
+// Start abre la sala en vivo y emite draw_started
+// Retorna la sesión con la que se emiten las etapas siguientes del sorteo
+func (s *DrawRoomService) Start(raffle *domain.Raffle, data map[string]interface{}) *DrawSession {
+	session := &DrawSession{service: s, raffle: raffle}
+
+	room, err := s.findOrCreate(raffle)
+	if err != nil {
+		s.logger.Error("Error abriendo sala de sorteo", logger.Int64("raffle_id", raffle.ID), logger.Error(err))
+		return session
+	}
+
+	room.Start()
+	if err := s.roomRepo.Save(room); err != nil {
+		s.logger.Error("Error abriendo sala de sorteo", logger.Int64("raffle_id", raffle.ID), logger.Error(err))
+		return session
+	}
+
+	session.room = room
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["draw_method"] = string(raffle.DrawMethod)
+	data["stream_url"] = room.StreamURL
+	session.emit(domain.DrawRoomEventStarted, data)
+
+	return session
+}
+
+// findOrCreate busca la sala de la rifa o la crea con la cuenta regresiva hacia su fecha de sorteo
+func (s *DrawRoomService) findOrCreate(raffle *domain.Raffle) (*domain.DrawRoom, error) {
+	room, err := s.roomRepo.FindByRaffleID(raffle.ID)
+	if err == nil {
+		return room, nil
+	}
+	if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	room = domain.NewDrawRoom(raffle)
+	if err := s.roomRepo.Save(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// emit persiste el evento en el timeline y lo transmite a los espectadores
+func (s *DrawRoomService) emit(raffle *domain.Raffle, room *domain.DrawRoom, eventType domain.DrawRoomEventType, step int, data map[string]interface{}) {
+	event, err := domain.NewDrawRoomEvent(room, eventType, step, data)
+	if err == nil {
+		err = s.roomRepo.AddEvent(event)
+	}
+	if err != nil {
+		s.logger.Error("Error guardando evento de sala de sorteo",
+			logger.Int64("raffle_id", raffle.ID),
+			logger.String("type", string(eventType)),
+			logger.Error(err))
+		return
+	}
+
+	payload := map[string]interface{}{
+		"event_id":    event.ID,
+		"step":        step,
+		"status":      string(room.Status),
+		"occurred_at": event.CreatedAt,
+	}
+	for key, value := range data {
+		payload[key] = value
+	}
+
+	s.wsHub.BroadcastDrawEvent(raffle.UUID.String(), websocket.MessageType(eventType), payload)
+}
+
+// DrawSession ejecución de un sorteo en la sala en vivo
+// Si la sala no se pudo abrir, la sesión no emite eventos
+type DrawSession struct {
+	service *DrawRoomService
+	raffle  *domain.Raffle
+	room    *domain.DrawRoom
+	step    int
+}
+
+// Step emite una etapa intermedia del sorteo después de la pausa entre etapas
+func (ds *DrawSession) Step(ctx context.Context, name string, data map[string]interface{}) {
+	if ds.room == nil {
+		return
+	}
+
+	ds.pause(ctx)
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["name"] = name
+	ds.emit(domain.DrawRoomEventStep, data)
+}
+
+// Reveal emite el número ganador después de la pausa y cierra la sala
+func (ds *DrawSession) Reveal(ctx context.Context, winnerNumber string, data map[string]interface{}) {
+	if ds.room == nil {
+		return
+	}
+
+	ds.pause(ctx)
+	ds.room.Finish()
+	if err := ds.service.roomRepo.Save(ds.room); err != nil {
+		ds.service.logger.Error("Error cerrando sala de sorteo", logger.Int64("raffle_id", ds.raffle.ID), logger.Error(err))
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["winner_number"] = winnerNumber
+	ds.emit(domain.DrawRoomEventWinnerRevealed, data)
+}
+
+// Abort registra que el sorteo no se completó y devuelve la sala a la cuenta regresiva
+func (ds *DrawSession) Abort(reason string) {
+	if ds.room == nil {
+		return
+	}
+
+	ds.room.Reset()
+	if err := ds.service.roomRepo.Save(ds.room); err != nil {
+		ds.service.logger.Error("Error reiniciando sala de sorteo", logger.Int64("raffle_id", ds.raffle.ID), logger.Error(err))
+	}
+
+	ds.emit(domain.DrawRoomEventAborted, map[string]interface{}{
+		"reason": reason,
+	})
+}
+
+// emit emite el siguiente evento de la ejecución
+func (ds *DrawSession) emit(eventType domain.DrawRoomEventType, data map[string]interface{}) {
+	ds.step++
+	ds.service.emit(ds.raffle, ds.room, eventType, ds.step, data)
+}
+
+// pause espera entre etapas (se interrumpe si el contexto se cancela)
+func (ds *DrawSession) pause(ctx context.Context) {
+	if ds.service.stepDelay <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(ds.service.stepDelay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,694 @@
+package raffle
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const (
+	// scheduledDrawBatchSize máximo de sorteos procesados por ejecución
+	scheduledDrawBatchSize = 50
+	// scheduledDrawLockTTL duración del lock distribuido por sorteo
+	scheduledDrawLockTTL = 2 * time.Minute
+)
+
+// DrawCompletedHandler se ejecuta después de que un sorteo programado se completa
+// Permite encadenar flujos posteriores al sorteo (premios, notificaciones)
+type DrawCompletedHandler interface {
+	OnDrawCompleted(ctx context.Context, raffle *domain.Raffle) error
+}
+
+// ExecuteScheduledDrawsOutput resultado de una ejecución del job
+type ExecuteScheduledDrawsOutput struct {
+	SalesClosed int
+	Drawn       int
+	Cancelled   int // Sorteos sin números vendidos
+	Skipped     int
+}
+
+// ExecuteScheduledDrawsUseCase cierra ventas y ejecuta los sorteos cuya fecha ya pasó
+// Es seguro ejecutarlo en varias réplicas: cada sorteo se procesa bajo un lock de Redis
+// y las escrituras son condicionales sobre el estado de la rifa
+type ExecuteScheduledDrawsUseCase struct {
+	raffleRepo        db.RaffleRepository
+	raffleNumberRepo  db.RaffleNumberRepository
+	prizeRepo         domain.RafflePrizeRepository
+	reservationRepo   repositories.ReservationRepository
+	auditRepo         domain.AuditLogRepository
+	lockService       *redis.LockService
+	wsHub             *websocket.Hub
+	drawRoom          *DrawRoomService
+	lotteryResolver   *LotteryDrawResolver // nil: las rifas de lotería quedan pendientes de sorteo manual
+	drawEntropy       *DrawEntropyResolver
+	jackpotRollover   *JackpotRollover     // nil: la regla rollover se resuelve con un nuevo sorteo
+	canceller         RaffleCanceller      // nil: los sorteos sin ventas quedan pendientes
+	logger            *logger.Logger
+	completedHandlers []DrawCompletedHandler
+}
+
+// NewExecuteScheduledDrawsUseCase crea una nueva instancia
+func NewExecuteScheduledDrawsUseCase(
+	raffleRepo db.RaffleRepository,
+	raffleNumberRepo db.RaffleNumberRepository,
+	prizeRepo domain.RafflePrizeRepository,
+	reservationRepo repositories.ReservationRepository,
+	auditRepo domain.AuditLogRepository,
+	lockService *redis.LockService,
+	wsHub *websocket.Hub,
+	drawRoom *DrawRoomService,
+	lotteryResolver *LotteryDrawResolver,
+	drawEntropy *DrawEntropyResolver,
+	logger *logger.Logger,
+) *ExecuteScheduledDrawsUseCase {
+	return &ExecuteScheduledDrawsUseCase{
+		raffleRepo:       raffleRepo,
+		raffleNumberRepo: raffleNumberRepo,
+		prizeRepo:        prizeRepo,
+		reservationRepo:  reservationRepo,
+		auditRepo:        auditRepo,
+		lockService:      lockService,
+		wsHub:            wsHub,
+		drawRoom:         drawRoom,
+		lotteryResolver:  lotteryResolver,
+		drawEntropy:      drawEntropy,
+		logger:           logger,
+	}
+}
+
+// RegisterCompletedHandler registra un handler que se ejecuta al completar cada sorteo
+func (uc *ExecuteScheduledDrawsUseCase) RegisterCompletedHandler(handler DrawCompletedHandler) {
+	uc.completedHandlers = append(uc.completedHandlers, handler)
+}
+
+// SetJackpotRollover configura la acumulación del premio mayor en sorteos de una serie
+func (uc *ExecuteScheduledDrawsUseCase) SetJackpotRollover(jackpotRollover *JackpotRollover) {
+	uc.jackpotRollover = jackpotRollover
+}
+
+// SetCanceller configura la cancelación de sorteos que llegan a su fecha sin números vendidos
+func (uc *ExecuteScheduledDrawsUseCase) SetCanceller(canceller RaffleCanceller) {
+	uc.canceller = canceller
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ExecuteScheduledDrawsUseCase) Execute(ctx context.Context) (*ExecuteScheduledDrawsOutput, error) {
+	raffles, err := uc.raffleRepo.FindDueForDraw(time.Now(), scheduledDrawBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExecuteScheduledDrawsOutput{}
+	for _, raffle := range raffles {
+		closed, drawn, cancelled, err := uc.processRaffle(ctx, raffle.ID)
+		if err != nil {
+			uc.logger.Error("Error processing scheduled draw",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+			output.Skipped++
+			continue
+		}
+
+		if closed {
+			output.SalesClosed++
+		}
+		if drawn {
+			output.Drawn++
+		} else if cancelled {
+			output.Cancelled++
+		} else if !closed {
+			output.Skipped++
+		}
+	}
+
+	return output, nil
+}
+
+// processRaffle cierra las ventas y, si corresponde, ejecuta el sorteo de una rifa
+// Si el sorteo no se completa, el siguiente intento se pospone con backoff
+func (uc *ExecuteScheduledDrawsUseCase) processRaffle(ctx context.Context, raffleID int64) (closed bool, drawn bool, cancelled bool, err error) {
+	// 1. Buscar la rifa para obtener su UUID
+	raffle, err := uc.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return false, false, false, err
+	}
+
+	// 2. Lock distribuido: solo una réplica procesa la rifa a la vez
+	lock, err := uc.lockService.AcquireLock(ctx, redis.DrawLockKey(raffle.UUID.String()), scheduledDrawLockTTL)
+	if err != nil {
+		if stderrors.Is(err, redis.ErrLockNotAcquired) {
+			return false, false, false, nil
+		}
+		return false, false, false, err
+	}
+	defer lock.Release(ctx)
+
+	// 3. Releer bajo el lock: otra réplica pudo haberla procesado
+	raffle, err = uc.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return false, false, false, err
+	}
+	if !raffle.IsDueForDraw() {
+		return false, false, false, nil
+	}
+
+	defer func() {
+		if drawn || cancelled {
+			return
+		}
+		raffle.DeferDraw(time.Now())
+		if deferErr := uc.raffleRepo.DeferDraw(raffle); deferErr != nil {
+			uc.logger.Warn("Error deferring scheduled draw",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(deferErr))
+		}
+	}()
+
+	// Sin el mínimo de vendidos el sorteo se pospone o cancela (MinimumSalesEnforcer)
+	if raffle.MinSoldCount != nil {
+		soldCount, err := uc.raffleNumberRepo.CountByStatus(raffle.ID, domain.RaffleNumberStatusSold)
+		if err != nil {
+			return false, false, false, err
+		}
+		if !raffle.MinimumSalesMet(soldCount) {
+			return false, false, false, nil
+		}
+	}
+
+	// 4. Cerrar ventas
+	if raffle.SalesClosedAt == nil {
+		if err := raffle.CloseSales(); err != nil {
+			return false, false, false, err
+		}
+
+		closed, err = uc.raffleRepo.CloseSales(raffle.ID, *raffle.SalesClosedAt)
+		if err != nil {
+			return false, false, false, err
+		}
+
+		if closed {
+			uc.wsHub.BroadcastSalesClosed(raffle.UUID.String(), raffle.DrawDate)
+			uc.logger.Info("Raffle sales closed",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.String("draw_method", string(raffle.DrawMethod)))
+		}
+	}
+
+	// 5. Esperar a que terminen los pagos en curso (máximo el timeout de checkout)
+	inCheckout, err := uc.hasReservationsInCheckout(ctx, raffle)
+	if err != nil {
+		return closed, false, false, err
+	}
+	if inCheckout && time.Since(*raffle.SalesClosedAt) < entities.ReservationCheckoutTimeout {
+		return closed, false, false, nil
+	}
+
+	// 6. Números vendidos que participan
+	candidates, err := uc.raffleNumberRepo.FindSoldNumbers(raffle.ID)
+	if err != nil {
+		return closed, false, false, err
+	}
+
+	// Sin números vendidos no hay nada que sortear: el sorteo se cancela (libera reservas y avisa al organizador)
+	if len(candidates) == 0 {
+		uc.logger.Warn("Raffle reached draw date without sold numbers",
+			logger.Int64("raffle_id", raffle.ID))
+		cancelled, err = uc.cancelWithoutSales(ctx, raffle)
+		return closed, false, cancelled, err
+	}
+
+	// 7. Solo los sorteos aleatorios y de lotería se ejecutan automáticamente; los demás quedan para el admin
+	switch raffle.DrawMethod {
+	case domain.DrawMethodRandom:
+	case domain.DrawMethodLoteriaCostaRica:
+		if uc.lotteryResolver == nil {
+			uc.markDrawPending(raffle, domain.DrawPendingManualRequired, nil)
+			return closed, false, false, nil
+		}
+	default:
+		uc.markDrawPending(raffle, domain.DrawPendingManualRequired, nil)
+		return closed, false, false, nil
+	}
+
+	// 8. Premios a sortear: si hay menos números vendidos que premios, quedan sin sortear los de menor categoría
+	prizes, err := LoadDrawPrizes(uc.prizeRepo, raffle)
+	if err != nil {
+		return closed, false, false, err
+	}
+	if len(prizes) > len(candidates) {
+		uc.logger.Warn("Raffle has fewer sold numbers than prizes",
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Int("prizes", len(prizes)),
+			logger.Int("sold_numbers", len(candidates)))
+		prizes = prizes[:len(candidates)]
+	}
+
+	if raffle.DrawMethod == domain.DrawMethodLoteriaCostaRica {
+		drawn, err = uc.drawFromLottery(ctx, raffle, candidates, prizes)
+	} else {
+		drawn, err = uc.drawRandom(ctx, raffle, candidates, prizes)
+	}
+	return closed, drawn, false, err
+}
+
+// cancelWithoutSales cancela un sorteo que llegó a su fecha sin números vendidos
+func (uc *ExecuteScheduledDrawsUseCase) cancelWithoutSales(ctx context.Context, raffle *domain.Raffle) (bool, error) {
+	if uc.canceller == nil {
+		return false, nil
+	}
+
+	reason := "El sorteo llegó a su fecha sin números vendidos"
+	if err := uc.canceller.CancelAutomatically(ctx, raffle.ID, reason); err != nil {
+		return false, err
+	}
+
+	uc.wsHub.BroadcastRaffleCancelled(raffle.UUID.String(), reason)
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleCancelled).
+		WithEntity("raffle", raffle.ID).
+		WithSeverity(domain.AuditSeverityWarning).
+		WithDescription(fmt.Sprintf("Sorteo cancelado automáticamente: %s", reason)).
+		WithMetadata(map[string]interface{}{
+			"draw_date":   raffle.DrawDate,
+			"draw_method": raffle.DrawMethod,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return true, nil
+}
+
+// markDrawPending registra por qué el sorteo vencido no se completó y avisa a los admins por auditoría
+// Solo se registra cuando cambia el motivo; los motivos que requieren al admin sacan al sorteo del job
+func (uc *ExecuteScheduledDrawsUseCase) markDrawPending(raffle *domain.Raffle, reason domain.DrawPendingReason, metadata map[string]interface{}) {
+	if !raffle.MarkDrawPending(reason, time.Now()) {
+		return
+	}
+
+	if err := uc.raffleRepo.MarkDrawPending(raffle); err != nil {
+		uc.logger.Error("Error marking draw pending",
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Error(err))
+		return
+	}
+
+	uc.logger.Warn("Scheduled draw pending",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.String("reason", string(reason)),
+		logger.Bool("requires_admin", reason.RequiresAdmin()))
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["reason"] = reason
+	metadata["requires_admin"] = reason.RequiresAdmin()
+	metadata["draw_date"] = raffle.DrawDate
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleDrawPending).
+		WithEntity("raffle", raffle.ID).
+		WithSeverity(domain.AuditSeverityWarning).
+		WithDescription(fmt.Sprintf("Sorteo vencido pendiente: %s", reason)).
+		WithMetadata(metadata).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+}
+
+// drawRandom ejecuta el sorteo verificable commit-reveal de todos los premios
+func (uc *ExecuteScheduledDrawsUseCase) drawRandom(ctx context.Context, raffle *domain.Raffle, candidates []string, prizes []*domain.RafflePrize) (bool, error) {
+	// Rifas publicadas antes del sorteo verificable no tienen semilla comprometida:
+	// se compromete ahora junto con una ronda del beacon aún no publicada y se sortea en una ejecución posterior
+	if raffle.DrawServerSeed == nil {
+		if err := raffle.CommitDrawSeed(); err != nil {
+			return false, errors.Wrap(errors.ErrInternalServer, err)
+		}
+		if _, err := uc.raffleRepo.CommitDrawSeed(raffle); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	committedAt := raffle.DrawSeedCommittedAt
+
+	// La ronda del beacon comprometida se publica al llegar la fecha del sorteo; se reintenta en la siguiente ejecución
+	entropy, err := uc.drawEntropy.Resolve(ctx, raffle)
+	if err != nil {
+		if err == ErrDrawEntropyNotAvailable {
+			return false, nil
+		}
+		return false, err
+	}
+
+	session := uc.drawRoom.Start(raffle, map[string]interface{}{
+		"candidates_count": len(candidates),
+		"prizes_count":     len(prizes),
+		"server_seed_hash": raffle.DrawSeedHash,
+	})
+
+	proof, err := domain.NewPrizesDrawProof(*raffle.DrawServerSeed, entropy.Value, candidates, 1, len(prizes), committedAt)
+	if err != nil {
+		session.Abort("Error calculando la prueba del sorteo")
+		return false, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	entropy.Apply(proof)
+
+	proofJSON, err := proof.ToJSON()
+	if err != nil {
+		session.Abort("Error calculando la prueba del sorteo")
+		return false, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	raffle.DrawProof = proofJSON
+
+	session.Step(ctx, "proof_computed", map[string]interface{}{
+		"public_entropy":  proof.PublicEntropy,
+		"beacon_round":    proof.BeaconRound,
+		"candidates_hash": proof.CandidatesHash,
+		"winner_index":    proof.WinnerIndex,
+	})
+
+	return uc.complete(ctx, raffle, session, prizes, proof.WinnerNumbers(), map[string]interface{}{
+		"candidates_count": len(candidates),
+		"server_seed_hash": proof.ServerSeedHash,
+	})
+}
+
+// drawFromLottery determina el ganador del premio mayor a partir del resultado oficial de la Lotería Nacional
+// Los premios secundarios se sortean de forma verificable entre los demás números vendidos.
+// Si el número oficial no se vendió se aplica la regla del sorteo: número vendido más cercano,
+// nuevo sorteo verificable de todos los premios o acumulación del premio mayor al siguiente sorteo de la serie
+func (uc *ExecuteScheduledDrawsUseCase) drawFromLottery(ctx context.Context, raffle *domain.Raffle, candidates []string, prizes []*domain.RafflePrize) (bool, error) {
+	resolution, err := uc.lotteryResolver.Resolve(ctx, raffle, candidates)
+	if err != nil {
+		return false, err
+	}
+
+	// Resultado aún no publicado o pendiente de confirmación: se reintenta con backoff
+	if resolution == nil {
+		uc.markDrawPending(raffle, domain.DrawPendingLotteryResult, map[string]interface{}{
+			"lottery_date": domain.LotteryDate(raffle.DrawDate).Format("2006-01-02"),
+		})
+		return false, nil
+	}
+
+	if resolution.WinnerNumber == "" {
+		policy, err := uc.unsoldWinnerPolicy(raffle)
+		if err != nil {
+			return false, err
+		}
+		if policy == nil {
+			uc.markDrawPending(raffle, domain.DrawPendingUnsoldNumber, map[string]interface{}{
+				"lottery_result_id": resolution.Result.ID,
+				"winning_number":    resolution.Result.WinningNumber,
+				"mapped_number":     resolution.MappedNumber,
+			})
+			return false, nil
+		}
+		raffle.UnsoldPolicyApplied = policy
+	} else if resolution.UsedFallback && raffle.UnsoldWinnerPolicy != nil {
+		raffle.UnsoldPolicyApplied = raffle.UnsoldWinnerPolicy
+	}
+
+	raffle.LotteryResultID = &resolution.Result.ID
+	raffle.LotteryMappedNumber = &resolution.MappedNumber
+
+	// Premios sorteados de forma verificable: desde el 2 si el premio mayor ya tiene ganador o se acumula,
+	// todos si el número oficial no se vendió y la regla es un nuevo sorteo
+	winnerNumbers := map[int]string{}
+	firstPosition := 2
+	if resolution.WinnerNumber != "" {
+		winnerNumbers[1] = resolution.WinnerNumber
+	} else if !raffle.IsJackpotRolledOver() {
+		firstPosition = 1
+	}
+	if count := len(prizes) - firstPosition + 1; count > 0 {
+		proof, err := uc.drawLotteryPrizes(ctx, raffle, candidates, resolution, firstPosition, count)
+		if err != nil || proof == nil {
+			return false, err
+		}
+		for position, number := range proof.WinnerNumbers() {
+			winnerNumbers[position] = number
+		}
+	}
+
+	session := uc.drawRoom.Start(raffle, map[string]interface{}{
+		"candidates_count":  len(candidates),
+		"prizes_count":      len(prizes),
+		"lottery_result_id": resolution.Result.ID,
+	})
+	session.Step(ctx, "lottery_result", map[string]interface{}{
+		"lottery_number": resolution.Result.WinningNumber,
+		"mapping_method": string(resolution.Rule.Method),
+		"mapped_number":  resolution.MappedNumber,
+		"used_fallback":  resolution.UsedFallback,
+		"unsold_policy":  raffle.UnsoldPolicyApplied,
+	})
+
+	return uc.complete(ctx, raffle, session, prizes, winnerNumbers, map[string]interface{}{
+		"candidates_count":  len(candidates),
+		"lottery_result_id": resolution.Result.ID,
+		"lottery_number":    resolution.Result.WinningNumber,
+		"mapping_method":    string(resolution.Rule.Method),
+		"mapped_number":     resolution.MappedNumber,
+		"fallback":          string(resolution.Rule.Fallback),
+		"used_fallback":     resolution.UsedFallback,
+		"unsold_policy":     raffle.UnsoldPolicyApplied,
+	})
+}
+
+// unsoldWinnerPolicy determina la regla a aplicar cuando el número oficial no se vendió
+// nil: el sorteo no tiene regla propia y queda pendiente para un admin (respaldo manual de la plataforma)
+func (uc *ExecuteScheduledDrawsUseCase) unsoldWinnerPolicy(raffle *domain.Raffle) (*domain.UnsoldWinnerPolicy, error) {
+	if raffle.UnsoldWinnerPolicy == nil {
+		return nil, nil
+	}
+
+	policy := *raffle.UnsoldWinnerPolicy
+	if policy != domain.UnsoldWinnerPolicyRollover {
+		return &policy, nil
+	}
+
+	// Sin un siguiente sorteo en la serie (serie finalizada o eliminada) el premio no se puede acumular
+	canRollOver := false
+	if uc.jackpotRollover != nil {
+		var err error
+		if canRollOver, err = uc.jackpotRollover.CanRollOver(raffle); err != nil {
+			return nil, err
+		}
+	}
+	if !canRollOver {
+		uc.logger.Warn("Jackpot cannot roll over, redrawing among sold numbers",
+			logger.Int64("raffle_id", raffle.ID))
+		policy = domain.UnsoldWinnerPolicyRedraw
+	}
+
+	return &policy, nil
+}
+
+// drawLotteryPrizes sortea count premios desde firstPosition entre los números vendidos que no ganaron el premio mayor
+// Usa el esquema commit-reveal con el resultado oficial de la lotería como parte de la entropía pública
+// Retorna nil sin error si el sorteo se pospone hasta que se publique entropía posterior al compromiso
+func (uc *ExecuteScheduledDrawsUseCase) drawLotteryPrizes(ctx context.Context, raffle *domain.Raffle, candidates []string, resolution *LotteryDrawResolution, firstPosition, count int) (*domain.DrawProof, error) {
+	// Rifas publicadas antes del sorteo verificable no tienen semilla comprometida: el resultado oficial
+	// ya es público, así que se compromete ahora junto con una ronda futura del beacon y se sortea después
+	if raffle.DrawServerSeed == nil {
+		if err := raffle.CommitDrawSeed(); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServer, err)
+		}
+		if _, err := uc.raffleRepo.CommitDrawSeed(raffle); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	committedAt := raffle.DrawSeedCommittedAt
+
+	remaining := make([]string, 0, len(candidates))
+	for _, number := range candidates {
+		if number != resolution.WinnerNumber {
+			remaining = append(remaining, number)
+		}
+	}
+
+	// Con la semilla comprometida antes del día del sorteo de la lotería nadie conocía el resultado oficial;
+	// si se comprometió después, la entropía incluye además la ronda del beacon emitida tras el compromiso
+	entropy := fmt.Sprintf("%s|%s|%s", raffle.UUID.String(), resolution.Result.DrawDate.Format("2006-01-02"), resolution.Result.WinningNumber)
+	var beaconEntropy *DrawEntropy
+	if committedAt == nil || !committedAt.Before(resolution.Result.DrawDate) {
+		var err error
+		beaconEntropy, err = uc.drawEntropy.Resolve(ctx, raffle)
+		if err != nil {
+			if err == ErrDrawEntropyNotAvailable {
+				return nil, nil
+			}
+			return nil, err
+		}
+		entropy = entropy + "|" + beaconEntropy.Value
+	}
+
+	proof, err := domain.NewPrizesDrawProof(*raffle.DrawServerSeed, entropy, remaining, firstPosition, count, committedAt)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	if beaconEntropy != nil {
+		beaconEntropy.Apply(proof)
+	}
+	if resolution.WinnerNumber != "" {
+		proof.Excluded = []string{resolution.WinnerNumber}
+	}
+
+	proofJSON, err := proof.ToJSON()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	raffle.DrawProof = proofJSON
+
+	return proof, nil
+}
+
+// complete marca la rifa como completada con un ganador por premio, persiste el resultado,
+// revela los ganadores en la sala (del último premio al premio mayor) y notifica
+func (uc *ExecuteScheduledDrawsUseCase) complete(ctx context.Context, raffle *domain.Raffle, session *DrawSession, prizes []*domain.RafflePrize, winnerNumbers map[int]string, metadata map[string]interface{}) (bool, error) {
+	winners := make([]*domain.RaffleWinner, 0, len(winnerNumbers))
+	for _, prize := range prizes {
+		number, ok := winnerNumbers[prize.Position]
+		if !ok {
+			continue
+		}
+
+		owner, err := uc.raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, number)
+		if err != nil {
+			session.Abort("Número ganador no encontrado")
+			return false, err
+		}
+		winners = append(winners, domain.NewRaffleWinner(raffle.ID, prize, number, owner.UserID))
+	}
+
+	completeRaffle := raffle.Complete
+	if raffle.IsJackpotRolledOver() {
+		completeRaffle = raffle.CompleteWithRollover
+	}
+	if err := completeRaffle(winners); err != nil {
+		session.Abort(err.Error())
+		return false, err
+	}
+	paidRevenue, err := uc.raffleRepo.GetPaidRevenue(raffle.ID)
+	if err != nil {
+		session.Abort("Error calculando los ingresos del sorteo")
+		return false, err
+	}
+	raffle.CalculateRevenue(paidRevenue)
+
+	// Sin ganador del premio mayor cuando se acumula al siguiente sorteo de la serie
+	winnerNumber := ""
+	if raffle.WinnerNumber != nil {
+		winnerNumber = *raffle.WinnerNumber
+	}
+
+	// Escritura condicional: si otra réplica completó el sorteo no se sobrescribe
+	completed, err := uc.raffleRepo.CompleteDraw(raffle)
+	if err != nil {
+		session.Abort("Error guardando el resultado del sorteo")
+		return false, err
+	}
+	if !completed {
+		session.Abort("El sorteo ya fue completado")
+		return false, nil
+	}
+
+	// Audit log
+	winnersData := DrawWinnersData(winners)
+	metadata["winner_number"] = winnerNumber
+	metadata["winner_user_id"] = raffle.WinnerUserID
+	metadata["winners"] = winnersData
+	metadata["draw_method"] = string(raffle.DrawMethod)
+
+	description := fmt.Sprintf("Sorteo automático ejecutado: número ganador %s (%d premios)", winnerNumber, len(winners))
+	if raffle.IsJackpotRolledOver() {
+		description = fmt.Sprintf("Sorteo automático ejecutado: número oficial no vendido, premio mayor acumulado al siguiente sorteo de la serie (%d premios)", len(winners))
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleCompleted).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(description).
+		WithMetadata(metadata).
+		Build()
+
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	uc.logger.Info("Scheduled draw completed",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.String("draw_method", string(raffle.DrawMethod)),
+		logger.String("winner_number", winnerNumber),
+		logger.Int("winners", len(winners)))
+
+	// Premios secundarios primero, el premio mayor se revela al cerrar la sala
+	for i := len(winners) - 1; i >= 0; i-- {
+		if winners[i].Position == 1 {
+			continue
+		}
+		session.Step(ctx, "prize_winner_revealed", winnersData[i])
+	}
+
+	// El hash de la semilla solo aplica a sorteos verificables
+	var seedHash *string
+	if raffle.HasDrawProof() {
+		seedHash = raffle.DrawSeedHash
+	}
+	session.Reveal(ctx, winnerNumber, map[string]interface{}{
+		"draw_seed_hash":      seedHash,
+		"winners":             winnersData,
+		"jackpot_rolled_over": raffle.IsJackpotRolledOver(),
+	})
+	uc.wsHub.BroadcastRaffleDrawn(raffle.UUID.String(), winnerNumber, seedHash)
+
+	// El acumulado pasa al siguiente sorteo abierto de la serie; si aún no existe lo recibe el próximo que se genere
+	if raffle.IsJackpotRolledOver() && uc.jackpotRollover != nil {
+		if err := uc.jackpotRollover.CarryForward(ctx, raffle); err != nil {
+			uc.logger.Error("Error carrying jackpot forward",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+		}
+	}
+
+	// Flujos posteriores (el sorteo ya quedó persistido, los errores solo se registran)
+	for _, handler := range uc.completedHandlers {
+		if err := handler.OnDrawCompleted(ctx, raffle); err != nil {
+			uc.logger.Error("Error in draw completed handler",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+		}
+	}
+
+	return true, nil
+}
+
+// hasReservationsInCheckout verifica si hay reservas en proceso de pago para la rifa
+func (uc *ExecuteScheduledDrawsUseCase) hasReservationsInCheckout(ctx context.Context, raffle *domain.Raffle) (bool, error) {
+	reservations, err := uc.reservationRepo.FindByRaffleID(ctx, raffle.UUID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Status == entities.ReservationStatusPending &&
+			reservation.Phase == entities.ReservationPhaseCheckout &&
+			!reservation.IsExpired() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
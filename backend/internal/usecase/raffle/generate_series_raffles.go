@@ -0,0 +1,308 @@
+package raffle
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const (
+	// raffleSeriesBatchSize máximo de series procesadas por ejecución
+	raffleSeriesBatchSize = 50
+	// raffleSeriesMissedRunTolerance atraso máximo de una ejecución cron; las más atrasadas se omiten
+	raffleSeriesMissedRunTolerance = time.Hour
+)
+
+// GenerateSeriesRafflesOutput resultado de una ejecución del job
+type GenerateSeriesRafflesOutput struct {
+	Generated int
+	Failed    int
+	Skipped   int
+}
+
+// GenerateSeriesRafflesUseCase genera el siguiente sorteo de las series recurrentes desde su plantilla
+// Se ejecuta desde el job (series con cron y series al completarse cuyo sorteo anterior finalizó)
+// y como DrawCompletedHandler al completarse un sorteo programado de una serie.
+// Es seguro ejecutarlo en varias réplicas: usa un lock por serie y la secuencia es única por serie
+type GenerateSeriesRafflesUseCase struct {
+	seriesRepo      domain.RaffleSeriesRepository
+	templateRepo    domain.RaffleTemplateRepository
+	raffleRepo      db.RaffleRepository
+	raffleImageRepo db.RaffleImageRepository
+	auditRepo       domain.AuditLogRepository
+	lockService     *redis.LockService
+	createRaffle    *CreateRaffleUseCase
+	publishRaffle   *PublishRaffleUseCase
+	jackpotRollover *JackpotRollover
+	logger          *logger.Logger
+}
+
+// NewGenerateSeriesRafflesUseCase crea una nueva instancia
+func NewGenerateSeriesRafflesUseCase(
+	seriesRepo domain.RaffleSeriesRepository,
+	templateRepo domain.RaffleTemplateRepository,
+	raffleRepo db.RaffleRepository,
+	raffleImageRepo db.RaffleImageRepository,
+	auditRepo domain.AuditLogRepository,
+	lockService *redis.LockService,
+	createRaffle *CreateRaffleUseCase,
+	publishRaffle *PublishRaffleUseCase,
+	jackpotRollover *JackpotRollover,
+	logger *logger.Logger,
+) *GenerateSeriesRafflesUseCase {
+	return &GenerateSeriesRafflesUseCase{
+		seriesRepo:      seriesRepo,
+		templateRepo:    templateRepo,
+		raffleRepo:      raffleRepo,
+		raffleImageRepo: raffleImageRepo,
+		auditRepo:       auditRepo,
+		lockService:     lockService,
+		createRaffle:    createRaffle,
+		publishRaffle:   publishRaffle,
+		jackpotRollover: jackpotRollover,
+		logger:          logger,
+	}
+}
+
+// Execute genera los sorteos de las series pendientes
+func (uc *GenerateSeriesRafflesUseCase) Execute(ctx context.Context) (*GenerateSeriesRafflesOutput, error) {
+	due, err := uc.seriesRepo.FindDue(time.Now(), raffleSeriesBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GenerateSeriesRafflesOutput{}
+	for _, series := range due {
+		generated, err := uc.generate(ctx, series)
+		if err != nil {
+			uc.logger.Error("Error generating series raffle",
+				logger.Int64("series_id", series.ID),
+				logger.Error(err))
+			output.Failed++
+			continue
+		}
+		if generated {
+			output.Generated++
+		} else {
+			output.Skipped++
+		}
+	}
+
+	return output, nil
+}
+
+// OnDrawCompleted genera el siguiente sorteo de la serie al completarse uno de sus sorteos
+func (uc *GenerateSeriesRafflesUseCase) OnDrawCompleted(ctx context.Context, raffle *domain.Raffle) error {
+	if raffle.SeriesID == nil {
+		return nil
+	}
+
+	series, err := uc.seriesRepo.FindByID(*raffle.SeriesID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	if series.Mode != domain.RaffleSeriesModeOnCompletion || series.Status != domain.RaffleSeriesStatusActive {
+		return nil
+	}
+
+	_, err = uc.generate(ctx, series)
+	return err
+}
+
+// generate crea, asocia las imágenes y (opcionalmente) publica el siguiente sorteo de la serie
+func (uc *GenerateSeriesRafflesUseCase) generate(ctx context.Context, series *domain.RaffleSeries) (bool, error) {
+	// 1. Lock distribuido de la serie
+	lock, err := uc.lockService.AcquireLock(ctx, redis.RaffleSeriesLockKey(series.UUID.String()), raffleSeriesLockTTL)
+	if err != nil {
+		if stderrors.Is(err, redis.ErrLockNotAcquired) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer lock.Release(ctx)
+
+	// 2. Releer bajo el lock: otra réplica pudo generar el sorteo o el organizador pausar la serie
+	series, err = uc.seriesRepo.FindByID(series.ID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	due, err := uc.isDue(series, now)
+	if err != nil || !due {
+		return false, err
+	}
+
+	if series.HasReachedMaxOccurrences() {
+		series.End(now)
+		return false, uc.seriesRepo.Update(series)
+	}
+
+	// 3. Las ejecuciones cron muy atrasadas (job detenido) se omiten en lugar de generar sorteos tardíos
+	scheduledAt := now
+	if series.Mode == domain.RaffleSeriesModeCron {
+		scheduledAt = *series.NextRunAt
+		if now.Sub(scheduledAt) > raffleSeriesMissedRunTolerance {
+			return false, uc.recordFailure(series, now, fmt.Sprintf("Ejecución del %s omitida por atraso", scheduledAt.In(series.Location()).Format("2006-01-02 15:04")))
+		}
+	}
+
+	// 4. Plantilla
+	template, err := uc.templateRepo.FindByID(series.TemplateID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			if pauseErr := series.Pause(); pauseErr == nil {
+				return false, uc.recordFailure(series, now, "La plantilla de la serie fue eliminada; la serie se pausó")
+			}
+		}
+		return false, err
+	}
+
+	// 5. Crear el sorteo con la siguiente secuencia
+	sequence := series.Occurrences + 1
+	drawDate := scheduledAt.Add(time.Duration(series.DrawOffsetHours) * time.Hour).Truncate(time.Minute)
+
+	createInput, err := raffleInputFromTemplate(template, series.UserID, drawDate, now)
+	if err != nil {
+		return false, uc.recordFailure(series, now, err.Error())
+	}
+	createInput.SeriesID = &series.ID
+	createInput.SeriesSequence = &sequence
+
+	output, err := uc.createRaffle.Execute(ctx, createInput)
+	if err != nil {
+		if recordErr := uc.recordFailure(series, now, err.Error()); recordErr != nil {
+			uc.logger.Error("Error recording series failure", logger.Int64("series_id", series.ID), logger.Error(recordErr))
+		}
+		return false, err
+	}
+	raffle := output.Raffle
+
+	if err := copyTemplateImages(uc.raffleImageRepo, template, raffle.ID); err != nil {
+		uc.logger.Error("Error copying template images to series raffle",
+			logger.Int64("series_id", series.ID),
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Error(err))
+	}
+
+	// Premio mayor acumulado de sorteos anteriores de la serie cuyo número oficial no se vendió
+	rollover, err := uc.jackpotRollover.Claim(ctx, raffle)
+	if err != nil {
+		uc.logger.Error("Error claiming jackpot rollover for series raffle",
+			logger.Int64("series_id", series.ID),
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Error(err))
+	}
+
+	// 6. Publicar; si falla el sorteo queda en borrador y el error queda registrado en la serie
+	var publishErr error
+	if series.AutoPublish {
+		if _, publishErr = uc.publishRaffle.Execute(ctx, &PublishRaffleInput{
+			RaffleID: raffle.ID,
+			UserID:   series.UserID,
+		}); publishErr != nil {
+			uc.logger.Warn("Error publishing series raffle",
+				logger.Int64("series_id", series.ID),
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(publishErr))
+		}
+	}
+
+	// 7. Registrar el sorteo en la serie y programar la siguiente ejecución
+	previousOccurrences := series.Occurrences
+	if err := series.RecordRaffle(raffle.ID, sequence, now); err != nil {
+		msg := err.Error()
+		series.LastError = &msg
+	}
+	if publishErr != nil {
+		msg := fmt.Sprintf("El sorteo #%d quedó en borrador: %s", sequence, publishErr.Error())
+		series.LastError = &msg
+	}
+
+	recorded, err := uc.seriesRepo.RecordGeneration(series, previousOccurrences)
+	if err != nil {
+		return false, err
+	}
+	if !recorded {
+		// No debería ocurrir bajo el lock; el índice único de secuencia impide duplicados
+		uc.logger.Warn("Series advanced by another run while generating",
+			logger.Int64("series_id", series.ID),
+			logger.Int64("raffle_id", raffle.ID))
+	}
+
+	// 8. Audit log
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleSeriesGenerated).
+		WithUser(series.UserID).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(fmt.Sprintf("Sorteo #%d generado por la serie %s", sequence, series.Name)).
+		WithMetadata(map[string]interface{}{
+			"series_id":   series.ID,
+			"template_id": template.ID,
+			"sequence":    sequence,
+			"draw_date":   raffle.DrawDate,
+			"published":   series.AutoPublish && publishErr == nil,
+			"rollover":    rollover.StringFixed(2),
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	uc.logger.Info("Series raffle generated",
+		logger.Int64("series_id", series.ID),
+		logger.Int64("raffle_id", raffle.ID),
+		logger.Int("sequence", sequence),
+		logger.Bool("published", series.AutoPublish && publishErr == nil))
+
+	return true, nil
+}
+
+// isDue verifica bajo el lock que la serie deba generar su siguiente sorteo
+func (uc *GenerateSeriesRafflesUseCase) isDue(series *domain.RaffleSeries, now time.Time) (bool, error) {
+	if series.Status != domain.RaffleSeriesStatusActive {
+		return false, nil
+	}
+
+	if series.Mode == domain.RaffleSeriesModeCron {
+		return series.NextRunAt != nil && !series.NextRunAt.After(now), nil
+	}
+
+	if series.LastRaffleID == nil {
+		return true, nil
+	}
+	last, err := uc.raffleRepo.FindByID(*series.LastRaffleID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return true, nil
+		}
+		return false, err
+	}
+	return last.Status == domain.RaffleStatusCompleted || last.Status == domain.RaffleStatusCancelled || last.DeletedAt != nil, nil
+}
+
+// recordFailure registra el error en la serie; las series con cron avanzan a su siguiente ejecución
+func (uc *GenerateSeriesRafflesUseCase) recordFailure(series *domain.RaffleSeries, now time.Time, reason string) error {
+	series.LastError = &reason
+	series.UpdatedAt = now
+	if series.Mode == domain.RaffleSeriesModeCron && series.NextRunAt != nil && now.Sub(*series.NextRunAt) > raffleSeriesMissedRunTolerance {
+		if err := series.ScheduleNextRun(now); err != nil {
+			return err
+		}
+	}
+
+	uc.logger.Warn("Series raffle not generated",
+		logger.Int64("series_id", series.ID),
+		logger.String("reason", reason))
+
+	return uc.seriesRepo.Update(series)
+}
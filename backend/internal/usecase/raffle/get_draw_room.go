@@ -0,0 +1,86 @@
+package raffle
+
+import (
+	"context"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// GetDrawRoomInput datos de entrada
+type GetDrawRoomInput struct {
+	RaffleID   *int64
+	RaffleUUID *string
+}
+
+// GetDrawRoomOutput sala del sorteo en vivo con su timeline
+type GetDrawRoomOutput struct {
+	Raffle *domain.Raffle
+	Room   *domain.DrawRoom
+	Events []*domain.DrawRoomEvent
+}
+
+// GetDrawRoomUseCase caso de uso para consultar la sala del sorteo en vivo
+// Quienes entran tarde reproducen el timeline y siguen los eventos nuevos por WebSocket/SSE
+type GetDrawRoomUseCase struct {
+	raffleRepo db.RaffleRepository
+	roomRepo   domain.DrawRoomRepository
+}
+
+// NewGetDrawRoomUseCase crea una nueva instancia
+func NewGetDrawRoomUseCase(raffleRepo db.RaffleRepository, roomRepo domain.DrawRoomRepository) *GetDrawRoomUseCase {
+	return &GetDrawRoomUseCase{
+		raffleRepo: raffleRepo,
+		roomRepo:   roomRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetDrawRoomUseCase) Execute(ctx context.Context, input *GetDrawRoomInput) (*GetDrawRoomOutput, error) {
+	if input.RaffleID == nil && input.RaffleUUID == nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	// 1. Buscar el sorteo
+	var raffle *domain.Raffle
+	var err error
+
+	if input.RaffleID != nil {
+		raffle, err = uc.raffleRepo.FindByID(*input.RaffleID)
+	} else {
+		raffle, err = uc.raffleRepo.FindByUUID(*input.RaffleUUID)
+	}
+
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrRaffleNotFound
+		}
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	// 2. Sin sala programada: cuenta regresiva hacia la fecha de sorteo (sin persistir)
+	room, err := uc.roomRepo.FindByRaffleID(raffle.ID)
+	if err == errors.ErrNotFound {
+		return &GetDrawRoomOutput{
+			Raffle: raffle,
+			Room:   domain.NewDrawRoom(raffle),
+			Events: []*domain.DrawRoomEvent{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Timeline para reproducir el sorteo
+	events, err := uc.roomRepo.ListEvents(room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetDrawRoomOutput{
+		Raffle: raffle,
+		Room:   room,
+		Events: events,
+	}, nil
+}
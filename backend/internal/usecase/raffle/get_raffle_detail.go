@@ -31,6 +31,7 @@ type GetRaffleDetailUseCase struct {
 	raffleRepo       db.RaffleRepository
 	raffleNumberRepo db.RaffleNumberRepository
 	raffleImageRepo  db.RaffleImageRepository
+	prizeRepo        domain.RafflePrizeRepository
 }
 
 // NewGetRaffleDetailUseCase crea una nueva instancia
@@ -38,11 +39,13 @@ func NewGetRaffleDetailUseCase(
 	raffleRepo db.RaffleRepository,
 	raffleNumberRepo db.RaffleNumberRepository,
 	raffleImageRepo db.RaffleImageRepository,
+	prizeRepo domain.RafflePrizeRepository,
 ) *GetRaffleDetailUseCase {
 	return &GetRaffleDetailUseCase{
 		raffleRepo:       raffleRepo,
 		raffleNumberRepo: raffleNumberRepo,
 		raffleImageRepo:  raffleImageRepo,
+		prizeRepo:        prizeRepo,
 	}
 }
 
@@ -70,6 +73,11 @@ func (uc *GetRaffleDetailUseCase) Execute(ctx context.Context, input *GetRaffleD
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
+	// Premios y ganadores
+	if err := loadPrizesAndWinners(uc.prizeRepo, []*domain.Raffle{raffle}); err != nil {
+		return nil, err
+	}
+
 	output := &GetRaffleDetailOutput{
 		Raffle: raffle,
 	}
@@ -0,0 +1,120 @@
+package raffle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// JackpotRollover acumula el premio mayor de los sorteos de una serie cuyo número oficial no se vendió
+// El acumulado queda pendiente en el sorteo de origen hasta que lo recibe el siguiente sorteo abierto de la serie
+// (el ya generado, o el próximo que genere la serie), por lo que un fallo intermedio no pierde el premio
+type JackpotRollover struct {
+	seriesRepo domain.RaffleSeriesRepository
+	raffleRepo db.RaffleRepository
+	auditRepo  domain.AuditLogRepository
+	logger     *logger.Logger
+}
+
+// NewJackpotRollover crea una nueva instancia
+func NewJackpotRollover(
+	seriesRepo domain.RaffleSeriesRepository,
+	raffleRepo db.RaffleRepository,
+	auditRepo domain.AuditLogRepository,
+	logger *logger.Logger,
+) *JackpotRollover {
+	return &JackpotRollover{
+		seriesRepo: seriesRepo,
+		raffleRepo: raffleRepo,
+		auditRepo:  auditRepo,
+		logger:     logger,
+	}
+}
+
+// CanRollOver verifica si el premio mayor tiene a qué sorteo acumularse:
+// un sorteo abierto ya generado por la serie o una serie que seguirá generando sorteos
+func (r *JackpotRollover) CanRollOver(raffle *domain.Raffle) (bool, error) {
+	if raffle.SeriesID == nil || raffle.SeriesSequence == nil || !raffle.HasCashPrize() {
+		return false, nil
+	}
+
+	if _, err := r.raffleRepo.FindNextOpenInSeries(*raffle.SeriesID, *raffle.SeriesSequence); err == nil {
+		return true, nil
+	} else if err != errors.ErrNotFound {
+		return false, err
+	}
+
+	series, err := r.seriesRepo.FindByID(*raffle.SeriesID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Una serie pausada conserva el acumulado hasta que se reanude
+	return series.Status != domain.RaffleSeriesStatusEnded && !series.HasReachedMaxOccurrences(), nil
+}
+
+// CarryForward pasa el premio acumulado al siguiente sorteo abierto de la serie si ya existe
+// Si aún no existe, el acumulado queda pendiente para el próximo sorteo que genere la serie
+func (r *JackpotRollover) CarryForward(ctx context.Context, raffle *domain.Raffle) error {
+	if raffle.SeriesID == nil || raffle.SeriesSequence == nil {
+		return nil
+	}
+
+	next, err := r.raffleRepo.FindNextOpenInSeries(*raffle.SeriesID, *raffle.SeriesSequence)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			r.logger.Info("Jackpot rollover pending next series raffle",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Int64("series_id", *raffle.SeriesID))
+			return nil
+		}
+		return err
+	}
+
+	_, err = r.Claim(ctx, next)
+	return err
+}
+
+// Claim acumula en el sorteo los premios mayores pendientes de los sorteos anteriores de su serie
+// Los premios se retuvieron en la liquidación de cada sorteo de origen y pasan a este en el libro mayor
+func (r *JackpotRollover) Claim(ctx context.Context, raffle *domain.Raffle) (decimal.Decimal, error) {
+	if raffle.SeriesID == nil || raffle.SeriesSequence == nil {
+		return decimal.Zero, nil
+	}
+
+	amount, err := r.raffleRepo.ClaimPendingRollover(raffle, r.logger)
+	if err != nil || amount.IsZero() {
+		return amount, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleRolledOver).
+		WithUser(raffle.UserID).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(fmt.Sprintf("Premio mayor acumulado de %s recibido de sorteos anteriores de la serie", amount.StringFixed(2))).
+		WithMetadata(map[string]interface{}{
+			"series_id":                  *raffle.SeriesID,
+			"amount":                     amount.StringFixed(2),
+			"rolled_over_from_raffle_id": raffle.RolledOverFromRaffleID,
+			"prize_amount":               raffle.PrizeAmount,
+		}).
+		Build()
+	if err := r.auditRepo.Create(auditLog); err != nil {
+		r.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	r.logger.Info("Jackpot rolled over",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.Int64("series_id", *raffle.SeriesID),
+		logger.String("amount", amount.StringFixed(2)))
+
+	return amount, nil
+}
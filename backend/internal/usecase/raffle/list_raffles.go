@@ -33,12 +33,14 @@ type ListRafflesOutput struct {
 // ListRafflesUseCase caso de uso para listar sorteos
 type ListRafflesUseCase struct {
 	raffleRepo db.RaffleRepository
+	prizeRepo  domain.RafflePrizeRepository
 }
 
 // NewListRafflesUseCase crea una nueva instancia
-func NewListRafflesUseCase(raffleRepo db.RaffleRepository) *ListRafflesUseCase {
+func NewListRafflesUseCase(raffleRepo db.RaffleRepository, prizeRepo domain.RafflePrizeRepository) *ListRafflesUseCase {
 	return &ListRafflesUseCase{
 		raffleRepo: raffleRepo,
+		prizeRepo:  prizeRepo,
 	}
 }
 
@@ -94,6 +96,11 @@ func (uc *ListRafflesUseCase) Execute(ctx context.Context, input *ListRafflesInp
 		return nil, errors.Wrap(errors.ErrDatabaseError, err)
 	}
 
+	// Premios y ganadores de la página
+	if err := loadPrizesAndWinners(uc.prizeRepo, raffles); err != nil {
+		return nil, err
+	}
+
 	// Calcular total de páginas
 	totalPages := int(total) / input.PageSize
 	if int(total)%input.PageSize > 0 {
@@ -0,0 +1,170 @@
+package raffle
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/lottery"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// lotteryMappingRuleKey parámetro de sistema con la regla de mapeo
+const lotteryMappingRuleKey = "lottery_mapping_rule"
+
+// LotteryDrawResolution resultado de aplicar la lotería oficial a una rifa
+type LotteryDrawResolution struct {
+	Result       *domain.LotteryResult
+	Rule         domain.LotteryMappingRule
+	MappedNumber int
+	WinnerNumber string // Vacío si el número no se vendió y la regla no elige otro (manual, redraw o rollover)
+	UsedFallback bool
+}
+
+// LotteryDrawResolver determina el ganador de rifas loteria_nacional_cr a partir del resultado oficial
+type LotteryDrawResolver struct {
+	resultRepo      domain.LotteryResultRepository
+	systemParamRepo *db.PostgresSystemParameterRepository
+	source          lottery.ResultSource // nil: solo ingreso manual con doble confirmación
+	logger          *logger.Logger
+}
+
+// NewLotteryDrawResolver crea una nueva instancia
+func NewLotteryDrawResolver(
+	resultRepo domain.LotteryResultRepository,
+	systemParamRepo *db.PostgresSystemParameterRepository,
+	source lottery.ResultSource,
+	logger *logger.Logger,
+) *LotteryDrawResolver {
+	return &LotteryDrawResolver{
+		resultRepo:      resultRepo,
+		systemParamRepo: systemParamRepo,
+		source:          source,
+		logger:          logger,
+	}
+}
+
+// Resolve aplica el resultado confirmado de la fecha del sorteo a la rifa
+// Retorna nil si el resultado aún no está disponible o confirmado
+func (r *LotteryDrawResolver) Resolve(ctx context.Context, raffle *domain.Raffle, soldNumbers []string) (*LotteryDrawResolution, error) {
+	result, err := r.findOrFetchResult(ctx, domain.LotteryDate(raffle.DrawDate))
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || !result.IsConfirmed() {
+		return nil, nil
+	}
+
+	// La regla propia del sorteo reemplaza el respaldo general de la plataforma
+	rule := r.mappingRule()
+	if raffle.UnsoldWinnerPolicy != nil {
+		rule.Fallback = raffle.UnsoldWinnerPolicy.LotteryFallback()
+	}
+
+	mapped, err := rule.MapNumber(result.WinningNumber, raffle)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	winner, usedFallback, err := rule.ResolveWinner(mapped, raffle, soldNumbers)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrValidationFailed, err)
+	}
+
+	return &LotteryDrawResolution{
+		Result:       result,
+		Rule:         rule,
+		MappedNumber: mapped,
+		WinnerNumber: winner,
+		UsedFallback: usedFallback,
+	}, nil
+}
+
+// findOrFetchResult busca el resultado registrado o lo importa desde la fuente configurada
+func (r *LotteryDrawResolver) findOrFetchResult(ctx context.Context, drawDate time.Time) (*domain.LotteryResult, error) {
+	result, err := r.resultRepo.FindActiveByDrawDate(drawDate)
+	if err == nil {
+		return result, nil
+	}
+	if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	if r.source == nil {
+		return nil, nil
+	}
+
+	official, err := r.source.FetchResult(ctx, drawDate)
+	if err != nil {
+		if stderrors.Is(err, lottery.ErrResultNotAvailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	raw, err := json.Marshal(official)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+
+	// Los resultados importados de la fuente oficial no requieren doble confirmación
+	now := time.Now()
+	result = &domain.LotteryResult{
+		DrawDate:      drawDate,
+		WinningNumber: official.WinningNumber,
+		Source:        domain.LotteryResultSource(r.source.Name()),
+		Status:        domain.LotteryResultStatusConfirmed,
+		ConfirmedAt:   &now,
+		RawPayload:    raw,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if official.DrawNumber != "" {
+		result.DrawNumber = &official.DrawNumber
+	}
+	if official.Series != "" {
+		result.Series = &official.Series
+	}
+
+	if err := r.resultRepo.Create(result); err != nil {
+		// Otra réplica (o un admin) pudo registrar el resultado en paralelo
+		if existing, findErr := r.resultRepo.FindActiveByDrawDate(drawDate); findErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	r.logger.Info("Lottery result imported",
+		logger.String("draw_date", drawDate.Format("2006-01-02")),
+		logger.String("winning_number", result.WinningNumber),
+		logger.String("source", string(result.Source)))
+
+	return result, nil
+}
+
+// mappingRule obtiene la regla configurada o la regla por defecto
+func (r *LotteryDrawResolver) mappingRule() domain.LotteryMappingRule {
+	rule := domain.DefaultLotteryMappingRule()
+	if r.systemParamRepo == nil {
+		return rule
+	}
+
+	var configured domain.LotteryMappingRule
+	if err := r.systemParamRepo.GetJSON(lotteryMappingRuleKey, &configured); err != nil {
+		if err != errors.ErrNotFound {
+			r.logger.Warn("Error reading lottery mapping rule, using default", logger.Error(err))
+		}
+		return rule
+	}
+
+	if err := configured.Validate(); err != nil {
+		r.logger.Warn("Invalid lottery mapping rule, using default", logger.Error(err))
+		return rule
+	}
+
+	return configured
+}
@@ -0,0 +1,281 @@
+package raffle
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const (
+	// minimumSalesBatchSize máximo de sorteos evaluados por ejecución
+	minimumSalesBatchSize = 50
+	// minimumSalesLockTTL duración del lock por sorteo (la cancelación solicita un reembolso por pago)
+	minimumSalesLockTTL = 5 * time.Minute
+)
+
+// RaffleCanceller cancela un sorteo reembolsando a sus compradores
+// Lo implementa el caso de uso de cancelación con reembolsos del módulo admin
+type RaffleCanceller interface {
+	CancelAutomatically(ctx context.Context, raffleID int64, reason string) error
+}
+
+// DrawPostponedMailer envía el aviso de postergación del sorteo
+type DrawPostponedMailer interface {
+	SendDrawPostponedEmail(email, raffleTitle, raffleID string, drawDate time.Time, reason string) error
+}
+
+// applyMinimumSales aplica la configuración de mínimo de ventas del organizador
+// Un valor 0 en minSoldCount o extensionDays elimina el mínimo o la extensión
+func applyMinimumSales(raffle *domain.Raffle, minSoldCount, cutoffHours, extensionDays *int) {
+	if minSoldCount != nil {
+		if *minSoldCount == 0 {
+			raffle.MinSoldCount = nil
+			raffle.MinSalesExtensionDays = nil
+		} else {
+			count := *minSoldCount
+			raffle.MinSoldCount = &count
+		}
+	}
+	if cutoffHours != nil {
+		raffle.MinSalesCutoffHours = *cutoffHours
+	}
+	if extensionDays != nil {
+		if *extensionDays == 0 {
+			raffle.MinSalesExtensionDays = nil
+		} else {
+			days := *extensionDays
+			raffle.MinSalesExtensionDays = &days
+		}
+	}
+}
+
+// EnforceMinimumSalesOutput resultado de una ejecución del job
+type EnforceMinimumSalesOutput struct {
+	Postponed int
+	Cancelled int
+	Skipped   int
+}
+
+// EnforceMinimumSalesUseCase evalúa los sorteos que llegaron a su corte sin el mínimo de números vendidos
+// Si el sorteo tiene extensión configurada y aún no la usó, pospone el sorteo; si no, lo cancela con reembolsos
+// Es seguro ejecutarlo en varias réplicas: usa el mismo lock por sorteo que el job de sorteos programados
+type EnforceMinimumSalesUseCase struct {
+	raffleRepo       db.RaffleRepository
+	raffleNumberRepo db.RaffleNumberRepository
+	userRepo         domain.UserRepository
+	auditRepo        domain.AuditLogRepository
+	lockService      *redis.LockService
+	wsHub            *websocket.Hub
+	canceller        RaffleCanceller
+	mailer           DrawPostponedMailer // nil: no se envían avisos de postergación
+	logger           *logger.Logger
+}
+
+// NewEnforceMinimumSalesUseCase crea una nueva instancia
+func NewEnforceMinimumSalesUseCase(
+	raffleRepo db.RaffleRepository,
+	raffleNumberRepo db.RaffleNumberRepository,
+	userRepo domain.UserRepository,
+	auditRepo domain.AuditLogRepository,
+	lockService *redis.LockService,
+	wsHub *websocket.Hub,
+	canceller RaffleCanceller,
+	mailer DrawPostponedMailer,
+	logger *logger.Logger,
+) *EnforceMinimumSalesUseCase {
+	return &EnforceMinimumSalesUseCase{
+		raffleRepo:       raffleRepo,
+		raffleNumberRepo: raffleNumberRepo,
+		userRepo:         userRepo,
+		auditRepo:        auditRepo,
+		lockService:      lockService,
+		wsHub:            wsHub,
+		canceller:        canceller,
+		mailer:           mailer,
+		logger:           logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *EnforceMinimumSalesUseCase) Execute(ctx context.Context) (*EnforceMinimumSalesOutput, error) {
+	raffles, err := uc.raffleRepo.FindBelowMinimumSales(time.Now(), minimumSalesBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &EnforceMinimumSalesOutput{}
+	for _, raffle := range raffles {
+		postponed, cancelled, err := uc.processRaffle(ctx, raffle.ID)
+		if err != nil {
+			uc.logger.Error("Error enforcing raffle minimum sales",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Error(err))
+			output.Skipped++
+			continue
+		}
+
+		switch {
+		case postponed:
+			output.Postponed++
+		case cancelled:
+			output.Cancelled++
+		default:
+			output.Skipped++
+		}
+	}
+
+	return output, nil
+}
+
+// processRaffle pospone o cancela un sorteo que no alcanzó su mínimo de ventas
+func (uc *EnforceMinimumSalesUseCase) processRaffle(ctx context.Context, raffleID int64) (postponed bool, cancelled bool, err error) {
+	// 1. Buscar la rifa para obtener su UUID
+	raffle, err := uc.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return false, false, err
+	}
+
+	// 2. Lock distribuido: excluye también al job de sorteos programados
+	lock, err := uc.lockService.AcquireLock(ctx, redis.DrawLockKey(raffle.UUID.String()), minimumSalesLockTTL)
+	if err != nil {
+		if stderrors.Is(err, redis.ErrLockNotAcquired) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	defer lock.Release(ctx)
+
+	// 3. Releer bajo el lock: pudieron venderse números u otra réplica pudo procesarla
+	raffle, err = uc.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return false, false, err
+	}
+
+	// 4. Contar los vendidos en raffle_numbers: raffles.sold_count puede estar desactualizado
+	// y la cancelación reembolsa a todos los compradores sin vuelta atrás
+	soldCount, err := uc.raffleNumberRepo.CountByStatus(raffle.ID, domain.RaffleNumberStatusSold)
+	if err != nil {
+		return false, false, err
+	}
+
+	now := time.Now()
+	if !raffle.IsMinimumSalesDue(now, soldCount) {
+		return false, false, nil
+	}
+
+	reason := fmt.Sprintf("Se vendieron %d números y el mínimo requerido era %d", soldCount, *raffle.MinSoldCount)
+
+	// 5. Posponer una sola vez si el organizador lo configuró
+	if raffle.CanExtendForMinimumSales() {
+		postponed, err = uc.postpone(raffle, soldCount, now, reason)
+		return postponed, false, err
+	}
+
+	// 6. Cancelar con reembolsos
+	if err := uc.canceller.CancelAutomatically(ctx, raffle.ID, reason); err != nil {
+		return false, false, err
+	}
+
+	uc.wsHub.BroadcastRaffleCancelled(raffle.UUID.String(), reason)
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleCancelled).
+		WithEntity("raffle", raffle.ID).
+		WithSeverity(domain.AuditSeverityWarning).
+		WithDescription(fmt.Sprintf("Sorteo cancelado por ventas insuficientes: %s", reason)).
+		WithMetadata(map[string]interface{}{
+			"sold_count":     soldCount,
+			"min_sold_count": *raffle.MinSoldCount,
+			"draw_date":      raffle.DrawDate,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return false, true, nil
+}
+
+// postpone mueve la fecha del sorteo los días configurados y avisa a compradores y organizador
+func (uc *EnforceMinimumSalesUseCase) postpone(raffle *domain.Raffle, soldCount int64, now time.Time, reason string) (bool, error) {
+	previousDrawDate := raffle.DrawDate
+	if err := raffle.ExtendForMinimumSales(now); err != nil {
+		return false, err
+	}
+
+	extended, err := uc.raffleRepo.ExtendDrawDate(raffle)
+	if err != nil || !extended {
+		return false, err
+	}
+
+	uc.wsHub.BroadcastDrawPostponed(raffle.UUID.String(), raffle.DrawDate, reason)
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRafflePostponed).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(fmt.Sprintf("Sorteo pospuesto por ventas insuficientes: %s", reason)).
+		WithMetadata(map[string]interface{}{
+			"sold_count":         soldCount,
+			"min_sold_count":     *raffle.MinSoldCount,
+			"previous_draw_date": previousDrawDate,
+			"draw_date":          raffle.DrawDate,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	uc.notifyPostponed(raffle, reason)
+
+	uc.logger.Info("Raffle draw postponed for minimum sales",
+		logger.Int64("raffle_id", raffle.ID),
+		logger.Int64("sold_count", soldCount),
+		logger.Int("min_sold_count", *raffle.MinSoldCount))
+
+	return true, nil
+}
+
+// notifyPostponed envía el aviso de postergación a los compradores y al organizador
+// Los errores de envío se registran sin afectar la postergación
+func (uc *EnforceMinimumSalesUseCase) notifyPostponed(raffle *domain.Raffle, reason string) {
+	if uc.mailer == nil {
+		return
+	}
+
+	numbers, err := uc.raffleNumberRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		uc.logger.Error("Error getting raffle buyers for postponement notice",
+			logger.Int64("raffle_id", raffle.ID),
+			logger.Error(err))
+		return
+	}
+
+	recipients := map[int64]bool{raffle.UserID: true}
+	for _, num := range numbers {
+		if num.Status == domain.RaffleNumberStatusSold && num.UserID != nil {
+			recipients[*num.UserID] = true
+		}
+	}
+
+	for userID := range recipients {
+		user, err := uc.userRepo.FindByID(userID)
+		if err != nil {
+			uc.logger.Warn("Error finding user for postponement notice",
+				logger.Int64("user_id", userID),
+				logger.Error(err))
+			continue
+		}
+
+		if err := uc.mailer.SendDrawPostponedEmail(user.Email, raffle.Title, raffle.UUID.String(), raffle.DrawDate, reason); err != nil {
+			uc.logger.Error("Error sending draw postponed email",
+				logger.Int64("raffle_id", raffle.ID),
+				logger.Int64("user_id", userID),
+				logger.Error(err))
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package raffle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// PricingRuleInput regla de precio recibida al crear o configurar un sorteo
+type PricingRuleInput struct {
+	Type            domain.PricingRuleType
+	Name            string
+	Quantity        int // Números del paquete o mínimo para el descuento (anticipado: 1 por defecto)
+	BundlePrice     *decimal.Decimal
+	DiscountPercent *decimal.Decimal
+	StartsAt        *time.Time
+	EndsAt          *time.Time
+	Active          *bool // Por defecto activa
+}
+
+// buildPricingRules construye y valida las reglas de precio contra el precio por número del sorteo
+func buildPricingRules(inputs []PricingRuleInput, pricePerNumber decimal.Decimal) ([]*domain.PricingRule, error) {
+	now := time.Now()
+	rules := make([]*domain.PricingRule, 0, len(inputs))
+	for _, input := range inputs {
+		rule := &domain.PricingRule{
+			Type:            input.Type,
+			Name:            input.Name,
+			Quantity:        input.Quantity,
+			BundlePrice:     input.BundlePrice,
+			DiscountPercent: input.DiscountPercent,
+			StartsAt:        input.StartsAt,
+			EndsAt:          input.EndsAt,
+			Active:          input.Active == nil || *input.Active,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if rule.Type == domain.PricingRuleTypeEarlyBird && rule.Quantity == 0 {
+			rule.Quantity = 1
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := domain.ValidatePricingRules(rules, pricePerNumber); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// SetPricingRulesInput datos de entrada
+type SetPricingRulesInput struct {
+	RaffleID int64
+	UserID   int64
+	UserRole domain.UserRole
+	Rules    []PricingRuleInput // Reemplaza las reglas actuales; vacío elimina todas
+}
+
+// SetPricingRulesUseCase caso de uso para configurar las reglas de precio de un sorteo
+// Las reglas nuevas aplican a las reservas creadas a partir de ahora; las existentes conservan su total
+type SetPricingRulesUseCase struct {
+	raffleRepo      db.RaffleRepository
+	pricingRuleRepo domain.PricingRuleRepository
+	auditRepo       domain.AuditLogRepository
+}
+
+// NewSetPricingRulesUseCase crea una nueva instancia
+func NewSetPricingRulesUseCase(
+	raffleRepo db.RaffleRepository,
+	pricingRuleRepo domain.PricingRuleRepository,
+	auditRepo domain.AuditLogRepository,
+) *SetPricingRulesUseCase {
+	return &SetPricingRulesUseCase{
+		raffleRepo:      raffleRepo,
+		pricingRuleRepo: pricingRuleRepo,
+		auditRepo:       auditRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *SetPricingRulesUseCase) Execute(ctx context.Context, input *SetPricingRulesInput) ([]*domain.PricingRule, error) {
+	// 1. Buscar el sorteo
+	raffle, err := uc.raffleRepo.FindByID(input.RaffleID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrRaffleNotFound
+		}
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	// 2. Verificar permisos (owner o admin)
+	if raffle.UserID != input.UserID && input.UserRole != domain.UserRoleAdmin {
+		return nil, errors.ErrForbidden
+	}
+
+	// 3. Solo mientras el sorteo vende números
+	if raffle.Status != domain.RaffleStatusDraft && raffle.Status != domain.RaffleStatusActive {
+		return nil, errors.New("RAFFLE_CLOSED", "Solo se pueden configurar precios de sorteos en borrador o activos", 400, nil)
+	}
+
+	// 4. Construir y validar las reglas
+	rules, err := buildPricingRules(input.Rules, raffle.PricePerNumber)
+	if err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	// 5. Guardar
+	if err := uc.pricingRuleRepo.ReplaceForRaffle(raffle.ID, rules); err != nil {
+		return nil, err
+	}
+
+	// 6. Audit log
+	auditLog := domain.NewAuditLog(domain.AuditActionRafflePricingSet).
+		WithUser(input.UserID).
+		WithEntity("raffle", raffle.ID).
+		WithDescription(fmt.Sprintf("Reglas de precio actualizadas (%d)", len(rules))).
+		WithMetadata(map[string]interface{}{
+			"pricing_rules": rules,
+		}).
+		Build()
+	_ = uc.auditRepo.Create(auditLog)
+
+	return rules, nil
+}
+
+// GetPricingRulesInput datos de entrada
+type GetPricingRulesInput struct {
+	RaffleID   *int64
+	RaffleUUID *string
+	Quantity   int // Opcional: cotiza esta cantidad de números con las reglas vigentes
+}
+
+// GetPricingRulesOutput resultado
+type GetPricingRulesOutput struct {
+	Raffle *domain.Raffle
+	Rules  []*domain.PricingRule
+	Quote  *domain.PriceBreakdown
+}
+
+// GetPricingRulesUseCase caso de uso público para consultar las reglas vigentes y cotizar una compra
+type GetPricingRulesUseCase struct {
+	raffleRepo      db.RaffleRepository
+	pricingRuleRepo domain.PricingRuleRepository
+}
+
+// NewGetPricingRulesUseCase crea una nueva instancia
+func NewGetPricingRulesUseCase(
+	raffleRepo db.RaffleRepository,
+	pricingRuleRepo domain.PricingRuleRepository,
+) *GetPricingRulesUseCase {
+	return &GetPricingRulesUseCase{
+		raffleRepo:      raffleRepo,
+		pricingRuleRepo: pricingRuleRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetPricingRulesUseCase) Execute(ctx context.Context, input *GetPricingRulesInput) (*GetPricingRulesOutput, error) {
+	if input.RaffleID == nil && input.RaffleUUID == nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	// 1. Buscar el sorteo
+	var raffle *domain.Raffle
+	var err error
+
+	if input.RaffleID != nil {
+		raffle, err = uc.raffleRepo.FindByID(*input.RaffleID)
+	} else {
+		raffle, err = uc.raffleRepo.FindByUUID(*input.RaffleUUID)
+	}
+
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrRaffleNotFound
+		}
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	// 2. Reglas vigentes (las inactivas o fuera de su ventana no se publican)
+	rules, err := uc.pricingRuleRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]*domain.PricingRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.IsActiveAt(now) {
+			active = append(active, rule)
+		}
+	}
+
+	output := &GetPricingRulesOutput{
+		Raffle: raffle,
+		Rules:  active,
+	}
+
+	// 3. Cotización opcional
+	if input.Quantity > 0 {
+		output.Quote = domain.CalculatePrice(raffle.PricePerNumber, input.Quantity, active, now)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,67 @@
+package raffle
+
+import (
+	"github.com/sorteos-platform/backend/internal/domain"
+)
+
+// LoadDrawPrizes obtiene los premios a sortear de una rifa en orden de posición
+// Las rifas sin premios registrados reciben su premio único a partir de los campos Prize*
+func LoadDrawPrizes(prizeRepo domain.RafflePrizeRepository, raffle *domain.Raffle) ([]*domain.RafflePrize, error) {
+	prizes, err := prizeRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prizes) == 0 {
+		prizes = []*domain.RafflePrize{raffle.DefaultPrize()}
+		if err := prizeRepo.ReplaceForRaffle(raffle.ID, prizes); err != nil {
+			return nil, err
+		}
+	}
+
+	raffle.Prizes = prizes
+	return prizes, nil
+}
+
+// DrawWinnersData resume los ganadores por premio para eventos, auditoría y notificaciones
+func DrawWinnersData(winners []*domain.RaffleWinner) []map[string]interface{} {
+	data := make([]map[string]interface{}, 0, len(winners))
+	for _, winner := range winners {
+		item := map[string]interface{}{
+			"position":      winner.Position,
+			"winner_number": winner.Number,
+		}
+		if winner.Prize != nil {
+			item["prize_description"] = winner.Prize.Description
+		}
+		data = append(data, item)
+	}
+	return data
+}
+
+// loadPrizesAndWinners carga los premios y ganadores de las rifas para los DTOs de listado y detalle
+func loadPrizesAndWinners(prizeRepo domain.RafflePrizeRepository, raffles []*domain.Raffle) error {
+	if len(raffles) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(raffles))
+	for _, raffle := range raffles {
+		ids = append(ids, raffle.ID)
+	}
+
+	prizes, err := prizeRepo.FindByRaffleIDs(ids)
+	if err != nil {
+		return err
+	}
+	winners, err := prizeRepo.FindWinnersByRaffleIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	for _, raffle := range raffles {
+		raffle.Prizes = prizes[raffle.ID]
+		raffle.Winners = winners[raffle.ID]
+	}
+	return nil
+}
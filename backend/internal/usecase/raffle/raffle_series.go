@@ -0,0 +1,363 @@
+package raffle
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// raffleSeriesLockTTL duración del lock por serie (la generación crea el sorteo con todos sus números)
+const raffleSeriesLockTTL = 2 * time.Minute
+
+// RaffleSeriesAction cambio de estado solicitado por el organizador
+type RaffleSeriesAction string
+
+const (
+	RaffleSeriesActionPause  RaffleSeriesAction = "pause"
+	RaffleSeriesActionResume RaffleSeriesAction = "resume"
+	RaffleSeriesActionEnd    RaffleSeriesAction = "end"
+)
+
+// findOwnedSeries obtiene una serie del organizador
+func findOwnedSeries(seriesRepo domain.RaffleSeriesRepository, seriesID, userID int64) (*domain.RaffleSeries, error) {
+	series, err := seriesRepo.FindByID(seriesID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("SERIES_NOT_FOUND", "Serie no encontrada", 404, nil)
+		}
+		return nil, err
+	}
+	if series.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return series, nil
+}
+
+// CreateRaffleSeriesInput datos de entrada
+type CreateRaffleSeriesInput struct {
+	UserID          int64
+	TemplateID      int64
+	Name            string
+	Mode            domain.RaffleSeriesMode
+	CronExpression  *string // Requerido con modo cron (ej. "0 18 * * 1": lunes 18:00)
+	Timezone        string  // Por defecto America/Costa_Rica
+	DrawOffsetHours int     // Horas entre la generación y el sorteo
+	AutoPublish     *bool   // Por defecto publica cada sorteo generado
+	MaxOccurrences  *int
+}
+
+// CreateRaffleSeriesUseCase caso de uso para crear una serie recurrente
+// Las series al completarse generan su primer sorteo en la siguiente ejecución del job;
+// las series con cron, en su próxima ejecución programada
+type CreateRaffleSeriesUseCase struct {
+	seriesRepo   domain.RaffleSeriesRepository
+	templateRepo domain.RaffleTemplateRepository
+	auditRepo    domain.AuditLogRepository
+	logger       *logger.Logger
+}
+
+// NewCreateRaffleSeriesUseCase crea una nueva instancia
+func NewCreateRaffleSeriesUseCase(
+	seriesRepo domain.RaffleSeriesRepository,
+	templateRepo domain.RaffleTemplateRepository,
+	auditRepo domain.AuditLogRepository,
+	logger *logger.Logger,
+) *CreateRaffleSeriesUseCase {
+	return &CreateRaffleSeriesUseCase{
+		seriesRepo:   seriesRepo,
+		templateRepo: templateRepo,
+		auditRepo:    auditRepo,
+		logger:       logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateRaffleSeriesUseCase) Execute(ctx context.Context, input *CreateRaffleSeriesInput) (*domain.RaffleSeries, error) {
+	// 1. La plantilla debe ser del organizador
+	template, err := findOwnedTemplate(uc.templateRepo, input.TemplateID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Construir y validar la serie
+	series := domain.NewRaffleSeries(input.UserID, template.ID, input.Name, input.Mode)
+	series.CronExpression = input.CronExpression
+	if input.Timezone != "" {
+		series.Timezone = input.Timezone
+	}
+	series.DrawOffsetHours = input.DrawOffsetHours
+	if input.AutoPublish != nil {
+		series.AutoPublish = *input.AutoPublish
+	}
+	series.MaxOccurrences = input.MaxOccurrences
+
+	if err := series.Validate(); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+	if err := series.ScheduleNextRun(time.Now()); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	// 3. Guardar
+	if err := uc.seriesRepo.Create(series); err != nil {
+		return nil, err
+	}
+
+	// 4. Audit log
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleSeriesUpdated).
+		WithUser(input.UserID).
+		WithEntity("raffle_series", series.ID).
+		WithDescription(fmt.Sprintf("Serie de sorteos creada: %s", series.Name)).
+		WithMetadata(map[string]interface{}{
+			"template_id":       template.ID,
+			"mode":              series.Mode,
+			"cron_expression":   series.CronExpression,
+			"draw_offset_hours": series.DrawOffsetHours,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return series, nil
+}
+
+// UpdateRaffleSeriesInput datos de entrada (los campos nil no se modifican)
+type UpdateRaffleSeriesInput struct {
+	SeriesID        int64
+	UserID          int64
+	Name            *string
+	CronExpression  *string
+	Timezone        *string
+	DrawOffsetHours *int
+	AutoPublish     *bool
+	MaxOccurrences  *int // 0 elimina el límite
+	Action          *RaffleSeriesAction
+}
+
+// UpdateRaffleSeriesUseCase caso de uso para modificar, pausar, reanudar o finalizar una serie
+// Usa el mismo lock que la generación para no pisar un sorteo en curso
+type UpdateRaffleSeriesUseCase struct {
+	seriesRepo  domain.RaffleSeriesRepository
+	auditRepo   domain.AuditLogRepository
+	lockService *redis.LockService
+	logger      *logger.Logger
+}
+
+// NewUpdateRaffleSeriesUseCase crea una nueva instancia
+func NewUpdateRaffleSeriesUseCase(
+	seriesRepo domain.RaffleSeriesRepository,
+	auditRepo domain.AuditLogRepository,
+	lockService *redis.LockService,
+	logger *logger.Logger,
+) *UpdateRaffleSeriesUseCase {
+	return &UpdateRaffleSeriesUseCase{
+		seriesRepo:  seriesRepo,
+		auditRepo:   auditRepo,
+		lockService: lockService,
+		logger:      logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateRaffleSeriesUseCase) Execute(ctx context.Context, input *UpdateRaffleSeriesInput) (*domain.RaffleSeries, error) {
+	series, err := findOwnedSeries(uc.seriesRepo, input.SeriesID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Lock de la serie
+	lock, err := uc.lockService.AcquireLock(ctx, redis.RaffleSeriesLockKey(series.UUID.String()), raffleSeriesLockTTL)
+	if err != nil {
+		if stderrors.Is(err, redis.ErrLockNotAcquired) {
+			return nil, errors.New("SERIES_BUSY", "La serie está generando un sorteo, intenta de nuevo en unos segundos", 409, nil)
+		}
+		return nil, err
+	}
+	defer lock.Release(ctx)
+
+	// 2. Releer bajo el lock
+	series, err = uc.seriesRepo.FindByID(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if series.Status == domain.RaffleSeriesStatusEnded {
+		return nil, errors.New("SERIES_ENDED", "La serie ya finalizó", 400, nil)
+	}
+
+	// 3. Aplicar cambios
+	now := time.Now()
+	if input.Name != nil {
+		series.Name = *input.Name
+	}
+	if input.CronExpression != nil {
+		if series.Mode != domain.RaffleSeriesModeCron {
+			return nil, errors.New("VALIDATION_FAILED", "Solo las series con cron admiten expresión cron", 400, nil)
+		}
+		series.CronExpression = input.CronExpression
+	}
+	if input.Timezone != nil {
+		series.Timezone = *input.Timezone
+	}
+	if input.DrawOffsetHours != nil {
+		series.DrawOffsetHours = *input.DrawOffsetHours
+	}
+	if input.AutoPublish != nil {
+		series.AutoPublish = *input.AutoPublish
+	}
+	if input.MaxOccurrences != nil {
+		if *input.MaxOccurrences == 0 {
+			series.MaxOccurrences = nil
+		} else {
+			if *input.MaxOccurrences < series.Occurrences {
+				return nil, errors.New("VALIDATION_FAILED", fmt.Sprintf("La serie ya generó %d sorteos", series.Occurrences), 400, nil)
+			}
+			maxOccurrences := *input.MaxOccurrences
+			series.MaxOccurrences = &maxOccurrences
+		}
+	}
+
+	if err := series.Validate(); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	if input.Action != nil {
+		switch *input.Action {
+		case RaffleSeriesActionPause:
+			err = series.Pause()
+		case RaffleSeriesActionResume:
+			err = series.Resume(now)
+		case RaffleSeriesActionEnd:
+			series.End(now)
+		default:
+			err = fmt.Errorf("acción inválida: %s", *input.Action)
+		}
+		if err != nil {
+			return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+		}
+	}
+
+	if series.Status == domain.RaffleSeriesStatusActive && series.HasReachedMaxOccurrences() {
+		series.End(now)
+	}
+
+	// Recalcular la próxima ejecución con la nueva expresión o zona horaria
+	if (input.CronExpression != nil || input.Timezone != nil) && series.Status == domain.RaffleSeriesStatusActive {
+		if err := series.ScheduleNextRun(now); err != nil {
+			return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+		}
+	}
+	series.UpdatedAt = now
+
+	// 4. Guardar
+	if err := uc.seriesRepo.Update(series); err != nil {
+		return nil, err
+	}
+
+	// 5. Audit log
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleSeriesUpdated).
+		WithUser(input.UserID).
+		WithEntity("raffle_series", series.ID).
+		WithDescription(fmt.Sprintf("Serie de sorteos actualizada: %s", series.Name)).
+		WithMetadata(map[string]interface{}{
+			"status":            series.Status,
+			"action":            input.Action,
+			"cron_expression":   series.CronExpression,
+			"draw_offset_hours": series.DrawOffsetHours,
+			"max_occurrences":   series.MaxOccurrences,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return series, nil
+}
+
+// ListRaffleSeriesUseCase caso de uso para listar las series del organizador
+type ListRaffleSeriesUseCase struct {
+	seriesRepo domain.RaffleSeriesRepository
+}
+
+// NewListRaffleSeriesUseCase crea una nueva instancia
+func NewListRaffleSeriesUseCase(seriesRepo domain.RaffleSeriesRepository) *ListRaffleSeriesUseCase {
+	return &ListRaffleSeriesUseCase{
+		seriesRepo: seriesRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListRaffleSeriesUseCase) Execute(ctx context.Context, userID int64) ([]*domain.RaffleSeries, error) {
+	return uc.seriesRepo.FindByUserID(userID)
+}
+
+// GetRaffleSeriesStatsInput datos de entrada
+type GetRaffleSeriesStatsInput struct {
+	SeriesID int64
+	UserID   int64
+	UserRole domain.UserRole
+}
+
+// GetRaffleSeriesStatsOutput serie con sus sorteos y estadísticas
+type GetRaffleSeriesStatsOutput struct {
+	Series   *domain.RaffleSeries
+	Template *domain.RaffleTemplate // nil si la plantilla fue eliminada
+	Raffles  []*domain.RaffleSeriesEntry
+	Stats    *domain.RaffleSeriesStats
+}
+
+// GetRaffleSeriesStatsUseCase caso de uso para consultar las estadísticas de una serie (owner o admin)
+type GetRaffleSeriesStatsUseCase struct {
+	seriesRepo   domain.RaffleSeriesRepository
+	templateRepo domain.RaffleTemplateRepository
+}
+
+// NewGetRaffleSeriesStatsUseCase crea una nueva instancia
+func NewGetRaffleSeriesStatsUseCase(
+	seriesRepo domain.RaffleSeriesRepository,
+	templateRepo domain.RaffleTemplateRepository,
+) *GetRaffleSeriesStatsUseCase {
+	return &GetRaffleSeriesStatsUseCase{
+		seriesRepo:   seriesRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetRaffleSeriesStatsUseCase) Execute(ctx context.Context, input *GetRaffleSeriesStatsInput) (*GetRaffleSeriesStatsOutput, error) {
+	series, err := uc.seriesRepo.FindByID(input.SeriesID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("SERIES_NOT_FOUND", "Serie no encontrada", 404, nil)
+		}
+		return nil, err
+	}
+	if series.UserID != input.UserID && input.UserRole != domain.UserRoleAdmin {
+		return nil, errors.ErrForbidden
+	}
+
+	entries, err := uc.seriesRepo.FindEntries(series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetRaffleSeriesStatsOutput{
+		Series:  series,
+		Raffles: entries,
+		Stats:   domain.SummarizeRaffleSeries(entries),
+	}
+
+	template, err := uc.templateRepo.FindByID(series.TemplateID)
+	if err != nil && err != errors.ErrNotFound {
+		return nil, err
+	}
+	output.Template = template
+
+	return output, nil
+}
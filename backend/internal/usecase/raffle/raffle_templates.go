@@ -0,0 +1,542 @@
+package raffle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RaffleTemplateInput datos del sorteo guardados en una plantilla
+type RaffleTemplateInput struct {
+	Title       string
+	Description string
+	CategoryID  *int64
+
+	PricePerNumber decimal.Decimal
+	TotalNumbers   int
+	MinNumber      int // Si MinNumber y MaxNumber son 0 se usa 0..TotalNumbers-1
+	MaxNumber      int
+
+	DrawMethod         domain.DrawMethod
+	UnsoldWinnerPolicy *domain.UnsoldWinnerPolicy
+
+	PrizeType        domain.PrizeType
+	PrizeAmount      *decimal.Decimal
+	PrizeDescription *string
+	Prizes           []RafflePrizeInput
+
+	PricingRules []domain.TemplatePricingRule // Ventanas en horas relativas a la creación del sorteo
+}
+
+// applyTemplateInput copia los datos del input a la plantilla
+func applyTemplateInput(template *domain.RaffleTemplate, input *RaffleTemplateInput) error {
+	template.Title = input.Title
+	template.Description = input.Description
+	template.CategoryID = input.CategoryID
+	template.PricePerNumber = input.PricePerNumber
+	template.TotalNumbers = input.TotalNumbers
+	template.MinNumber = input.MinNumber
+	template.MaxNumber = input.MaxNumber
+	if input.MinNumber == 0 && input.MaxNumber == 0 {
+		template.MaxNumber = input.TotalNumbers - 1
+	}
+	if input.DrawMethod != "" {
+		template.DrawMethod = input.DrawMethod
+	}
+	template.UnsoldWinnerPolicy = input.UnsoldWinnerPolicy
+	if input.PrizeType != "" {
+		template.PrizeType = input.PrizeType
+	}
+	template.PrizeAmount = input.PrizeAmount
+	template.PrizeDescription = input.PrizeDescription
+
+	prizes := make([]domain.TemplatePrize, 0, len(input.Prizes))
+	for _, prize := range input.Prizes {
+		prizeType := prize.PrizeType
+		if prizeType == "" {
+			prizeType = domain.PrizeTypePhysical
+		}
+		prizes = append(prizes, domain.TemplatePrize{
+			Description: prize.Description,
+			PrizeType:   prizeType,
+			Value:       prize.Value,
+			ImageURL:    prize.ImageURL,
+		})
+	}
+	if err := template.SetPrizes(prizes); err != nil {
+		return err
+	}
+
+	rules := make([]domain.TemplatePricingRule, 0, len(input.PricingRules))
+	for _, rule := range input.PricingRules {
+		if rule.Type == domain.PricingRuleTypeEarlyBird && rule.Quantity == 0 {
+			rule.Quantity = 1
+		}
+		rules = append(rules, rule)
+	}
+	return template.SetPricingRules(rules)
+}
+
+// raffleInputFromTemplate construye el input de creación de un sorteo a partir de la plantilla
+// Las reglas de precio con ventana se fijan relativas a createdAt
+func raffleInputFromTemplate(template *domain.RaffleTemplate, userID int64, drawDate, createdAt time.Time) (*CreateRaffleInput, error) {
+	input := &CreateRaffleInput{
+		UserID:           userID,
+		Title:            template.Title,
+		Description:      template.Description,
+		CategoryID:       template.CategoryID,
+		PricePerNumber:   template.PricePerNumber,
+		TotalNumbers:     template.TotalNumbers,
+		MinNumber:        template.MinNumber,
+		MaxNumber:        template.MaxNumber,
+		DrawDate:         drawDate,
+		DrawMethod:       template.DrawMethod,
+		PrizeType:        template.PrizeType,
+		PrizeAmount:      template.PrizeAmount,
+		PrizeDescription: template.PrizeDescription,
+
+		// La regla rollover requiere serie: los sorteos creados manualmente desde la plantilla la rechazan
+		UnsoldWinnerPolicy: template.UnsoldWinnerPolicy,
+	}
+
+	prizes, err := template.PrizeList()
+	if err != nil {
+		return nil, err
+	}
+	for _, prize := range prizes {
+		input.Prizes = append(input.Prizes, RafflePrizeInput{
+			Description: prize.Description,
+			PrizeType:   prize.PrizeType,
+			Value:       prize.Value,
+			ImageURL:    prize.ImageURL,
+		})
+	}
+
+	rules, err := template.PricingRulesAt(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		active := rule.Active
+		input.PricingRules = append(input.PricingRules, PricingRuleInput{
+			Type:            rule.Type,
+			Name:            rule.Name,
+			Quantity:        rule.Quantity,
+			BundlePrice:     rule.BundlePrice,
+			DiscountPercent: rule.DiscountPercent,
+			StartsAt:        rule.StartsAt,
+			EndsAt:          rule.EndsAt,
+			Active:          &active,
+		})
+	}
+
+	return input, nil
+}
+
+// copyTemplateImages asocia las imágenes de la plantilla al sorteo creado
+func copyTemplateImages(imageRepo db.RaffleImageRepository, template *domain.RaffleTemplate, raffleID int64) error {
+	images, err := template.ImageList()
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if err := imageRepo.Create(image.RaffleImage(raffleID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findOwnedTemplate obtiene una plantilla del organizador
+func findOwnedTemplate(templateRepo domain.RaffleTemplateRepository, templateID, userID int64) (*domain.RaffleTemplate, error) {
+	template, err := templateRepo.FindByID(templateID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.New("TEMPLATE_NOT_FOUND", "Plantilla no encontrada", 404, nil)
+		}
+		return nil, err
+	}
+	if template.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return template, nil
+}
+
+// CreateRaffleTemplateInput datos de entrada
+// Con FromRaffleID la plantilla se copia de un sorteo existente del organizador (incluidas sus imágenes);
+// sin él se usan los datos de Template
+type CreateRaffleTemplateInput struct {
+	UserID       int64
+	Name         string
+	FromRaffleID *int64
+	Template     *RaffleTemplateInput
+}
+
+// CreateRaffleTemplateUseCase caso de uso para guardar una plantilla de sorteo
+type CreateRaffleTemplateUseCase struct {
+	templateRepo    domain.RaffleTemplateRepository
+	raffleRepo      db.RaffleRepository
+	raffleImageRepo db.RaffleImageRepository
+	prizeRepo       domain.RafflePrizeRepository
+	pricingRuleRepo domain.PricingRuleRepository
+	auditRepo       domain.AuditLogRepository
+	logger          *logger.Logger
+}
+
+// NewCreateRaffleTemplateUseCase crea una nueva instancia
+func NewCreateRaffleTemplateUseCase(
+	templateRepo domain.RaffleTemplateRepository,
+	raffleRepo db.RaffleRepository,
+	raffleImageRepo db.RaffleImageRepository,
+	prizeRepo domain.RafflePrizeRepository,
+	pricingRuleRepo domain.PricingRuleRepository,
+	auditRepo domain.AuditLogRepository,
+	logger *logger.Logger,
+) *CreateRaffleTemplateUseCase {
+	return &CreateRaffleTemplateUseCase{
+		templateRepo:    templateRepo,
+		raffleRepo:      raffleRepo,
+		raffleImageRepo: raffleImageRepo,
+		prizeRepo:       prizeRepo,
+		pricingRuleRepo: pricingRuleRepo,
+		auditRepo:       auditRepo,
+		logger:          logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateRaffleTemplateUseCase) Execute(ctx context.Context, input *CreateRaffleTemplateInput) (*domain.RaffleTemplate, error) {
+	if (input.FromRaffleID == nil) == (input.Template == nil) {
+		return nil, errors.New("VALIDATION_FAILED", "Indica un sorteo de origen o los datos de la plantilla", 400, nil)
+	}
+
+	// 1. Límite de plantillas por organizador
+	count, err := uc.templateRepo.CountByUserID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxRaffleTemplates {
+		return nil, errors.New("TEMPLATE_LIMIT_REACHED", fmt.Sprintf("No puedes tener más de %d plantillas", domain.MaxRaffleTemplates), 400, nil)
+	}
+
+	// 2. Construir la plantilla
+	template := domain.NewRaffleTemplate(input.UserID, input.Name)
+	if input.FromRaffleID != nil {
+		if err := uc.copyFromRaffle(template, *input.FromRaffleID, input.UserID); err != nil {
+			return nil, err
+		}
+	} else if err := applyTemplateInput(template, input.Template); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	// 3. Guardar
+	if err := uc.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+
+	// 4. Audit log
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleTemplateSaved).
+		WithUser(input.UserID).
+		WithEntity("raffle_template", template.ID).
+		WithDescription(fmt.Sprintf("Plantilla de sorteo creada: %s", template.Name)).
+		WithMetadata(map[string]interface{}{
+			"from_raffle_id": input.FromRaffleID,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return template, nil
+}
+
+// copyFromRaffle copia los datos, premios, reglas de precio e imágenes de un sorteo del organizador
+func (uc *CreateRaffleTemplateUseCase) copyFromRaffle(template *domain.RaffleTemplate, raffleID, userID int64) error {
+	raffle, err := uc.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrRaffleNotFound
+		}
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if raffle.UserID != userID {
+		return errors.ErrForbidden
+	}
+
+	if template.Name == "" {
+		template.Name = raffle.Title
+		if len(template.Name) > 100 {
+			template.Name = template.Name[:100]
+		}
+	}
+	template.Title = raffle.Title
+	template.Description = raffle.Description
+	template.CategoryID = raffle.CategoryID
+	template.PricePerNumber = raffle.PricePerNumber
+	template.TotalNumbers = raffle.TotalNumbers
+	template.MinNumber = raffle.MinNumber
+	template.MaxNumber = raffle.MaxNumber
+	template.DrawMethod = raffle.DrawMethod
+	template.UnsoldWinnerPolicy = raffle.UnsoldWinnerPolicy
+	template.PrizeType = raffle.PrizeType
+	template.PrizeDescription = raffle.PrizeDescription
+
+	// El premio acumulado de sorteos anteriores de la serie no forma parte de la plantilla
+	template.PrizeAmount = raffle.WithoutRollover(raffle.PrizeAmount)
+
+	prizes, err := uc.prizeRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return err
+	}
+	templatePrizes := make([]domain.TemplatePrize, 0, len(prizes))
+	for _, prize := range prizes {
+		value := prize.Value
+		if prize.Position == 1 && prize.IsCash() {
+			value = raffle.WithoutRollover(value)
+		}
+		templatePrizes = append(templatePrizes, domain.TemplatePrize{
+			Description: prize.Description,
+			PrizeType:   prize.PrizeType,
+			Value:       value,
+			ImageURL:    prize.ImageURL,
+		})
+	}
+	if err := template.SetPrizes(templatePrizes); err != nil {
+		return err
+	}
+
+	// Las ventanas de las reglas se guardan relativas a la publicación (o creación) del sorteo
+	rules, err := uc.pricingRuleRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return err
+	}
+	reference := raffle.CreatedAt
+	if raffle.PublishedAt != nil {
+		reference = *raffle.PublishedAt
+	}
+	templateRules := make([]domain.TemplatePricingRule, 0, len(rules))
+	for _, rule := range rules {
+		templateRules = append(templateRules, domain.TemplatePricingRuleFrom(rule, reference))
+	}
+	if err := template.SetPricingRules(templateRules); err != nil {
+		return err
+	}
+
+	images, err := uc.raffleImageRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return err
+	}
+	templateImages := make([]domain.TemplateImage, 0, len(images))
+	for _, image := range images {
+		templateImages = append(templateImages, domain.TemplateImageFrom(image))
+	}
+	return template.SetImages(templateImages)
+}
+
+// UpdateRaffleTemplateInput datos de entrada
+// Las imágenes de la plantilla se conservan; los sorteos ya generados no se modifican
+type UpdateRaffleTemplateInput struct {
+	TemplateID int64
+	UserID     int64
+	Name       string
+	Template   *RaffleTemplateInput
+}
+
+// UpdateRaffleTemplateUseCase caso de uso para actualizar una plantilla
+type UpdateRaffleTemplateUseCase struct {
+	templateRepo domain.RaffleTemplateRepository
+	auditRepo    domain.AuditLogRepository
+	logger       *logger.Logger
+}
+
+// NewUpdateRaffleTemplateUseCase crea una nueva instancia
+func NewUpdateRaffleTemplateUseCase(
+	templateRepo domain.RaffleTemplateRepository,
+	auditRepo domain.AuditLogRepository,
+	logger *logger.Logger,
+) *UpdateRaffleTemplateUseCase {
+	return &UpdateRaffleTemplateUseCase{
+		templateRepo: templateRepo,
+		auditRepo:    auditRepo,
+		logger:       logger,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateRaffleTemplateUseCase) Execute(ctx context.Context, input *UpdateRaffleTemplateInput) (*domain.RaffleTemplate, error) {
+	template, err := findOwnedTemplate(uc.templateRepo, input.TemplateID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = input.Name
+	if err := applyTemplateInput(template, input.Template); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+	if err := template.Validate(); err != nil {
+		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
+	}
+
+	template.UpdatedAt = time.Now()
+	if err := uc.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionRaffleTemplateSaved).
+		WithUser(input.UserID).
+		WithEntity("raffle_template", template.ID).
+		WithDescription(fmt.Sprintf("Plantilla de sorteo actualizada: %s", template.Name)).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.logger.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	return template, nil
+}
+
+// DeleteRaffleTemplateUseCase caso de uso para eliminar una plantilla
+// No se puede eliminar una plantilla usada por una serie activa o pausada
+type DeleteRaffleTemplateUseCase struct {
+	templateRepo domain.RaffleTemplateRepository
+	seriesRepo   domain.RaffleSeriesRepository
+}
+
+// NewDeleteRaffleTemplateUseCase crea una nueva instancia
+func NewDeleteRaffleTemplateUseCase(
+	templateRepo domain.RaffleTemplateRepository,
+	seriesRepo domain.RaffleSeriesRepository,
+) *DeleteRaffleTemplateUseCase {
+	return &DeleteRaffleTemplateUseCase{
+		templateRepo: templateRepo,
+		seriesRepo:   seriesRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *DeleteRaffleTemplateUseCase) Execute(ctx context.Context, templateID, userID int64) error {
+	template, err := findOwnedTemplate(uc.templateRepo, templateID, userID)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := uc.seriesRepo.CountActiveByTemplateID(template.ID)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return errors.New("TEMPLATE_IN_USE", "La plantilla está en uso por una serie activa o pausada; finaliza la serie primero", 409, nil)
+	}
+
+	return uc.templateRepo.SoftDelete(template.ID)
+}
+
+// ListRaffleTemplatesUseCase caso de uso para listar las plantillas del organizador
+type ListRaffleTemplatesUseCase struct {
+	templateRepo domain.RaffleTemplateRepository
+}
+
+// NewListRaffleTemplatesUseCase crea una nueva instancia
+func NewListRaffleTemplatesUseCase(templateRepo domain.RaffleTemplateRepository) *ListRaffleTemplatesUseCase {
+	return &ListRaffleTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListRaffleTemplatesUseCase) Execute(ctx context.Context, userID int64) ([]*domain.RaffleTemplate, error) {
+	return uc.templateRepo.FindByUserID(userID)
+}
+
+// GetRaffleTemplateUseCase caso de uso para consultar una plantilla del organizador
+type GetRaffleTemplateUseCase struct {
+	templateRepo domain.RaffleTemplateRepository
+}
+
+// NewGetRaffleTemplateUseCase crea una nueva instancia
+func NewGetRaffleTemplateUseCase(templateRepo domain.RaffleTemplateRepository) *GetRaffleTemplateUseCase {
+	return &GetRaffleTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetRaffleTemplateUseCase) Execute(ctx context.Context, templateID, userID int64) (*domain.RaffleTemplate, error) {
+	return findOwnedTemplate(uc.templateRepo, templateID, userID)
+}
+
+// CreateRaffleFromTemplateInput datos de entrada
+type CreateRaffleFromTemplateInput struct {
+	TemplateID int64
+	UserID     int64
+	DrawDate   time.Time
+	Publish    bool // Publica el sorteo al crearlo
+}
+
+// CreateRaffleFromTemplateUseCase caso de uso para crear un sorteo a partir de una plantilla
+type CreateRaffleFromTemplateUseCase struct {
+	templateRepo    domain.RaffleTemplateRepository
+	raffleImageRepo db.RaffleImageRepository
+	createRaffle    *CreateRaffleUseCase
+	publishRaffle   *PublishRaffleUseCase
+}
+
+// NewCreateRaffleFromTemplateUseCase crea una nueva instancia
+func NewCreateRaffleFromTemplateUseCase(
+	templateRepo domain.RaffleTemplateRepository,
+	raffleImageRepo db.RaffleImageRepository,
+	createRaffle *CreateRaffleUseCase,
+	publishRaffle *PublishRaffleUseCase,
+) *CreateRaffleFromTemplateUseCase {
+	return &CreateRaffleFromTemplateUseCase{
+		templateRepo:    templateRepo,
+		raffleImageRepo: raffleImageRepo,
+		createRaffle:    createRaffle,
+		publishRaffle:   publishRaffle,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateRaffleFromTemplateUseCase) Execute(ctx context.Context, input *CreateRaffleFromTemplateInput) (*domain.Raffle, error) {
+	template, err := findOwnedTemplate(uc.templateRepo, input.TemplateID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	createInput, err := raffleInputFromTemplate(template, input.UserID, input.DrawDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := uc.createRaffle.Execute(ctx, createInput)
+	if err != nil {
+		return nil, errors.New("RAFFLE_CREATION_FAILED", err.Error(), 400, nil)
+	}
+
+	if err := copyTemplateImages(uc.raffleImageRepo, template, output.Raffle.ID); err != nil {
+		return nil, err
+	}
+
+	if !input.Publish {
+		return output.Raffle, nil
+	}
+
+	published, err := uc.publishRaffle.Execute(ctx, &PublishRaffleInput{
+		RaffleID: output.Raffle.ID,
+		UserID:   input.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return published.Raffle, nil
+}
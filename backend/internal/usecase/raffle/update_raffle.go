@@ -21,6 +21,14 @@ type UpdateRaffleInput struct {
 	Description *string
 	DrawDate    *time.Time
 	DrawMethod  *domain.DrawMethod
+
+	// Minimum sales: 0 en MinSoldCount o MinSalesExtensionDays elimina el mínimo o la extensión
+	MinSoldCount          *int
+	MinSalesCutoffHours   *int
+	MinSalesExtensionDays *int
+
+	// UnsoldWinnerPolicy regla si el número oficial no fue vendido; vacía elimina la regla
+	UnsoldWinnerPolicy *domain.UnsoldWinnerPolicy
 }
 
 // UpdateRaffleOutput resultado de la actualización
@@ -84,17 +92,37 @@ func (uc *UpdateRaffleUseCase) Execute(ctx context.Context, input *UpdateRaffleI
 	}
 
 	if input.DrawDate != nil {
-		// Validar que sea futura
-		if input.DrawDate.Before(time.Now()) {
-			return nil, errors.New("INVALID_DRAW_DATE", "La fecha del sorteo debe ser en el futuro", 400, nil)
+		// Solo antes del cierre de ventas: la ronda del beacon comprometida se mueve con la fecha
+		if err := raffle.RescheduleDraw(*input.DrawDate); err != nil {
+			return nil, errors.New("INVALID_DRAW_DATE", err.Error(), 400, nil)
 		}
-		raffle.DrawDate = *input.DrawDate
 	}
 
 	if input.DrawMethod != nil {
 		raffle.DrawMethod = *input.DrawMethod
 	}
 
+	if input.MinSoldCount != nil || input.MinSalesCutoffHours != nil || input.MinSalesExtensionDays != nil {
+		// La extensión es única: después de posponer el sorteo el mínimo queda fijo
+		if raffle.MinSalesExtendedAt != nil {
+			return nil, errors.New("MIN_SALES_LOCKED", "No se puede cambiar el mínimo de ventas de un sorteo ya pospuesto", 400, nil)
+		}
+		applyMinimumSales(raffle, input.MinSoldCount, input.MinSalesCutoffHours, input.MinSalesExtensionDays)
+	}
+
+	if input.UnsoldWinnerPolicy != nil {
+		// Los compradores participan bajo la regla vigente al comprar
+		if raffle.SoldCount > 0 {
+			return nil, errors.New("UNSOLD_POLICY_LOCKED", "No se puede cambiar la regla de número no vendido de un sorteo con ventas", 400, nil)
+		}
+		if *input.UnsoldWinnerPolicy == "" {
+			raffle.UnsoldWinnerPolicy = nil
+		} else {
+			policy := *input.UnsoldWinnerPolicy
+			raffle.UnsoldWinnerPolicy = &policy
+		}
+	}
+
 	// 6. Validar
 	if err := raffle.Validate(); err != nil {
 		return nil, errors.New("VALIDATION_FAILED", err.Error(), 400, nil)
@@ -111,6 +139,11 @@ func (uc *UpdateRaffleUseCase) Execute(ctx context.Context, input *UpdateRaffleI
 		"description": raffle.Description,
 		"draw_date":   raffle.DrawDate,
 		"draw_method": raffle.DrawMethod,
+
+		"min_sold_count":           raffle.MinSoldCount,
+		"min_sales_cutoff_hours":   raffle.MinSalesCutoffHours,
+		"min_sales_extension_days": raffle.MinSalesExtensionDays,
+		"unsold_winner_policy":     raffle.UnsoldWinnerPolicy,
 	}
 
 	auditLog := domain.NewAuditLog(domain.AuditActionRaffleCreated). // Will use a generic action
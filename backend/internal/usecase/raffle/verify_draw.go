@@ -0,0 +1,119 @@
+package raffle
+
+import (
+	"context"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+)
+
+// VerifyDrawInput datos de entrada
+type VerifyDrawInput struct {
+	RaffleID   *int64
+	RaffleUUID *string
+}
+
+// VerifyDrawOutput resultado de la verificación del sorteo
+type VerifyDrawOutput struct {
+	Raffle            *domain.Raffle
+	ServerSeedHash    *string
+	SeedCommittedAt   *time.Time
+	Proof             *domain.DrawProof
+	Verified          bool
+	VerificationError string
+	// CandidatesMatch indica si los números vendidos actuales coinciden con los participantes de la prueba
+	CandidatesMatch bool
+}
+
+// VerifyDrawUseCase caso de uso para verificar públicamente un sorteo commit-reveal
+type VerifyDrawUseCase struct {
+	raffleRepo       db.RaffleRepository
+	raffleNumberRepo db.RaffleNumberRepository
+}
+
+// NewVerifyDrawUseCase crea una nueva instancia
+func NewVerifyDrawUseCase(
+	raffleRepo db.RaffleRepository,
+	raffleNumberRepo db.RaffleNumberRepository,
+) *VerifyDrawUseCase {
+	return &VerifyDrawUseCase{
+		raffleRepo:       raffleRepo,
+		raffleNumberRepo: raffleNumberRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *VerifyDrawUseCase) Execute(ctx context.Context, input *VerifyDrawInput) (*VerifyDrawOutput, error) {
+	if input.RaffleID == nil && input.RaffleUUID == nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	// 1. Buscar el sorteo
+	var raffle *domain.Raffle
+	var err error
+
+	if input.RaffleID != nil {
+		raffle, err = uc.raffleRepo.FindByID(*input.RaffleID)
+	} else {
+		raffle, err = uc.raffleRepo.FindByUUID(*input.RaffleUUID)
+	}
+
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrRaffleNotFound
+		}
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	output := &VerifyDrawOutput{
+		Raffle:          raffle,
+		ServerSeedHash:  raffle.DrawSeedHash,
+		SeedCommittedAt: raffle.DrawSeedCommittedAt,
+	}
+
+	// 2. Antes del sorteo solo se publica el compromiso (hash de la semilla)
+	if !raffle.HasDrawProof() {
+		return output, nil
+	}
+
+	proof, err := domain.ParseDrawProof(raffle.DrawProof)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServer, err)
+	}
+	output.Proof = proof
+
+	// 3. Recalcular el número ganador a partir de la prueba
+	if err := proof.Verify(); err != nil {
+		output.VerificationError = err.Error()
+	} else if raffle.DrawSeedHash != nil && *raffle.DrawSeedHash != proof.ServerSeedHash {
+		output.VerificationError = "el hash publicado de la rifa no coincide con la semilla revelada"
+	} else if first, ok := proof.WinnerNumbers()[1]; ok && raffle.WinnerNumber != nil && *raffle.WinnerNumber != first {
+		output.VerificationError = "el número ganador de la rifa no coincide con la prueba"
+	} else {
+		output.Verified = true
+	}
+
+	// 4. Comparar los participantes de la prueba con los números vendidos registrados
+	// (sin los números que ganaron premios fuera de la prueba)
+	numbers, err := uc.raffleNumberRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	excluded := make(map[string]bool, len(proof.Excluded))
+	for _, number := range proof.Excluded {
+		excluded[number] = true
+	}
+
+	sold := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		if n.Status == domain.RaffleNumberStatusSold && !excluded[n.Number] {
+			sold = append(sold, n.Number)
+		}
+	}
+	output.CandidatesMatch = domain.HashDrawCandidates(domain.SortDrawCandidates(sold)) == proof.CandidatesHash
+
+	return output, nil
+}
@@ -0,0 +1,513 @@
+package refund
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/payment"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+const (
+	// attemptLease tiempo que un proceso reserva un reembolso mientras llama al proveedor
+	attemptLease = 2 * time.Minute
+	// providerStatusCheckInterval espera entre consultas de un reembolso aceptado pero no confirmado
+	providerStatusCheckInterval = 10 * time.Minute
+)
+
+// RequestRefundInput datos de entrada
+type RequestRefundInput struct {
+	PaymentID   string   // UUID del pago
+	Amount      *float64 // nil: reembolsa todo el saldo pendiente de reembolso del pago
+	Reason      string
+	RequestedBy *int64 // Admin que solicita (nil para procesos automáticos)
+}
+
+// ProcessDueOutput resultado de procesar reembolsos vencidos
+type ProcessDueOutput struct {
+	Processed int
+	Succeeded int
+	Failed    int
+}
+
+// RefundProcessor ejecuta reembolsos contra el proveedor de pago con reintentos
+// y acredita a la billetera los pagos hechos con saldo o los reembolsos que el proveedor rechazó
+type RefundProcessor struct {
+	db           *gorm.DB
+	refundRepo   domain.PaymentRefundRepository
+	provider     payment.PaymentProvider
+	providerName string
+	log          *logger.Logger
+}
+
+// NewRefundProcessor crea una nueva instancia
+func NewRefundProcessor(gormDB *gorm.DB, provider payment.PaymentProvider, providerName string, log *logger.Logger) *RefundProcessor {
+	return &RefundProcessor{
+		db:           gormDB,
+		refundRepo:   db.NewPaymentRefundRepository(gormDB, log),
+		provider:     provider,
+		providerName: providerName,
+		log:          log,
+	}
+}
+
+// Request crea el reembolso de un pago y ejecuta el primer intento
+// Si el intento falla el reembolso queda pendiente y lo reintenta ProcessDue
+func (p *RefundProcessor) Request(ctx context.Context, input *RequestRefundInput) (*domain.PaymentRefund, error) {
+	refund, err := p.Prepare(ctx, p.db, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Process(ctx, refund); err != nil {
+		return refund, err
+	}
+
+	return refund, nil
+}
+
+// Prepare crea el reembolso pendiente de un pago dentro de tx sin ejecutarlo
+// Permite registrar el reembolso en la misma transacción que lo origina; lo ejecuta Process o ProcessDue
+func (p *RefundProcessor) Prepare(ctx context.Context, tx *gorm.DB, input *RequestRefundInput) (*domain.PaymentRefund, error) {
+	if input.Reason == "" {
+		return nil, errors.New("VALIDATION_FAILED", "reason is required for refund", 400, nil)
+	}
+
+	paymentID, err := uuid.Parse(input.PaymentID)
+	if err != nil {
+		return nil, errors.New("VALIDATION_FAILED", "invalid payment id", 400, nil)
+	}
+
+	pay, err := db.NewPaymentRepository(tx).FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if pay == nil {
+		return nil, errors.New("PAYMENT_NOT_FOUND", "payment not found", 404, nil)
+	}
+
+	refundable := pay.RefundableAmount()
+	if refundable <= 0 {
+		return nil, errors.New("VALIDATION_FAILED",
+			fmt.Sprintf("cannot refund payment with status %s", pay.Status), 400, nil)
+	}
+
+	amount := refundable
+	if input.Amount != nil {
+		if *input.Amount <= 0 || *input.Amount > refundable {
+			return nil, errors.New("VALIDATION_FAILED",
+				fmt.Sprintf("invalid refund amount: must be between 0 and %.2f", refundable), 400, nil)
+		}
+		amount = *input.Amount
+	}
+
+	// Los pagos hechos con saldo se devuelven a la billetera
+	method := domain.PaymentRefundMethodProvider
+	provider := p.providerName
+	if pay.PaidWithWallet() {
+		method = domain.PaymentRefundMethodWalletCredit
+		provider = domain.PaymentRefundProviderWallet
+	}
+
+	refund := domain.NewPaymentRefund(
+		pay.ID.String(),
+		pay.UserID.String(),
+		pay.RaffleID.String(),
+		decimal.NewFromFloat(amount).Round(2),
+		pay.Currency,
+		method,
+		provider,
+		input.Reason,
+		input.RequestedBy,
+	)
+
+	if err := db.NewPaymentRefundRepository(tx, p.log).Create(refund); err != nil {
+		return nil, err
+	}
+
+	p.log.Info("Refund requested",
+		logger.Int64("refund_id", refund.ID),
+		logger.String("payment_id", refund.PaymentID),
+		logger.String("amount", refund.Amount.String()),
+		logger.String("method", string(refund.Method)))
+
+	return refund, nil
+}
+
+// ProcessDue reintenta reembolsos pendientes y consulta los que el proveedor aún no confirma
+func (p *RefundProcessor) ProcessDue(ctx context.Context, limit int) (*ProcessDueOutput, error) {
+	refunds, err := p.refundRepo.FindDue(time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProcessDueOutput{}
+	for _, refund := range refunds {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := p.Process(ctx, refund); err != nil {
+			p.log.Error("Error processing refund",
+				logger.Int64("refund_id", refund.ID),
+				logger.Error(err))
+			continue
+		}
+
+		output.Processed++
+		switch refund.Status {
+		case domain.PaymentRefundStatusSucceeded:
+			output.Succeeded++
+		case domain.PaymentRefundStatusFailed:
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// Process avanza la máquina de estados del reembolso un paso
+// Solo un proceso a la vez puede trabajar un reembolso (Claim)
+func (p *RefundProcessor) Process(ctx context.Context, refund *domain.PaymentRefund) error {
+	now := time.Now()
+	if !refund.IsDue(now) {
+		return nil
+	}
+
+	claimed, err := p.refundRepo.Claim(refund.ID, now, now.Add(attemptLease))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	// notRefunded: el proveedor confirmó que no devolvió el dinero (única condición para pasar a billetera)
+	notRefunded := false
+	switch {
+	case refund.Status == domain.PaymentRefundStatusProcessing:
+		notRefunded = p.checkProviderStatus(ctx, refund)
+	case refund.Method == domain.PaymentRefundMethodWalletCredit:
+		if err := refund.StartAttempt(); err != nil {
+			return err
+		}
+		return p.creditWallet(refund)
+	default:
+		if err := refund.StartAttempt(); err != nil {
+			return err
+		}
+		notRefunded = p.refundWithProvider(ctx, refund)
+	}
+
+	if refund.Status == domain.PaymentRefundStatusSucceeded {
+		return p.complete(refund)
+	}
+
+	// Rechazado definitivamente por el proveedor, el dinero se devuelve como crédito en billetera
+	// Si el fallo es incierto (timeouts agotados) o el pago ya estaba reembolsado, queda para revisión manual
+	if notRefunded && refund.CanFallbackToWallet() {
+		p.log.Warn("Provider refund failed, falling back to wallet credit",
+			logger.Int64("refund_id", refund.ID),
+			logger.String("payment_id", refund.PaymentID),
+			logger.Int("attempts", refund.Attempts))
+
+		if err := refund.FallbackToWalletCredit(); err != nil {
+			return err
+		}
+		if err := refund.StartAttempt(); err != nil {
+			return err
+		}
+		return p.creditWallet(refund)
+	}
+
+	if refund.Status == domain.PaymentRefundStatusFailed {
+		p.log.Error("Provider refund failed without confirmation, manual action required",
+			logger.Int64("refund_id", refund.ID),
+			logger.String("payment_id", refund.PaymentID),
+			logger.String("amount", refund.Amount.String()),
+			logger.String("last_error", derefString(refund.LastError)))
+	}
+
+	return p.refundRepo.Update(refund)
+}
+
+// refundWithProvider ejecuta el reembolso en Stripe/PayPal
+// Retorna true si es seguro que el proveedor no devolvió el dinero
+func (p *RefundProcessor) refundWithProvider(ctx context.Context, refund *domain.PaymentRefund) bool {
+	if p.provider == nil {
+		refund.RecordFailure("payment provider not configured", time.Now())
+		return true
+	}
+
+	var pay entities.Payment
+	if err := p.db.WithContext(ctx).Where("id = ?", refund.PaymentID).First(&pay).Error; err != nil {
+		refund.RecordFailure(fmt.Sprintf("payment lookup failed: %v", err), time.Now())
+		return true
+	}
+
+	// Clave estable por reembolso: un reintento tras un timeout no reembolsa dos veces
+	ctx = payment.WithIdempotencyKey(ctx, "refund:"+refund.UUID)
+	amountCents := refund.Amount.Mul(decimal.NewFromInt(100)).Round(0).IntPart()
+
+	result, err := p.provider.Refund(ctx, pay.StripePaymentIntentID, amountCents)
+	if err != nil {
+		p.log.Warn("Provider refund attempt failed",
+			logger.Int64("refund_id", refund.ID),
+			logger.Int("attempt", refund.Attempts),
+			logger.Error(err))
+
+		switch {
+		case stderrors.Is(err, payment.ErrAlreadyRefunded):
+			// El dinero ya se devolvió fuera de este reembolso: acreditar la billetera lo devolvería dos veces
+			refund.MarkFailed(fmt.Sprintf("payment already refunded at provider: %v", err))
+			return false
+		case stderrors.Is(err, payment.ErrRefundRejected):
+			refund.MarkFailed(err.Error())
+			return true
+		}
+
+		refund.RecordFailure(err.Error(), time.Now())
+		return false
+	}
+
+	return p.applyProviderResult(refund, result)
+}
+
+// checkProviderStatus consulta un reembolso aceptado por el proveedor pero aún no confirmado
+// Retorna true si el proveedor confirmó que el reembolso falló
+func (p *RefundProcessor) checkProviderStatus(ctx context.Context, refund *domain.PaymentRefund) bool {
+	if p.provider == nil || refund.ProviderRefundID == nil {
+		refund.MarkProcessing(derefString(refund.ProviderRefundID), derefString(refund.ProviderStatus), time.Now().Add(providerStatusCheckInterval))
+		return false
+	}
+
+	result, err := p.provider.GetRefund(ctx, *refund.ProviderRefundID)
+	if err != nil {
+		p.log.Warn("Error checking provider refund status",
+			logger.Int64("refund_id", refund.ID),
+			logger.Error(err))
+		refund.MarkProcessing(*refund.ProviderRefundID, derefString(refund.ProviderStatus), time.Now().Add(providerStatusCheckInterval))
+		return false
+	}
+
+	return p.applyProviderResult(refund, result)
+}
+
+// applyProviderResult traduce el estado del proveedor a la máquina de estados
+// Retorna true si el proveedor reporta el reembolso como fallido
+func (p *RefundProcessor) applyProviderResult(refund *domain.PaymentRefund, result *payment.Refund) bool {
+	switch result.Status {
+	case payment.RefundStatusSucceeded:
+		refund.MarkSucceeded(result.ID, result.RawStatus)
+	case payment.RefundStatusFailed:
+		reason := "provider rejected refund"
+		if result.FailureReason != "" {
+			reason = fmt.Sprintf("%s: %s", reason, result.FailureReason)
+		}
+		refund.ProviderRefundID = &result.ID
+		refund.ProviderStatus = &result.RawStatus
+		refund.MarkFailed(reason)
+		return true
+	default:
+		refund.MarkProcessing(result.ID, result.RawStatus, time.Now().Add(providerStatusCheckInterval))
+	}
+	return false
+}
+
+// creditWallet acredita el reembolso al saldo de la billetera del usuario
+func (p *RefundProcessor) creditWallet(refund *domain.PaymentRefund) error {
+	idempotencyKey := "refund:" + refund.UUID
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		walletRepo := db.NewWalletRepository(tx, p.log)
+		transactionRepo := db.NewWalletTransactionRepository(tx, p.log)
+
+		// Un reintento tras un fallo al guardar el reembolso no acredita dos veces
+		if existing, err := transactionRepo.FindByIdempotencyKey(idempotencyKey); err == nil {
+			refund.WalletTransactionID = &existing.ID
+			refund.MarkSucceeded("", string(existing.Status))
+			return p.applyToPayment(tx, refund)
+		} else if err != errors.ErrNotFound {
+			return err
+		}
+
+		user, err := db.NewUserRepository(tx).FindByUUID(refund.UserID)
+		if err != nil {
+			return err
+		}
+
+		wallet, err := walletRepo.FindByUserID(user.ID)
+		if err != nil {
+			return err
+		}
+		if err := walletRepo.Lock(wallet.ID); err != nil {
+			return err
+		}
+		// Releer con el lock tomado para partir del saldo vigente
+		if wallet, err = walletRepo.FindByID(wallet.ID); err != nil {
+			return err
+		}
+
+		balanceBefore := wallet.BalanceAvailable
+		if err := wallet.Credit(refund.Amount); err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+
+		now := time.Now()
+		referenceType := "payment_refund"
+		notes := refund.Reason
+		transaction := &domain.WalletTransaction{
+			UUID:           uuid.New().String(),
+			WalletID:       wallet.ID,
+			UserID:         user.ID,
+			Type:           domain.TransactionTypeRefund,
+			Amount:         refund.Amount,
+			Status:         domain.TransactionStatusCompleted,
+			BalanceBefore:  balanceBefore,
+			BalanceAfter:   wallet.BalanceAvailable,
+			ReferenceType:  &referenceType,
+			ReferenceID:    &refund.ID,
+			IdempotencyKey: idempotencyKey,
+			Notes:          &notes,
+			CreatedAt:      now,
+			CompletedAt:    &now,
+		}
+		if err := transaction.Validate(); err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+
+		if err := walletRepo.Update(wallet); err != nil {
+			return err
+		}
+		if err := transactionRepo.Create(transaction); err != nil {
+			return err
+		}
+
+		refund.WalletTransactionID = &transaction.ID
+		refund.MarkSucceeded("", string(transaction.Status))
+		return p.applyToPayment(tx, refund)
+	})
+
+	if err != nil {
+		p.log.Warn("Wallet refund attempt failed",
+			logger.Int64("refund_id", refund.ID),
+			logger.Int("attempt", refund.Attempts),
+			logger.Error(err))
+
+		refund.Status = domain.PaymentRefundStatusPending
+		refund.CompletedAt = nil
+		refund.RecordFailure(err.Error(), time.Now())
+		if refund.Status == domain.PaymentRefundStatusFailed {
+			p.log.Error("Wallet refund failed permanently, manual action required",
+				logger.Int64("refund_id", refund.ID),
+				logger.String("payment_id", refund.PaymentID),
+				logger.String("amount", refund.Amount.String()))
+		}
+		return p.refundRepo.Update(refund)
+	}
+
+	p.log.Info("Refund credited to wallet",
+		logger.Int64("refund_id", refund.ID),
+		logger.String("payment_id", refund.PaymentID),
+		logger.String("amount", refund.Amount.String()))
+
+	return nil
+}
+
+// complete persiste un reembolso exitoso en el proveedor junto con el pago
+func (p *RefundProcessor) complete(refund *domain.PaymentRefund) error {
+	if err := p.db.Transaction(func(tx *gorm.DB) error {
+		return p.applyToPayment(tx, refund)
+	}); err != nil {
+		return err
+	}
+
+	p.log.Info("Refund completed at provider",
+		logger.Int64("refund_id", refund.ID),
+		logger.String("payment_id", refund.PaymentID),
+		logger.String("provider", refund.Provider),
+		logger.String("provider_refund_id", derefString(refund.ProviderRefundID)),
+		logger.String("amount", refund.Amount.String()))
+
+	return nil
+}
+
+// applyToPayment acumula el monto reembolsado en el pago y guarda el reembolso en la misma transacción
+func (p *RefundProcessor) applyToPayment(tx *gorm.DB, refund *domain.PaymentRefund) error {
+	paymentID, err := uuid.Parse(refund.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	paymentRepo := db.NewPaymentRepository(tx)
+	pay, err := paymentRepo.FindByID(context.Background(), paymentID)
+	if err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+	if pay == nil {
+		return errors.New("PAYMENT_NOT_FOUND", "payment not found", 404, nil)
+	}
+
+	amount, _ := refund.Amount.Float64()
+	if err := pay.ApplyRefund(amount); err != nil {
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+	if err := paymentRepo.Update(context.Background(), pay); err != nil {
+		return errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	if err := p.postRefund(tx, refund); err != nil {
+		return err
+	}
+
+	return db.NewPaymentRefundRepository(tx, p.log).Update(refund)
+}
+
+// postRefund registra el asiento contable del reembolso: sale de la cuenta de devoluciones
+// hacia el procesador (reembolso al medio de pago) o hacia el saldo del usuario (crédito en billetera)
+func (p *RefundProcessor) postRefund(tx *gorm.DB, refund *domain.PaymentRefund) error {
+	entry := domain.NewJournalEntry(domain.JournalEntryRefund, "refund:"+refund.UUID, strings.ToUpper(refund.Currency)).
+		WithReference("payment_refund", refund.ID).
+		WithDescription(refund.Reason)
+
+	destination := domain.PlatformAccount(domain.LedgerAccountProcessorClearing)
+	if refund.Method == domain.PaymentRefundMethodWalletCredit {
+		user, err := db.NewUserRepository(tx).FindByUUID(refund.UserID)
+		if err != nil {
+			return err
+		}
+		wallet, err := db.NewWalletRepository(tx, p.log).FindByUserID(user.ID)
+		if err != nil {
+			return err
+		}
+		// El crédito se registra en la moneda de la billetera (la misma en que se acreditó el saldo)
+		entry.Currency = wallet.Currency
+		destination = domain.UserAvailableAccount(user.ID)
+		if refund.WalletTransactionID != nil {
+			entry.WithWalletTransaction(*refund.WalletTransactionID)
+		}
+	}
+
+	entry.Debit(domain.PlatformAccount(domain.LedgerAccountRefunds), refund.Amount).
+		Credit(destination, refund.Amount)
+
+	return db.NewLedgerRepository(tx, p.log).Post(entry)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,34 @@
+package settlement
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+)
+
+// DefaultPlatformFeePercentage comisión global por defecto si no hay parámetro configurado
+const DefaultPlatformFeePercentage = 10.0
+
+// GlobalPlatformFeePercentage obtiene la comisión global configurada en system_parameters
+func GlobalPlatformFeePercentage(systemParamRepo *db.PostgresSystemParameterRepository) float64 {
+	platformFee, err := systemParamRepo.GetFloat("platform_fee_percentage", DefaultPlatformFeePercentage)
+	if err != nil {
+		return DefaultPlatformFeePercentage
+	}
+	return platformFee
+}
+
+// OrganizerCommission obtiene la comisión efectiva de un organizador
+// Prioridad: commission_override del organizador (incluido 0) > platform_fee_percentage global
+func OrganizerCommission(ctx context.Context, gormDB *gorm.DB, systemParamRepo *db.PostgresSystemParameterRepository, organizerID int64) float64 {
+	defaultFee := GlobalPlatformFeePercentage(systemParamRepo)
+
+	var profile domain.OrganizerProfile
+	if err := gormDB.WithContext(ctx).Where("user_id = ?", organizerID).First(&profile).Error; err != nil {
+		return defaultFee
+	}
+	return profile.GetEffectiveCommission(defaultFee)
+}
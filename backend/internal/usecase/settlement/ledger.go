@@ -0,0 +1,32 @@
+package settlement
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+)
+
+// FeeJournalEntry asiento de la comisión de plataforma de un settlement:
+// se descuenta de la cuenta por pagar del organizador y se reconoce como ingreso
+func FeeJournalEntry(settlement *domain.Settlement) *domain.JournalEntry {
+	fee := decimal.NewFromFloat(settlement.PlatformFee).Round(2)
+
+	return domain.NewJournalEntry(domain.JournalEntrySettlementFee, fmt.Sprintf("settlement:%d:fee", settlement.ID), settlement.Currency).
+		WithReference("settlement", settlement.ID).
+		WithDescription(fmt.Sprintf("Comisión de plataforma (%.2f%%) del sorteo %d", settlement.PlatformFeePercentage, settlement.RaffleID)).
+		Debit(domain.OrganizerPayableAccount(settlement.OrganizerID), fee).
+		Credit(domain.PlatformAccount(domain.LedgerAccountPlatformRevenue), fee)
+}
+
+// PayoutJournalEntry asiento del pago de un settlement al organizador (transferencia desde los fondos de la plataforma)
+// currency es la moneda del settlement, la misma en que se registraron las compras del sorteo
+func PayoutJournalEntry(settlementID, organizerID int64, netPayout float64, currency string) *domain.JournalEntry {
+	amount := decimal.NewFromFloat(netPayout).Round(2)
+
+	return domain.NewJournalEntry(domain.JournalEntrySettlementPayout, fmt.Sprintf("settlement:%d:payout", settlementID), currency).
+		WithReference("settlement", settlementID).
+		Debit(domain.OrganizerPayableAccount(organizerID), amount).
+		Credit(domain.PlatformAccount(domain.LedgerAccountProcessorClearing), amount)
+}
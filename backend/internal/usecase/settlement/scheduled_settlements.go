@@ -0,0 +1,234 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RunOutput resultado de una corrida de liquidaciones programadas
+type RunOutput struct {
+	Organizers  int     // Organizadores con período vencido revisados
+	Batches     int     // Lotes creados
+	Settlements int     // Settlements creados (uno por sorteo)
+	NetPayout   float64 // Total a pagar en los lotes creados
+	Failed      int
+}
+
+// ScheduledSettlements crea liquidaciones de los sorteos completados según el payout_schedule de cada organizador
+// Los sorteos de un mismo período se agrupan en un lote; cada settlement queda pending para aprobación
+type ScheduledSettlements struct {
+	db              *gorm.DB
+	systemParamRepo *db.PostgresSystemParameterRepository
+	auditRepo       domain.AuditLogRepository
+	log             *logger.Logger
+}
+
+// NewScheduledSettlements crea una nueva instancia
+func NewScheduledSettlements(gormDB *gorm.DB, log *logger.Logger) *ScheduledSettlements {
+	return &ScheduledSettlements{
+		db:              gormDB,
+		systemParamRepo: db.NewSystemParameterRepository(gormDB, log),
+		auditRepo:       db.NewAuditLogRepository(gormDB),
+		log:             log,
+	}
+}
+
+// Run crea los lotes de los organizadores cuyo período de pago cerró
+// Es idempotente: los sorteos que ya tienen settlement no se vuelven a liquidar
+func (s *ScheduledSettlements) Run(ctx context.Context, now time.Time) (*RunOutput, error) {
+	output := &RunOutput{}
+
+	enabled, _ := s.systemParamRepo.GetBool("auto_settlement_creation", true)
+	if !enabled {
+		return output, nil
+	}
+
+	var profiles []*domain.OrganizerProfile
+	if err := s.db.WithContext(ctx).
+		Where("payout_schedule IN ?", []domain.PayoutSchedule{domain.PayoutScheduleWeekly, domain.PayoutScheduleMonthly}).
+		Find(&profiles).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrDatabaseError, err)
+	}
+
+	defaultFee := GlobalPlatformFeePercentage(s.systemParamRepo)
+
+	for _, profile := range profiles {
+		cutoff, ok := profile.PayoutCutoff(now)
+		if !ok {
+			continue
+		}
+		output.Organizers++
+
+		batch, err := s.settleOrganizer(ctx, profile, cutoff, profile.GetEffectiveCommission(defaultFee))
+		if err != nil {
+			s.log.Error("Error creating scheduled settlements",
+				logger.Int64("organizer_id", profile.UserID),
+				logger.Error(err))
+			output.Failed++
+			continue
+		}
+		if batch == nil {
+			continue
+		}
+
+		output.Batches++
+		output.Settlements += batch.RaffleCount
+		output.NetPayout += batch.NetPayout
+	}
+
+	return output, nil
+}
+
+// settleOrganizer crea el lote de un organizador con sus sorteos completados antes del corte
+func (s *ScheduledSettlements) settleOrganizer(ctx context.Context, profile *domain.OrganizerProfile, cutoff time.Time, commission float64) (*domain.SettlementBatch, error) {
+	var batch *domain.SettlementBatch
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var raffles []*domain.Raffle
+		if err := tx.
+			Where("user_id = ? AND status = ? AND completed_at IS NOT NULL AND completed_at < ? AND sold_count > 0",
+				profile.UserID, domain.RaffleStatusCompleted, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM settlements st WHERE st.raffle_id = raffles.id)").
+			Order("completed_at ASC").
+			Find(&raffles).Error; err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		if len(raffles) == 0 {
+			return nil
+		}
+
+		// Calcular los settlements; los premios en efectivo se pagan de lo recaudado y no se liquidan al organizador
+		raffleRepo := db.NewRaffleRepository(tx)
+		claimRepo := db.NewPrizeClaimRepository(tx, s.log)
+		settlements := make([]*domain.Settlement, 0, len(raffles))
+		for _, raffle := range raffles {
+			paidRevenue, err := raffleRepo.GetPaidRevenue(raffle.ID)
+			if err != nil {
+				return err
+			}
+			raffle.CalculateRevenue(paidRevenue)
+
+			// Se liquida en la moneda en que se registraron las compras del sorteo
+			currencies, err := raffleRepo.GetPaidCurrencies(raffle.ID)
+			if err != nil {
+				return err
+			}
+			if len(currencies) != 1 {
+				s.log.Error("Raffle payments are not in a single currency, skipping settlement",
+					logger.Int64("raffle_id", raffle.ID),
+					logger.Int64("organizer_id", profile.UserID),
+					logger.Int("currencies", len(currencies)))
+				continue
+			}
+
+			settlement := &domain.Settlement{
+				RaffleID:    raffle.ID,
+				OrganizerID: profile.UserID,
+				Currency:    currencies[0],
+				Status:      domain.SettlementStatusPending,
+			}
+			settlement.CalculateFromRaffle(raffle, commission)
+
+			cashPrizes, err := claimRepo.SumCashPrizes(raffle.ID)
+			if err != nil {
+				return err
+			}
+			prizes, _ := cashPrizes.Float64()
+			if err := settlement.WithholdPrizes(prizes); err != nil {
+				// Premios sin fondos: el sorteo queda sin liquidar para revisión del admin
+				s.log.Error("Raffle cash prizes are not funded by its revenue, skipping settlement",
+					logger.Int64("raffle_id", raffle.ID),
+					logger.Int64("organizer_id", profile.UserID),
+					logger.String("cash_prizes", cashPrizes.String()),
+					logger.Error(err))
+				continue
+			}
+
+			settlements = append(settlements, settlement)
+		}
+
+		if len(settlements) == 0 {
+			return nil
+		}
+
+		batch = &domain.SettlementBatch{
+			OrganizerID:           profile.UserID,
+			PayoutSchedule:        profile.PayoutSchedule,
+			PeriodEnd:             cutoff,
+			PlatformFeePercentage: commission,
+		}
+		if err := tx.Create(batch).Error; err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		settlementRepo := db.NewSettlementRepository(tx, s.log)
+		ledgerRepo := db.NewLedgerRepository(tx, s.log)
+		for _, settlement := range settlements {
+			settlement.BatchID = &batch.ID
+			if err := settlementRepo.Create(settlement); err != nil {
+				return err
+			}
+			// Asiento contable de la comisión (las comisiones en cero no generan asiento)
+			if feeEntry := FeeJournalEntry(settlement); !feeEntry.IsEmpty() {
+				if err := ledgerRepo.Post(feeEntry); err != nil {
+					return err
+				}
+			}
+			batch.Add(settlement)
+		}
+
+		if err := tx.Save(batch).Error; err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		// Acumular el monto pendiente de pago del organizador
+		if err := tx.Model(&domain.OrganizerProfile{}).
+			Where("user_id = ?", profile.UserID).
+			UpdateColumn("pending_payout", gorm.Expr("COALESCE(pending_payout, 0) + ?", batch.NetPayout)).Error; err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, nil
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionSettlementCreated).
+		WithUser(profile.UserID).
+		WithEntity("settlement_batch", batch.ID).
+		WithDescription(fmt.Sprintf("Liquidación %s programada: %d sorteo(s)", profile.PayoutSchedule, batch.RaffleCount)).
+		WithMetadata(map[string]interface{}{
+			"period_end":              batch.PeriodEnd,
+			"raffle_count":            batch.RaffleCount,
+			"gross_revenue":           batch.GrossRevenue,
+			"platform_fee":            batch.PlatformFee,
+			"platform_fee_percentage": commission,
+			"net_payout":              batch.NetPayout,
+		}).
+		Build()
+	if err := s.auditRepo.Create(auditLog); err != nil {
+		s.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	s.log.Info("Scheduled settlement batch created",
+		logger.Int64("batch_id", batch.ID),
+		logger.Int64("organizer_id", profile.UserID),
+		logger.String("payout_schedule", string(profile.PayoutSchedule)),
+		logger.Int("raffle_count", batch.RaffleCount),
+		logger.Float64("net_payout", batch.NetPayout))
+
+	return batch, nil
+}
@@ -2,12 +2,21 @@ package wallet
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/sorteos-platform/backend/internal/domain"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/datatypes"
+)
+
+// Claves de metadata con el desglose de una recarga (las registra el flujo de compra de créditos)
+const (
+	DepositMetadataChargeAmount = "charge_amount" // Monto cobrado al usuario por el procesador
+	DepositMetadataProcessorFee = "processor_fee" // Comisión retenida por el procesador (fija + porcentual)
 )
 
 // AddFundsInput representa los datos de entrada para agregar fondos
@@ -144,6 +153,15 @@ func (uc *AddFundsUseCase) Execute(ctx context.Context, input *AddFundsInput) (*
 		// Esto se manejará mejor con metadata
 	}
 
+	// La metadata conserva el desglose de comisiones para el asiento contable al confirmar
+	if len(input.Metadata) > 0 {
+		metadata, err := json.Marshal(input.Metadata)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrValidationFailed, err)
+		}
+		transaction.Metadata = datatypes.JSON(metadata)
+	}
+
 	// Validar transacción
 	if err := transaction.Validate(); err != nil {
 		return nil, errors.Wrap(errors.ErrValidationFailed, err)
@@ -244,6 +262,11 @@ func (uc *AddFundsUseCase) ConfirmAddFunds(ctx context.Context, transactionID in
 			return err
 		}
 
+		// 8. Registrar el asiento contable de la recarga
+		if err := walletRepo.PostJournalEntry(depositJournalEntry(tx, wallet.Currency)); err != nil {
+			return err
+		}
+
 		uc.logger.Info("Depósito confirmado exitosamente",
 			logger.Int64("tx_id", tx.ID),
 			logger.Int64("wallet_id", wallet.ID),
@@ -253,3 +276,43 @@ func (uc *AddFundsUseCase) ConfirmAddFunds(ctx context.Context, transactionID in
 		return nil
 	})
 }
+
+// depositJournalEntry asiento de una recarga confirmada
+// Con el desglose de la compra se reconocen en el mismo asiento la comisión del procesador
+// y la de la plataforma (diferencia entre lo cobrado y lo acreditado)
+func depositJournalEntry(tx *domain.WalletTransaction, currency string) *domain.JournalEntry {
+	charge, processorFee := depositFees(tx)
+
+	return domain.NewJournalEntry(domain.JournalEntryDeposit, "deposit:"+tx.UUID, currency).
+		WithReference("wallet_transaction", tx.ID).
+		WithWalletTransaction(tx.ID).
+		WithDescription(fmt.Sprintf("Recarga de %s", tx.Amount.String())).
+		Debit(domain.PlatformAccount(domain.LedgerAccountProcessorClearing), charge.Sub(processorFee)).
+		Debit(domain.PlatformAccount(domain.LedgerAccountProcessorFees), processorFee).
+		Credit(domain.UserAvailableAccount(tx.UserID), tx.Amount).
+		Credit(domain.PlatformAccount(domain.LedgerAccountPlatformRevenue), charge.Sub(tx.Amount))
+}
+
+// depositFees lee de la metadata el monto cobrado y la comisión del procesador
+// Sin desglose (o con uno inconsistente) se asume que lo cobrado es igual a lo acreditado
+func depositFees(tx *domain.WalletTransaction) (decimal.Decimal, decimal.Decimal) {
+	charge, processorFee := tx.Amount, decimal.Zero
+
+	var metadata map[string]interface{}
+	if len(tx.Metadata) == 0 || json.Unmarshal(tx.Metadata, &metadata) != nil {
+		return charge, processorFee
+	}
+
+	parsedCharge, err := decimal.NewFromString(fmt.Sprint(metadata[DepositMetadataChargeAmount]))
+	if err != nil || parsedCharge.LessThan(tx.Amount) {
+		return charge, processorFee
+	}
+	charge = parsedCharge.Round(2)
+
+	parsedFee, err := decimal.NewFromString(fmt.Sprint(metadata[DepositMetadataProcessorFee]))
+	if err == nil && parsedFee.GreaterThan(decimal.Zero) && parsedFee.LessThan(charge) {
+		processorFee = parsedFee.Round(2)
+	}
+
+	return charge, processorFee
+}
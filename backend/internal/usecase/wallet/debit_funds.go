@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,6 +10,7 @@ import (
 	"github.com/sorteos-platform/backend/internal/domain"
 	"github.com/sorteos-platform/backend/pkg/errors"
 	"github.com/sorteos-platform/backend/pkg/logger"
+	"gorm.io/datatypes"
 )
 
 // DebitFundsInput representa los datos de entrada para debitar fondos
@@ -125,6 +127,12 @@ func (uc *DebitFundsUseCase) Execute(ctx context.Context, input *DebitFundsInput
 			return err
 		}
 
+		// Releer saldos ya con el lock tomado (otro débito pudo confirmarse mientras esperábamos)
+		wallet, err = walletRepo.FindByID(wallet.ID)
+		if err != nil {
+			return err
+		}
+
 		// 3. Validar que se pueda debitar
 		if err := wallet.CanDebit(input.Amount); err != nil {
 			uc.logger.Warn("Débito rechazado - validación fallida",
@@ -132,6 +140,9 @@ func (uc *DebitFundsUseCase) Execute(ctx context.Context, input *DebitFundsInput
 				logger.String("amount", input.Amount.String()),
 				logger.String("balance", wallet.BalanceAvailable.String()),
 				logger.Error(err))
+			if wallet.IsActive() && !wallet.HasSufficientBalance(input.Amount) {
+				return errors.Wrap(errors.ErrInsufficientBalance, err)
+			}
 			return errors.Wrap(errors.ErrValidationFailed, err)
 		}
 
@@ -159,6 +170,14 @@ func (uc *DebitFundsUseCase) Execute(ctx context.Context, input *DebitFundsInput
 		now := time.Now()
 		transaction.CompletedAt = &now
 
+		if len(input.Metadata) > 0 {
+			metadata, err := json.Marshal(input.Metadata)
+			if err != nil {
+				return errors.Wrap(errors.ErrValidationFailed, err)
+			}
+			transaction.Metadata = datatypes.JSON(metadata)
+		}
+
 		// 6. Validar transacción
 		if err := transaction.Validate(); err != nil {
 			return errors.Wrap(errors.ErrValidationFailed, err)
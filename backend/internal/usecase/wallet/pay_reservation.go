@@ -0,0 +1,293 @@
+package wallet
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ReferenceTypeReservation tipo de referencia de las compras pagadas con saldo
+const ReferenceTypeReservation = "reservation"
+
+// PayReservationInput representa los datos de entrada para pagar una reserva con saldo
+type PayReservationInput struct {
+	ReservationID uuid.UUID
+	UserID        int64
+}
+
+// PayReservationOutput representa los datos de salida
+type PayReservationOutput struct {
+	Reservation *entities.Reservation     `json:"reservation"`
+	Payment     *entities.Payment         `json:"payment"`
+	Transaction *domain.WalletTransaction `json:"transaction"`
+	NewBalance  decimal.Decimal           `json:"new_balance"`
+	AlreadyPaid bool                      `json:"already_paid"` // true si la reserva ya estaba pagada (reintento)
+}
+
+// PayReservationUseCase paga una reserva con el saldo disponible de la billetera
+// El débito, el registro del pago, la confirmación de la reserva y la venta de los números
+// se ejecutan en una sola transacción de base de datos
+type PayReservationUseCase struct {
+	db     *gorm.DB
+	wsHub  *websocket.Hub
+	logger *logger.Logger
+}
+
+// NewPayReservationUseCase crea una nueva instancia del use case
+func NewPayReservationUseCase(gormDB *gorm.DB, wsHub *websocket.Hub, logger *logger.Logger) *PayReservationUseCase {
+	return &PayReservationUseCase{
+		db:     gormDB,
+		wsHub:  wsHub,
+		logger: logger,
+	}
+}
+
+// ReservationPurchaseKey clave de idempotencia del débito de una reserva
+func ReservationPurchaseKey(reservationID uuid.UUID) string {
+	return "reservation:" + reservationID.String() + ":purchase"
+}
+
+// Execute ejecuta el caso de uso de pago de reserva con saldo
+// Es idempotente por reserva: un reintento sobre una reserva ya pagada con saldo devuelve el pago existente
+func (uc *PayReservationUseCase) Execute(ctx context.Context, input *PayReservationInput) (*PayReservationOutput, error) {
+	var output *PayReservationOutput
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Obtener reserva con lock (serializa confirmaciones concurrentes de la misma reserva)
+		var reservation entities.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", input.ReservationID).
+			First(&reservation).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("RESERVATION_NOT_FOUND", "reserva no encontrada", 404, nil)
+			}
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		// 2. Verificar que la reserva pertenezca al usuario
+		user, err := db.NewUserRepository(tx).FindByID(input.UserID)
+		if err != nil {
+			return err
+		}
+		if user.UUID != reservation.UserID.String() {
+			return errors.New("RESERVATION_NOT_FOUND", "reserva no encontrada", 404, nil)
+		}
+
+		paymentRepo := db.NewPaymentRepository(tx)
+		existingPayment, err := paymentRepo.FindByReservationID(ctx, reservation.ID)
+		if err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		// 3. Idempotencia: la reserva ya fue pagada con saldo
+		if reservation.Status == entities.ReservationStatusConfirmed &&
+			existingPayment != nil && existingPayment.PaidWithWallet() {
+			transaction, err := db.NewWalletTransactionRepository(tx, uc.logger).
+				FindByIdempotencyKey(ReservationPurchaseKey(reservation.ID))
+			if err != nil {
+				return err
+			}
+			wallet, err := db.NewWalletRepository(tx, uc.logger).FindByUserID(input.UserID)
+			if err != nil {
+				return err
+			}
+
+			output = &PayReservationOutput{
+				Reservation: &reservation,
+				Payment:     existingPayment,
+				Transaction: transaction,
+				NewBalance:  wallet.BalanceAvailable,
+				AlreadyPaid: true,
+			}
+			return nil
+		}
+
+		// 4. Validar estado y fase de la reserva
+		if err := reservation.CanCheckout(); err != nil {
+			return reservationStateError(err)
+		}
+
+		// Un intento de pago con tarjeta en curso no puede convivir con el pago con saldo
+		if existingPayment != nil &&
+			existingPayment.Status != entities.PaymentStatusFailed &&
+			existingPayment.Status != entities.PaymentStatusCancelled {
+			return errors.New("PAYMENT_ALREADY_EXISTS", "ya existe un pago en curso para esta reserva", 409, nil)
+		}
+
+		// 5. Validar que el sorteo siga vendiendo
+		raffle, err := db.NewRaffleRepository(tx).FindByUUID(reservation.RaffleID.String())
+		if err != nil {
+			return err
+		}
+		if raffle.Status != domain.RaffleStatusActive || raffle.IsSalesClosed() {
+			return errors.New("SALES_CLOSED", "las ventas del sorteo están cerradas", 409, nil)
+		}
+
+		// 6. Debitar saldo (billetera y transacción dentro de la misma unidad de trabajo)
+		walletRepo := db.NewWalletRepository(tx, uc.logger)
+		transactionRepo := db.NewWalletTransactionRepository(tx, uc.logger)
+		debitFunds := NewDebitFundsUseCase(
+			walletRepo,
+			transactionRepo,
+			db.NewUserRepository(tx),
+			db.NewAuditLogRepository(tx),
+			uc.logger,
+		)
+
+		referenceType := ReferenceTypeReservation
+		notes := fmt.Sprintf("Compra de %d número(s) - %s", len(reservation.NumberIDs), raffle.Title)
+		debit, err := debitFunds.Execute(ctx, &DebitFundsInput{
+			UserID:         input.UserID,
+			Amount:         decimal.NewFromFloat(reservation.TotalAmount).Round(2),
+			IdempotencyKey: ReservationPurchaseKey(reservation.ID),
+			ReferenceType:  &referenceType,
+			Notes:          &notes,
+			Metadata: map[string]interface{}{
+				"reservation_id": reservation.ID.String(),
+				"raffle_id":      reservation.RaffleID.String(),
+				"number_ids":     []string(reservation.NumberIDs),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		wallet, err := walletRepo.FindByUserID(input.UserID)
+		if err != nil {
+			return err
+		}
+
+		// Asiento contable: el saldo del comprador pasa a la cuenta por pagar del organizador
+		purchaseEntry := domain.NewJournalEntry(domain.JournalEntryPurchase, ReservationPurchaseKey(reservation.ID), wallet.Currency).
+			WithReference(ReferenceTypeReservation, reservation.ID).
+			WithWalletTransaction(debit.Transaction.ID).
+			WithDescription(notes).
+			Debit(domain.UserAvailableAccount(input.UserID), debit.Transaction.Amount).
+			Credit(domain.OrganizerPayableAccount(raffle.UserID), debit.Transaction.Amount)
+		if err := walletRepo.PostJournalEntry(purchaseEntry); err != nil {
+			return err
+		}
+
+		// 7. Registrar el pago (sin payment intent: se usa la transacción de billetera como referencia)
+		pay, err := entities.NewPayment(
+			reservation.ID,
+			reservation.UserID,
+			reservation.RaffleID,
+			entities.PaymentMethodWallet+":"+debit.Transaction.UUID,
+			"",
+			reservation.TotalAmount,
+			wallet.Currency,
+		)
+		if err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+		if err := pay.MarkAsSucceeded(entities.PaymentMethodWallet); err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+		if err := pay.SetMetadata(entities.PaymentMetadata{
+			NumberCount:    len(reservation.NumberIDs),
+			NumberIDs:      reservation.NumberIDs,
+			RaffleTitle:    raffle.Title,
+			PriceBreakdown: reservation.PriceBreakdown,
+		}); err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+		if err := paymentRepo.Create(ctx, pay); err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		// 8. Confirmar la reserva
+		if err := reservation.Confirm(); err != nil {
+			return reservationStateError(err)
+		}
+		if err := db.NewReservationRepository(tx).Update(ctx, &reservation); err != nil {
+			return errors.Wrap(errors.ErrDatabaseError, err)
+		}
+
+		// 9. Marcar los números como vendidos
+		raffleNumberRepo := db.NewRaffleNumberRepository(tx)
+		for _, numberStr := range reservation.NumberIDs {
+			raffleNumber, err := raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberStr)
+			if err != nil {
+				return err
+			}
+			if err := raffleNumberRepo.MarkAsSold(raffleNumber.ID, input.UserID, int64(reservation.ID.ID())); err != nil {
+				return err
+			}
+		}
+
+		output = &PayReservationOutput{
+			Reservation: &reservation,
+			Payment:     pay,
+			Transaction: debit.Transaction,
+			NewBalance:  debit.NewBalance,
+		}
+		return nil
+	})
+	if err != nil {
+		uc.logger.Error("Error pagando reserva con saldo",
+			logger.String("reservation_id", input.ReservationID.String()),
+			logger.Int64("user_id", input.UserID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	if output.AlreadyPaid {
+		return output, nil
+	}
+
+	// Notificar vía WebSocket que los números se vendieron (después del commit)
+	userIDStr := output.Reservation.UserID.String()
+	for _, numberID := range output.Reservation.NumberIDs {
+		uc.wsHub.BroadcastNumberUpdate(
+			output.Reservation.RaffleID.String(),
+			numberID,
+			"sold",
+			&userIDStr,
+		)
+	}
+	uc.wsHub.NotifyPaymentSucceeded(
+		userIDStr,
+		output.Reservation.RaffleID.String(),
+		output.Reservation.ID.String(),
+		output.Payment.ID.String(),
+		output.Reservation.NumberIDs,
+	)
+
+	uc.logger.Info("Reserva pagada con saldo",
+		logger.String("reservation_id", output.Reservation.ID.String()),
+		logger.String("payment_id", output.Payment.ID.String()),
+		logger.Int64("tx_id", output.Transaction.ID),
+		logger.Int64("user_id", input.UserID),
+		logger.String("new_balance", output.NewBalance.String()))
+
+	return output, nil
+}
+
+// reservationStateError traduce los errores de estado de la reserva a errores de aplicación
+func reservationStateError(err error) error {
+	switch {
+	case stderrors.Is(err, entities.ErrReservationAlreadyPaid):
+		return errors.New("RESERVATION_ALREADY_PAID", "la reserva ya fue pagada", 409, err)
+	case stderrors.Is(err, entities.ErrReservationCancelled):
+		return errors.New("RESERVATION_CANCELLED", "la reserva fue cancelada", 409, err)
+	case stderrors.Is(err, entities.ErrReservationExpired):
+		return errors.New("RESERVATION_EXPIRED", "la reserva expiró", 409, err)
+	case stderrors.Is(err, entities.ErrInvalidReservationPhase):
+		return errors.New("INVALID_RESERVATION_PHASE", "la reserva no está en una fase que permita el pago", 409, err)
+	default:
+		return errors.Wrap(errors.ErrValidationFailed, err)
+	}
+}
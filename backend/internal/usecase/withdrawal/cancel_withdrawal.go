@@ -0,0 +1,84 @@
+package withdrawal
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// CancelWithdrawalInput datos de entrada para cancelar un retiro propio
+type CancelWithdrawalInput struct {
+	UserID         int64
+	WithdrawalUUID string
+}
+
+// CancelWithdrawalUseCase cancela un retiro pendiente de aprobación y libera la retención
+type CancelWithdrawalUseCase struct {
+	db        *gorm.DB
+	auditRepo domain.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewCancelWithdrawalUseCase crea una nueva instancia
+func NewCancelWithdrawalUseCase(gormDB *gorm.DB, log *logger.Logger) *CancelWithdrawalUseCase {
+	return &CancelWithdrawalUseCase{
+		db:        gormDB,
+		auditRepo: db.NewAuditLogRepository(gormDB),
+		log:       log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CancelWithdrawalUseCase) Execute(ctx context.Context, input *CancelWithdrawalInput) (*domain.Withdrawal, error) {
+	var withdrawal *domain.Withdrawal
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+
+		found, err := withdrawalRepo.FindByUUID(input.WithdrawalUUID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return errors.New("WITHDRAWAL_NOT_FOUND", "retiro no encontrado", 404, nil)
+			}
+			return err
+		}
+		if found.UserID != input.UserID {
+			return errors.New("WITHDRAWAL_NOT_FOUND", "retiro no encontrado", 404, nil)
+		}
+
+		withdrawal, err = withdrawalRepo.FindByIDForUpdate(found.ID)
+		if err != nil {
+			return err
+		}
+		if err := withdrawal.Cancel(); err != nil {
+			return errors.New("INVALID_WITHDRAWAL_STATE", err.Error(), 409, err)
+		}
+
+		if err := ReleaseHold(tx, withdrawal, "Retiro cancelado por el usuario", uc.log); err != nil {
+			return err
+		}
+		return withdrawalRepo.Update(withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalCancelled).
+		WithUser(input.UserID).
+		WithEntity("withdrawal", withdrawal.ID).
+		WithDescription("Retiro cancelado por el usuario").
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	uc.log.Info("Withdrawal cancelled by user",
+		logger.Int64("withdrawal_id", withdrawal.ID),
+		logger.Int64("user_id", input.UserID))
+
+	return withdrawal, nil
+}
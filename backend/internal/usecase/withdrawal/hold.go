@@ -0,0 +1,87 @@
+package withdrawal
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ReferenceTypeWithdrawal tipo de referencia de las transacciones de retiro
+const ReferenceTypeWithdrawal = "withdrawal"
+
+// TransactionKey clave de idempotencia de la transacción de un retiro
+func TransactionKey(withdrawal *domain.Withdrawal) string {
+	return fmt.Sprintf("withdrawal:%s", withdrawal.UUID)
+}
+
+// ReleaseHold devuelve al saldo de ganancias el monto retenido por un retiro rechazado o cancelado
+// y marca su transacción como fallida. Debe ejecutarse dentro de la transacción del llamador
+func ReleaseHold(tx *gorm.DB, withdrawal *domain.Withdrawal, reason string, log *logger.Logger) error {
+	entry := JournalEntry(withdrawal, domain.JournalEntryWithdrawalRelease).
+		Debit(domain.UserPendingAccount(withdrawal.UserID), withdrawal.Amount).
+		Credit(domain.UserEarningsAccount(withdrawal.UserID), withdrawal.Amount)
+
+	return updateHold(tx, withdrawal, entry, log, func(wallet *domain.Wallet, transaction *domain.WalletTransaction) error {
+		if err := wallet.ReleaseHeldEarnings(withdrawal.Amount); err != nil {
+			return err
+		}
+		return transaction.MarkAsFailed(reason)
+	})
+}
+
+// SettleHold descuenta definitivamente el monto retenido por un retiro transferido
+// y completa su transacción. Debe ejecutarse dentro de la transacción del llamador
+func SettleHold(tx *gorm.DB, withdrawal *domain.Withdrawal, log *logger.Logger) error {
+	entry := JournalEntry(withdrawal, domain.JournalEntryWithdrawalPayout).
+		Debit(domain.UserPendingAccount(withdrawal.UserID), withdrawal.Amount).
+		Credit(domain.PlatformAccount(domain.LedgerAccountProcessorClearing), withdrawal.Amount)
+
+	return updateHold(tx, withdrawal, entry, log, func(wallet *domain.Wallet, transaction *domain.WalletTransaction) error {
+		if err := wallet.SettleHeldEarnings(withdrawal.Amount); err != nil {
+			return err
+		}
+		return transaction.MarkAsCompleted()
+	})
+}
+
+// JournalEntry crea el asiento contable de una etapa del retiro (hold, release o payout)
+func JournalEntry(withdrawal *domain.Withdrawal, entryType domain.JournalEntryType) *domain.JournalEntry {
+	return domain.NewJournalEntry(entryType, fmt.Sprintf("%s:%s", TransactionKey(withdrawal), entryType), withdrawal.Currency).
+		WithReference(ReferenceTypeWithdrawal, withdrawal.ID)
+}
+
+func updateHold(tx *gorm.DB, withdrawal *domain.Withdrawal, entry *domain.JournalEntry, log *logger.Logger, apply func(*domain.Wallet, *domain.WalletTransaction) error) error {
+	walletRepo := db.NewWalletRepository(tx, log)
+	transactionRepo := db.NewWalletTransactionRepository(tx, log)
+
+	if err := walletRepo.Lock(withdrawal.WalletID); err != nil {
+		return err
+	}
+	wallet, err := walletRepo.FindByID(withdrawal.WalletID)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := transactionRepo.FindByIdempotencyKey(TransactionKey(withdrawal))
+	if err != nil {
+		return err
+	}
+
+	if err := apply(wallet, transaction); err != nil {
+		return errors.New("VALIDATION_FAILED", err.Error(), 400, err)
+	}
+
+	if err := walletRepo.Update(wallet); err != nil {
+		return err
+	}
+	if err := transactionRepo.Update(transaction); err != nil {
+		return err
+	}
+
+	return walletRepo.PostJournalEntry(entry.WithWalletTransaction(transaction.ID))
+}
@@ -0,0 +1,57 @@
+package withdrawal
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// ListWithdrawalsInput datos de entrada para listar los retiros del usuario
+type ListWithdrawalsInput struct {
+	UserID int64
+	Limit  int
+	Offset int
+}
+
+// ListWithdrawalsOutput resultado
+type ListWithdrawalsOutput struct {
+	Withdrawals []*domain.Withdrawal
+	Total       int64
+	Limit       int
+	Offset      int
+}
+
+// ListWithdrawalsUseCase lista el historial de retiros del usuario
+type ListWithdrawalsUseCase struct {
+	withdrawalRepo domain.WithdrawalRepository
+	log            *logger.Logger
+}
+
+// NewListWithdrawalsUseCase crea una nueva instancia
+func NewListWithdrawalsUseCase(gormDB *gorm.DB, log *logger.Logger) *ListWithdrawalsUseCase {
+	return &ListWithdrawalsUseCase{
+		withdrawalRepo: db.NewWithdrawalRepository(gormDB, log),
+		log:            log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ListWithdrawalsUseCase) Execute(ctx context.Context, input *ListWithdrawalsInput) (*ListWithdrawalsOutput, error) {
+	withdrawals, total, err := uc.withdrawalRepo.List(domain.WithdrawalFilters{
+		UserID: &input.UserID,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListWithdrawalsOutput{
+		Withdrawals: withdrawals,
+		Total:       total,
+		Limit:       input.Limit,
+		Offset:      input.Offset,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package withdrawal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/pkg/errors"
+	"github.com/sorteos-platform/backend/pkg/logger"
+)
+
+// RequestWithdrawalInput datos de entrada para solicitar un retiro
+type RequestWithdrawalInput struct {
+	UserID int64
+	Amount decimal.Decimal
+}
+
+// RequestWithdrawalUseCase solicita un retiro del saldo de ganancias a la cuenta IBAN del usuario
+// El monto se retiene en pending_balance hasta que un admin confirme o rechace la transferencia
+type RequestWithdrawalUseCase struct {
+	db              *gorm.DB
+	systemParamRepo *db.PostgresSystemParameterRepository
+	auditRepo       domain.AuditLogRepository
+	log             *logger.Logger
+}
+
+// NewRequestWithdrawalUseCase crea una nueva instancia
+func NewRequestWithdrawalUseCase(gormDB *gorm.DB, log *logger.Logger) *RequestWithdrawalUseCase {
+	return &RequestWithdrawalUseCase{
+		db:              gormDB,
+		systemParamRepo: db.NewSystemParameterRepository(gormDB, log),
+		auditRepo:       db.NewAuditLogRepository(gormDB),
+		log:             log,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RequestWithdrawalUseCase) Execute(ctx context.Context, input *RequestWithdrawalInput) (*domain.Withdrawal, error) {
+	amount := input.Amount.Round(2)
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, errors.WrapWithMessage(errors.ErrValidationFailed, "el monto debe ser mayor a cero", nil)
+	}
+
+	minAmount, _ := uc.systemParamRepo.GetFloat("min_withdrawal_amount", domain.DefaultMinWithdrawalAmount)
+	if amount.LessThan(decimal.NewFromFloat(minAmount)) {
+		return nil, errors.New("WITHDRAWAL_BELOW_MINIMUM",
+			fmt.Sprintf("el monto mínimo de retiro es %s", decimal.NewFromFloat(minAmount).StringFixed(2)), 400, nil)
+	}
+
+	var withdrawal *domain.Withdrawal
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Validar usuario: activo, KYC con cédula verificada e IBAN configurado
+		user, err := db.NewUserRepository(tx).FindByID(input.UserID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive() {
+			return errors.New("USER_NOT_ACTIVE", "tu cuenta no está activa", 403, nil)
+		}
+		if !user.HasMinimumKYC(domain.KYCLevelCedulaVerified) {
+			return errors.New("KYC_REQUIRED", "debes verificar tu cédula antes de retirar ganancias", 403, nil)
+		}
+		if user.IBAN == nil || *user.IBAN == "" {
+			return errors.New("IBAN_REQUIRED", "debes configurar tu IBAN antes de retirar ganancias", 400, nil)
+		}
+
+		// 2. Retener el monto (billetera con lock)
+		walletRepo := db.NewWalletRepository(tx, uc.log)
+		wallet, err := walletRepo.FindByUserID(input.UserID)
+		if err != nil {
+			return err
+		}
+		if err := walletRepo.Lock(wallet.ID); err != nil {
+			return err
+		}
+		wallet, err = walletRepo.FindByID(wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		if wallet.IsActive() && wallet.EarningsBalance.LessThan(amount) {
+			return errors.New("INSUFFICIENT_EARNINGS", "saldo de ganancias insuficiente", 400, nil)
+		}
+
+		balanceBefore := wallet.EarningsBalance
+		if err := wallet.HoldEarnings(amount); err != nil {
+			return errors.Wrap(errors.ErrValidationFailed, err)
+		}
+
+		// 3. Registrar la solicitud (un solo retiro abierto por usuario)
+		withdrawal = domain.NewWithdrawal(user, wallet, amount)
+		withdrawalRepo := db.NewWithdrawalRepository(tx, uc.log)
+		if err := withdrawalRepo.Create(withdrawal); err != nil {
+			return err
+		}
+
+		// 4. Transacción de retiro pendiente (se completa con la confirmación bancaria)
+		referenceType := ReferenceTypeWithdrawal
+		notes := fmt.Sprintf("Retiro a %s", maskIBAN(withdrawal.IBAN))
+		transaction := &domain.WalletTransaction{
+			UUID:           uuid.New().String(),
+			WalletID:       wallet.ID,
+			UserID:         user.ID,
+			Type:           domain.TransactionTypeWithdrawal,
+			Amount:         amount,
+			Status:         domain.TransactionStatusPending,
+			BalanceBefore:  balanceBefore,
+			BalanceAfter:   wallet.EarningsBalance,
+			ReferenceType:  &referenceType,
+			ReferenceID:    &withdrawal.ID,
+			IdempotencyKey: TransactionKey(withdrawal),
+			Notes:          &notes,
+		}
+		if err := db.NewWalletTransactionRepository(tx, uc.log).Create(transaction); err != nil {
+			return err
+		}
+
+		if err := walletRepo.Update(wallet); err != nil {
+			return err
+		}
+
+		// Asiento contable de la retención: de ganancias a saldo pendiente
+		holdEntry := JournalEntry(withdrawal, domain.JournalEntryWithdrawalHold).
+			WithWalletTransaction(transaction.ID).
+			Debit(domain.UserEarningsAccount(user.ID), amount).
+			Credit(domain.UserPendingAccount(user.ID), amount)
+		if err := walletRepo.PostJournalEntry(holdEntry); err != nil {
+			return err
+		}
+
+		withdrawal.TransactionID = &transaction.ID
+		return withdrawalRepo.Update(withdrawal)
+	})
+	if err != nil {
+		uc.log.Error("Error solicitando retiro",
+			logger.Int64("user_id", input.UserID),
+			logger.String("amount", amount.String()),
+			logger.Error(err))
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(domain.AuditActionWithdrawalRequested).
+		WithUser(input.UserID).
+		WithEntity("withdrawal", withdrawal.ID).
+		WithDescription(fmt.Sprintf("Retiro solicitado: %s %s", amount.String(), withdrawal.Currency)).
+		WithMetadata(map[string]interface{}{
+			"amount":         amount.String(),
+			"iban":           maskIBAN(withdrawal.IBAN),
+			"transaction_id": withdrawal.TransactionID,
+		}).
+		Build()
+	if err := uc.auditRepo.Create(auditLog); err != nil {
+		uc.log.Warn("Error creating audit log", logger.Error(err))
+	}
+
+	uc.log.Info("Withdrawal requested",
+		logger.Int64("withdrawal_id", withdrawal.ID),
+		logger.Int64("user_id", input.UserID),
+		logger.String("amount", amount.String()))
+
+	return withdrawal, nil
+}
+
+// maskIBAN oculta el IBAN excepto los últimos 4 dígitos
+func maskIBAN(iban string) string {
+	if len(iban) <= 4 {
+		return iban
+	}
+	return "CR**" + iban[len(iban)-4:]
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+)
+
+// luckyDipMaxAttempts is how many times a lucky dip re-draws numbers after losing them to concurrent buyers
+const luckyDipMaxAttempts = 3
+
+var (
+	ErrInvalidLuckyDipQuantity = fmt.Errorf("quantity must be between 1 and %d", entities.MaxNumbersPerReservation)
+	ErrInvalidLuckyDipFilter   = errors.New("invalid lucky dip constraints")
+)
+
+// LuckyDipInput represents the input for reserving random available numbers
+type LuckyDipInput struct {
+	RaffleID    uuid.UUID
+	UserID      uuid.UUID
+	Quantity    int
+	SessionID   string
+	EndingDigit *int // Only numbers ending with this digit (0-9)
+	MinNumber   *int // Only numbers >= MinNumber
+	MaxNumber   *int // Only numbers <= MaxNumber
+}
+
+// validate checks the quantity and the optional constraints
+func (input LuckyDipInput) validate() error {
+	if input.Quantity < 1 || input.Quantity > entities.MaxNumbersPerReservation {
+		return ErrInvalidLuckyDipQuantity
+	}
+	if input.EndingDigit != nil && (*input.EndingDigit < 0 || *input.EndingDigit > 9) {
+		return fmt.Errorf("%w: ending_digit must be between 0 and 9", ErrInvalidLuckyDipFilter)
+	}
+	if input.MinNumber != nil && input.MaxNumber != nil && *input.MinNumber > *input.MaxNumber {
+		return fmt.Errorf("%w: min_number is greater than max_number", ErrInvalidLuckyDipFilter)
+	}
+	return nil
+}
+
+// matches reports whether a raffle number satisfies the constraints
+func (input LuckyDipInput) matches(number string) bool {
+	if input.EndingDigit != nil && !strings.HasSuffix(number, strconv.Itoa(*input.EndingDigit)) {
+		return false
+	}
+	if input.MinNumber == nil && input.MaxNumber == nil {
+		return true
+	}
+
+	value, err := strconv.Atoi(number)
+	if err != nil {
+		return false
+	}
+	if input.MinNumber != nil && value < *input.MinNumber {
+		return false
+	}
+	if input.MaxNumber != nil && value > *input.MaxNumber {
+		return false
+	}
+	return true
+}
+
+// CreateLuckyDipReservation reserves Quantity random available numbers picked server-side
+// Numbers taken by concurrent buyers are skipped while locking, so the reservation is only
+// rejected when not enough matching numbers are left
+func (uc *ReservationUseCases) CreateLuckyDipReservation(ctx context.Context, input LuckyDipInput) (*entities.Reservation, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	// 1. Check for existing reservation with same session ID (idempotency)
+	existingReservation, err := uc.findSessionReservation(ctx, input.SessionID)
+	if err != nil || existingReservation != nil {
+		return existingReservation, err
+	}
+
+	// 2. Validate raffle exists and is active
+	raffle, err := uc.findOpenRaffle(input.RaffleID)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; attempt <= luckyDipMaxAttempts; attempt++ {
+		// 3. Collect the available numbers that satisfy the constraints
+		available, err := uc.raffleNumberRepo.FindAvailableByRaffleID(raffle.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching available numbers: %w", err)
+		}
+
+		candidates := make([]string, 0, len(available))
+		for _, number := range available {
+			if input.matches(number.Number) {
+				candidates = append(candidates, number.Number)
+			}
+		}
+		if len(candidates) < input.Quantity {
+			return nil, ErrInsufficientNumbers
+		}
+
+		// 4. Lock random candidates until the quantity is reached (skipping numbers locked by others)
+		reservationID := uuid.New()
+		numberIDs, locks, err := uc.lockRandomNumbers(ctx, input.RaffleID, reservationID, candidates, input.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if len(locks) < input.Quantity {
+			_ = redis.ReleaseMultipleLocks(ctx, locks)
+			continue
+		}
+
+		// 5. Create the reservation owning the locks
+		reservation, err := uc.newPricedReservation(raffle, CreateReservationInput{
+			RaffleID:  input.RaffleID,
+			UserID:    input.UserID,
+			NumberIDs: numberIDs,
+			SessionID: input.SessionID,
+		})
+		if err != nil {
+			_ = redis.ReleaseMultipleLocks(ctx, locks)
+			return nil, err
+		}
+		reservation.ID = reservationID
+
+		// 6. Save the reservation; re-draw if a number was reserved in the database meanwhile
+		if err := uc.saveReservation(ctx, raffle, reservation); err != nil {
+			_ = redis.ReleaseMultipleLocks(ctx, locks)
+			if errors.Is(err, ErrNumbersAlreadyReserved) {
+				continue
+			}
+			return nil, err
+		}
+
+		return reservation, nil
+	}
+
+	return nil, ErrInsufficientNumbers
+}
+
+// lockRandomNumbers locks up to quantity numbers picked in random order from candidates
+// and returns them with their locks, owned by the reservation ID so checkout can extend them
+func (uc *ReservationUseCases) lockRandomNumbers(ctx context.Context, raffleID, reservationID uuid.UUID, candidates []string, quantity int) ([]string, []*redis.Lock, error) {
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	numbers := make([]string, 0, quantity)
+	locks := make([]*redis.Lock, 0, quantity)
+	for _, number := range candidates {
+		if len(locks) == quantity {
+			break
+		}
+
+		lock, err := uc.lockService.AcquireLockWithOwner(ctx, redis.ReservationLockKey(raffleID.String(), number), reservationID.String(), entities.ReservationSelectionTimeout)
+		if err != nil {
+			if errors.Is(err, redis.ErrLockNotAcquired) {
+				continue
+			}
+			_ = redis.ReleaseMultipleLocks(ctx, locks)
+			return nil, nil, fmt.Errorf("error acquiring lock: %w", err)
+		}
+		numbers = append(numbers, number)
+		locks = append(locks, lock)
+	}
+
+	return numbers, locks, nil
+}
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	dbadapter "github.com/sorteos-platform/backend/internal/adapters/db"
+	"github.com/sorteos-platform/backend/internal/domain"
 	"github.com/sorteos-platform/backend/internal/domain/entities"
 	"github.com/sorteos-platform/backend/internal/domain/repositories"
 	"github.com/sorteos-platform/backend/internal/infrastructure/payment"
+	"github.com/sorteos-platform/backend/internal/infrastructure/websocket"
 )
 
 var (
@@ -23,6 +27,11 @@ var (
 	ErrPaymentNotFound         = errors.New("payment not found")
 )
 
+// CartPaymentHandler applies provider events for payment intents that pay a whole cart
+type CartPaymentHandler interface {
+	HandlePaymentEvent(ctx context.Context, eventType string, paymentIntentID string) error
+}
+
 // PaymentUseCases handles business logic for payments
 type PaymentUseCases struct {
 	paymentRepo         repositories.PaymentRepository
@@ -31,6 +40,9 @@ type PaymentUseCases struct {
 	idempotencyKeyRepo  repositories.IdempotencyKeyRepository
 	paymentProvider     payment.PaymentProvider
 	reservationUseCases *ReservationUseCases
+	ledgerRepo          domain.LedgerRepository
+	wsHub               *websocket.Hub // Private payment events
+	cartHandler         CartPaymentHandler
 }
 
 // NewPaymentUseCases creates a new payment use cases instance
@@ -41,6 +53,8 @@ func NewPaymentUseCases(
 	idempotencyKeyRepo repositories.IdempotencyKeyRepository,
 	paymentProvider payment.PaymentProvider,
 	reservationUseCases *ReservationUseCases,
+	ledgerRepo domain.LedgerRepository,
+	wsHub *websocket.Hub,
 ) *PaymentUseCases {
 	return &PaymentUseCases{
 		paymentRepo:         paymentRepo,
@@ -49,9 +63,16 @@ func NewPaymentUseCases(
 		idempotencyKeyRepo:  idempotencyKeyRepo,
 		paymentProvider:     paymentProvider,
 		reservationUseCases: reservationUseCases,
+		ledgerRepo:          ledgerRepo,
+		wsHub:               wsHub,
 	}
 }
 
+// SetCartPaymentHandler sets the handler for webhook events of cart payment intents
+func (uc *PaymentUseCases) SetCartPaymentHandler(handler CartPaymentHandler) {
+	uc.cartHandler = handler
+}
+
 // CreatePaymentIntentInput represents input for creating a payment intent
 type CreatePaymentIntentInput struct {
 	ReservationID   uuid.UUID
@@ -61,10 +82,11 @@ type CreatePaymentIntentInput struct {
 
 // CreatePaymentIntentOutput represents the output of creating a payment intent
 type CreatePaymentIntentOutput struct {
-	PaymentID    uuid.UUID
-	ClientSecret string
-	Amount       float64
-	Currency     string
+	PaymentID      uuid.UUID
+	ClientSecret   string
+	Amount         float64
+	Currency       string
+	PriceBreakdown json.RawMessage // Pricing rules applied to the amount
 }
 
 // CreatePaymentIntent creates a Stripe payment intent for a reservation
@@ -117,13 +139,18 @@ func (uc *PaymentUseCases) CreatePaymentIntent(ctx context.Context, input Create
 	if err != nil {
 		return nil, fmt.Errorf("error checking existing payment: %w", err)
 	}
+	if existingPayment != nil && existingPayment.CartID != nil {
+		// The reservation is paid through its cart's single intent
+		return nil, ErrPaymentAlreadyExists
+	}
 	if existingPayment != nil {
 		// Payment already exists, return client secret
 		return &CreatePaymentIntentOutput{
-			PaymentID:    existingPayment.ID,
-			ClientSecret: existingPayment.StripeClientSecret,
-			Amount:       existingPayment.Amount,
-			Currency:     existingPayment.Currency,
+			PaymentID:      existingPayment.ID,
+			ClientSecret:   existingPayment.StripeClientSecret,
+			Amount:         existingPayment.Amount,
+			Currency:       existingPayment.Currency,
+			PriceBreakdown: reservation.PriceBreakdown,
 		}, nil
 	}
 
@@ -171,9 +198,10 @@ func (uc *PaymentUseCases) CreatePaymentIntent(ctx context.Context, input Create
 
 	// Set metadata
 	paymentMetadata := entities.PaymentMetadata{
-		NumberCount: len(reservation.NumberIDs),
-		NumberIDs:   reservation.NumberIDs,
-		RaffleTitle: raffle.Title,
+		NumberCount:    len(reservation.NumberIDs),
+		NumberIDs:      reservation.NumberIDs,
+		RaffleTitle:    raffle.Title,
+		PriceBreakdown: reservation.PriceBreakdown,
 	}
 	if err := paymentEntity.SetMetadata(paymentMetadata); err != nil {
 		return nil, fmt.Errorf("error setting payment metadata: %w", err)
@@ -185,10 +213,11 @@ func (uc *PaymentUseCases) CreatePaymentIntent(ctx context.Context, input Create
 
 	// 9. Create response
 	output := &CreatePaymentIntentOutput{
-		PaymentID:    paymentEntity.ID,
-		ClientSecret: stripeIntent.ClientSecret,
-		Amount:       reservation.TotalAmount,
-		Currency:     stripeIntent.Currency,
+		PaymentID:      paymentEntity.ID,
+		ClientSecret:   stripeIntent.ClientSecret,
+		Amount:         reservation.TotalAmount,
+		Currency:       stripeIntent.Currency,
+		PriceBreakdown: reservation.PriceBreakdown,
 	}
 
 	// 10. Store idempotency key if provided
@@ -215,6 +244,11 @@ func (uc *PaymentUseCases) ProcessPaymentWebhook(ctx context.Context, eventType
 		return ErrPaymentNotFound
 	}
 
+	// Cart payments share the intent: every reservation is confirmed or released together
+	if paymentEntity.CartID != nil && uc.cartHandler != nil {
+		return uc.cartHandler.HandlePaymentEvent(ctx, eventType, paymentIntentID)
+	}
+
 	// 2. Get reservation
 	reservation, err := uc.reservationRepo.FindByID(ctx, paymentEntity.ReservationID)
 	if err != nil {
@@ -236,11 +270,24 @@ func (uc *PaymentUseCases) ProcessPaymentWebhook(ctx context.Context, eventType
 			return fmt.Errorf("error updating payment: %w", err)
 		}
 
+		// Record the capture in the ledger: funds at the processor owed to the organizer
+		if err := uc.postCardPurchase(paymentEntity); err != nil {
+			return fmt.Errorf("error posting purchase to ledger: %w", err)
+		}
+
 		// Confirm reservation
 		if err := uc.reservationUseCases.ConfirmReservation(ctx, reservation.ID); err != nil {
 			return fmt.Errorf("error confirming reservation: %w", err)
 		}
 
+		uc.wsHub.NotifyPaymentSucceeded(
+			paymentEntity.UserID.String(),
+			paymentEntity.RaffleID.String(),
+			reservation.ID.String(),
+			paymentEntity.ID.String(),
+			reservation.NumberIDs,
+		)
+
 		// TODO: Mark numbers as sold in raffle (future implementation)
 
 	case "payment_intent.payment_failed":
@@ -254,6 +301,13 @@ func (uc *PaymentUseCases) ProcessPaymentWebhook(ctx context.Context, eventType
 		}
 
 		// Reservation remains pending, user can retry
+		uc.wsHub.NotifyPaymentFailed(
+			paymentEntity.UserID.String(),
+			paymentEntity.RaffleID.String(),
+			reservation.ID.String(),
+			paymentEntity.ID.String(),
+			"Payment failed",
+		)
 
 	case "payment_intent.canceled":
 		// Cancel payment
@@ -278,6 +332,22 @@ func (uc *PaymentUseCases) ProcessPaymentWebhook(ctx context.Context, eventType
 	return nil
 }
 
+// postCardPurchase posts the journal entry for a card payment captured by the provider
+func (uc *PaymentUseCases) postCardPurchase(paymentEntity *entities.Payment) error {
+	raffle, err := uc.raffleRepo.FindByUUID(paymentEntity.RaffleID.String())
+	if err != nil {
+		return err
+	}
+
+	amount := decimal.NewFromFloat(paymentEntity.Amount).Round(2)
+	entry := domain.NewJournalEntry(domain.JournalEntryPurchase, "payment:"+paymentEntity.ID.String()+":capture", strings.ToUpper(paymentEntity.Currency)).
+		WithReference("payment", paymentEntity.ID).
+		Debit(domain.PlatformAccount(domain.LedgerAccountProcessorClearing), amount).
+		Credit(domain.OrganizerPayableAccount(raffle.UserID), amount)
+
+	return uc.ledgerRepo.Post(entry)
+}
+
 // GetPayment retrieves a payment by ID
 func (uc *PaymentUseCases) GetPayment(ctx context.Context, paymentID uuid.UUID) (*entities.Payment, error) {
 	payment, err := uc.paymentRepo.FindByID(ctx, paymentID)
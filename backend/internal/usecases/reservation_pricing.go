@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sorteos-platform/backend/internal/domain"
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+)
+
+// QuotePrice prices quantity numbers of the raffle with its pricing rules active at the given time
+func (uc *ReservationUseCases) QuotePrice(raffle *domain.Raffle, quantity int, at time.Time) (*domain.PriceBreakdown, error) {
+	rules, err := uc.pricingRuleRepo.FindByRaffleID(raffle.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pricing rules: %w", err)
+	}
+	return domain.CalculatePrice(raffle.PricePerNumber, quantity, rules, at), nil
+}
+
+// newPricedReservation creates a reservation entity whose total applies the raffle's pricing rules
+func (uc *ReservationUseCases) newPricedReservation(raffle *domain.Raffle, input CreateReservationInput) (*entities.Reservation, error) {
+	breakdown, err := uc.QuotePrice(raffle, len(input.NumberIDs), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	totalAmount, _ := breakdown.Total.Float64()
+	reservation, err := entities.NewReservation(input.RaffleID, input.UserID, input.NumberIDs, input.SessionID, totalAmount)
+	if err != nil {
+		return nil, fmt.Errorf("error creating reservation entity: %w", err)
+	}
+	if err := setReservationPrice(reservation, breakdown); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// reprice recomputes the reservation total after its numbers changed
+// Rules are evaluated at the reservation's creation time so early-bird windows are kept while selecting
+func (uc *ReservationUseCases) reprice(raffle *domain.Raffle, reservation *entities.Reservation) error {
+	breakdown, err := uc.QuotePrice(raffle, len(reservation.NumberIDs), reservation.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return setReservationPrice(reservation, breakdown)
+}
+
+// setReservationPrice stores the breakdown total and its JSON on the reservation
+func setReservationPrice(reservation *entities.Reservation, breakdown *domain.PriceBreakdown) error {
+	raw, err := json.Marshal(breakdown)
+	if err != nil {
+		return fmt.Errorf("error encoding price breakdown: %w", err)
+	}
+	totalAmount, _ := breakdown.Total.Float64()
+	return reservation.SetPrice(totalAmount, raw)
+}
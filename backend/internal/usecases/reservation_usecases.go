@@ -19,7 +19,9 @@ import (
 var (
 	ErrNumbersAlreadyReserved = errors.New("one or more numbers are already reserved")
 	ErrRaffleNotActive        = errors.New("raffle is not active")
+	ErrRaffleSalesClosed      = errors.New("raffle sales are closed")
 	ErrInsufficientNumbers    = errors.New("some requested numbers are not available")
+	ErrReservationLocksLost   = errors.New("reservation numbers are no longer held")
 )
 
 // ReservationUseCases handles business logic for reservations
@@ -27,9 +29,11 @@ type ReservationUseCases struct {
 	reservationRepo   repositories.ReservationRepository
 	raffleRepo        dbadapter.RaffleRepository
 	raffleNumberRepo  dbadapter.RaffleNumberRepository
+	pricingRuleRepo   domain.PricingRuleRepository
 	userRepo          domain.UserRepository
 	lockService       *redis.LockService
 	wsHub             *websocket.Hub // WebSocket hub for real-time updates
+	waitlist          *WaitlistUseCases // Optional: receives released numbers (set by NewWaitlistUseCases)
 }
 
 // NewReservationUseCases creates a new reservation use cases instance
@@ -37,6 +41,7 @@ func NewReservationUseCases(
 	reservationRepo repositories.ReservationRepository,
 	raffleRepo dbadapter.RaffleRepository,
 	raffleNumberRepo dbadapter.RaffleNumberRepository,
+	pricingRuleRepo domain.PricingRuleRepository,
 	userRepo domain.UserRepository,
 	lockService *redis.LockService,
 	wsHub *websocket.Hub,
@@ -45,6 +50,7 @@ func NewReservationUseCases(
 		reservationRepo:  reservationRepo,
 		raffleRepo:       raffleRepo,
 		raffleNumberRepo: raffleNumberRepo,
+		pricingRuleRepo:  pricingRuleRepo,
 		userRepo:         userRepo,
 		lockService:      lockService,
 		wsHub:            wsHub,
@@ -62,40 +68,26 @@ type CreateReservationInput struct {
 // CreateReservation creates a new number reservation with distributed locks
 func (uc *ReservationUseCases) CreateReservation(ctx context.Context, input CreateReservationInput) (*entities.Reservation, error) {
 	// 1. Check for existing reservation with same session ID (idempotency)
-	existingReservation, err := uc.reservationRepo.FindBySessionID(ctx, input.SessionID)
-	if err != nil {
-		return nil, fmt.Errorf("error checking existing reservation: %w", err)
-	}
-	if existingReservation != nil {
-		// Return existing reservation if it's still valid
-		if !existingReservation.IsExpired() && existingReservation.Status == entities.ReservationStatusPending {
-			return existingReservation, nil
-		}
+	existingReservation, err := uc.findSessionReservation(ctx, input.SessionID)
+	if err != nil || existingReservation != nil {
+		return existingReservation, err
 	}
 
 	// 2. Validate raffle exists and is active
-	raffle, err := uc.raffleRepo.FindByUUID(input.RaffleID.String())
+	raffle, err := uc.findOpenRaffle(input.RaffleID)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching raffle: %w", err)
+		return nil, err
 	}
-	if raffle == nil {
-		return nil, errors.New("raffle not found")
-	}
-	if raffle.Status != "active" {
-		return nil, ErrRaffleNotActive
-	}
-
-	// 3. Calculate total amount
-	pricePerNumber, _ := raffle.PricePerNumber.Float64()
-	totalAmount := float64(len(input.NumberIDs)) * pricePerNumber
 
-	// 4. Acquire distributed locks for all numbers
-	lockKeys := make([]string, len(input.NumberIDs))
-	for i, numberID := range input.NumberIDs {
-		lockKeys[i] = redis.ReservationLockKey(input.RaffleID.String(), numberID)
+	// 3-4. Create reservation entity priced with the raffle's pricing rules
+	// (its ID owns the number locks so checkout can extend them)
+	reservation, err := uc.newPricedReservation(raffle, input)
+	if err != nil {
+		return nil, err
 	}
 
-	locks, err := uc.lockService.AcquireMultipleLocks(ctx, lockKeys, entities.ReservationExpirationDuration)
+	// 5. Acquire distributed locks for all numbers (held for the selection phase)
+	locks, err := uc.lockService.AcquireMultipleLocksWithOwner(ctx, uc.lockKeys(reservation), reservation.ID.String(), entities.ReservationSelectionTimeout)
 	if err != nil {
 		if errors.Is(err, redis.ErrLockNotAcquired) {
 			return nil, ErrNumbersAlreadyReserved
@@ -110,35 +102,65 @@ func (uc *ReservationUseCases) CreateReservation(ctx context.Context, input Crea
 		}
 	}()
 
-	// 5. Double-check numbers aren't already reserved in database
-	count, err := uc.reservationRepo.CountActiveReservationsForNumbers(ctx, input.RaffleID, input.NumberIDs)
+	// 6. Save the reservation and mark its numbers as reserved
+	if err = uc.saveReservation(ctx, raffle, reservation); err != nil {
+		return nil, err
+	}
+
+	// Locks are held until the selection phase ends, extended by StartCheckout, or released on confirm/cancel/expiry
+	return reservation, nil
+}
+
+// findSessionReservation returns the still valid reservation created with the same session ID (idempotency)
+func (uc *ReservationUseCases) findSessionReservation(ctx context.Context, sessionID string) (*entities.Reservation, error) {
+	existingReservation, err := uc.reservationRepo.FindBySessionID(ctx, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("error checking existing reservations: %w", err)
+		return nil, fmt.Errorf("error checking existing reservation: %w", err)
 	}
-	if count > 0 {
-		return nil, ErrNumbersAlreadyReserved
+	if existingReservation != nil && !existingReservation.IsExpired() && existingReservation.Status == entities.ReservationStatusPending {
+		return existingReservation, nil
 	}
+	return nil, nil
+}
 
-	// 6. Create reservation entity
-	reservation, err := entities.NewReservation(
-		input.RaffleID,
-		input.UserID,
-		input.NumberIDs,
-		input.SessionID,
-		totalAmount,
-	)
+// findOpenRaffle returns the raffle if it exists, is active and still sells numbers
+func (uc *ReservationUseCases) findOpenRaffle(raffleID uuid.UUID) (*domain.Raffle, error) {
+	raffle, err := uc.raffleRepo.FindByUUID(raffleID.String())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching raffle: %w", err)
+	}
+	if raffle == nil {
+		return nil, errors.New("raffle not found")
+	}
+	if raffle.Status != "active" {
+		return nil, ErrRaffleNotActive
+	}
+	if raffle.IsSalesClosed() {
+		return nil, ErrRaffleSalesClosed
+	}
+	return raffle, nil
+}
+
+// saveReservation persists a reservation whose number locks are already held,
+// marks its numbers as reserved in raffle_numbers and broadcasts them
+func (uc *ReservationUseCases) saveReservation(ctx context.Context, raffle *domain.Raffle, reservation *entities.Reservation) error {
+	// Double-check numbers aren't already reserved in database
+	count, err := uc.reservationRepo.CountActiveReservationsForNumbers(ctx, reservation.RaffleID, reservation.NumberIDs)
 	if err != nil {
-		return nil, fmt.Errorf("error creating reservation entity: %w", err)
+		return fmt.Errorf("error checking existing reservations: %w", err)
+	}
+	if count > 0 {
+		return ErrNumbersAlreadyReserved
 	}
 
-	// 7. Save to database
+	// Save to database
 	if err := uc.reservationRepo.Create(ctx, reservation); err != nil {
-		return nil, fmt.Errorf("error saving reservation: %w", err)
+		return fmt.Errorf("error saving reservation: %w", err)
 	}
 
-	// 8. Update raffle_numbers table to mark as RESERVED
+	// Update raffle_numbers table to mark as RESERVED
 	// Get numeric user ID from UUID
-	user, userErr := uc.userRepo.FindByUUID(input.UserID.String())
+	user, userErr := uc.userRepo.FindByUUID(reservation.UserID.String())
 	if userErr != nil {
 		fmt.Printf("[CreateReservation] Error finding user by UUID: %v\n", userErr)
 	}
@@ -147,24 +169,23 @@ func (uc *ReservationUseCases) CreateReservation(ctx context.Context, input Crea
 		numericUserID = user.ID
 	}
 
-	if err := uc.raffleNumberRepo.ReserveNumbers(raffle.ID, input.NumberIDs, numericUserID, 0, entities.ReservationExpirationDuration); err != nil {
+	if err := uc.raffleNumberRepo.ReserveNumbers(raffle.ID, reservation.NumberIDs, numericUserID, 0, entities.ReservationSelectionTimeout); err != nil {
 		// Log error but continue - the reservation record is the source of truth
 		fmt.Printf("[CreateReservation] Error marking numbers as reserved: %v\n", err)
 	}
 
-	// 9. Notify via WebSocket about new reservation
-	userIDStr := input.UserID.String()
-	for _, numberID := range input.NumberIDs {
+	// Notify via WebSocket about new reservation
+	userIDStr := reservation.UserID.String()
+	for _, numberID := range reservation.NumberIDs {
 		uc.wsHub.BroadcastNumberUpdate(
-			input.RaffleID.String(),
+			reservation.RaffleID.String(),
 			numberID,
 			"reserved",
 			&userIDStr,
 		)
 	}
 
-	// Locks will be held until they expire (10 minutes) or until the reservation is confirmed/cancelled
-	return reservation, nil
+	return nil
 }
 
 // ConfirmReservation confirms a reservation after successful payment
@@ -262,8 +283,11 @@ func (uc *ReservationUseCases) CancelReservation(ctx context.Context, reservatio
 		}
 	}
 
-	// Notify via WebSocket that numbers are available again
-	for _, numberID := range reservation.NumberIDs {
+	// Release locks manually
+	lockErr := uc.releaseLocks(ctx, reservation)
+
+	// Offer the released numbers to the waitlist and notify the rest as available again
+	for _, numberID := range uc.offerToWaitlist(ctx, reservation) {
 		uc.wsHub.BroadcastNumberUpdate(
 			reservation.RaffleID.String(),
 			numberID,
@@ -272,8 +296,7 @@ func (uc *ReservationUseCases) CancelReservation(ctx context.Context, reservatio
 		)
 	}
 
-	// Release locks manually
-	return uc.releaseLocks(ctx, reservation)
+	return lockErr
 }
 
 // ExpireReservations finds and expires all pending reservations that have passed their expiration time
@@ -285,40 +308,20 @@ func (uc *ReservationUseCases) ExpireReservations(ctx context.Context) (int, err
 
 	count := 0
 	for _, reservation := range expiredReservations {
-		if err := reservation.Expire(); err != nil {
+		available, err := uc.expireReservation(ctx, reservation)
+		if err != nil {
 			// Log error but continue processing other reservations
 			continue
 		}
 
-		if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
-			// Log error but continue
-			continue
-		}
-
-		// Release locks (they may have already expired, but try anyway)
-		_ = uc.releaseLocks(ctx, reservation)
-
-		// Update raffle_numbers table to mark as AVAILABLE
-		raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
-		if err == nil {
-			for _, numberStr := range reservation.NumberIDs {
-				raffleNumber, err := uc.raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberStr)
-				if err != nil {
-					continue
-				}
-				// Cancel reservation on this number
-				if err := uc.raffleNumberRepo.CancelReservation(raffleNumber.ID); err != nil {
-					fmt.Printf("[ExpireReservations] Error releasing number %s: %v\n", numberStr, err)
-				}
-			}
+		// Notify via WebSocket only the numbers available again (the rest are offered to the waitlist)
+		if len(available) > 0 {
+			uc.wsHub.BroadcastReservationExpired(
+				reservation.RaffleID.String(),
+				available,
+			)
 		}
 
-		// Notify via WebSocket that numbers are available again
-		uc.wsHub.BroadcastReservationExpired(
-			reservation.RaffleID.String(),
-			reservation.NumberIDs,
-		)
-
 		count++
 	}
 
@@ -342,8 +345,9 @@ func (uc *ReservationUseCases) GetUserReservations(ctx context.Context, userID u
 	return uc.reservationRepo.FindByUserID(ctx, userID)
 }
 
-// MoveToCheckout transitions a reservation from selection to checkout phase
-// This is called when user clicks "Pay Now" button
+// MoveToCheckout transitions a reservation from selection to checkout phase (start checkout)
+// This is called when user clicks "Pay Now" button: the number set is frozen and the
+// number locks are extended until the checkout deadline
 func (uc *ReservationUseCases) MoveToCheckout(ctx context.Context, reservationID uuid.UUID) error {
 	reservation, err := uc.reservationRepo.FindByID(ctx, reservationID)
 	if err != nil {
@@ -353,16 +357,46 @@ func (uc *ReservationUseCases) MoveToCheckout(ctx context.Context, reservationID
 		return errors.New("reservation not found")
 	}
 
-	// Transition to checkout phase (extends timeout to 5 more minutes)
+	// Reject checkout once the draw job has closed sales
+	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
+	if err != nil {
+		return fmt.Errorf("error fetching raffle: %w", err)
+	}
+	if raffle.IsSalesClosed() {
+		return ErrRaffleSalesClosed
+	}
+
+	// Transition to checkout phase (expires ReservationCheckoutTimeout from now)
 	if err := reservation.MoveToCheckout(); err != nil {
 		return err
 	}
 
+	// Keep the numbers locked until the checkout deadline
+	if err := uc.extendLocks(ctx, reservation, time.Until(reservation.ExpiresAt)); err != nil {
+		return err
+	}
+
 	// Save updated reservation
 	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
 		return fmt.Errorf("error updating reservation: %w", err)
 	}
 
+	// Keep raffle_numbers reserved until the checkout deadline as well
+	if user, err := uc.userRepo.FindByUUID(reservation.UserID.String()); err == nil && user != nil {
+		if err := uc.raffleNumberRepo.ExtendReservation(raffle.ID, reservation.NumberIDs, user.ID, reservation.ExpiresAt); err != nil {
+			// Log error but continue - the reservation record is the source of truth
+			fmt.Printf("[MoveToCheckout] Error extending reserved numbers: %v\n", err)
+		}
+	}
+
+	// Notify the user's private channel with the new checkout deadline
+	uc.wsHub.NotifyReservationCheckout(
+		reservation.UserID.String(),
+		reservation.RaffleID.String(),
+		reservation.ID.String(),
+		reservation.ExpiresAt,
+	)
+
 	return nil
 }
 
@@ -385,36 +419,46 @@ func (uc *ReservationUseCases) AddNumberToReservation(ctx context.Context, reser
 		return entities.ErrReservationExpired
 	}
 
-	// 3. Acquire lock for the new number
+	// Reject new numbers once sales are closed
+	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
+	if err != nil {
+		return fmt.Errorf("error fetching raffle: %w", err)
+	}
+	if raffle.IsSalesClosed() {
+		return ErrRaffleSalesClosed
+	}
+
+	// 3. Acquire lock for the new number (owned by the reservation until it expires)
 	lockKey := redis.ReservationLockKey(reservation.RaffleID.String(), numberID)
-	lock, err := uc.lockService.AcquireLock(ctx, lockKey, entities.ReservationSelectionTimeout)
+	lock, err := uc.lockService.AcquireLockWithOwner(ctx, lockKey, reservation.ID.String(), reservation.TimeRemaining())
 	if err != nil {
 		if errors.Is(err, redis.ErrLockNotAcquired) {
 			return errors.New("number is already reserved")
 		}
 		return fmt.Errorf("error acquiring lock: %w", err)
 	}
-	defer lock.Release(ctx)
 
 	// 4. Check number availability in database
 	// (This would require a method in raffle number repository)
 	// For now, we skip this check
 
-	// 5. Add number to reservation
+	// 5. Add number to reservation and recompute its total
 	if err := reservation.AddNumber(numberID); err != nil {
+		_ = lock.Release(ctx)
+		return err
+	}
+	if err := uc.reprice(raffle, reservation); err != nil {
+		_ = lock.Release(ctx)
 		return err
 	}
 
 	// 6. Update in database
 	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
+		_ = lock.Release(ctx)
 		return fmt.Errorf("error updating reservation: %w", err)
 	}
 
 	// 7. Update raffle_numbers table to mark as RESERVED
-	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
-	if err != nil {
-		return fmt.Errorf("error fetching raffle: %w", err)
-	}
 
 	// Get numeric user ID from UUID
 	user, userErr := uc.userRepo.FindByUUID(reservation.UserID.String())
@@ -468,22 +512,25 @@ func (uc *ReservationUseCases) RemoveNumberFromReservation(ctx context.Context,
 		return entities.ErrReservationExpired
 	}
 
-	// 4. Remove number from reservation
+	// 4. Get raffle to obtain integer ID and pricing rules
+	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
+	if err != nil {
+		return fmt.Errorf("error fetching raffle: %w", err)
+	}
+
+	// 5. Remove number from reservation and recompute its total
 	if err := reservation.RemoveNumber(numberID); err != nil {
 		return err
 	}
+	if err := uc.reprice(raffle, reservation); err != nil {
+		return err
+	}
 
-	// 5. Update in database
+	// 6. Update in database
 	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
 		return fmt.Errorf("error updating reservation: %w", err)
 	}
 
-	// 6. Get raffle to obtain integer ID
-	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
-	if err != nil {
-		return fmt.Errorf("error fetching raffle: %w", err)
-	}
-
 	// 7. Release number in raffle_numbers table (mark as available)
 	raffleNumber, err := uc.raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberID)
 	if err == nil {
@@ -509,16 +556,38 @@ func (uc *ReservationUseCases) RemoveNumberFromReservation(ctx context.Context,
 	return nil
 }
 
-// releaseLocks releases Redis locks for a reservation
-func (uc *ReservationUseCases) releaseLocks(ctx context.Context, reservation *entities.Reservation) error {
-	// Build lock keys for all numbers in the reservation
-	lockKeys := make([]string, len(reservation.NumberIDs))
+// lockKeys returns the Redis lock keys of the reservation's numbers
+func (uc *ReservationUseCases) lockKeys(reservation *entities.Reservation) []string {
+	keys := make([]string, len(reservation.NumberIDs))
 	for i, numberID := range reservation.NumberIDs {
-		lockKeys[i] = redis.ReservationLockKey(reservation.RaffleID.String(), numberID)
+		keys[i] = redis.ReservationLockKey(reservation.RaffleID.String(), numberID)
+	}
+	return keys
+}
+
+// extendLocks sets the TTL of the reservation's number locks
+// A lock that already expired is re-acquired if no one else took the number meanwhile
+func (uc *ReservationUseCases) extendLocks(ctx context.Context, reservation *entities.Reservation, ttl time.Duration) error {
+	owner := reservation.ID.String()
+	for _, key := range uc.lockKeys(reservation) {
+		err := uc.lockService.OwnedLock(key, owner).Extend(ctx, ttl)
+		if errors.Is(err, redis.ErrLockNotHeld) {
+			_, err = uc.lockService.AcquireLockWithOwner(ctx, key, owner, ttl)
+		}
+		if err != nil {
+			if errors.Is(err, redis.ErrLockNotAcquired) {
+				return ErrReservationLocksLost
+			}
+			return fmt.Errorf("error extending lock %s: %w", key, err)
+		}
 	}
+	return nil
+}
 
+// releaseLocks releases Redis locks for a reservation
+func (uc *ReservationUseCases) releaseLocks(ctx context.Context, reservation *entities.Reservation) error {
 	// Force release all locks (no ownership verification needed for cancellation)
-	if err := uc.lockService.ForceReleaseMultipleLocks(ctx, lockKeys); err != nil {
+	if err := uc.lockService.ForceReleaseMultipleLocks(ctx, uc.lockKeys(reservation)); err != nil {
 		fmt.Printf("[releaseLocks] Error releasing locks for reservation %s: %v\n", reservation.ID.String(), err)
 		return err
 	}
@@ -526,7 +595,72 @@ func (uc *ReservationUseCases) releaseLocks(ctx context.Context, reservation *en
 	return nil
 }
 
+// WarnExpiringReservations notifies users whose reservation expires within `within`
+// The job runs every `interval`, so only reservations entering the warning window since the
+// previous run are notified (each reservation is warned once)
+func (uc *ReservationUseCases) WarnExpiringReservations(ctx context.Context, within, interval time.Duration) (int, error) {
+	now := time.Now()
+	reservations, err := uc.reservationRepo.FindExpiringBetween(ctx, now.Add(within-interval), now.Add(within))
+	if err != nil {
+		return 0, fmt.Errorf("find expiring: %w", err)
+	}
+
+	for _, reservation := range reservations {
+		uc.wsHub.NotifyReservationExpiring(
+			reservation.UserID.String(),
+			reservation.RaffleID.String(),
+			reservation.ID.String(),
+			reservation.ExpiresAt,
+		)
+	}
+
+	return len(reservations), nil
+}
+
+// expireReservation marks a pending reservation as expired, releases its number locks,
+// returns its numbers to available in raffle_numbers and offers them to the waitlist
+// Returns the numbers that are available to everyone again
+func (uc *ReservationUseCases) expireReservation(ctx context.Context, reservation *entities.Reservation) ([]string, error) {
+	if err := reservation.Expire(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	// Release locks (they may have already expired, but try anyway)
+	_ = uc.releaseLocks(ctx, reservation)
+
+	// Update raffle_numbers table to mark as AVAILABLE
+	raffle, err := uc.raffleRepo.FindByUUID(reservation.RaffleID.String())
+	if err == nil {
+		for _, numberStr := range reservation.NumberIDs {
+			raffleNumber, err := uc.raffleNumberRepo.FindByRaffleAndNumber(raffle.ID, numberStr)
+			if err != nil {
+				continue
+			}
+			// Cancel reservation on this number
+			if err := uc.raffleNumberRepo.CancelReservation(raffleNumber.ID); err != nil {
+				fmt.Printf("[expireReservation] Error releasing number %s: %v\n", numberStr, err)
+			}
+		}
+	}
+
+	return uc.offerToWaitlist(ctx, reservation), nil
+}
+
+// offerToWaitlist offers the numbers released by a reservation to the raffle waitlist
+// and returns the numbers left available to everyone
+func (uc *ReservationUseCases) offerToWaitlist(ctx context.Context, reservation *entities.Reservation) []string {
+	if uc.waitlist == nil {
+		return reservation.NumberIDs
+	}
+	return uc.waitlist.OfferReleasedNumbers(ctx, reservation.RaffleID, reservation.NumberIDs)
+}
+
 // ExpireOldReservations finds and expires old pending reservations
+// (past expires_at, or in checkout for longer than the checkout timeout)
 func (uc *ReservationUseCases) ExpireOldReservations(ctx context.Context) (int, error) {
 	// 1. Find expired reservations
 	expiredReservations, err := uc.reservationRepo.FindExpired(ctx)
@@ -541,18 +675,15 @@ func (uc *ReservationUseCases) ExpireOldReservations(ctx context.Context) (int,
 	count := 0
 
 	for _, reservation := range expiredReservations {
-		// 2. Mark reservation as expired
-		reservation.Status = entities.ReservationStatusExpired
-		reservation.Phase = entities.ReservationPhaseExpired
-		reservation.UpdatedAt = time.Now()
-
-		if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
+		// 2. Mark reservation as expired and release its numbers (waitlist first)
+		available, err := uc.expireReservation(ctx, reservation)
+		if err != nil {
 			// Log error but continue with next reservations
 			continue
 		}
 
 		// 3. Notify via WebSocket that numbers are now available
-		for _, numberID := range reservation.NumberIDs {
+		for _, numberID := range available {
 			uc.wsHub.BroadcastNumberUpdate(
 				reservation.RaffleID.String(),
 				numberID,
@@ -0,0 +1,338 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sorteos-platform/backend/internal/domain/entities"
+	"github.com/sorteos-platform/backend/internal/domain/repositories"
+	"github.com/sorteos-platform/backend/internal/infrastructure/redis"
+)
+
+var (
+	ErrWaitlistEntryNotFound = errors.New("user is not in the raffle waitlist")
+)
+
+// WaitlistMailer sends the waitlist offer email
+type WaitlistMailer interface {
+	SendWaitlistOfferEmail(email, raffleTitle, raffleID string, numbers []string, expiresAt time.Time) error
+}
+
+// WaitlistUseCases handles the per-raffle waitlist
+// Numbers released by cancelled or expired reservations are offered to waitlisted users in
+// FIFO order and held exclusively for them during WaitlistOfferHoldDuration
+type WaitlistUseCases struct {
+	waitlistRepo repositories.WaitlistRepository
+	reservations *ReservationUseCases
+	mailer       WaitlistMailer // Optional: offers are always notified through the hub
+	holdDuration time.Duration
+}
+
+// NewWaitlistUseCases creates a new waitlist use cases instance
+// and registers it to receive the numbers released by reservations
+func NewWaitlistUseCases(
+	waitlistRepo repositories.WaitlistRepository,
+	reservations *ReservationUseCases,
+	mailer WaitlistMailer,
+) *WaitlistUseCases {
+	uc := &WaitlistUseCases{
+		waitlistRepo: waitlistRepo,
+		reservations: reservations,
+		mailer:       mailer,
+		holdDuration: entities.WaitlistOfferHoldDuration,
+	}
+	reservations.waitlist = uc
+	return uc
+}
+
+// WaitlistStatusOutput is a user's waitlist entry with its position in the queue
+type WaitlistStatusOutput struct {
+	Entry    *entities.WaitlistEntry `json:"entry"`
+	Position int                     `json:"position"` // 1-based position while waiting, 0 otherwise
+}
+
+// Join adds the user to the raffle waitlist (idempotent while the entry is active)
+func (uc *WaitlistUseCases) Join(ctx context.Context, raffleID, userID uuid.UUID, quantity int) (*WaitlistStatusOutput, error) {
+	if _, err := uc.reservations.findOpenRaffle(raffleID); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.waitlistRepo.FindActiveByUserAndRaffle(ctx, userID, raffleID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching waitlist entry: %w", err)
+	}
+
+	if entry == nil {
+		entry, err = entities.NewWaitlistEntry(raffleID, userID, quantity)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.waitlistRepo.Create(ctx, entry); err != nil {
+			return nil, fmt.Errorf("error saving waitlist entry: %w", err)
+		}
+	}
+
+	return uc.status(ctx, entry)
+}
+
+// Get returns the user's active waitlist entry for the raffle
+func (uc *WaitlistUseCases) Get(ctx context.Context, raffleID, userID uuid.UUID) (*WaitlistStatusOutput, error) {
+	entry, err := uc.findActive(ctx, raffleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.status(ctx, entry)
+}
+
+// Leave removes the user from the raffle waitlist, passing any held numbers to the next in line
+func (uc *WaitlistUseCases) Leave(ctx context.Context, raffleID, userID uuid.UUID) error {
+	entry, err := uc.findActive(ctx, raffleID, userID)
+	if err != nil {
+		return err
+	}
+
+	offered := entry.Status == entities.WaitlistStatusOffered
+	if err := entry.Cancel(); err != nil {
+		return err
+	}
+	if err := uc.waitlistRepo.Update(ctx, entry); err != nil {
+		return fmt.Errorf("error updating waitlist entry: %w", err)
+	}
+
+	if offered {
+		uc.passOffer(ctx, entry)
+	}
+	return nil
+}
+
+// Claim turns the user's active offer into a reservation of the held numbers
+// The reservation reuses the offer's lock owner, so the numbers never become free in between
+func (uc *WaitlistUseCases) Claim(ctx context.Context, raffleID, userID uuid.UUID, sessionID string) (*entities.Reservation, error) {
+	entry, err := uc.findActive(ctx, raffleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != entities.WaitlistStatusOffered || entry.OfferReservationID == nil {
+		return nil, entities.ErrWaitlistNoOffer
+	}
+	if entry.IsOfferExpired() {
+		return nil, entities.ErrWaitlistOfferExpired
+	}
+
+	raffle, err := uc.reservations.findOpenRaffle(raffleID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := uc.reservations.newPricedReservation(raffle, CreateReservationInput{
+		RaffleID:  raffleID,
+		UserID:    userID,
+		NumberIDs: entry.OfferedNumbers,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reservation.ID = *entry.OfferReservationID
+
+	// Move the held locks to the selection phase timeout
+	if err := uc.reservations.extendLocks(ctx, reservation, entities.ReservationSelectionTimeout); err != nil {
+		return nil, err
+	}
+
+	if err := entry.Claim(); err != nil {
+		return nil, err
+	}
+	if err := uc.waitlistRepo.Update(ctx, entry); err != nil {
+		return nil, fmt.Errorf("error updating waitlist entry: %w", err)
+	}
+
+	if err := uc.reservations.saveReservation(ctx, raffle, reservation); err != nil {
+		_ = uc.reservations.releaseLocks(ctx, reservation)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ExpireOffers removes the entries whose offer was not claimed in time and passes
+// their numbers to the next users in line
+func (uc *WaitlistUseCases) ExpireOffers(ctx context.Context) (int, error) {
+	entries, err := uc.waitlistRepo.FindExpiredOffers(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error fetching expired waitlist offers: %w", err)
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if err := entry.ExpireOffer(); err != nil {
+			continue
+		}
+		if err := uc.waitlistRepo.Update(ctx, entry); err != nil {
+			// Log error but continue
+			fmt.Printf("[ExpireOffers] Error updating waitlist entry %s: %v\n", entry.ID, err)
+			continue
+		}
+
+		uc.passOffer(ctx, entry)
+		count++
+	}
+
+	return count, nil
+}
+
+// OfferReleasedNumbers offers numbers released by a reservation to the raffle waitlist in
+// FIFO order and returns the numbers that were not offered (they are available to everyone)
+func (uc *WaitlistUseCases) OfferReleasedNumbers(ctx context.Context, raffleID uuid.UUID, numbers []string) []string {
+	if len(numbers) == 0 {
+		return numbers
+	}
+
+	entries, err := uc.waitlistRepo.FindWaiting(ctx, raffleID, len(numbers))
+	if err != nil {
+		fmt.Printf("[OfferReleasedNumbers] Error fetching waitlist: %v\n", err)
+		return numbers
+	}
+
+	remaining := numbers
+	for _, entry := range entries {
+		if len(remaining) == 0 {
+			break
+		}
+		remaining = uc.offer(ctx, entry, remaining)
+	}
+
+	return remaining
+}
+
+// offer holds up to entry.Quantity of the numbers for the entry and notifies the user
+// Returns the numbers not held for the entry
+func (uc *WaitlistUseCases) offer(ctx context.Context, entry *entities.WaitlistEntry, numbers []string) []string {
+	reservationID := uuid.New()
+
+	held := make([]string, 0, entry.Quantity)
+	locks := make([]*redis.Lock, 0, entry.Quantity)
+	remaining := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		if len(held) == entry.Quantity {
+			remaining = append(remaining, number)
+			continue
+		}
+
+		lock, err := uc.reservations.lockService.AcquireLockWithOwner(ctx, redis.ReservationLockKey(entry.RaffleID.String(), number), reservationID.String(), uc.holdDuration)
+		if err != nil {
+			// Locked by someone else in the meantime: not released anymore
+			continue
+		}
+		held = append(held, number)
+		locks = append(locks, lock)
+	}
+
+	if len(held) == 0 {
+		return remaining
+	}
+
+	if err := entry.Offer(held, reservationID, uc.holdDuration); err != nil {
+		_ = redis.ReleaseMultipleLocks(ctx, locks)
+		return append(remaining, held...)
+	}
+	if err := uc.waitlistRepo.Update(ctx, entry); err != nil {
+		fmt.Printf("[OfferReleasedNumbers] Error updating waitlist entry %s: %v\n", entry.ID, err)
+		_ = redis.ReleaseMultipleLocks(ctx, locks)
+		return append(remaining, held...)
+	}
+
+	uc.notifyOffer(entry)
+	return remaining
+}
+
+// passOffer releases the numbers held for an entry that left the waitlist or let the offer expire
+// and offers them to the next users in line
+func (uc *WaitlistUseCases) passOffer(ctx context.Context, entry *entities.WaitlistEntry) {
+	if entry.OfferReservationID == nil || len(entry.OfferedNumbers) == 0 {
+		return
+	}
+
+	// Only release holds still owned by the offer (they may have expired and been taken)
+	owner := entry.OfferReservationID.String()
+	released := make([]string, 0, len(entry.OfferedNumbers))
+	for _, number := range entry.OfferedNumbers {
+		lock := uc.reservations.lockService.OwnedLock(redis.ReservationLockKey(entry.RaffleID.String(), number), owner)
+		if err := lock.Release(ctx); err != nil && !errors.Is(err, redis.ErrLockNotHeld) {
+			fmt.Printf("[WaitlistOffer] Error releasing held number %s: %v\n", number, err)
+			continue
+		}
+		released = append(released, number)
+	}
+
+	available := uc.OfferReleasedNumbers(ctx, entry.RaffleID, released)
+	for _, numberID := range available {
+		uc.reservations.wsHub.BroadcastNumberUpdate(entry.RaffleID.String(), numberID, "available", nil)
+	}
+}
+
+// notifyOffer tells the user about the offer through the hub and by email
+func (uc *WaitlistUseCases) notifyOffer(entry *entities.WaitlistEntry) {
+	numbers := []string(entry.OfferedNumbers)
+	raffleID := entry.RaffleID.String()
+
+	// The numbers are not available to others during the hold window
+	userIDStr := entry.UserID.String()
+	for _, numberID := range numbers {
+		uc.reservations.wsHub.BroadcastNumberUpdate(raffleID, numberID, "reserved", &userIDStr)
+	}
+
+	uc.reservations.wsHub.NotifyWaitlistOffer(userIDStr, raffleID, entry.ID.String(), numbers, *entry.OfferExpiresAt)
+
+	if uc.mailer == nil {
+		return
+	}
+
+	user, err := uc.reservations.userRepo.FindByUUID(userIDStr)
+	if err != nil || user == nil {
+		fmt.Printf("[WaitlistOffer] Error finding user %s: %v\n", userIDStr, err)
+		return
+	}
+	raffleTitle := ""
+	if raffle, err := uc.reservations.raffleRepo.FindByUUID(raffleID); err == nil && raffle != nil {
+		raffleTitle = raffle.Title
+	}
+
+	// Email delivery must not delay the expiration job
+	go func() {
+		if err := uc.mailer.SendWaitlistOfferEmail(user.Email, raffleTitle, raffleID, numbers, *entry.OfferExpiresAt); err != nil {
+			fmt.Printf("[WaitlistOffer] Error sending offer email to %s: %v\n", user.Email, err)
+		}
+	}()
+}
+
+// findActive returns the user's waiting or offered entry for the raffle
+func (uc *WaitlistUseCases) findActive(ctx context.Context, raffleID, userID uuid.UUID) (*entities.WaitlistEntry, error) {
+	entry, err := uc.waitlistRepo.FindActiveByUserAndRaffle(ctx, userID, raffleID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching waitlist entry: %w", err)
+	}
+	if entry == nil {
+		return nil, ErrWaitlistEntryNotFound
+	}
+	return entry, nil
+}
+
+// status builds the entry output with its queue position
+func (uc *WaitlistUseCases) status(ctx context.Context, entry *entities.WaitlistEntry) (*WaitlistStatusOutput, error) {
+	output := &WaitlistStatusOutput{Entry: entry}
+	if entry.Status != entities.WaitlistStatusWaiting {
+		return output, nil
+	}
+
+	ahead, err := uc.waitlistRepo.CountWaitingBefore(ctx, entry.RaffleID, entry.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error counting waitlist position: %w", err)
+	}
+	output.Position = ahead + 1
+	return output, nil
+}
@@ -0,0 +1,5 @@
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS draw_proof,
+    DROP COLUMN IF EXISTS draw_seed_committed_at,
+    DROP COLUMN IF EXISTS draw_server_seed,
+    DROP COLUMN IF EXISTS draw_seed_hash;
@@ -0,0 +1,13 @@
+-- Migration: 000023_raffle_draw_proof
+-- Purpose: Sorteo verificable (commit-reveal) para rifas con draw_method = 'random'
+
+ALTER TABLE raffles
+    ADD COLUMN draw_seed_hash VARCHAR(64),
+    ADD COLUMN draw_server_seed VARCHAR(64),
+    ADD COLUMN draw_seed_committed_at TIMESTAMP,
+    ADD COLUMN draw_proof JSONB;
+
+COMMENT ON COLUMN raffles.draw_seed_hash IS 'SHA-256 de la semilla del servidor, publicado al activar la rifa';
+COMMENT ON COLUMN raffles.draw_server_seed IS 'Semilla secreta del servidor, se revela en draw_proof al ejecutar el sorteo';
+COMMENT ON COLUMN raffles.draw_seed_committed_at IS 'Momento en que se publicó el hash de la semilla';
+COMMENT ON COLUMN raffles.draw_proof IS 'Prueba pública del sorteo: semilla revelada, entropía pública, participantes e índice ganador';
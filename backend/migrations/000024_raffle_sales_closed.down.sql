@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_raffles_draw_due;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS sales_closed_at;
@@ -0,0 +1,11 @@
+-- Migration: 000024_raffle_sales_closed
+-- Purpose: Cierre de ventas y sorteo automático al llegar draw_date
+
+ALTER TABLE raffles
+    ADD COLUMN sales_closed_at TIMESTAMP;
+
+COMMENT ON COLUMN raffles.sales_closed_at IS 'Momento en que el job de sorteos cerró las ventas de la rifa';
+
+-- Índice para el job de sorteos programados
+CREATE INDEX idx_raffles_draw_due ON raffles(draw_date)
+    WHERE status = 'active' AND winner_number IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DELETE FROM system_parameters WHERE key = 'lottery_mapping_rule';
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS lottery_result_id;
+
+DROP TRIGGER IF EXISTS update_lottery_results_updated_at ON lottery_results;
+DROP TABLE IF EXISTS lottery_results;
@@ -0,0 +1,49 @@
+-- Migration: 000025_lottery_results
+-- Purpose: Resultados de la Lotería Nacional de Costa Rica para rifas con draw_method = 'loteria_nacional_cr'
+
+CREATE TABLE lottery_results (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    draw_date DATE NOT NULL,
+    draw_number VARCHAR(20),
+    winning_number VARCHAR(10) NOT NULL,
+    series VARCHAR(10),
+    source VARCHAR(20) NOT NULL,
+    status VARCHAR(30) NOT NULL DEFAULT 'pending_confirmation',
+    entered_by BIGINT REFERENCES users(id),
+    confirmed_by BIGINT REFERENCES users(id),
+    confirmed_at TIMESTAMP,
+    rejection_reason TEXT,
+    raw_payload JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_lottery_results_source CHECK (source IN ('http', 'file', 'manual')),
+    CONSTRAINT chk_lottery_results_status CHECK (status IN ('pending_confirmation', 'confirmed', 'rejected')),
+    CONSTRAINT chk_lottery_results_two_person CHECK (
+        source <> 'manual' OR confirmed_by IS NULL OR confirmed_by <> entered_by
+    )
+);
+
+-- Solo un resultado vigente (pendiente o confirmado) por fecha
+CREATE UNIQUE INDEX idx_lottery_results_draw_date_active ON lottery_results(draw_date)
+    WHERE status <> 'rejected';
+
+CREATE TRIGGER update_lottery_results_updated_at
+    BEFORE UPDATE ON lottery_results
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE raffles
+    ADD COLUMN lottery_result_id BIGINT REFERENCES lottery_results(id);
+
+INSERT INTO system_parameters (key, value, value_type, category, description) VALUES
+    ('lottery_mapping_rule', '{"method":"last_digits","fallback":"next_sold"}', 'json', 'business',
+     'Regla para mapear el premio mayor de la Lotería Nacional al rango de la rifa (method: last_digits|modulo, fallback: next_sold|previous_sold|nearest_sold|manual)')
+ON CONFLICT (key) DO NOTHING;
+
+COMMENT ON TABLE lottery_results IS 'Resultados oficiales del premio mayor de la Lotería Nacional (JPS)';
+COMMENT ON COLUMN lottery_results.draw_date IS 'Fecha del sorteo en hora de Costa Rica';
+COMMENT ON COLUMN lottery_results.entered_by IS 'Admin que ingresó el resultado manualmente';
+COMMENT ON COLUMN lottery_results.confirmed_by IS 'Segundo admin que confirmó o rechazó el resultado manual';
+COMMENT ON COLUMN raffles.lottery_result_id IS 'Resultado de lotería utilizado para determinar el ganador';
@@ -0,0 +1,14 @@
+-- Rollback de migración 000026
+
+DROP TRIGGER IF EXISTS update_payment_refunds_updated_at ON payment_refunds;
+
+DROP INDEX IF EXISTS idx_payment_refunds_in_flight;
+DROP INDEX IF EXISTS idx_payment_refunds_due;
+DROP INDEX IF EXISTS idx_payment_refunds_raffle_id;
+DROP INDEX IF EXISTS idx_payment_refunds_payment_id;
+
+DROP TABLE IF EXISTS payment_refunds;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS refunded_at,
+    DROP COLUMN IF EXISTS refunded_amount;
@@ -0,0 +1,64 @@
+-- Migration: 000026_payment_refunds
+-- Purpose: Reembolsos reales a través del proveedor de pago con reintentos y respaldo a billetera
+
+-- Montos reembolsados en pagos (reembolsos parciales acumulados)
+ALTER TABLE payments
+    ADD COLUMN refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN refunded_at TIMESTAMP;
+
+-- Tabla de reembolsos (uno o más por pago)
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE RESTRICT,
+    raffle_id UUID NOT NULL REFERENCES raffles(uuid) ON DELETE RESTRICT,
+
+    -- Monto
+    amount DECIMAL(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+
+    -- Forma de devolución
+    method VARCHAR(20) NOT NULL,                  -- provider, wallet_credit
+    provider VARCHAR(20) NOT NULL,                -- stripe, paypal, wallet
+    provider_refund_id VARCHAR(255),              -- ID del reembolso en el proveedor
+    provider_status VARCHAR(50),                  -- Estado reportado por el proveedor
+    wallet_transaction_id BIGINT REFERENCES wallet_transactions(id),
+
+    -- Máquina de estados
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, processing, succeeded, failed
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_error TEXT,
+
+    -- Contexto
+    reason TEXT NOT NULL,
+    requested_by BIGINT REFERENCES users(id),
+
+    -- Auditoría
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+
+    CONSTRAINT chk_payment_refunds_amount CHECK (amount > 0),
+    CONSTRAINT chk_payment_refunds_method CHECK (method IN ('provider', 'wallet_credit')),
+    CONSTRAINT chk_payment_refunds_status CHECK (status IN ('pending', 'processing', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_payment_refunds_payment_id ON payment_refunds(payment_id);
+CREATE INDEX idx_payment_refunds_raffle_id ON payment_refunds(raffle_id);
+CREATE INDEX idx_payment_refunds_due ON payment_refunds(next_attempt_at)
+    WHERE status IN ('pending', 'processing');
+
+-- Un solo reembolso en curso por pago (evita reembolsar dos veces en paralelo)
+CREATE UNIQUE INDEX idx_payment_refunds_in_flight ON payment_refunds(payment_id)
+    WHERE status IN ('pending', 'processing');
+
+CREATE TRIGGER update_payment_refunds_updated_at
+    BEFORE UPDATE ON payment_refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE payment_refunds IS 'Reembolsos de pagos ejecutados en Stripe/PayPal o acreditados a la billetera';
+COMMENT ON COLUMN payment_refunds.method IS 'provider: devolución al medio de pago original; wallet_credit: crédito a la billetera';
+COMMENT ON COLUMN payment_refunds.next_attempt_at IS 'Próximo reintento (backoff) o próxima consulta de estado al proveedor';
@@ -0,0 +1,21 @@
+-- Rollback de migración 000027
+
+DELETE FROM system_parameters WHERE key = 'prize_claim_deadline_days';
+
+DROP TRIGGER IF EXISTS update_prize_claims_updated_at ON prize_claims;
+
+DROP INDEX IF EXISTS idx_prize_claims_open;
+DROP INDEX IF EXISTS idx_prize_claims_winner_user_id;
+DROP INDEX IF EXISTS idx_prize_claims_raffle_id;
+
+DROP TABLE IF EXISTS prize_claims;
+
+ALTER TABLE raffles
+    DROP CONSTRAINT IF EXISTS chk_raffles_prize_amount,
+    DROP CONSTRAINT IF EXISTS chk_raffles_prize_type,
+    DROP COLUMN IF EXISTS prize_description,
+    DROP COLUMN IF EXISTS prize_amount,
+    DROP COLUMN IF EXISTS prize_type;
+
+-- Nota: PostgreSQL no permite eliminar valores de un ENUM; las acciones de auditoría
+-- prize_paid, prize_claim_opened y prize_claim_resolved permanecen en audit_action
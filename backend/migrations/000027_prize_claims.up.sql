@@ -0,0 +1,84 @@
+-- Migration: 000027_prize_claims
+-- Purpose: Entrega de premios (efectivo a billetera y reclamo de premios físicos)
+
+-- Tipo de premio del sorteo
+ALTER TABLE raffles
+    ADD COLUMN prize_type VARCHAR(20) NOT NULL DEFAULT 'physical',
+    ADD COLUMN prize_amount DECIMAL(12,2),
+    ADD COLUMN prize_description TEXT,
+    ADD CONSTRAINT chk_raffles_prize_type CHECK (prize_type IN ('cash', 'physical')),
+    ADD CONSTRAINT chk_raffles_prize_amount CHECK (
+        prize_type <> 'cash' OR (prize_amount IS NOT NULL AND prize_amount > 0)
+    );
+
+COMMENT ON COLUMN raffles.prize_type IS 'cash: se acredita al saldo de ganancias del ganador; physical: requiere reclamo y entrega';
+COMMENT ON COLUMN raffles.prize_amount IS 'Monto del premio en efectivo';
+
+-- Reclamos de premios (uno por sorteo completado con ganador)
+CREATE TABLE IF NOT EXISTS prize_claims (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE RESTRICT,
+    winner_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    winner_number VARCHAR(20) NOT NULL,
+
+    -- Premio
+    prize_type VARCHAR(20) NOT NULL,
+    amount DECIMAL(12,2),
+    description TEXT,
+    wallet_transaction_id BIGINT REFERENCES wallet_transactions(id),
+
+    -- Estado del reclamo
+    status VARCHAR(30) NOT NULL,
+    claim_deadline TIMESTAMP,
+
+    -- Verificación de identidad
+    identity_confirmed_at TIMESTAMP,
+    identity_confirmed_by BIGINT REFERENCES users(id),
+    identity_notes TEXT,
+
+    -- Entrega
+    delivery_address TEXT,
+    carrier VARCHAR(100),
+    tracking_number VARCHAR(100),
+    shipped_at TIMESTAMP,
+    delivered_at TIMESTAMP,
+
+    -- Resolución
+    resolved_by BIGINT REFERENCES users(id),
+    resolution_notes TEXT,
+
+    -- Auditoría
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+
+    CONSTRAINT chk_prize_claims_prize_type CHECK (prize_type IN ('cash', 'physical')),
+    CONSTRAINT chk_prize_claims_status CHECK (
+        status IN ('paid', 'pending_identity', 'pending_delivery', 'shipped', 'delivered', 'expired', 'cancelled')
+    )
+);
+
+-- Un solo reclamo por sorteo (la entrega del premio es idempotente)
+CREATE UNIQUE INDEX idx_prize_claims_raffle_id ON prize_claims(raffle_id);
+CREATE INDEX idx_prize_claims_winner_user_id ON prize_claims(winner_user_id);
+CREATE INDEX idx_prize_claims_open ON prize_claims(claim_deadline)
+    WHERE status IN ('pending_identity', 'pending_delivery', 'shipped');
+
+CREATE TRIGGER update_prize_claims_updated_at
+    BEFORE UPDATE ON prize_claims
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE prize_claims IS 'Entrega de premios: acreditación de efectivo o reclamo de premio físico';
+COMMENT ON COLUMN prize_claims.claim_deadline IS 'Fecha límite para confirmar identidad; vencida, el reclamo expira';
+
+-- Plazo para reclamar premios físicos
+INSERT INTO system_parameters (key, value, value_type, category, description) VALUES
+    ('prize_claim_deadline_days', '30', 'int', 'business', 'Días que tiene el ganador para reclamar un premio físico')
+ON CONFLICT (key) DO NOTHING;
+
+-- Acciones de auditoría de premios
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'prize_paid';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'prize_claim_opened';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'prize_claim_resolved';
@@ -0,0 +1,15 @@
+-- Rollback de migración 000028
+
+DELETE FROM system_parameters WHERE key = 'min_withdrawal_amount';
+
+DROP TRIGGER IF EXISTS update_withdrawals_updated_at ON withdrawals;
+
+DROP INDEX IF EXISTS idx_withdrawals_user_open;
+DROP INDEX IF EXISTS idx_withdrawals_batch_id;
+DROP INDEX IF EXISTS idx_withdrawals_status;
+DROP INDEX IF EXISTS idx_withdrawals_user_id;
+
+DROP TABLE IF EXISTS withdrawals;
+
+-- Nota: PostgreSQL no permite eliminar valores de un ENUM; las acciones withdrawal_*
+-- permanecen en audit_action
@@ -0,0 +1,72 @@
+-- Migration: 000028_withdrawals
+-- Purpose: Retiros del saldo de ganancias a cuentas IBAN con cola de aprobación y lotes bancarios
+
+CREATE TABLE IF NOT EXISTS withdrawals (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE RESTRICT,
+
+    -- Monto y destino (copiados al solicitar: cambios posteriores del IBAN no afectan el retiro)
+    amount DECIMAL(12,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'CRC',
+    iban VARCHAR(24) NOT NULL,
+    account_holder VARCHAR(255) NOT NULL,
+    account_holder_id VARCHAR(50),
+
+    -- Transacción de billetera (pending mientras el retiro está abierto)
+    transaction_id BIGINT REFERENCES wallet_transactions(id),
+
+    -- Estado
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+
+    -- Revisión
+    reviewed_by BIGINT REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    rejection_reason TEXT,
+
+    -- Lote bancario
+    batch_id VARCHAR(50),
+    exported_at TIMESTAMP,
+    bank_reference VARCHAR(100),
+
+    -- Auditoría
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+
+    CONSTRAINT chk_withdrawals_amount CHECK (amount > 0),
+    CONSTRAINT chk_withdrawals_status CHECK (
+        status IN ('pending', 'approved', 'processing', 'completed', 'rejected', 'cancelled')
+    )
+);
+
+CREATE INDEX idx_withdrawals_user_id ON withdrawals(user_id);
+CREATE INDEX idx_withdrawals_status ON withdrawals(status, created_at);
+CREATE INDEX idx_withdrawals_batch_id ON withdrawals(batch_id) WHERE batch_id IS NOT NULL;
+
+-- Un solo retiro abierto por usuario
+CREATE UNIQUE INDEX idx_withdrawals_user_open ON withdrawals(user_id)
+    WHERE status IN ('pending', 'approved', 'processing');
+
+CREATE TRIGGER update_withdrawals_updated_at
+    BEFORE UPDATE ON withdrawals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE withdrawals IS 'Retiros de earnings_balance a cuentas IBAN costarricenses';
+COMMENT ON COLUMN withdrawals.status IS 'pending -> approved -> processing (lote exportado) -> completed; rejected/cancelled liberan la retención';
+COMMENT ON COLUMN withdrawals.batch_id IS 'Lote de transferencias bancarias en el que se exportó el retiro';
+
+-- Monto mínimo de retiro
+INSERT INTO system_parameters (key, value, value_type, category, description) VALUES
+    ('min_withdrawal_amount', '5000.0', 'float', 'payment', 'Monto mínimo de retiro de ganancias (CRC)')
+ON CONFLICT (key) DO NOTHING;
+
+-- Acciones de auditoría de retiros
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_requested';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_approved';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_exported';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_completed';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_rejected';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'withdrawal_cancelled';
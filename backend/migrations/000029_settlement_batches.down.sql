@@ -0,0 +1,11 @@
+-- Rollback de migración 000029
+
+DROP INDEX IF EXISTS idx_settlements_batch_id;
+
+ALTER TABLE settlements DROP COLUMN IF EXISTS batch_id;
+
+DROP TRIGGER IF EXISTS update_settlement_batches_updated_at ON settlement_batches;
+
+DROP INDEX IF EXISTS idx_settlement_batches_organizer_id;
+
+DROP TABLE IF EXISTS settlement_batches;
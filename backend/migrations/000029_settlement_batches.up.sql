@@ -0,0 +1,41 @@
+-- Migration: 000029_settlement_batches
+-- Purpose: Liquidaciones programadas según payout_schedule del organizador, agrupadas en lotes de pago
+
+CREATE TABLE IF NOT EXISTS settlement_batches (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    organizer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+
+    -- Período
+    payout_schedule VARCHAR(20) NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+
+    -- Totales
+    raffle_count INT NOT NULL DEFAULT 0,
+    gross_revenue DECIMAL(12,2) NOT NULL DEFAULT 0,
+    platform_fee DECIMAL(12,2) NOT NULL DEFAULT 0,
+    platform_fee_percentage DECIMAL(5,2) NOT NULL,
+    net_payout DECIMAL(12,2) NOT NULL DEFAULT 0,
+
+    -- Auditoría
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_settlement_batches_schedule CHECK (payout_schedule IN ('weekly', 'monthly'))
+);
+
+CREATE INDEX idx_settlement_batches_organizer_id ON settlement_batches(organizer_id, period_end DESC);
+
+CREATE TRIGGER update_settlement_batches_updated_at
+    BEFORE UPDATE ON settlement_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE settlements
+    ADD COLUMN batch_id BIGINT REFERENCES settlement_batches(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_settlements_batch_id ON settlements(batch_id) WHERE batch_id IS NOT NULL;
+
+COMMENT ON TABLE settlement_batches IS 'Lotes de liquidaciones creados automáticamente según payout_schedule';
+COMMENT ON COLUMN settlement_batches.period_end IS 'Corte del período: incluye sorteos completados antes de esta fecha';
+COMMENT ON COLUMN settlements.batch_id IS 'Lote de pago programado (NULL si se creó manualmente)';
@@ -0,0 +1,5 @@
+-- Rollback de migración 000030
+
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
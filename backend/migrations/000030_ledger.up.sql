@@ -0,0 +1,109 @@
+-- Migration: 000030_ledger
+-- Purpose: Libro mayor de partida doble detrás de wallets y wallet_transactions
+
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    account_type VARCHAR(30) NOT NULL,
+    owner_user_id BIGINT REFERENCES users(id) ON DELETE RESTRICT,
+    currency VARCHAR(3) NOT NULL DEFAULT 'CRC',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_ledger_accounts_type CHECK (
+        account_type IN (
+            'user_available', 'user_earnings', 'user_pending', 'organizer_payable',
+            'platform_revenue', 'processor_clearing', 'processor_fees', 'refunds', 'opening_balance'
+        )
+    ),
+    CONSTRAINT chk_ledger_accounts_owner CHECK (
+        (account_type IN ('user_available', 'user_earnings', 'user_pending', 'organizer_payable')) = (owner_user_id IS NOT NULL)
+    )
+);
+
+-- Una cuenta por tipo, dueño y moneda (las cuentas de plataforma no tienen dueño)
+CREATE UNIQUE INDEX idx_ledger_accounts_unique ON ledger_accounts(account_type, (COALESCE(owner_user_id, 0)), currency);
+CREATE INDEX idx_ledger_accounts_owner ON ledger_accounts(owner_user_id) WHERE owner_user_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    entry_type VARCHAR(30) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'CRC',
+    idempotency_key VARCHAR(255) UNIQUE NOT NULL,
+
+    -- Origen del movimiento
+    reference_type VARCHAR(50),
+    reference_id VARCHAR(100),
+    wallet_transaction_id BIGINT REFERENCES wallet_transactions(id),
+    description TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_journal_entries_reference ON journal_entries(reference_type, reference_id);
+CREATE INDEX idx_journal_entries_wallet_transaction ON journal_entries(wallet_transaction_id) WHERE wallet_transaction_id IS NOT NULL;
+CREATE INDEX idx_journal_entries_type ON journal_entries(entry_type, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id BIGINT NOT NULL REFERENCES journal_entries(id) ON DELETE RESTRICT,
+    account_id BIGINT NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    direction VARCHAR(6) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+
+    CONSTRAINT chk_journal_lines_direction CHECK (direction IN ('debit', 'credit')),
+    CONSTRAINT chk_journal_lines_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_journal_lines_entry_id ON journal_lines(entry_id);
+CREATE INDEX idx_journal_lines_account_id ON journal_lines(account_id);
+
+-- Saldos de apertura: los saldos existentes se registran contra opening_balance
+-- para que el verificador de consistencia parta de billeteras cuadradas
+INSERT INTO ledger_accounts (account_type, owner_user_id, currency)
+SELECT DISTINCT 'opening_balance', NULL::BIGINT, currency
+FROM wallets
+WHERE balance_available <> 0 OR earnings_balance <> 0 OR pending_balance <> 0
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ledger_accounts (account_type, owner_user_id, currency)
+SELECT t.account_type, w.user_id, w.currency
+FROM wallets w
+CROSS JOIN (VALUES ('user_available'), ('user_earnings'), ('user_pending')) AS t(account_type)
+WHERE (t.account_type = 'user_available' AND w.balance_available <> 0)
+   OR (t.account_type = 'user_earnings' AND w.earnings_balance <> 0)
+   OR (t.account_type = 'user_pending' AND w.pending_balance <> 0)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO journal_entries (entry_type, currency, idempotency_key, reference_type, reference_id, description)
+SELECT 'opening_balance', w.currency, 'opening_balance:wallet:' || w.id, 'wallet', w.id::TEXT, 'Saldo de apertura del libro mayor'
+FROM wallets w
+WHERE w.balance_available <> 0 OR w.earnings_balance <> 0 OR w.pending_balance <> 0;
+
+-- Una línea por saldo de usuario (crédito si es positivo) y su contrapartida en opening_balance
+INSERT INTO journal_lines (entry_id, account_id, direction, amount)
+SELECT e.id, a.id,
+    CASE WHEN b.amount > 0 THEN 'credit' ELSE 'debit' END,
+    ABS(b.amount)
+FROM wallets w
+JOIN journal_entries e ON e.idempotency_key = 'opening_balance:wallet:' || w.id
+CROSS JOIN LATERAL (VALUES
+    ('user_available', w.balance_available),
+    ('user_earnings', w.earnings_balance),
+    ('user_pending', w.pending_balance)
+) AS b(account_type, amount)
+JOIN ledger_accounts a ON a.account_type = b.account_type AND a.owner_user_id = w.user_id AND a.currency = w.currency
+WHERE b.amount <> 0;
+
+INSERT INTO journal_lines (entry_id, account_id, direction, amount)
+SELECT e.id, a.id,
+    CASE WHEN t.total > 0 THEN 'debit' ELSE 'credit' END,
+    ABS(t.total)
+FROM wallets w
+JOIN journal_entries e ON e.idempotency_key = 'opening_balance:wallet:' || w.id
+CROSS JOIN LATERAL (SELECT w.balance_available + w.earnings_balance + w.pending_balance AS total) t
+JOIN ledger_accounts a ON a.account_type = 'opening_balance' AND a.owner_user_id IS NULL AND a.currency = w.currency
+WHERE t.total <> 0;
+
+COMMENT ON TABLE ledger_accounts IS 'Cuentas del libro mayor (por usuario u organizador y globales de plataforma)';
+COMMENT ON TABLE journal_entries IS 'Asientos contables: cada movimiento de dinero registra débitos y créditos balanceados';
+COMMENT ON TABLE journal_lines IS 'Líneas de asiento; la suma de débitos de un asiento es igual a la de créditos';
@@ -0,0 +1,17 @@
+-- Rollback de migración 000031
+
+DELETE FROM system_parameters WHERE key = 'credit_reconciliation_stale_minutes';
+
+DROP INDEX IF EXISTS idx_credit_purchases_open_updated_at;
+
+DROP INDEX IF EXISTS idx_credit_reconciliation_items_purchase_id;
+DROP INDEX IF EXISTS idx_credit_reconciliation_items_reconciliation_id;
+
+DROP TABLE IF EXISTS credit_purchase_reconciliation_items;
+
+DROP INDEX IF EXISTS idx_credit_purchase_reconciliations_started_at;
+
+DROP TABLE IF EXISTS credit_purchase_reconciliations;
+
+-- Nota: PostgreSQL no permite eliminar valores de un ENUM; las acciones credit_purchase_*
+-- permanecen en audit_action
@@ -0,0 +1,65 @@
+-- Migration: 000031_credit_reconciliations
+-- Purpose: Conciliación nocturna de compras de créditos contra Pagadito y reporte de diferencias para admins
+
+CREATE TABLE IF NOT EXISTS credit_purchase_reconciliations (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+
+    -- Admin que la ejecutó manualmente (NULL = job nocturno)
+    triggered_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    stale_before TIMESTAMP NOT NULL,
+
+    -- Totales
+    checked INT NOT NULL DEFAULT 0,
+    credited INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    expired INT NOT NULL DEFAULT 0,
+    still_pending INT NOT NULL DEFAULT 0,
+    discrepancies INT NOT NULL DEFAULT 0,
+
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_credit_purchase_reconciliations_started_at ON credit_purchase_reconciliations(started_at DESC);
+
+CREATE TABLE IF NOT EXISTS credit_purchase_reconciliation_items (
+    id BIGSERIAL PRIMARY KEY,
+    reconciliation_id BIGINT NOT NULL REFERENCES credit_purchase_reconciliations(id) ON DELETE CASCADE,
+
+    purchase_id BIGINT NOT NULL REFERENCES credit_purchases(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    ern VARCHAR(255) NOT NULL,
+    local_status VARCHAR(20) NOT NULL,
+    pagadito_status VARCHAR(20),
+    action VARCHAR(20) NOT NULL,
+
+    charge_amount DECIMAL(12,2) NOT NULL,
+    pagadito_amount DECIMAL(12,2),
+
+    detail TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_credit_reconciliation_items_action CHECK (
+        action IN ('credited', 'failed', 'expired', 'still_pending', 'needs_review', 'error')
+    )
+);
+
+CREATE INDEX idx_credit_reconciliation_items_reconciliation_id ON credit_purchase_reconciliation_items(reconciliation_id);
+CREATE INDEX idx_credit_reconciliation_items_purchase_id ON credit_purchase_reconciliation_items(purchase_id);
+
+-- Búsqueda de compras sin conciliar
+CREATE INDEX IF NOT EXISTS idx_credit_purchases_open_updated_at ON credit_purchases(updated_at)
+    WHERE status IN ('pending', 'processing');
+
+INSERT INTO system_parameters (key, value, value_type, category, description) VALUES
+    ('credit_reconciliation_stale_minutes', '60', 'int', 'payment', 'Minutos sin actualizar antes de conciliar una compra de créditos con Pagadito')
+ON CONFLICT (key) DO NOTHING;
+
+-- Acciones de auditoría de compras de créditos
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'credit_purchase_completed';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'credit_purchase_failed';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'credit_purchase_expired';
+
+COMMENT ON TABLE credit_purchase_reconciliations IS 'Ejecuciones de la conciliación de compras de créditos contra Pagadito';
+COMMENT ON TABLE credit_purchase_reconciliation_items IS 'Compras acreditadas, cerradas o con diferencias en cada conciliación';
@@ -0,0 +1,6 @@
+-- Rollback de migración 000032
+
+DROP INDEX IF EXISTS idx_draw_room_events_room_created;
+
+DROP TABLE IF EXISTS draw_room_events;
+DROP TABLE IF EXISTS draw_rooms;
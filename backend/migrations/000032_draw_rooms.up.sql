@@ -0,0 +1,35 @@
+-- Migration: 000032_draw_rooms
+-- Purpose: Sala del sorteo en vivo (cuenta regresiva, transmisión y timeline de eventos del sorteo)
+
+CREATE TABLE IF NOT EXISTS draw_rooms (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL UNIQUE REFERENCES raffles(id) ON DELETE CASCADE,
+
+    -- Cuenta regresiva y transmisión opcional
+    scheduled_at TIMESTAMP NOT NULL,
+    stream_url VARCHAR(500),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_draw_rooms_status CHECK (status IN ('scheduled', 'live', 'finished'))
+);
+
+CREATE TABLE IF NOT EXISTS draw_room_events (
+    id BIGSERIAL PRIMARY KEY,
+    draw_room_id BIGINT NOT NULL REFERENCES draw_rooms(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    step INT NOT NULL DEFAULT 0,
+    data JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_draw_room_events_type CHECK (
+        type IN ('draw_scheduled', 'draw_started', 'draw_step', 'winner_revealed', 'draw_aborted')
+    )
+);
+
+CREATE INDEX idx_draw_room_events_room_created ON draw_room_events(draw_room_id, created_at);
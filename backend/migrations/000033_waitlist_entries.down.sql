@@ -0,0 +1,7 @@
+-- Rollback de migración 000033
+
+DROP INDEX IF EXISTS idx_waitlist_entries_offer_expires;
+DROP INDEX IF EXISTS idx_waitlist_entries_queue;
+DROP INDEX IF EXISTS idx_waitlist_entries_active_user;
+
+DROP TABLE IF EXISTS waitlist_entries;
@@ -0,0 +1,33 @@
+-- Migration: 000033_waitlist_entries
+-- Purpose: Lista de espera por sorteo para ofrecer números liberados (FIFO con retención exclusiva)
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    raffle_id UUID NOT NULL REFERENCES raffles(uuid) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+
+    -- Oferta activa: números retenidos para el usuario hasta offer_expires_at
+    offered_numbers TEXT[],
+    offer_reservation_id UUID,
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_waitlist_entries_status CHECK (status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled')),
+    CONSTRAINT chk_waitlist_entries_quantity CHECK (quantity BETWEEN 1 AND 10)
+);
+
+-- Un usuario solo puede estar una vez en la lista de espera activa de un sorteo
+CREATE UNIQUE INDEX idx_waitlist_entries_active_user
+    ON waitlist_entries(raffle_id, user_id)
+    WHERE status IN ('waiting', 'offered');
+
+-- Orden FIFO de la lista de espera
+CREATE INDEX idx_waitlist_entries_queue ON waitlist_entries(raffle_id, created_at) WHERE status = 'waiting';
+
+-- Ofertas por vencer (job de expiración)
+CREATE INDEX idx_waitlist_entries_offer_expires ON waitlist_entries(offer_expires_at) WHERE status = 'offered';
@@ -0,0 +1,16 @@
+-- Rollback de migración 000034
+
+DROP INDEX IF EXISTS idx_payments_cart_id;
+DROP INDEX IF EXISTS idx_payments_intent_reservation;
+
+ALTER TABLE payments
+    ADD CONSTRAINT payments_stripe_payment_intent_id_key UNIQUE (stripe_payment_intent_id);
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS cart_id;
+
+DROP INDEX IF EXISTS idx_carts_open_reservations;
+DROP INDEX IF EXISTS idx_carts_payment_intent_id;
+DROP INDEX IF EXISTS idx_carts_user_id;
+
+DROP TABLE IF EXISTS carts;
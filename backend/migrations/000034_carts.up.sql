@@ -0,0 +1,37 @@
+-- Migration: 000034_carts
+-- Purpose: Carrito multi-sorteo: varias reservas pagadas con un solo payment intent o un solo débito de billetera
+
+CREATE TABLE IF NOT EXISTS carts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    reservation_ids TEXT[] NOT NULL,
+    total_amount DECIMAL(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+
+    -- Pago único del carrito
+    payment_method VARCHAR(50),
+    payment_intent_id VARCHAR(255),
+    error_message TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP,
+
+    CONSTRAINT chk_carts_status CHECK (status IN ('open', 'paid', 'failed', 'cancelled')),
+    CONSTRAINT chk_carts_total_amount CHECK (total_amount > 0)
+);
+
+CREATE INDEX idx_carts_user_id ON carts(user_id);
+CREATE INDEX idx_carts_payment_intent_id ON carts(payment_intent_id) WHERE payment_intent_id IS NOT NULL;
+CREATE INDEX idx_carts_open_reservations ON carts USING GIN (reservation_ids) WHERE status = 'open';
+
+-- Los pagos de un carrito comparten el payment intent (un pago por reserva)
+ALTER TABLE payments
+    ADD COLUMN cart_id UUID REFERENCES carts(id) ON DELETE SET NULL;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_stripe_payment_intent_id_key;
+CREATE UNIQUE INDEX idx_payments_intent_reservation ON payments(stripe_payment_intent_id, reservation_id);
+CREATE INDEX idx_payments_cart_id ON payments(cart_id) WHERE cart_id IS NOT NULL;
+
+COMMENT ON COLUMN payments.cart_id IS 'Carrito cuyo pago único cubre esta reserva';
@@ -0,0 +1,4 @@
+-- Rollback de migración 000035
+
+-- Nota: PostgreSQL no permite eliminar valores de un ENUM; la acción inventory_repaired
+-- permanece en audit_action
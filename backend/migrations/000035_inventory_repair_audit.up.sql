@@ -0,0 +1,4 @@
+-- Migration: 000035_inventory_repair_audit
+-- Purpose: Acción de auditoría de las reparaciones de inventario (reservas, raffle_numbers, locks y contadores)
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'inventory_repaired';
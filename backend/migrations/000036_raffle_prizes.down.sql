@@ -0,0 +1,20 @@
+-- Rollback de migración 000036
+-- Nota: los reclamos de premios secundarios se eliminan para restaurar un reclamo por sorteo
+
+DELETE FROM prize_claims WHERE prize_position > 1;
+
+DROP INDEX IF EXISTS idx_prize_claims_raffle_position;
+CREATE UNIQUE INDEX idx_prize_claims_raffle_id ON prize_claims(raffle_id);
+
+ALTER TABLE prize_claims
+    DROP COLUMN IF EXISTS prize_position,
+    DROP COLUMN IF EXISTS prize_id;
+
+DROP INDEX IF EXISTS idx_raffle_winners_user_id;
+DROP INDEX IF EXISTS idx_raffle_winners_number;
+DROP INDEX IF EXISTS idx_raffle_winners_prize;
+DROP TABLE IF EXISTS raffle_winners;
+
+DROP TRIGGER IF EXISTS update_raffle_prizes_updated_at ON raffle_prizes;
+DROP INDEX IF EXISTS idx_raffle_prizes_position;
+DROP TABLE IF EXISTS raffle_prizes;
@@ -0,0 +1,76 @@
+-- Migration: 000036_raffle_prizes
+-- Purpose: Varios premios ordenados por sorteo con un número ganador distinto por premio
+
+CREATE TABLE IF NOT EXISTS raffle_prizes (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    description TEXT NOT NULL,
+    prize_type VARCHAR(20) NOT NULL DEFAULT 'physical',
+    value DECIMAL(12,2),
+    image_url TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_raffle_prizes_position CHECK (position BETWEEN 1 AND 10),
+    CONSTRAINT chk_raffle_prizes_prize_type CHECK (prize_type IN ('cash', 'physical')),
+    CONSTRAINT chk_raffle_prizes_value CHECK (
+        prize_type <> 'cash' OR (value IS NOT NULL AND value > 0)
+    )
+);
+
+CREATE UNIQUE INDEX idx_raffle_prizes_position ON raffle_prizes(raffle_id, position);
+
+CREATE TRIGGER update_raffle_prizes_updated_at
+    BEFORE UPDATE ON raffle_prizes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE raffle_prizes IS 'Premios del sorteo; position 1 es el premio mayor (reflejado en raffles.prize_*)';
+COMMENT ON COLUMN raffle_prizes.value IS 'Monto del premio en efectivo o valor estimado del premio físico';
+
+-- Ganadores por premio
+CREATE TABLE IF NOT EXISTS raffle_winners (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE RESTRICT,
+    prize_id BIGINT NOT NULL REFERENCES raffle_prizes(id) ON DELETE RESTRICT,
+    position INT NOT NULL,
+    number VARCHAR(20) NOT NULL,
+    user_id BIGINT REFERENCES users(id) ON DELETE RESTRICT,
+    drawn_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Un ganador por premio y un número no puede ganar dos premios del mismo sorteo
+CREATE UNIQUE INDEX idx_raffle_winners_prize ON raffle_winners(prize_id);
+CREATE UNIQUE INDEX idx_raffle_winners_number ON raffle_winners(raffle_id, number);
+CREATE INDEX idx_raffle_winners_user_id ON raffle_winners(user_id);
+
+COMMENT ON TABLE raffle_winners IS 'Número ganador de cada premio; raffles.winner_number conserva el del premio mayor';
+
+-- Premio único de los sorteos existentes
+INSERT INTO raffle_prizes (raffle_id, position, description, prize_type, value, created_at, updated_at)
+SELECT id, 1, COALESCE(NULLIF(prize_description, ''), title), prize_type, prize_amount, created_at, updated_at
+FROM raffles
+ON CONFLICT (raffle_id, position) DO NOTHING;
+
+-- Ganador de los sorteos ya completados
+INSERT INTO raffle_winners (raffle_id, prize_id, position, number, user_id, drawn_at, created_at)
+SELECT r.id, rp.id, 1, r.winner_number, r.winner_user_id, COALESCE(r.completed_at, r.updated_at), COALESCE(r.completed_at, r.updated_at)
+FROM raffles r
+JOIN raffle_prizes rp ON rp.raffle_id = r.id AND rp.position = 1
+WHERE r.winner_number IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Reclamos por premio (antes uno por sorteo)
+ALTER TABLE prize_claims
+    ADD COLUMN prize_id BIGINT REFERENCES raffle_prizes(id),
+    ADD COLUMN prize_position INT NOT NULL DEFAULT 1;
+
+UPDATE prize_claims pc
+SET prize_id = rp.id
+FROM raffle_prizes rp
+WHERE rp.raffle_id = pc.raffle_id AND rp.position = 1;
+
+DROP INDEX IF EXISTS idx_prize_claims_raffle_id;
+CREATE UNIQUE INDEX idx_prize_claims_raffle_position ON prize_claims(raffle_id, prize_position);
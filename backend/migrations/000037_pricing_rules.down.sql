@@ -0,0 +1,11 @@
+-- Rollback de migración 000037
+-- Nota: el valor raffle_pricing_set de audit_action no se elimina (PostgreSQL no soporta DROP VALUE)
+
+DROP INDEX IF EXISTS idx_payments_raffle_status;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS price_breakdown;
+
+DROP TRIGGER IF EXISTS update_pricing_rules_updated_at ON pricing_rules;
+DROP INDEX IF EXISTS idx_pricing_rules_raffle_id;
+DROP TABLE IF EXISTS pricing_rules;
@@ -0,0 +1,47 @@
+-- Migration: 000037_pricing_rules
+-- Purpose: Reglas de precio por sorteo (paquetes, descuentos por cantidad y anticipados) y desglose del total de cada reserva
+
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id BIGSERIAL PRIMARY KEY,
+    raffle_id BIGINT NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity INT NOT NULL,
+    bundle_price DECIMAL(12,2),
+    discount_percent DECIMAL(5,2),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_pricing_rules_type CHECK (type IN ('bundle', 'quantity_tier', 'early_bird')),
+    CONSTRAINT chk_pricing_rules_quantity CHECK (quantity >= 1),
+    CONSTRAINT chk_pricing_rules_amount CHECK (
+        (type = 'bundle' AND bundle_price > 0 AND discount_percent IS NULL) OR
+        (type <> 'bundle' AND bundle_price IS NULL AND discount_percent > 0 AND discount_percent < 100)
+    ),
+    CONSTRAINT chk_pricing_rules_window CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_pricing_rules_raffle_id ON pricing_rules(raffle_id);
+
+CREATE TRIGGER update_pricing_rules_updated_at
+    BEFORE UPDATE ON pricing_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE pricing_rules IS 'Reglas de precio evaluadas al calcular el total de una reserva';
+COMMENT ON COLUMN pricing_rules.quantity IS 'Números del paquete (bundle) o mínimo de números para aplicar el descuento';
+
+-- Desglose del total cobrado (subtotal, descuentos por regla y total)
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS price_breakdown JSONB;
+
+COMMENT ON COLUMN reservations.price_breakdown IS 'Desglose de total_amount con las reglas de precio aplicadas';
+
+-- Ingresos por sorteo a partir de los pagos (reportes y liquidaciones)
+CREATE INDEX IF NOT EXISTS idx_payments_raffle_status ON payments(raffle_id, status);
+
+-- Acción de auditoría al configurar las reglas de precio
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_pricing_set';
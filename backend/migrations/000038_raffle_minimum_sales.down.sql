@@ -0,0 +1,15 @@
+-- Rollback de migración 000038
+-- Nota: los valores raffle_draw_postponed y raffle_cancelled de audit_action no se eliminan (PostgreSQL no soporta DROP VALUE)
+
+DROP INDEX IF EXISTS idx_raffles_min_sales_pending;
+
+ALTER TABLE raffles
+    DROP CONSTRAINT IF EXISTS chk_raffles_min_sales_extension_days,
+    DROP CONSTRAINT IF EXISTS chk_raffles_min_sales_cutoff_hours,
+    DROP CONSTRAINT IF EXISTS chk_raffles_min_sold_count;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS min_sales_extended_at,
+    DROP COLUMN IF EXISTS min_sales_extension_days,
+    DROP COLUMN IF EXISTS min_sales_cutoff_hours,
+    DROP COLUMN IF EXISTS min_sold_count;
@@ -0,0 +1,27 @@
+-- Migration: 000038_raffle_minimum_sales
+-- Purpose: Mínimo de números vendidos por sorteo con postergación única o cancelación automática con reembolsos
+
+ALTER TABLE raffles
+    ADD COLUMN IF NOT EXISTS min_sold_count INT,
+    ADD COLUMN IF NOT EXISTS min_sales_cutoff_hours INT NOT NULL DEFAULT 24,
+    ADD COLUMN IF NOT EXISTS min_sales_extension_days INT,
+    ADD COLUMN IF NOT EXISTS min_sales_extended_at TIMESTAMP;
+
+ALTER TABLE raffles
+    ADD CONSTRAINT chk_raffles_min_sold_count CHECK (min_sold_count IS NULL OR (min_sold_count > 0 AND min_sold_count <= total_numbers)),
+    ADD CONSTRAINT chk_raffles_min_sales_cutoff_hours CHECK (min_sales_cutoff_hours BETWEEN 0 AND 168),
+    ADD CONSTRAINT chk_raffles_min_sales_extension_days CHECK (min_sales_extension_days IS NULL OR min_sales_extension_days BETWEEN 1 AND 30);
+
+-- Sorteos activos con mínimo pendiente de evaluar (job de mínimo de ventas)
+CREATE INDEX IF NOT EXISTS idx_raffles_min_sales_pending
+    ON raffles(draw_date)
+    WHERE min_sold_count IS NOT NULL AND status = 'active' AND deleted_at IS NULL;
+
+COMMENT ON COLUMN raffles.min_sold_count IS 'Mínimo de números vendidos para realizar el sorteo; NULL = sin mínimo';
+COMMENT ON COLUMN raffles.min_sales_cutoff_hours IS 'Horas antes de draw_date en que se evalúa el mínimo de ventas';
+COMMENT ON COLUMN raffles.min_sales_extension_days IS 'Días a posponer el sorteo una sola vez si no se alcanza el mínimo; NULL = cancelar';
+COMMENT ON COLUMN raffles.min_sales_extended_at IS 'Momento en que se pospuso el sorteo por ventas insuficientes';
+
+-- Acciones de auditoría del job de mínimo de ventas
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_draw_postponed';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_cancelled';
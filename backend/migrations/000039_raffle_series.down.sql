@@ -0,0 +1,19 @@
+-- Rollback de migración 000039
+-- Nota: los valores raffle_template_saved, raffle_series_updated y raffle_series_generated de audit_action
+-- no se eliminan (PostgreSQL no soporta DROP VALUE)
+
+DROP INDEX IF EXISTS idx_raffles_series_sequence;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS series_sequence,
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TRIGGER IF EXISTS update_raffle_series_updated_at ON raffle_series;
+DROP INDEX IF EXISTS idx_raffle_series_due;
+DROP INDEX IF EXISTS idx_raffle_series_template_id;
+DROP INDEX IF EXISTS idx_raffle_series_user_id;
+DROP TABLE IF EXISTS raffle_series;
+
+DROP TRIGGER IF EXISTS update_raffle_templates_updated_at ON raffle_templates;
+DROP INDEX IF EXISTS idx_raffle_templates_user_id;
+DROP TABLE IF EXISTS raffle_templates;
@@ -0,0 +1,113 @@
+-- Migration: 000039_raffle_series
+-- Purpose: Plantillas de sorteo y series recurrentes que generan y publican el siguiente sorteo automáticamente
+
+CREATE TABLE IF NOT EXISTS raffle_templates (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+
+    -- Datos del sorteo a generar
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    price_per_number DECIMAL(10,2) NOT NULL,
+    total_numbers INT NOT NULL,
+    min_number INT NOT NULL,
+    max_number INT NOT NULL,
+    draw_method VARCHAR(50) NOT NULL,
+    prize_type VARCHAR(20) NOT NULL,
+    prize_amount DECIMAL(12,2),
+    prize_description TEXT,
+
+    -- Premios, reglas de precio e imágenes copiadas a cada sorteo generado
+    prizes JSONB NOT NULL DEFAULT '[]',
+    pricing_rules JSONB NOT NULL DEFAULT '[]',
+    images JSONB NOT NULL DEFAULT '[]',
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+
+    CONSTRAINT chk_raffle_templates_price CHECK (price_per_number > 0),
+    CONSTRAINT chk_raffle_templates_numbers CHECK (total_numbers > 0 AND total_numbers <= 10000 AND max_number - min_number + 1 = total_numbers)
+);
+
+CREATE INDEX idx_raffle_templates_user_id ON raffle_templates(user_id) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER update_raffle_templates_updated_at
+    BEFORE UPDATE ON raffle_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE raffle_templates IS 'Plantillas de sorteo del organizador para crear sorteos repetidos';
+COMMENT ON COLUMN raffle_templates.pricing_rules IS 'Reglas de precio; las ventanas de tiempo son relativas a la creación del sorteo';
+COMMENT ON COLUMN raffle_templates.images IS 'Imágenes procesadas que se asocian a cada sorteo generado';
+
+CREATE TABLE IF NOT EXISTS raffle_series (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    template_id BIGINT NOT NULL REFERENCES raffle_templates(id),
+    name VARCHAR(100) NOT NULL,
+
+    -- Recurrencia
+    mode VARCHAR(20) NOT NULL,
+    cron_expression VARCHAR(100),
+    timezone VARCHAR(50) NOT NULL DEFAULT 'America/Costa_Rica',
+    draw_offset_hours INT NOT NULL,
+    auto_publish BOOLEAN NOT NULL DEFAULT TRUE,
+    max_occurrences INT,
+
+    -- Estado de la serie
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    occurrences INT NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP,
+    last_raffle_id BIGINT REFERENCES raffles(id) ON DELETE SET NULL,
+    last_run_at TIMESTAMP,
+    last_error TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMP,
+
+    CONSTRAINT chk_raffle_series_mode CHECK (mode IN ('on_completion', 'cron')),
+    CONSTRAINT chk_raffle_series_cron CHECK ((mode = 'cron') = (cron_expression IS NOT NULL)),
+    CONSTRAINT chk_raffle_series_status CHECK (status IN ('active', 'paused', 'ended')),
+    CONSTRAINT chk_raffle_series_draw_offset CHECK (draw_offset_hours BETWEEN 25 AND 2160),
+    CONSTRAINT chk_raffle_series_max_occurrences CHECK (max_occurrences IS NULL OR max_occurrences > 0),
+    CONSTRAINT chk_raffle_series_occurrences CHECK (occurrences >= 0)
+);
+
+CREATE INDEX idx_raffle_series_user_id ON raffle_series(user_id);
+CREATE INDEX idx_raffle_series_template_id ON raffle_series(template_id);
+
+-- Series activas pendientes de generar (job de series)
+CREATE INDEX idx_raffle_series_due ON raffle_series(next_run_at) WHERE status = 'active';
+
+CREATE TRIGGER update_raffle_series_updated_at
+    BEFORE UPDATE ON raffle_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE raffle_series IS 'Series de sorteos recurrentes generados desde una plantilla';
+COMMENT ON COLUMN raffle_series.mode IS 'on_completion: al completarse el sorteo anterior; cron: según cron_expression en timezone';
+COMMENT ON COLUMN raffle_series.draw_offset_hours IS 'Horas entre la generación del sorteo y su fecha de sorteo';
+COMMENT ON COLUMN raffle_series.occurrences IS 'Sorteos generados; es también la secuencia del último sorteo';
+
+-- Sorteos generados por una serie
+ALTER TABLE raffles
+    ADD COLUMN IF NOT EXISTS series_id BIGINT REFERENCES raffle_series(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS series_sequence INT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_raffles_series_sequence
+    ON raffles(series_id, series_sequence)
+    WHERE series_id IS NOT NULL;
+
+COMMENT ON COLUMN raffles.series_id IS 'Serie recurrente que generó el sorteo';
+COMMENT ON COLUMN raffles.series_sequence IS 'Número del sorteo dentro de su serie (1, 2, ...)';
+
+-- Acciones de auditoría de plantillas y series
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_template_saved';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_series_updated';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_series_generated';
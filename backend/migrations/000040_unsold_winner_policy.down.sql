@@ -0,0 +1,23 @@
+-- Rollback de migración 000040
+-- Nota: el valor raffle_jackpot_rolled_over de audit_action no se elimina (PostgreSQL no soporta DROP VALUE)
+
+ALTER TABLE raffle_templates
+    DROP CONSTRAINT IF EXISTS chk_raffle_templates_unsold_winner_policy;
+
+ALTER TABLE raffle_templates
+    DROP COLUMN IF EXISTS unsold_winner_policy;
+
+DROP INDEX IF EXISTS idx_raffles_pending_rollover;
+
+ALTER TABLE raffles
+    DROP CONSTRAINT IF EXISTS chk_raffles_rollover_amount,
+    DROP CONSTRAINT IF EXISTS chk_raffles_unsold_policy_applied,
+    DROP CONSTRAINT IF EXISTS chk_raffles_unsold_winner_policy;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS rolled_over_to_raffle_id,
+    DROP COLUMN IF EXISTS rolled_over_from_raffle_id,
+    DROP COLUMN IF EXISTS rollover_amount,
+    DROP COLUMN IF EXISTS lottery_mapped_number,
+    DROP COLUMN IF EXISTS unsold_policy_applied,
+    DROP COLUMN IF EXISTS unsold_winner_policy;
@@ -0,0 +1,38 @@
+-- Migration: 000040_unsold_winner_policy
+-- Purpose: Regla por sorteo cuando el número oficial de la Lotería Nacional no fue vendido
+-- (nuevo sorteo, número vendido más cercano o acumulación del premio mayor al siguiente sorteo de la serie)
+
+ALTER TABLE raffles
+    ADD COLUMN IF NOT EXISTS unsold_winner_policy VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS unsold_policy_applied VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS lottery_mapped_number INT,
+    ADD COLUMN IF NOT EXISTS rollover_amount DECIMAL(12, 2),
+    ADD COLUMN IF NOT EXISTS rolled_over_from_raffle_id BIGINT REFERENCES raffles(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS rolled_over_to_raffle_id BIGINT REFERENCES raffles(id) ON DELETE SET NULL;
+
+ALTER TABLE raffles
+    ADD CONSTRAINT chk_raffles_unsold_winner_policy CHECK (unsold_winner_policy IS NULL OR unsold_winner_policy IN ('redraw', 'nearest_sold', 'rollover')),
+    ADD CONSTRAINT chk_raffles_unsold_policy_applied CHECK (unsold_policy_applied IS NULL OR unsold_policy_applied IN ('redraw', 'nearest_sold', 'rollover')),
+    ADD CONSTRAINT chk_raffles_rollover_amount CHECK (rollover_amount IS NULL OR rollover_amount > 0);
+
+-- Premios mayores acumulados pendientes de pasar al siguiente sorteo de la serie
+CREATE INDEX IF NOT EXISTS idx_raffles_pending_rollover
+    ON raffles(series_id, series_sequence)
+    WHERE unsold_policy_applied = 'rollover' AND rolled_over_to_raffle_id IS NULL;
+
+COMMENT ON COLUMN raffles.unsold_winner_policy IS 'Regla si el número oficial no fue vendido: redraw, nearest_sold o rollover; NULL = regla general (lottery_mapping_rule)';
+COMMENT ON COLUMN raffles.unsold_policy_applied IS 'Regla aplicada en el sorteo porque el número oficial no se vendió (rollover sin serie disponible aplica redraw)';
+COMMENT ON COLUMN raffles.lottery_mapped_number IS 'Número oficial de la lotería llevado al rango del sorteo';
+COMMENT ON COLUMN raffles.rollover_amount IS 'Premio mayor acumulado recibido de sorteos anteriores de la serie (incluido en prize_amount)';
+COMMENT ON COLUMN raffles.rolled_over_from_raffle_id IS 'Último sorteo de la serie cuyo premio mayor se acumuló en este sorteo';
+COMMENT ON COLUMN raffles.rolled_over_to_raffle_id IS 'Sorteo de la serie que recibió el premio mayor acumulado de este sorteo';
+
+-- Las plantillas conservan la regla para los sorteos generados por sus series
+ALTER TABLE raffle_templates
+    ADD COLUMN IF NOT EXISTS unsold_winner_policy VARCHAR(20);
+
+ALTER TABLE raffle_templates
+    ADD CONSTRAINT chk_raffle_templates_unsold_winner_policy CHECK (unsold_winner_policy IS NULL OR unsold_winner_policy IN ('redraw', 'nearest_sold', 'rollover'));
+
+-- Acción de auditoría de acumulación del premio mayor
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_jackpot_rolled_over';
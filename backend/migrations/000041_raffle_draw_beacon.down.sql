@@ -0,0 +1,2 @@
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS draw_beacon_round;
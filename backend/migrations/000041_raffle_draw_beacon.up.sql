@@ -0,0 +1,7 @@
+-- Migration: 000041_raffle_draw_beacon
+-- Purpose: La entropía pública del sorteo verificable es una ronda del beacon drand comprometida al publicar la rifa
+
+ALTER TABLE raffles
+    ADD COLUMN draw_beacon_round BIGINT;
+
+COMMENT ON COLUMN raffles.draw_beacon_round IS 'Ronda del beacon drand (quicknet) emitida al cerrar las ventas; su aleatoriedad es la entropía pública del sorteo. NULL en rifas publicadas antes: se usa la ronda de draw_date';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_raffles_due_for_draw;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS draw_next_attempt_at,
+    DROP COLUMN IF EXISTS draw_attempts;
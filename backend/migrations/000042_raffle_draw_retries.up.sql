@@ -0,0 +1,13 @@
+-- Migration: 000042_raffle_draw_retries
+-- Purpose: Backoff de reintentos del sorteo automático para que los sorteos que no pueden completarse
+-- no bloqueen a los sorteos vencidos más recientes
+
+ALTER TABLE raffles
+    ADD COLUMN draw_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN draw_next_attempt_at TIMESTAMP;
+
+CREATE INDEX idx_raffles_due_for_draw ON raffles(COALESCE(draw_next_attempt_at, draw_date))
+    WHERE status = 'active' AND winner_number IS NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN raffles.draw_attempts IS 'Intentos del sorteo automático que no completaron el sorteo';
+COMMENT ON COLUMN raffles.draw_next_attempt_at IS 'Momento del siguiente intento del sorteo automático (NULL: en la fecha del sorteo)';
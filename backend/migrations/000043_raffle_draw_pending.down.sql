@@ -0,0 +1,9 @@
+-- Nota: PostgreSQL no permite eliminar valores de un enum; 'raffle_draw_pending' permanece en audit_action
+
+DROP INDEX IF EXISTS idx_raffles_draw_pending;
+
+ALTER TABLE raffles DROP CONSTRAINT IF EXISTS chk_raffles_draw_pending_reason;
+
+ALTER TABLE raffles
+    DROP COLUMN IF EXISTS draw_pending_since,
+    DROP COLUMN IF EXISTS draw_pending_reason;
@@ -0,0 +1,19 @@
+-- Migration: 000043_raffle_draw_pending
+-- Purpose: Registrar por qué un sorteo vencido no se completó automáticamente y listar para los admins
+-- los que requieren un sorteo manual
+
+ALTER TABLE raffles
+    ADD COLUMN draw_pending_reason VARCHAR(30),
+    ADD COLUMN draw_pending_since TIMESTAMP;
+
+ALTER TABLE raffles
+    ADD CONSTRAINT chk_raffles_draw_pending_reason
+    CHECK (draw_pending_reason IS NULL OR draw_pending_reason IN ('lottery_result_pending', 'lottery_number_unsold', 'manual_draw_required'));
+
+CREATE INDEX idx_raffles_draw_pending ON raffles(draw_pending_since)
+    WHERE draw_pending_reason IS NOT NULL AND status = 'active';
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'raffle_draw_pending';
+
+COMMENT ON COLUMN raffles.draw_pending_reason IS 'Motivo por el que el sorteo vencido no se completó (NULL: sin pendiente)';
+COMMENT ON COLUMN raffles.draw_pending_since IS 'Momento en que se registró el motivo del sorteo pendiente';
@@ -0,0 +1,9 @@
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS chk_settlements_net_payout;
+
+ALTER TABLE settlements DROP COLUMN IF EXISTS prizes_withheld;
+
+ALTER TABLE settlements
+    ADD CONSTRAINT chk_settlements_net_payout
+    CHECK (net_payout = gross_revenue - platform_fee);
+
+COMMENT ON COLUMN settlements.net_payout IS 'gross_revenue - platform_fee';
@@ -0,0 +1,14 @@
+-- Migration: 000044_settlement_prizes_withheld
+-- Purpose: Los premios en efectivo se pagan de lo recaudado por el sorteo y se descuentan del pago al organizador
+
+ALTER TABLE settlements
+    ADD COLUMN prizes_withheld DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS chk_settlements_net_payout;
+
+ALTER TABLE settlements
+    ADD CONSTRAINT chk_settlements_net_payout
+    CHECK (prizes_withheld >= 0 AND net_payout = gross_revenue - platform_fee - prizes_withheld);
+
+COMMENT ON COLUMN settlements.prizes_withheld IS 'Premios en efectivo pagados de lo recaudado (no se liquidan al organizador)';
+COMMENT ON COLUMN settlements.net_payout IS 'gross_revenue - platform_fee - prizes_withheld';
@@ -0,0 +1,22 @@
+-- Migration: 000045_settlement_currency
+-- Purpose: Registrar la moneda de cada settlement (la de los pagos del sorteo) para que sus asientos
+-- contables se registren en la misma moneda que las compras
+
+ALTER TABLE settlements
+    ADD COLUMN currency VARCHAR(3);
+
+UPDATE settlements s
+SET currency = (
+    SELECT UPPER(MIN(p.currency))
+    FROM payments p
+    JOIN raffles r ON r.uuid = p.raffle_id
+    WHERE r.id = s.raffle_id AND p.status = 'succeeded'
+);
+
+-- Los asientos de settlements sin pagos se registraban en la moneda por defecto
+UPDATE settlements SET currency = 'CRC' WHERE currency IS NULL;
+
+ALTER TABLE settlements
+    ALTER COLUMN currency SET NOT NULL;
+
+COMMENT ON COLUMN settlements.currency IS 'Moneda de los pagos del sorteo liquidado';
@@ -19,6 +19,8 @@ type Config struct {
 	SMTP                  SMTPConfig
 	Twilio                TwilioConfig
 	Business              BusinessConfig
+	Lottery               LotteryConfig
+	DrawBeacon            DrawBeaconConfig
 	SkipEmailVerification bool
 	EmailProvider         string // "sendgrid" o "smtp"
 }
@@ -123,6 +125,21 @@ type BusinessConfig struct {
 	RateLimitLoginPerMinute   int
 	RateLimitReservePerMinute int
 	RateLimitPaymentPerMinute int
+	InventoryAutoRepair       bool // El job de consistencia de inventario corrige además de reportar
+}
+
+// LotteryConfig fuente de resultados de la Lotería Nacional de Costa Rica
+type LotteryConfig struct {
+	Source   string        // "http", "file" o vacío (solo ingreso manual)
+	URL      string        // Endpoint HTTP (o stub local) que retorna el resultado por fecha
+	FilePath string        // Archivo JSON con resultados oficiales
+	Timeout  time.Duration // Timeout de la fuente HTTP
+}
+
+// DrawBeaconConfig beacon público (drand) cuya ronda es la entropía de los sorteos verificables
+type DrawBeaconConfig struct {
+	URL     string        // Endpoint HTTP de drand
+	Timeout time.Duration // Timeout de las llamadas HTTP
 }
 
 // Load carga la configuración desde variables de entorno
@@ -218,6 +235,17 @@ func Load() (*Config, error) {
 			RateLimitLoginPerMinute:   viper.GetInt("CONFIG_RATE_LIMIT_LOGIN_PER_MINUTE"),
 			RateLimitReservePerMinute: viper.GetInt("CONFIG_RATE_LIMIT_RESERVE_PER_MINUTE"),
 			RateLimitPaymentPerMinute: viper.GetInt("CONFIG_RATE_LIMIT_PAYMENT_PER_MINUTE"),
+			InventoryAutoRepair:       viper.GetBool("CONFIG_INVENTORY_AUTO_REPAIR"),
+		},
+		Lottery: LotteryConfig{
+			Source:   viper.GetString("CONFIG_LOTTERY_SOURCE"),
+			URL:      viper.GetString("CONFIG_LOTTERY_URL"),
+			FilePath: viper.GetString("CONFIG_LOTTERY_FILE_PATH"),
+			Timeout:  viper.GetDuration("CONFIG_LOTTERY_TIMEOUT"),
+		},
+		DrawBeacon: DrawBeaconConfig{
+			URL:     viper.GetString("CONFIG_DRAW_BEACON_URL"),
+			Timeout: viper.GetDuration("CONFIG_DRAW_BEACON_TIMEOUT"),
 		},
 		SkipEmailVerification: viper.GetBool("CONFIG_SKIP_EMAIL_VERIFICATION"),
 		EmailProvider:         viper.GetString("CONFIG_EMAIL_PROVIDER"),
@@ -275,6 +303,13 @@ func setDefaults() {
 	viper.SetDefault("CONFIG_RATE_LIMIT_LOGIN_PER_MINUTE", 5)
 	viper.SetDefault("CONFIG_RATE_LIMIT_RESERVE_PER_MINUTE", 10)
 	viper.SetDefault("CONFIG_RATE_LIMIT_PAYMENT_PER_MINUTE", 5)
+	viper.SetDefault("CONFIG_INVENTORY_AUTO_REPAIR", false)
+
+	// Lotería Nacional (sin fuente: solo ingreso manual con doble confirmación)
+	viper.SetDefault("CONFIG_LOTTERY_SOURCE", "")
+	viper.SetDefault("CONFIG_LOTTERY_TIMEOUT", "15s")
+	viper.SetDefault("CONFIG_DRAW_BEACON_URL", "https://api.drand.sh")
+	viper.SetDefault("CONFIG_DRAW_BEACON_TIMEOUT", "10s")
 
 	// Email
 	viper.SetDefault("CONFIG_EMAIL_PROVIDER", "sendgrid") // "sendgrid" o "smtp"